@@ -4,18 +4,37 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"tenant-crud-simply/internal/iam/application/auth"
+	"tenant-crud-simply/internal/iam/application/me"
+	"tenant-crud-simply/internal/iam/application/onboarding"
+	"tenant-crud-simply/internal/iam/application/privacy"
+	"tenant-crud-simply/internal/iam/application/scim"
+	"tenant-crud-simply/internal/iam/application/search"
+	"tenant-crud-simply/internal/iam/application/tenant_export"
+	"tenant-crud-simply/internal/iam/application/user_import"
+	"tenant-crud-simply/internal/iam/domain/branding"
+	"tenant-crud-simply/internal/iam/domain/custom_field"
+	"tenant-crud-simply/internal/iam/domain/feature_flag"
+	"tenant-crud-simply/internal/iam/domain/group"
+	"tenant-crud-simply/internal/iam/domain/metering"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/plan"
+	"tenant-crud-simply/internal/iam/domain/settings"
 	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/domain/tenant_domain"
 	"tenant-crud-simply/internal/iam/domain/user"
 	"tenant-crud-simply/internal/iam/middleware"
 	"tenant-crud-simply/internal/infra/jwt"
 	"tenant-crud-simply/internal/pkg/log/acess_log"
 	"tenant-crud-simply/internal/pkg/log/auditoria_log"
 	"tenant-crud-simply/internal/pkg/mailer"
+	"tenant-crud-simply/internal/pkg/scheduler"
 	"time"
 
 	"tenant-crud-simply/cmd/server"
 	"tenant-crud-simply/internal/infra/database/postgres"
+	"tenant-crud-simply/internal/infra/database/tenantdb"
 
 	"github.com/spf13/viper"
 	"golang.ngrok.com/ngrok/v2"
@@ -40,13 +59,143 @@ func Environment() {
 }
 
 func initIamDomain(db *gorm.DB) {
-	middleware.New(db)
-	tenant.New(db)
+	TenantRouting(db)
+	tenant.New(db, tenant.Config{
+		GracePeriod:        time.Duration(viper.GetInt64("tenant.purge.grace_period_days")) * 24 * time.Hour,
+		AnonymizeLogs:      viper.GetString("tenant.purge.logs") != "delete",
+		TrialPeriod:        time.Duration(viper.GetInt64("tenant.lifecycle.trial_days")) * 24 * time.Hour,
+		TrialExpiredStatus: model.TenantStatus(viper.GetString("tenant.lifecycle.trial_expired_status")),
+		SchemaIsolation:    viper.GetBool("tenant.isolation.schema_enabled"),
+		DatabaseIsolation:  viper.GetBool("tenant.isolation.database_enabled"),
+	})
+	Quotas(db)
+	branding.New(db)
+	custom_field.New(db)
 	user.New(db)
+	group.New(db)
+	tenant_domain.New(db, tenant_domain.Config{
+		BaseDomain: viper.GetString("tenant.domains.base_domain"),
+		Resolver:   txtResolver(),
+	})
+	metering.New(db, metering.Config{
+		MaxHoursPerRun: viper.GetInt("metering.max_hours_per_run"),
+	})
+	tenant_export.New(db, ExportConfig())
+	feature_flag.New(db)
 	auth.New(db)
+	onboarding.New(db, onboardingConfig())
+	user_import.New(db, userImportConfig())
+	me.New()
+	privacy.New(db, PrivacyConfig())
+	scim.New(db)
+	search.New(db)
 
 }
 
+// TenantRouting inicializa o direcionamento das consultas para o schema ou o banco dedicado de
+// cada tenant (também usado pela linha de comando).
+func TenantRouting(db *gorm.DB) {
+	if viper.GetBool("tenant.isolation.database_enabled") {
+		if _, err := tenantdb.New(db, TenantDatabaseConfig()); err != nil {
+			log.Fatalf("[BOOTSTRAP-TENANT-DB] Falha ao iniciar o registro de bancos de tenant: %v", err)
+		}
+	}
+	middleware.New(db, middleware.Config{
+		HostResolution:    viper.GetBool("tenant.domains.enabled"),
+		BaseDomain:        viper.GetString("tenant.domains.base_domain"),
+		SchemaIsolation:   viper.GetBool("tenant.isolation.schema_enabled"),
+		DatabaseIsolation: viper.GetBool("tenant.isolation.database_enabled"),
+	})
+}
+
+// Quotas inicializa as configurações e os planos dos tenants, que limitam a criação de usuários
+// (também usado pela importação de tenants na linha de comando).
+func Quotas(db *gorm.DB) {
+	settings.New(db, settings.Config{
+		Defaults: viper.GetStringMap("settings.defaults"),
+	})
+	plan.New(db, plan.Config{
+		DefaultCode:     viper.GetString("plans.default_code"),
+		EnforceRequests: viper.GetBool("plans.enforce_requests"),
+	})
+}
+
+// onboardingConfig lê as configurações e os grupos padrão aplicados a todo novo tenant.
+func onboardingConfig() onboarding.Config {
+	var groups []onboarding.GroupConfig
+	if err := viper.UnmarshalKey("onboarding.groups", &groups); err != nil {
+		log.Printf("[BOOTSTRAP-ONBOARDING] Grupos padrão inválidos: %v", err)
+	}
+	return onboarding.Config{
+		Settings:  viper.GetStringMap("onboarding.settings"),
+		Groups:    groups,
+		InviteTTL: time.Duration(viper.GetInt64("onboarding.invite_ttl_hours")) * time.Hour,
+	}
+}
+
+// userImportConfig lê a configuração da importação de usuários em lote.
+func userImportConfig() user_import.Config {
+	return user_import.Config{
+		Directory:     viper.GetString("users.import.directory"),
+		TTL:           time.Duration(viper.GetInt64("users.import.ttl_hours")) * time.Hour,
+		MaxConcurrent: viper.GetInt("users.import.max_concurrent"),
+		BatchSize:     viper.GetInt("users.import.batch_size"),
+		MaxRows:       viper.GetInt("users.import.max_rows"),
+		MaxFileSize:   viper.GetInt64("users.import.max_file_mb") << 20,
+		InviteTTL:     time.Duration(viper.GetInt64("users.import.invite_ttl_hours")) * time.Hour,
+	}
+}
+
+// ExportConfig lê a configuração de exportação de tenants (também usada pela linha de comando).
+func ExportConfig() tenant_export.Config {
+	return tenant_export.Config{
+		Directory:     viper.GetString("tenant.export.directory"),
+		TTL:           time.Duration(viper.GetInt64("tenant.export.ttl_hours")) * time.Hour,
+		MaxConcurrent: viper.GetInt("tenant.export.max_concurrent"),
+	}
+}
+
+// PrivacyConfig lê a configuração da anonimização de usuários (também usada pela linha de comando).
+func PrivacyConfig() privacy.Config {
+	return privacy.Config{
+		EmailDomain: viper.GetString("privacy.anonymization.email_domain"),
+		IPv4Prefix:  viper.GetInt("privacy.anonymization.ipv4_prefix"),
+		IPv6Prefix:  viper.GetInt("privacy.anonymization.ipv6_prefix"),
+	}
+}
+
+// TenantDatabaseConfig lê a configuração dos bancos dedicados de tenant (também usada pela linha de comando).
+func TenantDatabaseConfig() tenantdb.Config {
+	return tenantdb.Config{
+		Key:          viper.GetString("tenant.isolation.database_key"),
+		IdleTimeout:  time.Duration(viper.GetInt64("tenant.isolation.database_idle_timeout_min")) * time.Minute,
+		MaxOpenConns: viper.GetInt("tenant.isolation.database_max_open_conns"),
+	}
+}
+
+// txtResolver escolhe o resolvedor da verificação de domínios: DNS real ("dns", padrão)
+// ou registros fixos do configs.json ("static"), para ambiente local.
+func txtResolver() tenant_domain.TXTResolver {
+	if viper.GetString("tenant.domains.verification.resolver") != "static" {
+		return nil
+	}
+
+	// Lista (e não mapa) porque o viper trata os pontos das chaves como níveis
+	var records []struct {
+		Name   string   `mapstructure:"name"`
+		Values []string `mapstructure:"values"`
+	}
+	if err := viper.UnmarshalKey("tenant.domains.verification.static_records", &records); err != nil {
+		log.Printf("[BOOTSTRAP-DOMAIN] Registros TXT estáticos inválidos: %v", err)
+	}
+	resolver := tenant_domain.StaticResolver{}
+	for _, r := range records {
+		resolver[strings.ToLower(r.Name)] = r.Values
+	}
+	log.Println("[BOOTSTRAP-DOMAIN] Verificação de domínios usando registros TXT estáticos.")
+	return resolver
+}
+
 func initLogs(db *gorm.DB) error {
 	configAcessLog := acess_log.Config{
 		LogEnabled: viper.GetBool("log.enabled"),
@@ -155,9 +304,45 @@ func startNgrokForward(ctx context.Context, token string, port int) error {
 	return nil
 }
 
+// startJobs agenda as rotinas periódicas da aplicação, encerradas junto com o ctx.
+func startJobs(ctx context.Context) {
+	if viper.GetBool("tenant.purge.enabled") {
+		interval := time.Duration(viper.GetInt64("tenant.purge.interval_min")) * time.Minute
+		if interval <= 0 {
+			interval = time.Hour
+		}
+		scheduler.Every(ctx, "tenant-purge", interval, tenant.MustUse().Service.PurgeExpired)
+	}
+	if viper.GetInt64("tenant.lifecycle.trial_days") > 0 {
+		interval := time.Duration(viper.GetInt64("tenant.lifecycle.trial_check_interval_min")) * time.Minute
+		if interval <= 0 {
+			interval = time.Hour
+		}
+		scheduler.Every(ctx, "tenant-trial-expire", interval, tenant.MustUse().Service.ExpireTrials)
+	}
+	if viper.GetBool("metering.enabled") {
+		interval := time.Duration(viper.GetInt64("metering.interval_min")) * time.Minute
+		if interval <= 0 {
+			interval = 15 * time.Minute
+		}
+		scheduler.Every(ctx, "usage-aggregate", interval, metering.MustUse().Service.Aggregate)
+	}
+	if viper.GetInt64("tenant.export.ttl_hours") > 0 {
+		scheduler.Every(ctx, "tenant-export-expire", time.Hour, tenant_export.MustUse().Service.ExpireOld)
+	}
+	if viper.GetInt64("users.import.ttl_hours") > 0 {
+		scheduler.Every(ctx, "user-import-expire", time.Hour, user_import.MustUse().Service.ExpireOld)
+	}
+	if registry, err := tenantdb.Use(); err == nil {
+		scheduler.Every(ctx, "tenant-db-evict", time.Minute, registry.EvictIdle)
+	}
+}
+
 func (a *Application) Start(ctx context.Context) error {
 	log.Println("[BOOTSTRAP] Iniciando servidor no ambiente:", viper.GetString("app.env"))
 
+	startJobs(ctx)
+
 	errCh := make(chan error, 1)
 
 	// --- sobe o servidor normalmente ---
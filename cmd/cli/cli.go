@@ -1,18 +1,25 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 
+	"github.com/google/uuid"
 	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 	"gorm.io/gorm"
 
 	"tenant-crud-simply/cmd/bootstrap"
+	"tenant-crud-simply/internal/iam/application/privacy"
+	"tenant-crud-simply/internal/iam/application/tenant_export"
 	"tenant-crud-simply/internal/infra/database/admin"
 	"tenant-crud-simply/internal/infra/database/migrations"
 	"tenant-crud-simply/internal/infra/database/postgres"
+	"tenant-crud-simply/internal/infra/database/tenantdb"
 )
 
 type options struct {
@@ -24,6 +31,18 @@ type options struct {
 	DBDelete          bool
 	DBBackup          bool
 	BackupDestination string
+	TenantExport      string
+	ExportFormat      string
+	TenantImport      string
+	ImportMode        string
+	EmailConflict     string
+	DryRun            bool
+	CloneName         string
+	CloneDocument     string
+	ImportParent      string
+	TenantDB          string
+	AnonymizeUser     string
+	AnonymizeReason   string
 }
 
 func Execute() error {
@@ -70,6 +89,15 @@ func Execute() error {
 		operations = true
 	}
 
+	// Com --tenant-db, --migration-update e --db-backup atuam apenas nos bancos dedicados informados
+	if opts.TenantDB != "" {
+		if err := tenantDatabases(db, opts); err != nil {
+			return err
+		}
+		operations = true
+		opts.Update, opts.DBBackup = false, false
+	}
+
 	if opts.Update {
 		if manager == nil {
 			manager = migrations.NewManager(db)
@@ -78,6 +106,20 @@ func Execute() error {
 			return fmt.Errorf("falha ao aplicar migrations de atualização: %w", err)
 		}
 		log.Println("Migrations de atualização aplicadas com sucesso.")
+
+		// Tenants isolados por schema recebem as migrations de tenant em cada schema
+		schemas, err := manager.ApplyTenantUpdates()
+		if err != nil {
+			return fmt.Errorf("falha ao aplicar migrations nos schemas de tenant: %w", err)
+		}
+		log.Printf("Migrations de tenant aplicadas em %d schema(s).", schemas)
+
+		// E os bancos dedicados registrados, quando o isolamento por banco está habilitado
+		if viper.GetBool("tenant.isolation.database_enabled") {
+			if err := migrateTenantDatabases(db, "all"); err != nil {
+				return err
+			}
+		}
 		operations = true
 	}
 
@@ -123,6 +165,27 @@ func Execute() error {
 		operations = true
 	}
 
+	if opts.TenantExport != "" {
+		if err := exportTenant(db, opts); err != nil {
+			return fmt.Errorf("falha ao exportar tenant: %w", err)
+		}
+		operations = true
+	}
+
+	if opts.TenantImport != "" {
+		if err := importTenant(db, opts); err != nil {
+			return fmt.Errorf("falha ao importar tenant: %w", err)
+		}
+		operations = true
+	}
+
+	if opts.AnonymizeUser != "" {
+		if err := anonymizeUser(db, opts); err != nil {
+			return fmt.Errorf("falha ao anonimizar usuário: %w", err)
+		}
+		operations = true
+	}
+
 	if opts.Start {
 		if err := startServer(); err != nil {
 			return fmt.Errorf("falha ao iniciar servidor: %w", err)
@@ -143,11 +206,23 @@ func parseOptions(args []string) (options, error) {
 	fs.BoolVar(&opts.Start, "start", false, "Inicia o servidor HTTP")
 	fs.BoolVar(&opts.Stop, "stop", false, "Finaliza o servidor HTTP")
 	fs.BoolVar(&opts.Seed, "migration-seed", false, "Aplica migrations de seed")
-	fs.BoolVar(&opts.Update, "migration-update", false, "Aplica migrations de atualização")
+	fs.BoolVar(&opts.Update, "migration-update", false, "Aplica migrations de atualização (em public e em todos os schemas de tenant)")
 	fs.BoolVar(&opts.DBCheck, "db-check", false, "Checa status do banco de dados")
 	fs.BoolVar(&opts.DBDelete, "db-delete", false, "Remove todas as tabelas do banco de dados")
 	fs.BoolVar(&opts.DBBackup, "db-backup", false, "Realiza backup do banco de dados")
-	fs.StringVar(&opts.BackupDestination, "local", "", "Diretório de destino para o backup do banco")
+	fs.StringVar(&opts.BackupDestination, "local", "", "Diretório de destino para o backup do banco ou a exportação de tenant")
+	fs.StringVar(&opts.TenantExport, "tenant-export", "", "Exporta os dados do tenant informado (UUID)")
+	fs.StringVar(&opts.ExportFormat, "export-format", "json", "Formato da exportação de tenant: json ou ndjson")
+	fs.StringVar(&opts.TenantImport, "tenant-import", "", "Importa um tenant a partir do pacote (.zip) de exportação informado")
+	fs.StringVar(&opts.ImportMode, "import-mode", "restore", "Modo da importação: restore (mantém UUIDs) ou clone (novos UUIDs)")
+	fs.StringVar(&opts.EmailConflict, "email-conflict", "fail", "Emails já existentes na importação: fail, skip ou rename")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "Apenas simula a importação, sem gravar")
+	fs.StringVar(&opts.CloneName, "clone-name", "", "Nome do novo tenant (importação em modo clone)")
+	fs.StringVar(&opts.CloneDocument, "clone-document", "", "Documento do novo tenant (importação em modo clone)")
+	fs.StringVar(&opts.ImportParent, "import-parent", "", "UUID do tenant pai do tenant importado")
+	fs.StringVar(&opts.AnonymizeUser, "anonymize-user", "", "Anonimiza os dados pessoais do usuário informado (UUID) e emite o certificado de eliminação")
+	fs.StringVar(&opts.AnonymizeReason, "anonymize-reason", "", "Motivo da anonimização, registrado no certificado")
+	fs.StringVar(&opts.TenantDB, "tenant-db", "", "Aplica --migration-update ou --db-backup no banco dedicado do tenant informado (UUID) ou em todos (all)")
 
 	if err := fs.Parse(args); err != nil {
 		return options{}, err
@@ -157,9 +232,195 @@ func parseOptions(args []string) (options, error) {
 }
 
 func (o options) anyOperation() bool {
-	return o.Start || o.Stop || o.Seed || o.Update || o.DBCheck || o.DBDelete || o.DBBackup
+	return o.Start || o.Stop || o.Seed || o.Update || o.DBCheck || o.DBDelete || o.DBBackup || o.TenantExport != "" || o.TenantImport != "" || o.AnonymizeUser != ""
 }
 
 func (o options) requiresDatabase() bool {
-	return o.Seed || o.Update || o.DBCheck || o.DBDelete
+	return o.Seed || o.Update || o.DBCheck || o.DBDelete || o.TenantExport != "" || o.TenantImport != "" || o.TenantDB != "" || o.AnonymizeUser != ""
+}
+
+// tenantDatabases executa as operações de --tenant-db (migrations e/ou backup) nos bancos dedicados.
+func tenantDatabases(db *gorm.DB, opts options) error {
+	if !opts.Update && !opts.DBBackup {
+		return fmt.Errorf("--tenant-db deve ser usado com --migration-update ou --db-backup")
+	}
+	if opts.Update {
+		if err := migrateTenantDatabases(db, opts.TenantDB); err != nil {
+			return err
+		}
+	}
+	if opts.DBBackup {
+		if err := backupTenantDatabases(db, opts.TenantDB, opts.BackupDestination); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectTenantDatabases inicializa o registro de bancos dedicados e resolve o alvo de --tenant-db.
+func selectTenantDatabases(db *gorm.DB, target string) (*tenantdb.Registry, []uuid.UUID, error) {
+	registry, err := tenantdb.New(db, bootstrap.TenantDatabaseConfig())
+	if err != nil {
+		return nil, nil, fmt.Errorf("falha ao iniciar o registro de bancos de tenant: %w", err)
+	}
+	if target != "all" {
+		tenantUUID, err := uuid.Parse(target)
+		if err != nil {
+			return nil, nil, fmt.Errorf("UUID de tenant inválido: %s", target)
+		}
+		return registry, []uuid.UUID{tenantUUID}, nil
+	}
+	tenants, err := registry.Tenants(context.Background())
+	return registry, tenants, err
+}
+
+// migrateTenantDatabases aplica as migrations de banco dedicado no(s) tenant(s) de target (UUID ou all).
+func migrateTenantDatabases(db *gorm.DB, target string) error {
+	registry, tenants, err := selectTenantDatabases(db, target)
+	if err != nil {
+		return err
+	}
+	defer registry.Close()
+
+	for _, tenantUUID := range tenants {
+		if err := registry.Migrate(context.Background(), tenantUUID); err != nil {
+			return fmt.Errorf("falha ao aplicar migrations no banco do tenant %s: %w", tenantUUID, err)
+		}
+	}
+	log.Printf("Migrations de banco dedicado aplicadas em %d banco(s) de tenant.", len(tenants))
+	return nil
+}
+
+// backupTenantDatabases gera um backup por banco dedicado (tenant-<uuid>.dump) no diretório informado.
+func backupTenantDatabases(db *gorm.DB, target, directory string) error {
+	if directory == "" {
+		return fmt.Errorf("para executar o backup informe o diretório de destino com --local=<caminho>")
+	}
+	registry, tenants, err := selectTenantDatabases(db, target)
+	if err != nil {
+		return err
+	}
+	defer registry.Close()
+
+	for _, tenantUUID := range tenants {
+		conn, err := registry.Connection(context.Background(), tenantUUID)
+		if err != nil {
+			return fmt.Errorf("falha ao ler a conexão do tenant %s: %w", tenantUUID, err)
+		}
+		dest := filepath.Join(directory, fmt.Sprintf("tenant-%s.dump", tenantUUID))
+		if err := admin.Backup(admin.BackupOptions{
+			Destination: dest,
+			Connection: &admin.ConnectionInfo{
+				Host:     conn.Host,
+				Port:     conn.Port,
+				User:     conn.User,
+				Password: conn.Password,
+				Database: conn.Database,
+				SSLMode:  conn.SSLMode,
+			},
+		}); err != nil {
+			return fmt.Errorf("falha ao executar backup do tenant %s: %w", tenantUUID, err)
+		}
+		log.Printf("Backup do tenant %s gerado em %s", tenantUUID, dest)
+	}
+	return nil
+}
+
+// exportTenant gera a exportação de forma síncrona, no diretório --local ou no configurado
+// em tenant.export.directory. O pacote também fica disponível para um download pela API.
+func exportTenant(db *gorm.DB, opts options) error {
+	tenantUUID, err := uuid.Parse(opts.TenantExport)
+	if err != nil {
+		return fmt.Errorf("UUID de tenant inválido: %s", opts.TenantExport)
+	}
+
+	cfg := bootstrap.ExportConfig()
+	if opts.BackupDestination != "" {
+		cfg.Directory = opts.BackupDestination
+	}
+
+	service := tenant_export.NewService(tenant_export.NewRepository(db), cfg)
+	export, err := service.Run(context.Background(), tenantUUID, nil, tenant_export.Format(opts.ExportFormat))
+	if err != nil {
+		return err
+	}
+	log.Printf("Exportação %s gerada em %s (%d bytes, sha256 %s)", export.UUID, export.FileName, export.SizeBytes, export.SHA256)
+	return nil
+}
+
+// anonymizeUser anonimiza o usuário de forma síncrona, respeitando as retenções legais, e
+// imprime o certificado de eliminação.
+func anonymizeUser(db *gorm.DB, opts options) error {
+	userUUID, err := uuid.Parse(opts.AnonymizeUser)
+	if err != nil {
+		return fmt.Errorf("UUID de usuário inválido: %s", opts.AnonymizeUser)
+	}
+
+	// Usuários de tenants isolados ficam no schema ou no banco dedicado do tenant
+	bootstrap.TenantRouting(db)
+
+	service := privacy.NewService(privacy.NewRepository(db), bootstrap.PrivacyConfig())
+	cert, err := service.Anonymize(context.Background(), userUUID, privacy.Options{
+		Source: privacy.SourceCLI,
+		Reason: opts.AnonymizeReason,
+	})
+	if errors.Is(err, privacy.ErrAlreadyAnonymized) {
+		log.Printf("Usuário %s já anonimizado (certificado %s).", userUUID, cert.UUID)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("Usuário %s anonimizado como %s. Certificado %s (sha256 %s), registros alterados: %v",
+		userUUID, cert.Pseudonym, cert.UUID, cert.SHA256, cert.Affected)
+	return nil
+}
+
+// importTenant importa o pacote de exportação e imprime o relatório.
+func importTenant(db *gorm.DB, opts options) error {
+	file, err := os.Open(opts.TenantImport)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	importOpts := tenant_export.ImportOptions{
+		Mode:          tenant_export.ImportMode(opts.ImportMode),
+		EmailConflict: tenant_export.EmailConflict(opts.EmailConflict),
+		DryRun:        opts.DryRun,
+		Name:          opts.CloneName,
+		Document:      opts.CloneDocument,
+	}
+	if opts.ImportParent != "" {
+		parent, err := uuid.Parse(opts.ImportParent)
+		if err != nil {
+			return fmt.Errorf("UUID do tenant pai inválido: %s", opts.ImportParent)
+		}
+		importOpts.ParentUUID = &parent
+	}
+
+	// O plano padrão e os domínios de email permitidos limitam os usuários importados
+	bootstrap.Quotas(db)
+	service := tenant_export.NewService(tenant_export.NewRepository(db), bootstrap.ExportConfig())
+	report, err := service.Import(context.Background(), file, info.Size(), importOpts)
+	if err != nil {
+		return err
+	}
+
+	prefix := "Importação concluída"
+	if report.DryRun {
+		prefix = "Simulação (nada foi gravado)"
+	}
+	log.Printf("%s: tenant %s → %s (%s)", prefix, report.SourceTenantUUID, report.TenantUUID, report.Mode)
+	for _, d := range report.Datasets {
+		log.Printf("  %-14s criados: %d  ignorados: %d", d.Name, d.Created, d.Skipped)
+	}
+	for _, w := range report.Warnings {
+		log.Printf("  aviso: %s", w)
+	}
+	return nil
 }
@@ -4,8 +4,24 @@ import (
 	"fmt"
 	"os"
 	"tenant-crud-simply/internal/iam/application/auth"
+	"tenant-crud-simply/internal/iam/application/me"
+	"tenant-crud-simply/internal/iam/application/onboarding"
+	"tenant-crud-simply/internal/iam/application/privacy"
+	"tenant-crud-simply/internal/iam/application/scim"
+	"tenant-crud-simply/internal/iam/application/search"
+	"tenant-crud-simply/internal/iam/application/tenant_export"
+	"tenant-crud-simply/internal/iam/application/user_import"
+	"tenant-crud-simply/internal/iam/domain/branding"
+	"tenant-crud-simply/internal/iam/domain/custom_field"
+	"tenant-crud-simply/internal/iam/domain/feature_flag"
+	"tenant-crud-simply/internal/iam/domain/group"
+	"tenant-crud-simply/internal/iam/domain/metering"
+	"tenant-crud-simply/internal/iam/domain/plan"
+	"tenant-crud-simply/internal/iam/domain/settings"
 	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/domain/tenant_domain"
 	"tenant-crud-simply/internal/iam/domain/user"
+	"tenant-crud-simply/internal/iam/middleware"
 
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
@@ -33,6 +49,13 @@ func SetupRouter() *gin.Engine {
 
 	r := gin.Default()
 
+	// 2. Proxies confiáveis: só deles o X-Forwarded-For é aceito para obter o IP do cliente (c.ClientIP),
+	// usado no access_log e na lista de IPs permitidos dos tenants. Sem proxies, vale o IP da conexão.
+	if err := r.SetTrustedProxies(viper.GetStringSlice("server.trusted_proxies")); err != nil {
+		fmt.Printf("ERROR: Invalid 'server.trusted_proxies': %v\n", err)
+		os.Exit(1)
+	}
+
 	// Acessível em /doc/index.html
 	r.GET("/doc/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	SetupApiRoutes(r)
@@ -41,6 +64,8 @@ func SetupRouter() *gin.Engine {
 
 func SetupApiRoutes(r *gin.Engine) {
 	route := r.Group("/api")
+	// Resolve o tenant pelo Host antes da autenticação (no-op se tenant.domains.enabled = false)
+	route.Use(middleware.MustUse().Middleware.ResolveTenantHost())
 
 	tenantController, err := tenant.Use()
 	if err != nil {
@@ -50,11 +75,89 @@ func SetupApiRoutes(r *gin.Engine) {
 	if err != nil {
 		panic(err)
 	}
+	groupController, err := group.Use()
+	if err != nil {
+		panic(err)
+	}
+	settingsController, err := settings.Use()
+	if err != nil {
+		panic(err)
+	}
+	brandingController, err := branding.Use()
+	if err != nil {
+		panic(err)
+	}
+	customFieldController, err := custom_field.Use()
+	if err != nil {
+		panic(err)
+	}
+	planController, err := plan.Use()
+	if err != nil {
+		panic(err)
+	}
+	meteringController, err := metering.Use()
+	if err != nil {
+		panic(err)
+	}
+	domainController, err := tenant_domain.Use()
+	if err != nil {
+		panic(err)
+	}
+	exportController, err := tenant_export.Use()
+	if err != nil {
+		panic(err)
+	}
+	onboardingController, err := onboarding.Use()
+	if err != nil {
+		panic(err)
+	}
+	userImportController, err := user_import.Use()
+	if err != nil {
+		panic(err)
+	}
+	meController, err := me.Use()
+	if err != nil {
+		panic(err)
+	}
+	privacyController, err := privacy.Use()
+	if err != nil {
+		panic(err)
+	}
+	scimController, err := scim.Use()
+	if err != nil {
+		panic(err)
+	}
+	featureFlagController, err := feature_flag.Use()
+	if err != nil {
+		panic(err)
+	}
+	searchController, err := search.Use()
+	if err != nil {
+		panic(err)
+	}
 	authController, err := auth.Use()
 	if err != nil {
 		panic(err)
 	}
 	tenantController.Routes(route)
 	userController.Routes(route)
+	groupController.Routes(route)
+	settingsController.Routes(route)
+	domainController.Routes(route)
+	brandingController.Routes(route)
+	customFieldController.Routes(route)
+	planController.Routes(route)
+	meteringController.Routes(route)
+	exportController.Routes(route)
+	onboardingController.Routes(route)
+	userImportController.Routes(route)
+	meController.Routes(route)
+	privacyController.Routes(route)
+	scimController.Routes(route)
+	featureFlagController.Routes(route)
+	searchController.Routes(route)
 	authController.Routes(route)
+
+	// SCIM 2.0 para os provedores de identidade: fora de /api, autenticado pelo token SCIM do tenant
+	scimController.ProvisioningRoutes(r.Group("/scim/v2"))
 }
@@ -22,7 +22,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retorna os dados do usuário logado se o token for válido.",
+                "description": "Retorna os dados do usuário logado se o token for válido e as feature flags avaliadas para ele (chave -\u003e ligada).",
                 "consumes": [
                     "application/json"
                 ],
@@ -35,9 +35,9 @@ const docTemplate = `{
                 "summary": "Verifica o status do login",
                 "responses": {
                     "200": {
-                        "description": "Dados do usuário logado",
+                        "description": "Dados do usuário logado e feature flags",
                         "schema": {
-                            "$ref": "#/definitions/auth.LoginResponse"
+                            "$ref": "#/definitions/auth.HealthcheckResponse"
                         }
                     },
                     "401": {
@@ -86,6 +86,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
+                    "403": {
+                        "description": "Tenant do usuário excluído ou IP de origem não permitido",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
                     "404": {
                         "description": "Credenciais inválidas (usuário/senha errados)",
                         "schema": {
@@ -246,234 +252,194 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tenant": {
+        "/api/branding": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Busca um tenant no sistema usando o UUID ou o Documento (CNPJ/CPF). Pelo menos um dos dois campos deve ser fornecido.",
+                "description": "Retorna a identidade visual efetiva aplicada aos emails enviados aos usuários do tenant (remetente, logo, cor e rodapé) e a origem de cada campo: 'tenant', 'parent' (herdado de um tenant ancestral), 'global' (configs.json) ou 'default'. SystemAdmin sem 'tenant_identifier' vê o padrão do sistema.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant"
+                    "Branding"
                 ],
-                "summary": "Busca um Tenant",
+                "summary": "Identidade visual dos emails",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID do tenant a ser buscado. (Ex: 8871abf3-ed11-4770-b986-e8d98d022d4f)",
-                        "name": "uuid",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Documento (CNPJ/CPF) do tenant a ser buscado. (Ex: 12345678901234)",
-                        "name": "document",
+                        "description": "UUID ou Documento do tenant (opcional para SystemAdmin e PartnerAdmin)",
+                        "name": "tenant_identifier",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "model.Tenant encontrado com sucesso.",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tenant.TenantResponseDto"
+                            "$ref": "#/definitions/branding.BrandingResponseDto"
                         }
                     },
-                    "400": {
-                        "description": "Requisição inválida (UUID inválido, ou nenhum dos campos 'uuid'/'document' fornecido).",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
                     "404": {
-                        "description": "model.Tenant não encontrado com os dados fornecidos.",
+                        "description": "Tenant não encontrado.",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
                     "500": {
-                        "description": "Erro interno do servidor.",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     }
                 }
             },
-            "delete": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Exclui permanentemente um tenant no sistema usando o UUID ou o Documento (CNPJ/CPF). Pelo menos um dos dois campos deve ser fornecido.",
+                "description": "Altera os campos informados: 'sender_name', 'logo_url' (https), 'primary_color' (#RRGGBB) e 'footer' (texto puro). Informe null para voltar a herdar o valor do tenant pai ou do padrão do sistema. Os tenants filhos herdam a identidade visual que não sobrescreverem.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant"
+                    "Branding"
                 ],
-                "summary": "Deleta um model.Tenant",
+                "summary": "Altera a identidade visual dos emails",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID do tenant a ser excluído. (Ex: 8871abf3-ed11-4770-b986-e8d98d022d4f)",
-                        "name": "uuid",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)",
+                        "name": "tenant_identifier",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Documento (CNPJ/CPF) do tenant a ser excluído. (Ex: 12345678901234)",
-                        "name": "document",
-                        "in": "query"
+                        "description": "Campos a alterar, ex.: {\\",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "model.Tenant excluído com sucesso (No Content).",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/branding.BrandingResponseDto"
                         }
                     },
                     "400": {
-                        "description": "Requisição inválida (UUID inválido, ou nenhum dos campos 'uuid'/'document' fornecido).",
+                        "description": "Campo desconhecido ou valor inválido.",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
-                    "404": {
-                        "description": "model.Tenant não encontrado com os dados fornecidos.",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
-                    "500": {
-                        "description": "Erro interno do servidor.",
+                    "404": {
+                        "description": "Tenant não encontrado.",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
-                    }
-                }
-            }
-        },
-        "/api/tenant/create": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Cria um novo tenant no sistema",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Tenant"
-                ],
-                "summary": "Cria um novo tenant",
-                "parameters": [
-                    {
-                        "description": "Dados do tenant",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/tenant.CreateTenantRequestDto"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/tenant.TenantResponseDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/rest_err.RestErr"
                         }
                     }
                 }
             }
         },
-        "/api/tenant/list": {
+        "/api/custom-fields": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retorna uma lista paginada de todos os tenants registrados no sistema.",
+                "description": "Retorna as definições que valem para os registros do tenant: as do próprio tenant, as herdadas dos ancestrais e as globais. Em chaves repetidas, vale a do dono mais próximo. SystemAdmin sem 'tenant_identifier' recebe apenas as definições globais.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant"
+                    "CustomFields"
                 ],
-                "summary": "Lista Tenants",
+                "summary": "Lista os campos personalizados",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "O número da página a ser retornada (deve ser \u003e= 1).",
-                        "name": "page",
+                        "type": "string",
+                        "description": "Entidade: tenant ou user",
+                        "name": "entity",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "O número de itens por página (máximo 100).",
-                        "name": "pageSize",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (opcional para SystemAdmin e PartnerAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Lista de tenants retornada com sucesso.",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tenant.TenantsResponseDto"
+                            "$ref": "#/definitions/custom_field.CustomFieldsResponseDto"
                         }
                     },
                     "400": {
-                        "description": "Requisição inválida (parâmetros de paginação ausentes ou inválidos, ou pageSize \u003e 100).",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
                     "500": {
-                        "description": "Erro interno do servidor.",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     }
                 }
-            }
-        },
-        "/api/tenant/{uuid}": {
-            "patch": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Atualiza dados de um tenant existente. O tenant a ser atualizado é identificado pelo UUID no path",
+                "description": "Define um campo aceito no 'metadata' de tenants ou usuários. A definição vale para o tenant dono e seus descendentes; SystemAdmin sem 'tenant_identifier' cria uma definição global. Tipos: string (com 'pattern' opcional), number, boolean, date (AAAA-MM-DD) e enum (com 'enum_values').",
                 "consumes": [
                     "application/json"
                 ],
@@ -481,54 +447,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenant"
+                    "CustomFields"
                 ],
-                "summary": "Atualiza um Tenant",
+                "summary": "Cria um campo personalizado",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID do tenant a ser atualizado.",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
+                        "description": "UUID ou Documento do tenant dono (opcional para SystemAdmin e PartnerAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
                     },
                     {
-                        "description": "Campos do tenant a serem atualizados. Apenas os campos presentes serão modificados.",
+                        "description": "Definição do campo",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/tenant.UpdateTenantRequestDto"
+                            "$ref": "#/definitions/custom_field.CreateCustomFieldRequestDto"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "model.Tenant atualizado com sucesso.",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/tenant.TenantResponseDto"
+                            "$ref": "#/definitions/custom_field.CustomFieldResponseDto"
                         }
                     },
                     "400": {
-                        "description": "Requisição inválida (corpo JSON mal formatado, UUID inválido ou dados de entrada inválidos).",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
                     "404": {
-                        "description": "model.Tenant não encontrado para o UUID fornecido.",
+                        "description": "Tenant não encontrado.",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
                     "409": {
-                        "description": "Conflito (o novo 'document' fornecido já está em uso por outro tenant).",
+                        "description": "Chave já definida para o tenant.",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
                     "500": {
-                        "description": "Erro interno do servidor.",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
@@ -536,49 +507,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/list": {
-            "get": {
+        "/api/custom-fields/{uuid}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retorna uma lista paginada de usuários.",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Remove a definição. Os valores já gravados no 'metadata' dos registros são mantidos, mas a chave deixa de ser aceita em novas alterações.",
                 "tags": [
-                    "User"
+                    "CustomFields"
                 ],
-                "summary": "Lista Usuários",
+                "summary": "Remove um campo personalizado",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Número da página (padrão 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Tamanho da página (padrão 10)",
-                        "name": "size",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Filtro opcional: UUID ou Documento do Tenant (Apenas para SystemAdmin)",
-                        "name": "tenant_identifier",
-                        "in": "query"
+                        "description": "UUID do campo",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/user.UserResponseDto"
-                            }
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Campo não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
                     "500": {
@@ -588,36 +557,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/user/{identifier}": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Busca um usuário. Se o identificador for passado na URL, busca aquele usuário específico. Se for vazio (/api/user), busca o perfil do usuário logado.",
+                "description": "Altera rótulo, obrigatoriedade, valores do enum ou padrão de um campo. Chave, tipo e entidade não podem ser alterados. Os valores já gravados não são revalidados; a nova regra vale a partir da próxima alteração do registro.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "CustomFields"
                 ],
-                "summary": "Busca um Usuário",
+                "summary": "Altera um campo personalizado",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID ou Email do usuário (Opcional)",
-                        "name": "identifier",
-                        "in": "path"
+                        "description": "UUID do campo",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Atributos a alterar",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/custom_field.UpdateCustomFieldRequestDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.UserResponseDto"
+                            "$ref": "#/definitions/custom_field.CustomFieldResponseDto"
                         }
                     },
                     "400": {
@@ -633,7 +613,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Campo não encontrado.",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
@@ -645,14 +625,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/domain": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Registra um novo usuário no sistema, associado a um tenant (empresa/organização).",
+                "description": "Cadastra um domínio próprio para o tenant. O domínio só passa a resolver o tenant após a verificação: publique o registro TXT retornado em 'verification' e chame /api/domain/{uuid}/verify.",
                 "consumes": [
                     "application/json"
                 ],
@@ -660,101 +642,305 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Domain"
                 ],
-                "summary": "Cria um novo Usuário",
+                "summary": "Cadastra um domínio próprio",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Identificador (UUID ou Documento) do Tenant ao qual o usuário será associado.",
-                        "name": "identifier",
-                        "in": "path",
-                        "required": true
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
                     },
                     {
-                        "description": "Objeto do usuário que precisa ser criado.",
+                        "description": "Domínio",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.CreateUserRequestDto"
+                            "$ref": "#/definitions/tenant_domain.AddCustomDomainRequestDto"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Usuário criado com sucesso.",
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/user.UserResponseDto"
+                            "$ref": "#/definitions/tenant_domain.DomainResponseDto"
                         }
                     },
                     "400": {
-                        "description": "Requisição inválida (corpo JSON mal formatado, dados de entrada inválidos, ou 'identifier' do tenant ausente).",
+                        "description": "Domínio inválido.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Domínio já cadastrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/domain/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista o subdomínio e os domínios próprios do tenant. Domínios próprios pendentes trazem o registro TXT que deve ser publicado para a verificação.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Domain"
+                ],
+                "summary": "Lista os domínios do Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tenant_domain.DomainsResponseDto"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
                     "404": {
-                        "description": "Tenant não encontrado (o 'identifier' fornecido não corresponde a nenhum tenant existente).",
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/domain/subdomain": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Define ou troca o subdomínio do tenant sob o domínio base da plataforma (ex.: 'acme' -\u003e acme.\u003cbase_domain\u003e). Não exige verificação.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Domain"
+                ],
+                "summary": "Define o subdomínio do Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Subdomínio",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tenant_domain.SetSubdomainRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tenant_domain.DomainResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Subdomínio inválido ou reservado.",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
                     "409": {
-                        "description": "Conflito (o 'email' fornecido já está em uso).",
+                        "description": "Subdomínio em uso por outro tenant.",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
                     "500": {
-                        "description": "Erro interno do servidor.",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/domain/{uuid}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Exclui permanentemente um usuário no sistema usando o UUID ou o Email passado na URL.",
+                "description": "Remove o subdomínio ou domínio próprio. O host deixa de resolver o tenant imediatamente.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Domain"
                 ],
-                "summary": "Deleta um Usuário",
+                "summary": "Remove um domínio do Tenant",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID ou Email do usuário a ser deletado",
-                        "name": "identifier",
+                        "description": "UUID do domínio",
+                        "name": "uuid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "No Content"
+                        "description": "Domínio removido"
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Domínio não encontrado.",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
+                    }
+                }
+            }
+        },
+        "/api/domain/{uuid}/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Consulta o registro TXT '_tenant-verification.\u003cdomínio\u003e' e, se o valor conferir com o token do domínio, marca-o como verificado. A partir daí o domínio resolve o tenant.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Domain"
+                ],
+                "summary": "Verifica um domínio próprio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do domínio",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tenant_domain.DomainResponseDto"
+                        }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Domínio não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Domínio já verificado ou registro TXT não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "502": {
+                        "description": "Falha ao consultar o DNS.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/feature-flags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna todas as feature flags globais, ordenadas pela chave. Apenas SystemAdmin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Lista as feature flags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/feature_flag.FeatureFlagsResponseDto"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
@@ -767,13 +953,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Atualiza dados de um usuário existente. O usuário a ser atualizado é identificado pelo UUID/Email no path.",
+                "description": "Cria uma flag global. Ligada, vale para 'rollout_percentage'% dos tenants ('rollout_by' = tenant) ou dos usuários ('rollout_by' = user), sorteados por um hash estável do UUID. Overrides por tenant ou usuário têm precedência. Apenas SystemAdmin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -781,32 +967,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "FeatureFlags"
                 ],
-                "summary": "Atualiza um Usuário",
+                "summary": "Cria uma feature flag",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Idenficador do usuário",
-                        "name": "identifier",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Dados para atualização",
+                        "description": "Dados da flag",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.UpdateUserRequestDto"
+                            "$ref": "#/definitions/feature_flag.CreateFeatureFlagRequestDto"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/user.UserResponseDto"
+                            "$ref": "#/definitions/feature_flag.FeatureFlagResponseDto"
                         }
                     },
                     "400": {
@@ -815,14 +994,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
                     },
                     "409": {
-                        "description": "Conflict",
+                        "description": "Chave já existe.",
                         "schema": {
                             "$ref": "#/definitions/rest_err.RestErr"
                         }
@@ -835,137 +1014,6975 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "auth.LoginRequest": {
+        },
+        "/api/feature-flags/{key}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna a flag e os seus overrides por tenant e por usuário. Apenas SystemAdmin.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Detalha uma feature flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chave da flag",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/feature_flag.FeatureFlagDetailResponseDto"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a flag e todos os seus overrides. Código que ainda consulta a flag passa a recebê-la desligada. Apenas SystemAdmin.",
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Remove uma feature flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chave da flag",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Altera descrição, estado global e liberação gradual. A chave não pode ser alterada. Aumentar o percentual mantém liberados os tenants ou usuários que já estavam. Apenas SystemAdmin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Altera uma feature flag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chave da flag",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Atributos a alterar",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/feature_flag.UpdateFeatureFlagRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/feature_flag.FeatureFlagResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/feature-flags/{key}/tenants/{identifier}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liga ou desliga a flag para o tenant e seus descendentes (que podem ter o próprio override), independentemente do estado global e do percentual. Overrides de usuário têm precedência. Apenas SystemAdmin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Define o override de um tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chave da flag",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant",
+                        "name": "identifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Estado da flag para o tenant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/feature_flag.OverrideRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/feature_flag.OverrideResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "O tenant volta a herdar o override do ancestral mais próximo ou a seguir o estado global. Apenas SystemAdmin.",
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Remove o override de um tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chave da flag",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant",
+                        "name": "identifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/feature-flags/{key}/users/{uuid}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Liga ou desliga a flag para o usuário, com precedência sobre o override do tenant e o estado global. Apenas SystemAdmin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Define o override de um usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chave da flag",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID do usuário",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Estado da flag para o usuário",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/feature_flag.OverrideRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/feature_flag.OverrideResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "O usuário volta a seguir o override do tenant ou o estado global. Apenas SystemAdmin.",
+                "tags": [
+                    "FeatureFlags"
+                ],
+                "summary": "Remove o override de um usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chave da flag",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID do usuário",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria um grupo (time/departamento) dentro do tenant do usuário autenticado.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Cria um novo Grupo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Dados do grupo",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/group.CreateGroupRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/group.GroupResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Já existe um grupo com este nome no tenant.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna uma lista paginada dos grupos do tenant.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Lista Grupos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Número da página (padrão 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tamanho da página (padrão 10, máximo 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/group.GroupsResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/user/{identifier}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna os grupos do tenant aos quais o usuário (UUID ou Email) pertence. TenantUser só pode consultar a si mesmo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Lista os grupos de um Usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Email do usuário",
+                        "name": "identifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/group.GroupResponseDto"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca um grupo do tenant pelo UUID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Busca um Grupo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do grupo",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/group.GroupResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exclui um grupo do tenant. Os vínculos de membros são removidos junto.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Deleta um Grupo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do grupo",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atualiza nome e/ou descrição de um grupo do tenant.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Atualiza um Grupo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do grupo",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Campos a serem atualizados",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/group.UpdateGroupRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/group.GroupResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{uuid}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna uma lista paginada dos usuários vinculados ao grupo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Lista membros do Grupo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do grupo",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Número da página (padrão 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tamanho da página (padrão 10, máximo 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/group.MembersResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Vincula um usuário (UUID ou Email) do mesmo tenant ao grupo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Adiciona um membro ao Grupo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do grupo",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Usuário a ser adicionado",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/group.AddMemberRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Usuário pertence a outro tenant.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Usuário já é membro do grupo.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/group/{uuid}/members/{user}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove o vínculo de um usuário com o grupo.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Group"
+                ],
+                "summary": "Remove um membro do Grupo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do grupo",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID do usuário",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna os dados do usuário autenticado, incluindo telefone, avatar, idioma e fuso horário.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Consulta o próprio perfil",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.UserResponseDto"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exclui o usuário autenticado, confirmando a senha atual, e encerra todas as suas sessões. Depende da configuração 'allow_self_delete' do tenant; o último administrador (TENANT_ADMIN ou PARTNER_ADMIN) do tenant não pode se excluir. SYSTEM_ADMIN não pode usar esta rota.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Exclui a própria conta",
+                "parameters": [
+                    {
+                        "description": "Senha atual",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/me.DeleteAccountRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Conta excluída"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Senha incorreta ou exclusão não permitida pelo tenant",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Último administrador do tenant",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Altera nome, telefone, avatar (URL https), idioma (BCP 47) e fuso horário (IANA) do usuário autenticado. Apenas os campos enviados são alterados; \"\" limpa o campo (exceto o nome). Email, role e campos personalizados continuam em PATCH /api/user/{identifier}.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Atualiza o próprio perfil",
+                "parameters": [
+                    {
+                        "description": "Campos do perfil",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/me.UpdateProfileRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.UserResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Campo inválido",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/logins": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista as tentativas de login do usuário autenticado (sucesso e falha), da mais recente para a mais antiga, com paginação por cursor.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Histórico de login",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tamanho da página (padrão 10, máximo 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor da página anterior",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/me.LoginHistoryResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Cursor ou tamanho inválido",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Troca a senha do usuário autenticado mediante a senha atual. As demais sessões do usuário são encerradas; a sessão atual continua válida.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Troca a própria senha",
+                "parameters": [
+                    {
+                        "description": "Senha atual e nova senha (mínimo de 8 caracteres)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/me.ChangePasswordRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Senha alterada"
+                    },
+                    "400": {
+                        "description": "Nova senha inválida ou igual à atual",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Senha atual incorreta",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/metering/series": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Chamadas, erros e latência (p50/p95) por hora ou dia, método e endpoint. SystemAdmin vê todos os tenants (ou o informado); TenantAdmin vê o próprio tenant.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metering"
+                ],
+                "summary": "Série de Consumo por Endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (apenas SystemAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "day",
+                        "description": "hour ou day",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rota exata, ex: /api/user/:uuid",
+                        "name": "endpoint",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial YYYY-MM-DD (padrão: 6 dias antes de 'to')",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final inclusiva YYYY-MM-DD (padrão: hoje, UTC)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Página",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Itens por página",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/metering.SeriesResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/metering/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Totais de chamadas, erros e latência por tenant no intervalo, ordenados pelo número de chamadas. SystemAdmin vê todos os tenants (ou o informado); TenantAdmin vê o próprio tenant. Dados consolidados periodicamente a partir do access_log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metering"
+                ],
+                "summary": "Resumo de Consumo por Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (apenas SystemAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data inicial YYYY-MM-DD (padrão: 6 dias antes de 'to')",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data final inclusiva YYYY-MM-DD (padrão: hoje, UTC)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Página",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Itens por página",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/metering.SummaryResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/plan": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adiciona um plano ao catálogo. Limites omitidos são ilimitados.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plan"
+                ],
+                "summary": "Cria um Plano",
+                "parameters": [
+                    {
+                        "description": "Dados do plano",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/plan.CreatePlanRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/plan.PlanResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Já existe um plano com este código.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/plan/assign": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Associa o tenant a um plano do catálogo. Os novos limites valem imediatamente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plan"
+                ],
+                "summary": "Define o Plano de um Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant",
+                        "name": "tenant_identifier",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Código do plano",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/plan.AssignPlanRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/plan.PlanResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant ou plano não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/plan/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista o catálogo de planos com seus limites (null = ilimitado).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plan"
+                ],
+                "summary": "Lista os Planos",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/plan.PlansResponseDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/plan/{code}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Substitui o nome e os limites do plano. Limites omitidos passam a ser ilimitados. Vale imediatamente para todos os tenants do plano.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plan"
+                ],
+                "summary": "Atualiza um Plano",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código do plano",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Nome e limites",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/plan.UpdatePlanRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/plan.PlanResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Plano não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove um plano do catálogo. Planos contratados por algum tenant, ou o plano padrão, não podem ser removidos.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plan"
+                ],
+                "summary": "Remove um Plano",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Código do plano",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Plano removido"
+                    },
+                    "404": {
+                        "description": "Plano não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Plano em uso.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/privacy/certificates/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o certificado emitido na anonimização de um usuário.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Privacy"
+                ],
+                "summary": "Consulta um Certificado de Eliminação",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do certificado",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/privacy.CertificateResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Certificado não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/privacy/holds": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista as retenções ativas; all=true inclui as já liberadas. Apenas SYSTEM_ADMIN.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Privacy"
+                ],
+                "summary": "Lista as Retenções Legais",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Incluir as retenções liberadas",
+                        "name": "all",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/privacy.HoldResponseDto"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Impede a anonimização do usuário ou de todos os usuários do tenant (informe apenas um) até que a retenção seja liberada. Apenas SYSTEM_ADMIN.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Privacy"
+                ],
+                "summary": "Cria uma Retenção Legal",
+                "parameters": [
+                    {
+                        "description": "Usuário ou tenant e motivo",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/privacy.CreateHoldRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/privacy.HoldResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/privacy/holds/{uuid}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Encerra a retenção; o registro é mantido com a data e o autor da liberação. Apenas SYSTEM_ADMIN.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Privacy"
+                ],
+                "summary": "Libera uma Retenção Legal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID da retenção",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/privacy.HoldResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Retenção não encontrada ou já liberada.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/privacy/users/{uuid}/anonymize": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Substitui o nome, o email e o telefone do usuário por um pseudônimo no cadastro, no access log, no audit log e no histórico de login, mascara os seus IPs, revoga as sessões e torna a senha inutilizável. O registro do usuário é mantido para as referências e estatísticas. Retorna o certificado de eliminação. Uma anonimização interrompida é retomada ao repetir o pedido; um usuário já anonimizado retorna o certificado existente.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Privacy"
+                ],
+                "summary": "Anonimiza um Usuário (LGPD)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do usuário",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motivo do pedido",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/privacy.AnonymizeRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Usuário já anonimizado",
+                        "schema": {
+                            "$ref": "#/definitions/privacy.CertificateResponseDto"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/privacy.CertificateResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Usuário não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Usuário ou tenant sob retenção legal, ou exportação/importação em andamento.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca aproximada (trigramas) e por palavras em nome e documento de tenants e em nome e email de usuários. Os resultados vêm agrupados por tipo, ordenados por relevância (score), com o trecho encontrado destacado entre \u003cmark\u003e e \u003c/mark\u003e. SYSTEM_ADMIN pesquisa todos os tenants; PARTNER_ADMIN, o próprio tenant e seus descendentes; TENANT_ADMIN, apenas o próprio tenant.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Busca tenants e usuários",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Termo de busca (2 a 100 caracteres)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipos pesquisados, separados por vírgula: tenant, user (padrão: todos)",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resultados por tipo (padrão 10, máximo 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/search.SearchResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o valor efetivo de todas as configurações do tenant e a origem de cada uma: 'tenant' (sobrescrito pelo tenant), 'parent' (herdado de um tenant ancestral), 'global' (configs.json) ou 'default' (padrão da definição).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Lista as configurações do Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/settings.SettingsResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sobrescreve configurações do tenant. O corpo é um objeto chave -\u003e valor; cada valor é validado contra a definição da configuração (veja /api/settings/schema). Informe null para remover a sobrescrita e voltar a herdar o valor. A alteração é atômica: se algum valor for inválido, nada é gravado.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Altera as configurações do Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Configurações a alterar, ex.: {\\",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/settings.SettingsResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Configuração desconhecida, valor inválido ou allowed_ip_ranges sem o IP da requisição no próprio tenant.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/settings/schema": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna todas as configurações disponíveis, com tipo, descrição e valor padrão.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Lista as definições de configuração",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/settings.DefinitionsResponseDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca um tenant no sistema usando o UUID ou o Documento (CNPJ/CPF). Pelo menos um dos dois campos deve ser fornecido.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Busca um Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant a ser buscado. (Ex: 8871abf3-ed11-4770-b986-e8d98d022d4f)",
+                        "name": "uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Documento (CNPJ/CPF) do tenant a ser buscado. (Ex: 12345678901234)",
+                        "name": "document",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "model.Tenant encontrado com sucesso.",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TenantResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida (UUID inválido, ou nenhum dos campos 'uuid'/'document' fornecido).",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "model.Tenant não encontrado com os dados fornecidos.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exclui logicamente um tenant usando o UUID ou o Documento (CNPJ/CPF). Pelo menos um dos dois campos deve ser fornecido. O tenant deixa de ser listado, seus usuários não conseguem mais autenticar e todas as sessões ativas são revogadas. Pode ser restaurado por um SYSTEM_ADMIN dentro do período de carência; depois disso é expurgado definitivamente.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Deleta um model.Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant a ser excluído. (Ex: 8871abf3-ed11-4770-b986-e8d98d022d4f)",
+                        "name": "uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Documento (CNPJ/CPF) do tenant a ser excluído. (Ex: 12345678901234)",
+                        "name": "document",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "model.Tenant excluído com sucesso (No Content).",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida (UUID inválido, ou nenhum dos campos 'uuid'/'document' fornecido).",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "model.Tenant não encontrado com os dados fornecidos.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/create": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria um novo tenant no sistema",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Cria um novo tenant",
+                "parameters": [
+                    {
+                        "description": "Dados do tenant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tenant.CreateTenantRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TenantResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "403": {
+                        "description": "Isolamento por schema ou banco solicitado por quem não é SYSTEM_ADMIN",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "422": {
+                        "description": "Modo de isolamento desabilitado ou banco dedicado inacessível",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria um tenant a partir de um pacote gerado pela exportação. 'restore' mantém os UUIDs (ex.: produção → homologação); 'clone' gera novos UUIDs, nome e documento (ex.: tenant modelo). Usuários, grupos, configurações e domínios são inseridos em uma única transação; sessões e logs não são importados. Usuários importados precisam redefinir a senha. Com dry_run=true nada é gravado e o relatório mostra o que seria criado.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Importa um Tenant",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Pacote .zip da exportação",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "restore (padrão) ou clone",
+                        "name": "mode",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "fail (padrão), skip ou rename",
+                        "name": "email_conflict",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Apenas simula a importação",
+                        "name": "dry_run",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Nome do novo tenant (clone)",
+                        "name": "name",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Documento do novo tenant (clone)",
+                        "name": "document",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tipo do documento do novo tenant: cpf, cnpj ou foreign (clone; vazio = detecção automática)",
+                        "name": "document_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant pai do tenant importado",
+                        "name": "parent_uuid",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Simulação (dry_run)",
+                        "schema": {
+                            "$ref": "#/definitions/tenant_export.ImportResponseDto"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/tenant_export.ImportResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Pacote, versão, checksum ou opções inválidos.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "402": {
+                        "description": "Usuários importados acima do limite do plano.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant pai não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Tenant, documento ou emails já existentes.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna os tenants com filtros, ordenação e paginação por cursor. SYSTEM_ADMIN vê todos os tenants; PARTNER_ADMIN, apenas a própria hierarquia. A resposta traz o total de registros e o cursor da próxima página, também informado no cabeçalho Link (rel=\"next\").",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Lista Tenants",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filtra pelo nome (contém, sem diferenciar maiúsculas).",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra pelo status (trial, active, past_due, suspended, cancelled).",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Criados a partir de (2006-01-02 ou RFC 3339, inclusivo).",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Criados até (2006-01-02 inclui o dia inteiro).",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "name",
+                        "description": "Campo de ordenação: name, document, create_at ou update_at. Prefixo '-' para ordem decrescente.",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "O número de itens por página (máximo 100).",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor da próxima página (nextCursor da resposta anterior).",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra por campo personalizado (valor exato), ex.: metadata[contract_number]=123.",
+                        "name": "metadata[chave]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lista de tenants retornada com sucesso.",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TenantPageResponseDto"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links da primeira e da próxima página (RFC 8288)."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Filtro, ordenação, cursor ou tamanho de página inválidos.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/onboard": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria, em uma única transação, o tenant, seu primeiro TENANT_ADMIN, as configurações, o plano e as etapas de provisionamento configuradas. Sem 'admin.password' o admin recebe um convite por email para definir a senha. Idempotente pelo documento: repetir a mesma requisição retorna 200 com o onboarding existente (replayed = true).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Onboarding de Tenant",
+                "parameters": [
+                    {
+                        "description": "Tenant, admin, configurações e plano",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/onboarding.OnboardRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Onboarding já realizado com os mesmos dados",
+                        "schema": {
+                            "$ref": "#/definitions/onboarding.OnboardResponseDto"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/onboarding.OnboardResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant fora da hierarquia ou plan_code enviado por quem não é SYSTEM_ADMIN.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant pai ou plano não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Documento ou email já existentes.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o plano do tenant e o consumo de cada limite no período corrente (mês UTC). Limites null são ilimitados.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Consumo do Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/plan.UsageResponseDto"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atualiza dados de um tenant existente. O tenant a ser atualizado é identificado pelo UUID no path",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Atualiza um Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant a ser atualizado.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Campos do tenant a serem atualizados. Apenas os campos presentes serão modificados.",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tenant.UpdateTenantRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "model.Tenant atualizado com sucesso.",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TenantResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida (corpo JSON mal formatado, UUID inválido ou dados de entrada inválidos).",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "model.Tenant não encontrado para o UUID fornecido.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflito (o novo 'document' fornecido já está em uso por outro tenant).",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Muda o status do tenant para active (a partir de trial, past_due ou suspended). O motivo é gravado no histórico de status. PARTNER_ADMIN só altera tenants descendentes, nunca o próprio.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Ativa um Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motivo da mudança",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TransitionTenantRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status alterado com sucesso.",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TenantResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "UUID inválido ou motivo ausente.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant fora da hierarquia do usuário.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Transição não permitida a partir do status atual.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Muda o status do tenant para cancelled. O cancelamento é definitivo: o acesso fica bloqueado e nenhuma outra transição é permitida. Os dados são mantidos; para removê-los, exclua o tenant. PARTNER_ADMIN só altera tenants descendentes, nunca o próprio.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Cancela um Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motivo da mudança",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TransitionTenantRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status alterado com sucesso.",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TenantResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "UUID inválido ou motivo ausente.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant fora da hierarquia do usuário.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Transição não permitida a partir do status atual.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}/export": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Inicia em segundo plano a exportação completa do tenant (tenant, usuários sem senha, sessões, grupos, configurações, domínios, access log e audit log) em um zip com manifest.json e checksums SHA-256. Acompanhe pelo status; o arquivo pode ser baixado uma única vez.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Exporta os Dados de um Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Formato (json ou ndjson)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/tenant_export.CreateExportRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/tenant_export.ExportResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Exportação já em andamento.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}/export/{export_uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o andamento da exportação: pending, running, ready (download_url disponível), failed, downloaded ou expired.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Status da Exportação",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID da exportação",
+                        "name": "export_uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tenant_export.ExportResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Exportação não encontrada.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}/export/{export_uuid}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Entrega o zip da exportação. O download é permitido uma única vez: o arquivo é removido do servidor após a entrega.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Download da Exportação",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID da exportação",
+                        "name": "export_uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Exportação não encontrada.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Exportação indisponível para download.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}/past-due": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Muda o status do tenant para past_due: os usuários continuam autenticando, mas apenas requisições de leitura (GET) são aceitas. PARTNER_ADMIN só altera tenants descendentes, nunca o próprio.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Marca um Tenant com pagamento pendente",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motivo da mudança",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TransitionTenantRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status alterado com sucesso.",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TenantResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "UUID inválido ou motivo ausente.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant fora da hierarquia do usuário.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Transição não permitida a partir do status atual.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Desfaz a exclusão lógica de um tenant, desde que ainda esteja dentro do período de carência configurado (tenant.purge.grace_period_days). As sessões revogadas na exclusão não são restauradas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Restaura um Tenant excluído",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant a ser restaurado.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tenant restaurado com sucesso.",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TenantResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "UUID inválido.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Tenant não está excluído ou o período de carência expirou.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}/scim/token": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna os dados do token ativo (sem o valor): emissão, autor e último uso.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "Consulta o Token SCIM do Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.TokenResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Nenhum token ativo.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Gera o bearer token usado pelo provedor de identidade nos endpoints /scim/v2, revogando o token anterior. O token é exibido apenas nesta resposta.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "Emite o Token SCIM do Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/scim.TokenResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "402": {
+                        "description": "Limite de chaves de API do plano atingido (tokens ativos \u003e= max_api_keys).",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoga o token ativo. O provedor de identidade deixa de conseguir provisionar usuários até que um novo token seja emitido.",
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "Revoga o Token SCIM do Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Token revogado."
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Nenhum token ativo.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}/status/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o status atual e todas as mudanças de status do tenant, da mais recente para a mais antiga. TENANT_ADMIN consulta apenas o próprio tenant; PARTNER_ADMIN, tenants da própria hierarquia.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Histórico de status de um Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Histórico retornado com sucesso.",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TenantStatusHistoryResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "UUID inválido.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant fora do escopo do usuário.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}/subtree": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna todos os tenants descendentes (filhos, netos...) do tenant informado, com os mesmos filtros, ordenação e paginação por cursor de /api/tenant/list. PARTNER_ADMIN só pode consultar tenants da própria hierarquia. O cursor da próxima página também é informado no cabeçalho Link (rel=\"next\").",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Lista a subárvore de um Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant raiz da subárvore.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra pelo nome (contém, sem diferenciar maiúsculas).",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra pelo status (trial, active, past_due, suspended, cancelled).",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Criados a partir de (2006-01-02 ou RFC 3339, inclusivo).",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Criados até (2006-01-02 inclui o dia inteiro).",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "name",
+                        "description": "Campo de ordenação: name, document, create_at ou update_at. Prefixo '-' para ordem decrescente.",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "O número de itens por página (máximo 100).",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor da próxima página (nextCursor da resposta anterior).",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra por campo personalizado (valor exato), ex.: metadata[contract_number]=123.",
+                        "name": "metadata[chave]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Descendentes retornados com sucesso.",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TenantPageResponseDto"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links da primeira e da próxima página (RFC 8288)."
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "UUID, filtro, ordenação, cursor ou tamanho de página inválidos.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant fora da hierarquia do usuário.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tenant/{uuid}/suspend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Muda o status do tenant para suspended: os usuários deixam de autenticar e as requisições com tokens já emitidos são bloqueadas. Pode ser revertido com /activate. PARTNER_ADMIN só altera tenants descendentes, nunca o próprio.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenant"
+                ],
+                "summary": "Suspende um Tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do tenant.",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Motivo da mudança",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TransitionTenantRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status alterado com sucesso.",
+                        "schema": {
+                            "$ref": "#/definitions/tenant.TenantResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "UUID inválido ou motivo ausente.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Tenant fora da hierarquia do usuário.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Transição não permitida a partir do status atual.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recebe uma planilha CSV (separada por vírgula ou ponto e vírgula) ou XLSX com o cabeçalho name, email e, opcionalmente, role (padrão TENANT_USER), password e metadata.\u003cchave\u003e. Todas as linhas são validadas (formato do email, role permitida ao seu perfil, campos personalizados e emails repetidos no arquivo ou já cadastrados) e os erros são informados por linha. Com dry_run=true nada é criado. Caso contrário, os usuários são criados em lotes, em segundo plano: linhas sem senha recebem um convite por email para definir a senha. Acompanhe pelo status e baixe o resultado de cada linha ao final.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Importa Usuários em Lote",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Planilha .csv ou .xlsx",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID ou Documento do tenant (obrigatório para SYSTEM_ADMIN; PARTNER_ADMIN usa o próprio se vazio)",
+                        "name": "tenant_identifier",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Apenas valida o arquivo",
+                        "name": "dry_run",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Importa as linhas válidas mesmo que outras tenham erros",
+                        "name": "skip_invalid",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Validação (dry_run)",
+                        "schema": {
+                            "$ref": "#/definitions/user_import.ImportResponseDto"
+                        }
+                    },
+                    "202": {
+                        "description": "Importação agendada (job)",
+                        "schema": {
+                            "$ref": "#/definitions/user_import.ImportResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Arquivo ilegível, sem cabeçalho válido ou grande demais.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "422": {
+                        "description": "Linhas inválidas (sem skip_invalid) ou nenhuma linha válida.",
+                        "schema": {
+                            "$ref": "#/definitions/user_import.ImportResponseDto"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/import/{uuid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna o andamento da importação: pending, running, completed (result_url disponível), failed ou expired, com os contadores de usuários criados, convidados e com falha.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Status da Importação de Usuários",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID da importação",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user_import.JobResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Importação não encontrada.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/import/{uuid}/result": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Entrega o CSV com o resultado de cada linha (line, email, status, user_uuid, message). status: created, invited, failed ou skipped (inválida na validação). Disponível até expirar (users.import.ttl_hours).",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Resultado da Importação de Usuários",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID da importação",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Importação não encontrada.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Resultado indisponível.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retorna os usuários com filtros, ordenação e paginação por cursor. A resposta traz o total de registros e o cursor da próxima página, também informado no cabeçalho Link (rel=\"next\"). Com \"Accept: text/csv\", todos os usuários filtrados são enviados em CSV (sem paginação), na ordenação pedida.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Lista Usuários",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filtro opcional: UUID ou Documento do Tenant (SystemAdmin e PartnerAdmin)",
+                        "name": "tenant_identifier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra pelo nome (contém, sem diferenciar maiúsculas)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra pelo email (contém, sem diferenciar maiúsculas)",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra pelo domínio do email, ex.: empresa.com.br",
+                        "name": "email_domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra pela role (SYSTEM_ADMIN, PARTNER_ADMIN, TENANT_ADMIN, TENANT_USER)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filtra usuários ativos (true) ou inativos (false)",
+                        "name": "live",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Criados a partir de (2006-01-02 ou RFC 3339, inclusivo)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Criados até (2006-01-02 inclui o dia inteiro)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Atualizados a partir de (2006-01-02 ou RFC 3339, inclusivo)",
+                        "name": "updated_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Atualizados até (2006-01-02 inclui o dia inteiro)",
+                        "name": "updated_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Último login a partir de (usuários sem login ficam de fora)",
+                        "name": "last_login_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Último login até (usuários sem login ficam de fora)",
+                        "name": "last_login_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "name",
+                        "description": "Campo de ordenação: name, email, create_at, update_at ou last_login_at. Prefixo '-' para ordem decrescente",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tamanho da página (padrão 10, máximo 100)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor da próxima página (next_cursor da resposta anterior)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filtra por campo personalizado (valor exato), ex.: metadata[cost_center]=CC-10",
+                        "name": "metadata[chave]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.UserListResponseDto"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Links da primeira e da próxima página (RFC 8288)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/{identifier}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Busca um usuário. Se o identificador for passado na URL, busca aquele usuário específico. Se for vazio (/api/user), busca o perfil do usuário logado.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Busca um Usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Email do usuário (Opcional)",
+                        "name": "identifier",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.UserResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registra um novo usuário no sistema, associado a um tenant (empresa/organização).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Cria um novo Usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identificador (UUID ou Documento) do Tenant ao qual o usuário será associado.",
+                        "name": "identifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Objeto do usuário que precisa ser criado.",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.CreateUserRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Usuário criado com sucesso.",
+                        "schema": {
+                            "$ref": "#/definitions/user.UserResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Requisição inválida (corpo JSON mal formatado, dados de entrada inválidos, ou 'identifier' do tenant ausente).",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Tenant não encontrado (o 'identifier' fornecido não corresponde a nenhum tenant existente).",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflito (o 'email' fornecido já está em uso).",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Erro interno do servidor.",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exclui permanentemente um usuário no sistema usando o UUID ou o Email passado na URL.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Deleta um Usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID ou Email do usuário a ser deletado",
+                        "name": "identifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Atualiza dados de um usuário existente. O usuário a ser atualizado é identificado pelo UUID/Email no path.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Atualiza um Usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Idenficador do usuário",
+                        "name": "identifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Dados para atualização",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.UpdateUserRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.UserResponseDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest_err.RestErr"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Groups": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista os grupos do tenant do token. Filtros: comparações \"eq\" unidas por \"and\" sobre displayName e id. Use excludedAttributes=members para omitir os membros.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Lista Grupos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filtro (ex.: displayName eq \\",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Posição do primeiro resultado (padrão 1)",
+                        "name": "startIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resultados por página (padrão e máximo 100)",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "members para omitir os membros",
+                        "name": "excludedAttributes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalidFilter",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria o grupo no tenant do token. Os membros (value = id do usuário) devem pertencer ao tenant.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Cria Grupo",
+                "parameters": [
+                    {
+                        "description": "Grupo",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.GroupResource"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/scim.GroupResource"
+                        }
+                    },
+                    "400": {
+                        "description": "invalidValue ou invalidSyntax",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "uniqueness",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Groups/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Consulta Grupo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do grupo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "members para omitir os membros",
+                        "name": "excludedAttributes",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.GroupResource"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Substitui o nome e o conjunto completo de membros do grupo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Substitui Grupo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do grupo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Grupo",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.GroupResource"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.GroupResource"
+                        }
+                    },
+                    "400": {
+                        "description": "invalidValue ou invalidSyntax",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "uniqueness",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Exclui Grupo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do grupo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Grupo excluído."
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aplica operações add, replace e remove (RFC 7644, seção 3.5.2). Membros podem ser removidos por filtro (members[value eq \"id\"]) ou por uma lista em value.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Altera Grupo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do grupo",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Operações",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.PatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.GroupResource"
+                        }
+                    },
+                    "400": {
+                        "description": "invalidSyntax, invalidPath, invalidValue ou noTarget",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "uniqueness",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/ResourceTypes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista os tipos de recurso suportados (User e Group).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Tipos de Recurso",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/ServiceProviderConfig": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Recursos suportados (RFC 7643, seção 5): PATCH e filtros \"eq\" unidos por \"and\"; bulk, sort, etag e troca de senha não são suportados.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Configuração do Provedor",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lista os usuários do tenant do token. Filtros: comparações \"eq\" unidas por \"and\" sobre userName, emails.value, id e active (ex.: userName eq \"ana@empresa.com\"). Paginação por startIndex (a partir de 1) e count (máximo 100).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Lista Usuários",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filtro (ex.: userName eq \\",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Posição do primeiro resultado (padrão 1)",
+                        "name": "startIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Resultados por página (padrão e máximo 100)",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalidFilter",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cria o usuário no tenant do token com o perfil TENANT_USER. userName deve ser o email. Sem password, o usuário define a senha pela redefinição de senha.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Cria Usuário",
+                "parameters": [
+                    {
+                        "description": "Usuário",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.UserResource"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/scim.UserResource"
+                        }
+                    },
+                    "400": {
+                        "description": "invalidValue ou invalidSyntax",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "402": {
+                        "description": "Limite do plano atingido.",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "uniqueness",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim/v2/Users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Consulta Usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.UserResource"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Substitui o usuário: nome, userName (email), active, telefone, locale e timezone. Desativar (active=false) encerra as sessões do usuário.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Substitui Usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Usuário",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.UserResource"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.UserResource"
+                        }
+                    },
+                    "400": {
+                        "description": "invalidValue ou invalidSyntax",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "uniqueness ou último administrador",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exclui o usuário e encerra as suas sessões.",
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Exclui Usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Usuário excluído."
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Último administrador do tenant.",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aplica operações add, replace e remove (RFC 7644, seção 3.5.2), com ou sem path, incluindo filtros no path (ex.: emails[type eq \"work\"].value). A ativação e a desativação usam o atributo active.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SCIM"
+                ],
+                "summary": "SCIM: Altera Usuário",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID do usuário",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Operações",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/scim.PatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/scim.UserResource"
+                        }
+                    },
+                    "400": {
+                        "description": "invalidSyntax, invalidPath, invalidValue ou noTarget",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "uniqueness ou último administrador",
+                        "schema": {
+                            "$ref": "#/definitions/scim.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "auth.HealthcheckResponse": {
+            "type": "object",
+            "properties": {
+                "expire": {
+                    "type": "string"
+                },
+                "feature_flags": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "system_time_utc": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/user.UserResponseDto"
+                }
+            }
+        },
+        "auth.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expire": {
+                    "type": "string"
+                },
+                "system_time_utc": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/user.UserResponseDto"
+                }
+            }
+        },
+        "auth.OTPRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.OTPResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "otp",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "otp": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "branding.BrandingResponseDto": {
+            "type": "object",
+            "properties": {
+                "footer": {
+                    "type": "string"
+                },
+                "logo_url": {
+                    "type": "string"
+                },
+                "primary_color": {
+                    "type": "string"
+                },
+                "sender_name": {
+                    "type": "string"
+                },
+                "sources": {
+                    "description": "Sources indica a origem de cada campo: tenant, parent, global ou default",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "tenant_uuid": {
+                    "description": "TenantUUID vazio indica o padrão do sistema",
+                    "type": "string"
+                }
+            }
+        },
+        "custom_field.CreateCustomFieldRequestDto": {
+            "type": "object",
+            "required": [
+                "entity",
+                "key",
+                "label",
+                "type"
+            ],
+            "properties": {
+                "entity": {
+                    "enum": [
+                        "tenant",
+                        "user"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.CustomFieldEntity"
+                        }
+                    ]
+                },
+                "enum_values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "enum": [
+                        "string",
+                        "number",
+                        "boolean",
+                        "date",
+                        "enum"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.CustomFieldType"
+                        }
+                    ]
+                }
+            }
+        },
+        "custom_field.CustomFieldResponseDto": {
+            "type": "object",
+            "properties": {
+                "create_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "$ref": "#/definitions/model.CustomFieldEntity"
+                },
+                "enum_values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "tenant_uuid": {
+                    "description": "null = definição global",
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/model.CustomFieldType"
+                },
+                "update_at": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "custom_field.CustomFieldsResponseDto": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "$ref": "#/definitions/model.CustomFieldEntity"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/custom_field.CustomFieldResponseDto"
+                    }
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "custom_field.UpdateCustomFieldRequestDto": {
+            "type": "object",
+            "properties": {
+                "enum_values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "label": {
+                    "type": "string"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "feature_flag.CreateFeatureFlagRequestDto": {
+            "type": "object",
+            "required": [
+                "key"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "rollout_by": {
+                    "description": "RolloutBy: tenant (padrão) ou user",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.RolloutSubject"
+                        }
+                    ]
+                },
+                "rollout_percentage": {
+                    "description": "RolloutPercentage é o percentual liberado quando a flag está ligada (padrão 100)",
+                    "type": "integer"
+                }
+            }
+        },
+        "feature_flag.FeatureFlagDetailResponseDto": {
+            "type": "object",
+            "properties": {
+                "create_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "overrides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/feature_flag.OverrideResponseDto"
+                    }
+                },
+                "rollout_by": {
+                    "$ref": "#/definitions/model.RolloutSubject"
+                },
+                "rollout_percentage": {
+                    "type": "integer"
+                },
+                "update_at": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "feature_flag.FeatureFlagResponseDto": {
+            "type": "object",
+            "properties": {
+                "create_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "rollout_by": {
+                    "$ref": "#/definitions/model.RolloutSubject"
+                },
+                "rollout_percentage": {
+                    "type": "integer"
+                },
+                "update_at": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "feature_flag.FeatureFlagsResponseDto": {
+            "type": "object",
+            "properties": {
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/feature_flag.FeatureFlagResponseDto"
+                    }
+                }
+            }
+        },
+        "feature_flag.OverrideRequestDto": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "feature_flag.OverrideResponseDto": {
+            "type": "object",
+            "properties": {
+                "create_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "feature_flag.UpdateFeatureFlagRequestDto": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "rollout_by": {
+                    "$ref": "#/definitions/model.RolloutSubject"
+                },
+                "rollout_percentage": {
+                    "type": "integer"
+                }
+            }
+        },
+        "group.AddMemberRequestDto": {
+            "type": "object",
+            "required": [
+                "user"
+            ],
+            "properties": {
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "group.CreateGroupRequestDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "group.GroupResponseDto": {
+            "type": "object",
+            "properties": {
+                "create_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                },
+                "update_at": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "group.GroupsResponseDto": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/group.GroupResponseDto"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "group.MemberResponseDto": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "group.MembersResponseDto": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/group.MemberResponseDto"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "group.UpdateGroupRequestDto": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "me.ChangePasswordRequestDto": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "me.DeleteAccountRequestDto": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "me.LoginAttemptResponseDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason é o motivo da falha: invalid_password, tenant_disabled ou ip_denied",
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "me.LoginHistoryResponseDto": {
+            "type": "object",
+            "properties": {
+                "logins": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/me.LoginAttemptResponseDto"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "me.UpdateProfileRequestDto": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "Locale (BCP 47) e Timezone (IANA) vazios usam a configuração do tenant",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "metering.SeriesResponseDto": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "granularity": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/metering.UsageRowResponseDto"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "metering.SummaryResponseDto": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "tenants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/metering.TenantSummaryResponseDto"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "metering.TenantSummaryResponseDto": {
+            "type": "object",
+            "properties": {
+                "avg_p50_ms": {
+                    "type": "number"
+                },
+                "calls": {
+                    "type": "integer"
+                },
+                "client_errors": {
+                    "type": "integer"
+                },
+                "max_p95_ms": {
+                    "type": "number"
+                },
+                "server_errors": {
+                    "type": "integer"
+                },
+                "tenant_name": {
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "metering.UsageRowResponseDto": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string"
+                },
+                "calls": {
+                    "type": "integer"
+                },
+                "client_errors": {
+                    "type": "integer"
+                },
+                "endpoint": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "p50_ms": {
+                    "type": "number"
+                },
+                "p95_ms": {
+                    "type": "number"
+                },
+                "server_errors": {
+                    "type": "integer"
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CustomFieldEntity": {
+            "type": "string",
+            "enum": [
+                "tenant",
+                "user"
+            ],
+            "x-enum-varnames": [
+                "CustomFieldEntityTenant",
+                "CustomFieldEntityUser"
+            ]
+        },
+        "model.CustomFieldType": {
+            "type": "string",
+            "enum": [
+                "string",
+                "number",
+                "boolean",
+                "date",
+                "enum"
+            ],
+            "x-enum-comments": {
+                "CustomFieldDate": "2006-01-02"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "",
+                "2006-01-02",
+                ""
+            ],
+            "x-enum-varnames": [
+                "CustomFieldString",
+                "CustomFieldNumber",
+                "CustomFieldBoolean",
+                "CustomFieldDate",
+                "CustomFieldEnum"
+            ]
+        },
+        "model.RolloutSubject": {
+            "type": "string",
+            "enum": [
+                "tenant",
+                "user"
+            ],
+            "x-enum-varnames": [
+                "RolloutByTenant",
+                "RolloutByUser"
+            ]
+        },
+        "onboarding.OnboardAdminRequestDto": {
+            "type": "object",
+            "required": [
+                "email",
+                "name"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "description": "Senha inicial. Se omitida, o admin recebe um convite por email para defini-la.",
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "onboarding.OnboardAdminResponseDto": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "onboarding.OnboardRequestDto": {
+            "type": "object",
+            "required": [
+                "admin",
+                "document",
+                "name"
+            ],
+            "properties": {
+                "admin": {
+                    "$ref": "#/definitions/onboarding.OnboardAdminRequestDto"
+                },
+                "document": {
+                    "type": "string"
+                },
+                "document_type": {
+                    "description": "DocumentType: cpf, cnpj ou foreign. Vazio = detecta CPF/CNPJ pelo formato",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "type": "string"
+                },
+                "plan_code": {
+                    "type": "string"
+                },
+                "settings": {
+                    "description": "Configurações do tenant (chave → valor), como em PATCH /api/settings",
+                    "type": "object"
+                }
+            }
+        },
+        "onboarding.OnboardResponseDto": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "$ref": "#/definitions/onboarding.OnboardAdminResponseDto"
+                },
+                "invite_sent": {
+                    "type": "boolean"
+                },
+                "invited": {
+                    "description": "Invited indica que o admin foi convidado por email (sem senha inicial)",
+                    "type": "boolean"
+                },
+                "replayed": {
+                    "description": "Replayed indica que o onboarding já havia sido feito com os mesmos dados",
+                    "type": "boolean"
+                },
+                "steps": {
+                    "description": "Steps lista as etapas de provisionamento executadas",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "tenant": {
+                    "$ref": "#/definitions/onboarding.OnboardTenantResponseDto"
+                }
+            }
+        },
+        "onboarding.OnboardTenantResponseDto": {
+            "type": "object",
+            "properties": {
+                "create_at": {
+                    "type": "string"
+                },
+                "document": {
+                    "type": "string"
+                },
+                "document_formatted": {
+                    "type": "string"
+                },
+                "document_type": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "plan.AssignPlanRequestDto": {
+            "type": "object",
+            "required": [
+                "plan_code"
+            ],
+            "properties": {
+                "plan_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "plan.CreatePlanRequestDto": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "max_api_keys": {
+                    "type": "integer"
+                },
+                "max_users": {
+                    "type": "integer"
+                },
+                "monthly_request_quota": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "plan.MetricUsageResponseDto": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "used": {
+                    "type": "integer"
+                }
+            }
+        },
+        "plan.PlanResponseDto": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "create_at": {
+                    "type": "string"
+                },
+                "max_api_keys": {
+                    "type": "integer"
+                },
+                "max_users": {
+                    "type": "integer"
+                },
+                "monthly_request_quota": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "update_at": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "plan.PlansResponseDto": {
+            "type": "object",
+            "properties": {
+                "plans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/plan.PlanResponseDto"
+                    }
+                }
+            }
+        },
+        "plan.UpdatePlanRequestDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "max_api_keys": {
+                    "type": "integer"
+                },
+                "max_users": {
+                    "type": "integer"
+                },
+                "monthly_request_quota": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "plan.UsageResponseDto": {
+            "type": "object",
+            "properties": {
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/plan.MetricUsageResponseDto"
+                    }
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "plan": {
+                    "$ref": "#/definitions/plan.PlanResponseDto"
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "privacy.AnonymizeRequestDto": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "Reason é o motivo do pedido (ex.: protocolo da solicitação do titular), gravado no certificado",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "privacy.CertificateResponseDto": {
+            "type": "object",
+            "properties": {
+                "affected": {
+                    "description": "Affected é o número de registros alterados por tabela",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "create_at": {
+                    "type": "string"
+                },
+                "pseudonym": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source é api ou cli",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status é running (interrompida; repita o pedido para concluir) ou completed",
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "privacy.CreateHoldRequestDto": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "privacy.HoldResponseDto": {
+            "type": "object",
+            "properties": {
+                "create_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "released_at": {
+                    "type": "string"
+                },
+                "released_by": {
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest_err.Causes": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest_err.RestErr": {
+            "type": "object",
+            "properties": {
+                "causes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest_err.Causes"
+                    }
+                },
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "trace_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "scim.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "scimType": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "scim.GroupMember": {
+            "type": "object",
+            "properties": {
+                "$ref": {
+                    "type": "string"
+                },
+                "display": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "scim.GroupResource": {
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scim.GroupMember"
+                    }
+                },
+                "meta": {
+                    "$ref": "#/definitions/scim.Meta"
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "scim.ListResponse": {
+            "type": "object",
+            "properties": {
+                "Resources": {
+                    "type": "array",
+                    "items": {}
+                },
+                "itemsPerPage": {
+                    "type": "integer"
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "startIndex": {
+                    "type": "integer"
+                },
+                "totalResults": {
+                    "type": "integer"
+                }
+            }
+        },
+        "scim.Meta": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "lastModified": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "resourceType": {
+                    "type": "string"
+                }
+            }
+        },
+        "scim.MultiValue": {
+            "type": "object",
+            "properties": {
+                "primary": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "scim.Name": {
+            "type": "object",
+            "properties": {
+                "familyName": {
+                    "type": "string"
+                },
+                "formatted": {
+                    "type": "string"
+                },
+                "givenName": {
+                    "type": "string"
+                }
+            }
+        },
+        "scim.PatchOperation": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "object"
+                }
+            }
+        },
+        "scim.PatchRequest": {
+            "type": "object",
+            "properties": {
+                "Operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scim.PatchOperation"
+                    }
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "scim.TokenResponseDto": {
+            "type": "object",
+            "properties": {
+                "base_url": {
+                    "description": "BaseURL é a URL a configurar no provedor de identidade",
+                    "type": "string"
+                },
+                "create_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "scim.UserResource": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scim.MultiValue"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/scim.Meta"
+                },
+                "name": {
+                    "$ref": "#/definitions/scim.Name"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "phoneNumbers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/scim.MultiValue"
+                    }
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "search.GroupDto": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/search.ResultDto"
+                    }
+                },
+                "type": {
+                    "$ref": "#/definitions/search.Type"
+                }
+            }
+        },
+        "search.HighlightDto": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "fragment": {
+                    "description": "HTML escapado, termos entre \u003cmark\u003e e \u003c/mark\u003e",
+                    "type": "string"
+                }
+            }
+        },
+        "search.ResultDto": {
+            "type": "object",
+            "properties": {
+                "highlight": {
+                    "$ref": "#/definitions/search.HighlightDto"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subtitle": {
+                    "description": "Documento formatado ou email",
+                    "type": "string"
+                },
+                "tenant_name": {
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Nome do tenant ou do usuário",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "search.SearchResponseDto": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/search.GroupDto"
+                    }
+                },
+                "query": {
+                    "type": "string"
+                }
+            }
+        },
+        "search.Type": {
+            "type": "string",
+            "enum": [
+                "tenant",
+                "user"
+            ],
+            "x-enum-varnames": [
+                "TypeTenant",
+                "TypeUser"
+            ]
+        },
+        "settings.DefinitionResponseDto": {
+            "type": "object",
+            "properties": {
+                "default": {},
+                "description": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/settings.Kind"
+                }
+            }
+        },
+        "settings.DefinitionsResponseDto": {
+            "type": "object",
+            "properties": {
+                "definitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/settings.DefinitionResponseDto"
+                    }
+                }
+            }
+        },
+        "settings.Kind": {
+            "type": "string",
+            "enum": [
+                "string",
+                "int",
+                "bool",
+                "string_list"
+            ],
+            "x-enum-varnames": [
+                "KindString",
+                "KindInt",
+                "KindBool",
+                "KindStringList"
+            ]
+        },
+        "settings.SettingResponseDto": {
+            "type": "object",
+            "properties": {
+                "inherited_from": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/settings.Kind"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "value": {}
+            }
+        },
+        "settings.SettingsResponseDto": {
+            "type": "object",
+            "properties": {
+                "settings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/settings.SettingResponseDto"
+                    }
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "tenant.CreateTenantRequestDto": {
+            "type": "object",
+            "required": [
+                "document",
+                "name"
+            ],
+            "properties": {
+                "database": {
+                    "description": "Database são os dados de conexão do banco dedicado, obrigatórios com isolation = database",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/tenant.TenantDatabaseRequestDto"
+                        }
+                    ]
+                },
+                "document": {
+                    "type": "string"
+                },
+                "document_type": {
+                    "description": "DocumentType: cpf, cnpj ou foreign. Vazio = detecta CPF/CNPJ pelo formato",
+                    "type": "string"
+                },
+                "isolation": {
+                    "description": "Isolation: shared (padrão), schema (schema próprio; com tenant.isolation.schema_enabled) ou\ndatabase (banco dedicado; com tenant.isolation.database_enabled). Os dois últimos apenas para SYSTEM_ADMIN",
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Metadata são os campos personalizados, validados pelas definições de /api/custom-fields",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "tenant.TenantDatabaseRequestDto": {
+            "type": "object",
+            "required": [
+                "db_name",
+                "host",
+                "user"
+            ],
+            "properties": {
+                "db_name": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "string"
+                },
+                "ssl_mode": {
+                    "description": "SSLMode: disable, require (padrão), verify-ca ou verify-full",
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "tenant.TenantPageResponseDto": {
+            "type": "object",
+            "properties": {
+                "nextCursor": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "tenants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tenant.TenantResponseDto"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "tenant.TenantResponseDto": {
+            "type": "object",
+            "properties": {
+                "createAt": {
+                    "type": "string"
+                },
+                "document": {
+                    "type": "string"
+                },
+                "documentFormatted": {
+                    "description": "Documento formatado para exibição",
+                    "type": "string"
+                },
+                "documentType": {
+                    "description": "Tipo do documento (cpf, cnpj ou foreign). Vazio = documento legado ainda não validado",
+                    "type": "string"
+                },
+                "isolation": {
+                    "description": "Isolamento dos dados: shared ou schema",
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Campos personalizados (veja /api/custom-fields)",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentUuid": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status do ciclo de vida: trial, active, past_due, suspended ou cancelled",
+                    "type": "string"
+                },
+                "trialEndsAt": {
+                    "type": "string"
+                },
+                "updateAt": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "tenant.TenantStatusChangeResponseDto": {
+            "type": "object",
+            "properties": {
+                "changedBy": {
+                    "type": "string"
+                },
+                "createAt": {
+                    "type": "string"
+                },
+                "fromStatus": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "toStatus": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "tenant.TenantStatusHistoryResponseDto": {
+            "type": "object",
+            "properties": {
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tenant.TenantStatusChangeResponseDto"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tenantUuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "tenant.TransitionTenantRequestDto": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "reason"
             ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "auth.LoginResponse": {
+        "tenant.UpdateTenantRequestDto": {
             "type": "object",
             "properties": {
-                "expire": {
+                "document": {
                     "type": "string"
                 },
-                "system_time_utc": {
+                "document_type": {
+                    "description": "DocumentType: cpf, cnpj ou foreign. Vazio = detecta CPF/CNPJ pelo formato",
                     "type": "string"
                 },
-                "token": {
+                "metadata": {
+                    "description": "Metadata altera apenas os campos informados; null remove o campo",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/user.UserResponseDto"
+                "parent_uuid": {
+                    "description": "ParentUUID move o tenant na hierarquia. String vazia torna o tenant raiz (apenas SystemAdmin).",
+                    "type": "string"
                 }
             }
         },
-        "auth.OTPRequest": {
+        "tenant_domain.AddCustomDomainRequestDto": {
             "type": "object",
             "required": [
-                "email"
+                "hostname"
             ],
             "properties": {
-                "email": {
+                "hostname": {
                     "type": "string"
                 }
             }
         },
-        "auth.OTPResetPasswordRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "otp",
-                "password"
+        "tenant_domain.DomainKind": {
+            "type": "string",
+            "enum": [
+                "subdomain",
+                "custom",
+                "subdomain",
+                "custom"
+            ],
+            "x-enum-comments": {
+                "DomainKindCustom": "Domínio próprio do tenant, exige verificação DNS",
+                "DomainKindSubdomain": "Rótulo sob o domínio base da plataforma"
+            },
+            "x-enum-descriptions": [
+                "Rótulo sob o domínio base da plataforma",
+                "Domínio próprio do tenant, exige verificação DNS"
             ],
+            "x-enum-varnames": [
+                "KindSubdomain",
+                "KindCustom"
+            ]
+        },
+        "tenant_domain.DomainResponseDto": {
+            "type": "object",
             "properties": {
-                "email": {
+                "create_at": {
                     "type": "string"
                 },
-                "otp": {
+                "host": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
-                }
-            }
-        },
-        "rest_err.Causes": {
-            "type": "object",
-            "properties": {
-                "field": {
+                "kind": {
+                    "$ref": "#/definitions/tenant_domain.DomainKind"
+                },
+                "tenant_uuid": {
                     "type": "string"
                 },
-                "message": {
+                "uuid": {
+                    "type": "string"
+                },
+                "verification": {
+                    "$ref": "#/definitions/tenant_domain.VerificationResponseDto"
+                },
+                "verified": {
+                    "type": "boolean"
+                },
+                "verified_at": {
                     "type": "string"
                 }
             }
         },
-        "rest_err.RestErr": {
+        "tenant_domain.DomainsResponseDto": {
             "type": "object",
             "properties": {
-                "causes": {
+                "domains": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/rest_err.Causes"
+                        "$ref": "#/definitions/tenant_domain.DomainResponseDto"
                     }
-                },
-                "code": {
-                    "type": "integer"
-                },
-                "error": {
+                }
+            }
+        },
+        "tenant_domain.SetSubdomainRequestDto": {
+            "type": "object",
+            "required": [
+                "subdomain"
+            ],
+            "properties": {
+                "subdomain": {
+                    "type": "string"
+                }
+            }
+        },
+        "tenant_domain.VerificationResponseDto": {
+            "type": "object",
+            "properties": {
+                "record_name": {
                     "type": "string"
                 },
-                "message": {
+                "record_type": {
                     "type": "string"
                 },
-                "trace_id": {
+                "record_value": {
                     "type": "string"
                 }
             }
         },
-        "tenant.CreateTenantRequestDto": {
+        "tenant_export.CreateExportRequestDto": {
             "type": "object",
-            "required": [
-                "document",
-                "name"
-            ],
             "properties": {
-                "document": {
+                "format": {
+                    "description": "json (padrão) ou ndjson",
                     "type": "string"
+                }
+            }
+        },
+        "tenant_export.DatasetReportResponseDto": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
                 },
                 "name": {
                     "type": "string"
+                },
+                "skipped": {
+                    "type": "integer"
                 }
             }
         },
-        "tenant.TenantResponseDto": {
+        "tenant_export.ExportResponseDto": {
             "type": "object",
             "properties": {
-                "createAt": {
+                "create_at": {
                     "type": "string"
                 },
-                "document": {
+                "download_url": {
                     "type": "string"
                 },
-                "live": {
-                    "type": "boolean"
+                "downloaded_at": {
+                    "type": "string"
                 },
-                "name": {
+                "error": {
                     "type": "string"
                 },
-                "updateAt": {
+                "finished_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tenant_uuid": {
                     "type": "string"
                 },
                 "uuid": {
@@ -973,34 +7990,32 @@ const docTemplate = `{
                 }
             }
         },
-        "tenant.TenantsResponseDto": {
+        "tenant_export.ImportResponseDto": {
             "type": "object",
             "properties": {
-                "page": {
-                    "type": "integer"
-                },
-                "size": {
-                    "type": "integer"
-                },
-                "tenants": {
+                "datasets": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/tenant.TenantResponseDto"
+                        "$ref": "#/definitions/tenant_export.DatasetReportResponseDto"
                     }
-                }
-            }
-        },
-        "tenant.UpdateTenantRequestDto": {
-            "type": "object",
-            "properties": {
-                "document": {
-                    "type": "string"
                 },
-                "live": {
+                "dry_run": {
                     "type": "boolean"
                 },
-                "name": {
+                "mode": {
+                    "type": "string"
+                },
+                "source_tenant_uuid": {
+                    "type": "string"
+                },
+                "tenant_uuid": {
                     "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -1016,6 +8031,11 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "metadata": {
+                    "description": "Metadata são os campos personalizados, validados pelas definições de /api/custom-fields",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
                 "name": {
                     "type": "string"
                 },
@@ -1034,6 +8054,11 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "metadata": {
+                    "description": "Metadata altera apenas os campos informados; null remove o campo",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
                 "name": {
                     "type": "string"
                 },
@@ -1045,27 +8070,69 @@ const docTemplate = `{
                 }
             }
         },
+        "user.UserListResponseDto": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/user.UserResponseDto"
+                    }
+                }
+            }
+        },
         "user.UserResponseDto": {
             "type": "object",
             "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
                 "create_at": {
                     "type": "string"
                 },
                 "email": {
                     "type": "string"
                 },
+                "last_login_at": {
+                    "description": "LastLoginAt é a data do último login; ausente se o usuário nunca fez login",
+                    "type": "string"
+                },
                 "live": {
                     "type": "boolean"
                 },
+                "locale": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Campos personalizados (veja /api/custom-fields)",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
                 "name": {
                     "type": "string"
                 },
+                "phone": {
+                    "description": "Dados de perfil, alterados pelo próprio usuário em /api/me",
+                    "type": "string"
+                },
                 "role": {
                     "$ref": "#/definitions/user.UserRole"
                 },
                 "tenant_uuid": {
                     "type": "string"
                 },
+                "timezone": {
+                    "type": "string"
+                },
                 "update_at": {
                     "type": "string"
                 },
@@ -1078,17 +8145,121 @@ const docTemplate = `{
             "type": "string",
             "enum": [
                 "SYSTEM_ADMIN",
+                "PARTNER_ADMIN",
                 "TENANT_ADMIN",
                 "TENANT_USER",
                 "SYSTEM_ADMIN",
+                "PARTNER_ADMIN",
                 "TENANT_ADMIN",
                 "TENANT_USER"
             ],
+            "x-enum-comments": {
+                "RolePartnerAdmin": "Administra o próprio tenant e toda a sua subárvore"
+            },
+            "x-enum-descriptions": [
+                "",
+                "Administra o próprio tenant e toda a sua subárvore",
+                "",
+                ""
+            ],
             "x-enum-varnames": [
                 "RoleSystemAdmin",
+                "RolePartnerAdmin",
                 "RoleTenantAdmin",
                 "RoleTenantUser"
             ]
+        },
+        "user_import.ImportResponseDto": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/user_import.RowErrorResponseDto"
+                    }
+                },
+                "invites": {
+                    "type": "integer"
+                },
+                "job": {
+                    "description": "Job é a importação agendada; ausente em dry-run ou quando a importação é recusada",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/user_import.JobResponseDto"
+                        }
+                    ]
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "valid_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "user_import.JobResponseDto": {
+            "type": "object",
+            "properties": {
+                "create_at": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "invited": {
+                    "type": "integer"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "result_url": {
+                    "type": "string"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tenant_uuid": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "user_import.RowErrorResponseDto": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -2,9 +2,12 @@ package auth
 
 import (
 	"errors"
+	"log"
 	"net/http"
+	"tenant-crud-simply/internal/iam/domain/feature_flag"
 	"tenant-crud-simply/internal/iam/domain/user"
 	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/acess_log"
 	"tenant-crud-simply/internal/pkg/log/auditoria_log"
 	"tenant-crud-simply/internal/pkg/mailer"
 	"tenant-crud-simply/internal/pkg/rest_err"
@@ -53,11 +56,13 @@ func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
 // @Param request body LoginRequest true "Credenciais do Usuário (Email e Senha)"
 // @Success 200 {object} LoginResponse "Login bem-sucedido"
 // @Failure 400 {object} rest_err.RestErr "Requisição inválida (JSON mal formatado)"
+// @Failure 403 {object} rest_err.RestErr "Tenant do usuário excluído ou IP de origem não permitido"
 // @Failure 404 {object} rest_err.RestErr "Credenciais inválidas (usuário/senha errados)"
 // @Failure 409 {object} rest_err.RestErr "Token duplicado ou conflito"
 // @Failure 500 {object} rest_err.RestErr "Erro interno do servidor"
 // @Router /api/auth/login [post]
 func (ctrl *controllerImpl) Login(c *gin.Context) {
+	start := time.Now()
 	traceID := c.GetHeader("X-Request-ID")
 	if traceID == "" {
 		traceID = uuid.NewString()
@@ -81,7 +86,13 @@ func (ctrl *controllerImpl) Login(c *gin.Context) {
 		return
 	}
 
-	uLogin, err := ctrl.Service.Login(c.Request.Context(), req.Email, req.Password)
+	var hostTenant *uuid.UUID
+	if t, ok := middleware.GetHostTenant(c); ok {
+		hostTenant = &t
+	}
+
+	uLogin, err := ctrl.Service.Login(c.Request.Context(), req.Email, req.Password, hostTenant, c.ClientIP())
+	ctrl.recordAttempt(c, uLogin.User, err)
 	if err != nil {
 		var restError *rest_err.RestErr
 		switch {
@@ -91,6 +102,13 @@ func (ctrl *controllerImpl) Login(c *gin.Context) {
 		case errors.Is(err, ErrTokenDuplicated):
 			restError = rest_err.NewConflictValidationError(nil, err.Error(), nil)
 
+		case errors.Is(err, ErrTenantDisabled):
+			restError = rest_err.NewForbiddenError(nil, err.Error())
+
+		case errors.Is(err, middleware.ErrIPNotAllowed):
+			restError = rest_err.NewForbiddenError(nil, "Acesso não permitido a partir deste endereço IP.")
+			logDeniedLogin(c, uLogin.User, traceID, start, restError.Code)
+
 		default:
 			restError = rest_err.NewInternalServerError(nil, "internal server error", nil)
 		}
@@ -118,6 +136,7 @@ func (ctrl *controllerImpl) Login(c *gin.Context) {
 			Email:      uLogin.User.Email,
 			Role:       uLogin.User.Role,
 			Live:       uLogin.User.Live,
+			Metadata:   uLogin.User.Metadata,
 			CreateAt:   uLogin.User.CreateAt,
 			UpdateAt:   uLogin.User.UpdateAt,
 		},
@@ -141,6 +160,68 @@ func (ctrl *controllerImpl) Login(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// loginReasons são as falhas de login registradas no histórico do usuário. Outros erros
+// (ex.: falha interna) não entram no histórico.
+var loginReasons = map[error]string{
+	ErrPwdWrong:                LoginReasonInvalidPassword,
+	ErrTenantDisabled:          LoginReasonTenantDisabled,
+	middleware.ErrIPNotAllowed: LoginReasonIPDenied,
+}
+
+// recordAttempt grava a tentativa no histórico de login (/api/me/logins) quando o usuário é conhecido.
+// Uma falha ao gravar não impede o login.
+func (ctrl *controllerImpl) recordAttempt(c *gin.Context, u user.User, loginErr error) {
+	if u.UUID == uuid.Nil {
+		return
+	}
+	attempt := LoginAttempt{
+		UserUUID:   u.UUID,
+		TenantUUID: u.TenantUUID,
+		Success:    loginErr == nil,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	}
+	if loginErr != nil {
+		reason, ok := "", false
+		for target, r := range loginReasons {
+			if errors.Is(loginErr, target) {
+				reason, ok = r, true
+				break
+			}
+		}
+		if !ok {
+			return
+		}
+		attempt.Reason = reason
+	}
+	if err := ctrl.Service.RecordLoginAttempt(c.Request.Context(), attempt); err != nil {
+		log.Printf("[AUTH] falha ao registrar a tentativa de login do usuário %s: %v", u.UUID, err)
+	}
+}
+
+// logDeniedLogin registra no access_log a tentativa de login barrada pela lista de IPs do tenant.
+func logDeniedLogin(c *gin.Context, u user.User, traceID string, start time.Time, statusCode int) {
+	middleware.LogAccess(c.Request.Context(), acess_log.AccessLog{
+		TenantUUID:   u.TenantUUID,
+		UserUUID:     &u.UUID,
+		Identifier:   u.Email,
+		RayTraceCode: traceID,
+		Method:       c.Request.Method,
+		Path:         c.Request.URL.Path,
+		Route:        c.FullPath(),
+		Host:         c.Request.Host,
+		StatusCode:   statusCode,
+		Status:       acess_log.StatusIPDenied,
+		IP:           c.ClientIP(),
+		UserAgent:    c.Request.UserAgent(),
+		Referer:      c.Request.Referer(),
+		ContentType:  c.ContentType(),
+		UserLanguage: c.GetHeader("Accept-Language"),
+		RequestTime:  start.UTC(),
+		LatencyMs:    float64(time.Since(start).Microseconds()) / 1000.0,
+	})
+}
+
 // @Summary Revoga o token de acesso
 // @Description Invalida o token de acesso atual do usuário.
 // @Tags Auth
@@ -254,12 +335,12 @@ func (ctrl *controllerImpl) ResetPassword(c *gin.Context) {
 }
 
 // @Summary Verifica o status do login
-// @Description Retorna os dados do usuário logado se o token for válido.
+// @Description Retorna os dados do usuário logado se o token for válido e as feature flags avaliadas para ele (chave -> ligada).
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Security     BearerAuth
-// @Success 200 {object} LoginResponse "Dados do usuário logado"
+// @Success 200 {object} HealthcheckResponse "Dados do usuário logado e feature flags"
 // @Failure 401 {object} rest_err.RestErr "Não autorizado"
 // @Router /api/auth/healthcheck [get]
 func (ctrl *controllerImpl) Healthcheck(c *gin.Context) {
@@ -278,6 +359,7 @@ func (ctrl *controllerImpl) Healthcheck(c *gin.Context) {
 			Email:      lUser.User.Email,
 			Role:       lUser.User.Role,
 			Live:       lUser.User.Live,
+			Metadata:   lUser.User.Metadata,
 			CreateAt:   lUser.User.CreateAt,
 			UpdateAt:   lUser.User.UpdateAt,
 		},
@@ -286,5 +368,8 @@ func (ctrl *controllerImpl) Healthcheck(c *gin.Context) {
 		Expire:        lUser.AcessToken.Expiry,
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, HealthcheckResponse{
+		LoginResponse: response,
+		FeatureFlags:  feature_flag.Flags(c),
+	})
 }
@@ -11,3 +11,10 @@ type LoginResponse struct {
 	SystemTimeUTC time.Time            `json:"system_time_utc"`
 	Expire        time.Time            `json:"expire"`
 }
+
+// HealthcheckResponse acrescenta ao login as feature flags avaliadas para o usuário, para que
+// o frontend ligue e desligue funcionalidades.
+type HealthcheckResponse struct {
+	LoginResponse
+	FeatureFlags map[string]bool `json:"feature_flags"`
+}
@@ -5,6 +5,7 @@ import "errors"
 var (
 	ErrPwdWrong        = errors.New("error when logging in")
 	ErrTokenDuplicated = errors.New("error when logging in")
+	ErrTenantDisabled  = errors.New("tenant disabled")
 	OTPCodeExist       = errors.New("otp code has exist")
 	OTPCodeWrong       = errors.New("otp code wrong")
 )
@@ -12,6 +12,11 @@ func SaveOTP(email, code string) {
 	OTPCache.Set(email, code, 5*time.Minute)
 }
 
+// SaveOTPFor guarda um código com validade própria (ex.: convites).
+func SaveOTPFor(email, code string, ttl time.Duration) {
+	OTPCache.Set(email, code, ttl)
+}
+
 func GetOTP(email string) (string, bool) {
 	v, found := OTPCache.Get(email)
 	if !found {
@@ -20,3 +20,26 @@ type Login struct {
 func (AcessToken) TableName() string {
 	return "users_acess_tokens"
 }
+
+// Motivos de falha registrados no histórico de login.
+const (
+	LoginReasonInvalidPassword = "invalid_password"
+	LoginReasonTenantDisabled  = "tenant_disabled"
+	LoginReasonIPDenied        = "ip_denied"
+)
+
+// LoginAttempt é uma tentativa de login de um usuário conhecido (email cadastrado).
+type LoginAttempt struct {
+	UUID       uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserUUID   uuid.UUID  `gorm:"type:uuid;not null"`
+	TenantUUID *uuid.UUID `gorm:"type:uuid"`
+	Success    bool       `gorm:"not null"`
+	Reason     string     `gorm:"size:32;not null;default:''"` // Vazio nos logins bem-sucedidos
+	IP         string     `gorm:"type:inet;not null"`
+	UserAgent  string     `gorm:"type:text;not null;default:''"`
+	CreatedAt  time.Time  `gorm:"not null"`
+}
+
+func (LoginAttempt) TableName() string {
+	return "user_login_history"
+}
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"time"
 
+	"tenant-crud-simply/internal/pkg/listing"
+
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
@@ -14,7 +17,12 @@ type Repository interface {
 	CreateAcessToken(ctx context.Context, m AcessToken) error
 	RevokeAcessToken(ctx context.Context, token string) error
 	RevokeAllUserTokens(ctx context.Context, userID string) error
+	// RevokeOtherUserTokens revoga os tokens ativos do usuário, exceto keepToken.
+	RevokeOtherUserTokens(ctx context.Context, userID, keepToken string) error
 	GetAcessToken(ctx context.Context, token string) (AcessToken, error)
+	SaveLoginAttempt(ctx context.Context, attempt LoginAttempt) error
+	// ListLoginAttempts lista o histórico de login do usuário, do mais recente ao mais antigo.
+	ListLoginAttempts(ctx context.Context, userUUID uuid.UUID, opts listing.Options) (listing.Page[LoginAttempt], error)
 }
 
 type repositoryImpl struct {
@@ -74,6 +82,14 @@ func (r *repositoryImpl) RevokeAllUserTokens(ctx context.Context, userID string)
 	return nil
 }
 
+func (r *repositoryImpl) RevokeOtherUserTokens(ctx context.Context, userID, keepToken string) error {
+	now := time.Now().UTC()
+	return r.db.WithContext(ctx).
+		Model(&AcessToken{}).
+		Where("user_uuid = ? AND expire_date > ? AND token <> ?", userID, now, keepToken).
+		Update("expire_date", now).Error
+}
+
 func (r *repositoryImpl) GetAcessToken(ctx context.Context, token string) (AcessToken, error) {
 	var m AcessToken
 	result := r.db.WithContext(ctx).First(&m, "token = ?", token)
@@ -82,3 +98,27 @@ func (r *repositoryImpl) GetAcessToken(ctx context.Context, token string) (Acess
 	}
 	return m, nil
 }
+
+func (r *repositoryImpl) SaveLoginAttempt(ctx context.Context, attempt LoginAttempt) error {
+	if attempt.CreatedAt.IsZero() {
+		attempt.CreatedAt = time.Now().UTC()
+	}
+	return r.db.WithContext(ctx).Create(&attempt).Error
+}
+
+var loginAttemptSpec = listing.Spec[LoginAttempt]{
+	Sortable: map[string]listing.Column[LoginAttempt]{
+		"created_at": {Name: "created_at", Value: func(a LoginAttempt) any { return a.CreatedAt }},
+	},
+	DefaultSort: "-created_at",
+	IDColumn:    "uuid",
+	ID:          func(a LoginAttempt) uuid.UUID { return a.UUID },
+}
+
+func (r *repositoryImpl) ListLoginAttempts(ctx context.Context, userUUID uuid.UUID, opts listing.Options) (listing.Page[LoginAttempt], error) {
+	// host() devolve o IP sem a máscara (/32) que o driver acrescenta ao ler o inet
+	query := r.db.WithContext(ctx).Model(&LoginAttempt{}).
+		Select("uuid, user_uuid, tenant_uuid, success, reason, host(ip) AS ip, user_agent, created_at").
+		Where("user_uuid = ?", userUUID)
+	return listing.Find(query, opts, loginAttemptSpec)
+}
@@ -3,11 +3,18 @@ package auth
 import (
 	"context"
 	"fmt"
+	"log"
 	"tenant-crud-simply/internal/iam/application/auth/internal/cache"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/settings"
+	"tenant-crud-simply/internal/iam/domain/tenant"
 	"tenant-crud-simply/internal/iam/domain/user"
+	"tenant-crud-simply/internal/iam/middleware"
 	"tenant-crud-simply/internal/infra/jwt"
+	"tenant-crud-simply/internal/pkg/listing"
 	"tenant-crud-simply/internal/pkg/mailer"
 	"tenant-crud-simply/internal/pkg/util"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -17,12 +24,23 @@ type implService struct {
 }
 
 type Service interface {
-	Login(ctx context.Context, email, pwd string) (Login, error)
+	// Login autentica o usuário. Quando hostTenant é informado (requisição pelo host de um tenant),
+	// apenas usuários desse tenant (ou SYSTEM_ADMIN) podem autenticar. clientIP é verificado contra a
+	// lista de IPs permitidos do tenant; quando negado, retorna middleware.ErrIPNotAllowed junto com o usuário.
+	// Senha errada (ErrPwdWrong) e tenant bloqueado (ErrTenantDisabled) também retornam o usuário, para o
+	// histórico de login.
+	Login(ctx context.Context, email, pwd string, hostTenant *uuid.UUID, clientIP string) (Login, error)
 	RevokeAcessToken(ctx context.Context, token string) error
 	GetAcessToken(ctx context.Context, token string) (AcessToken, error)
 	CreateOTPCode(ctx context.Context, email string) error
 	ValidateOTPCode(ctx context.Context, email, codeDst string) bool
 	ChangeUserPwd(ctx context.Context, otpCode, email, pwd string) (bool, error)
+	// SendInvite envia ao usuário recém-criado um código para definir a senha em /auth/password/reset.
+	SendInvite(ctx context.Context, email, tenantName string, ttl time.Duration) error
+	// RevokeOtherSessions encerra as sessões do usuário, exceto a do token keepToken ("" encerra todas).
+	RevokeOtherSessions(ctx context.Context, userUUID uuid.UUID, keepToken string) error
+	RecordLoginAttempt(ctx context.Context, attempt LoginAttempt) error
+	LoginHistory(ctx context.Context, userUUID uuid.UUID, opts listing.Options) (listing.Page[LoginAttempt], error)
 }
 
 func NewService(Repository Repository) Service {
@@ -30,7 +48,7 @@ func NewService(Repository Repository) Service {
 		Repository: Repository,
 	}
 }
-func (s *implService) Login(ctx context.Context, email, pwd string) (Login, error) {
+func (s *implService) Login(ctx context.Context, email, pwd string, hostTenant *uuid.UUID, clientIP string) (Login, error) {
 	rUser, err := user.MustUse().Service.Read(ctx, user.User{
 		Email: email,
 	})
@@ -38,13 +56,36 @@ func (s *implService) Login(ctx context.Context, email, pwd string) (Login, erro
 		return Login{}, ErrPwdWrong
 	}
 	if err := util.UsePassword().Compare(rUser.Password, pwd); err != nil {
+		return Login{User: rUser}, ErrPwdWrong
+	}
+	// Mesmo erro de credenciais para não revelar que o email existe em outro tenant
+	if hostTenant != nil && !middleware.AllowedOnHost(rUser, *hostTenant) {
 		return Login{}, ErrPwdWrong
 	}
 	var tenantID uuid.UUID
 	if rUser.TenantUUID != nil {
 		tenantID = *rUser.TenantUUID
+
+		// Tenants excluídos (exclusão lógica), suspensos ou cancelados não podem autenticar.
+		// SYSTEM_ADMIN não é bloqueado pelo status do tenant, como no middleware
+		rTenant, err := tenant.MustUse().Service.Read(ctx, tenant.Tenant{UUID: tenantID})
+		if err != nil {
+			return Login{User: rUser}, ErrTenantDisabled
+		}
+		if rUser.Role != model.RoleSystemAdmin && !rTenant.Status.AllowsAccess() {
+			return Login{User: rUser}, ErrTenantDisabled
+		}
+	}
+	// Só após validar a senha, para não revelar a restrição de rede a quem não tem as credenciais
+	if err := middleware.CheckClientIP(ctx, rUser, clientIP); err != nil {
+		return Login{User: rUser}, err
+	}
+	// 0 mantém a duração padrão do servidor (security.jwt_access_expiry_min)
+	lifetimeMin, err := settings.Get[int](ctx, tenantID, settings.KeySessionLifetimeMin)
+	if err != nil {
+		return Login{}, err
 	}
-	token, expTime, err := jwt.Use().GenerateAccessToken(rUser.UUID, tenantID)
+	token, expTime, err := jwt.Use().GenerateAccessTokenWithExpiry(rUser.UUID, tenantID, time.Duration(lifetimeMin)*time.Minute)
 	if err != nil {
 		return Login{}, err
 	}
@@ -62,6 +103,11 @@ func (s *implService) Login(ctx context.Context, email, pwd string) (Login, erro
 		return response, err
 	}
 
+	// Falha ao registrar o último login não impede o acesso
+	if err := user.MustUse().Service.RecordLogin(ctx, rUser.UUID, time.Now().UTC()); err != nil {
+		log.Printf("[AUTH] falha ao registrar o último login do usuário %s: %v", rUser.UUID, err)
+	}
+
 	return response, nil
 }
 
@@ -91,10 +137,12 @@ func (s *implService) CreateOTPCode(ctx context.Context, email string) error {
 		return mailer.ErrMailerNotInitialized
 	}
 	cache.SaveOTP(email, otpCode)
-	err = mailService.SendRaw(
+	err = mailService.SendTemplate(
 		email,
-		"OTP Code",
-		fmt.Sprintf("<h1>Seu código OTP é: %s</h1>", otpCode),
+		"Código de verificação",
+		`<p>Use o código abaixo para continuar:</p><h2 style="letter-spacing:4px;">{{.Code}}</h2>`+
+			`<p>Se você não solicitou este código, ignore este email.</p>`,
+		map[string]any{"Code": otpCode},
 	)
 	if err != nil {
 		return err
@@ -131,3 +179,41 @@ func (s *implService) ChangeUserPwd(ctx context.Context, otpCode, email, pwd str
 	}
 	return true, nil
 }
+
+// inviteCodeLength é maior que o do OTP comum porque o convite vale por mais tempo.
+const inviteCodeLength = 12
+
+func (s *implService) SendInvite(ctx context.Context, email, tenantName string, ttl time.Duration) error {
+	mailService := mailer.Use()
+	if mailService == nil {
+		return mailer.ErrMailerNotInitialized
+	}
+	code, err := GenerateOTP(inviteCodeLength)
+	if err != nil {
+		return err
+	}
+	cache.SaveOTPFor(email, code, ttl)
+	return mailService.SendTemplate(
+		email,
+		fmt.Sprintf("Convite para %s", tenantName),
+		`<h1>Você foi convidado para administrar {{.Tenant}}</h1>`+
+			`<p>Defina sua senha informando o código abaixo em "Redefinir senha". Ele é válido por {{.Hours}} horas.</p>`+
+			`<h2 style="letter-spacing:4px;">{{.Code}}</h2>`,
+		map[string]any{"Tenant": tenantName, "Hours": int(ttl.Hours()), "Code": code},
+	)
+}
+
+func (s *implService) RevokeOtherSessions(ctx context.Context, userUUID uuid.UUID, keepToken string) error {
+	if keepToken == "" {
+		return s.Repository.RevokeAllUserTokens(ctx, userUUID.String())
+	}
+	return s.Repository.RevokeOtherUserTokens(ctx, userUUID.String(), keepToken)
+}
+
+func (s *implService) RecordLoginAttempt(ctx context.Context, attempt LoginAttempt) error {
+	return s.Repository.SaveLoginAttempt(ctx, attempt)
+}
+
+func (s *implService) LoginHistory(ctx context.Context, userUUID uuid.UUID, opts listing.Options) (listing.Page[LoginAttempt], error) {
+	return s.Repository.ListLoginAttempts(ctx, userUUID, opts)
+}
@@ -0,0 +1,297 @@
+package me
+
+import (
+	"errors"
+	"net/http"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/user"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/listing"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	Read(c *gin.Context)
+	Update(c *gin.Context)
+	ChangePassword(c *gin.Context)
+	Logins(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID *uuid.UUID
+		userUUID   *uuid.UUID
+		identifier string
+		rayTrace   string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:   tenantUUID,
+		UserUUID:     userUUID,
+		Identifier:   identifier,
+		RayTraceCode: rayTrace,
+		Domain:       "me",
+		Action:       action,
+		Function:     function,
+		Success:      success,
+		InputData:    auditoria_log.SerializeData(input),
+		OutputData:   auditoria_log.SerializeData(output),
+	})
+}
+
+// Routes registra as rotas de autoatendimento do usuário autenticado. Todas agem apenas sobre
+// o próprio usuário, por isso aceitam qualquer perfil.
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	meGroup := routes.Group("/me")
+	allRoles := []model.UserRole{model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin, model.RoleTenantUser}
+
+	{
+		meGroup.GET("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(allRoles...), ctrl.Read)
+		meGroup.PATCH("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(allRoles...), ctrl.Update)
+		meGroup.DELETE("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(allRoles...), ctrl.Delete)
+		meGroup.POST("/password", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(allRoles...), ctrl.ChangePassword)
+		meGroup.GET("/logins", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(allRoles...), ctrl.Logins)
+	}
+}
+
+// restError converte os erros do serviço na resposta HTTP.
+func restError(login *middleware.Login, err error) *rest_err.RestErr {
+	trace := &login.Metadata.RayTraceCode
+	switch {
+	case errors.Is(err, ErrWrongPassword):
+		return rest_err.NewForbiddenError(trace, "Senha atual incorreta.")
+	case errors.Is(err, ErrSamePassword), errors.Is(err, ErrInvalidProfile),
+		errors.Is(err, listing.ErrInvalidOptions):
+		return rest_err.NewBadRequestError(trace, err.Error())
+	case errors.Is(err, ErrSelfDeleteDisabled):
+		return rest_err.NewForbiddenError(trace, "A política do tenant não permite excluir a própria conta.")
+	case errors.Is(err, ErrLastAdmin):
+		return rest_err.NewConflictValidationError(trace, err.Error(), nil)
+	case errors.Is(err, user.ErrNotFound):
+		return rest_err.NewNotFoundError(trace, "user not found")
+	default:
+		return rest_err.NewInternalServerError(trace, "internal server error", nil)
+	}
+}
+
+// @Summary      Consulta o próprio perfil
+// @Description  Retorna os dados do usuário autenticado, incluindo telefone, avatar, idioma e fuso horário.
+// @Tags         Me
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  user.UserResponseDto
+// @Failure      401  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/me [get]
+func (ctrl *controllerImpl) Read(c *gin.Context) {
+	login, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	u, err := ctrl.Service.Profile(c.Request.Context(), login.User.UUID)
+	if err != nil {
+		e := restError(login, err)
+		c.JSON(e.Code, e)
+		return
+	}
+	c.JSON(http.StatusOK, toUserResponse(u))
+}
+
+// @Summary      Atualiza o próprio perfil
+// @Description  Altera nome, telefone, avatar (URL https), idioma (BCP 47) e fuso horário (IANA) do usuário autenticado. Apenas os campos enviados são alterados; "" limpa o campo (exceto o nome). Email, role e campos personalizados continuam em PATCH /api/user/{identifier}.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body UpdateProfileRequestDto true "Campos do perfil"
+// @Success      200  {object}  user.UserResponseDto
+// @Failure      400  {object}  rest_err.RestErr  "Campo inválido"
+// @Failure      401  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/me [patch]
+func (ctrl *controllerImpl) Update(c *gin.Context) {
+	login, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	var req UpdateProfileRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		e := rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "invalid json body")
+		c.JSON(e.Code, e)
+		return
+	}
+
+	u, err := ctrl.Service.UpdateProfile(c.Request.Context(), login.User.UUID, req.profile())
+	if err != nil {
+		e := restError(login, err)
+		ctrl.logAudit(c, login, "update_profile", "Update", false, req, err.Error())
+		c.JSON(e.Code, e)
+		return
+	}
+
+	response := toUserResponse(u)
+	ctrl.logAudit(c, login, "update_profile", "Update", true, req, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Troca a própria senha
+// @Description  Troca a senha do usuário autenticado mediante a senha atual. As demais sessões do usuário são encerradas; a sessão atual continua válida.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body ChangePasswordRequestDto true "Senha atual e nova senha (mínimo de 8 caracteres)"
+// @Success      204  "Senha alterada"
+// @Failure      400  {object}  rest_err.RestErr  "Nova senha inválida ou igual à atual"
+// @Failure      401  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr  "Senha atual incorreta"
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/me/password [post]
+func (ctrl *controllerImpl) ChangePassword(c *gin.Context) {
+	login, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	var req ChangePasswordRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		e := rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "invalid json body (new_password: mínimo de 8 caracteres)")
+		c.JSON(e.Code, e)
+		return
+	}
+
+	// As senhas nunca vão para o log de auditoria
+	err := ctrl.Service.ChangePassword(c.Request.Context(), login.User.UUID, req.CurrentPassword, req.NewPassword, login.AcessToken.Token)
+	if err != nil {
+		e := restError(login, err)
+		ctrl.logAudit(c, login, "change_password", "ChangePassword", false, nil, err.Error())
+		c.JSON(e.Code, e)
+		return
+	}
+
+	ctrl.logAudit(c, login, "change_password", "ChangePassword", true, nil, gin.H{"status": "changed"})
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Histórico de login
+// @Description  Lista as tentativas de login do usuário autenticado (sucesso e falha), da mais recente para a mais antiga, com paginação por cursor.
+// @Tags         Me
+// @Produce      json
+// @Security     BearerAuth
+// @Param        size   query  int     false  "Tamanho da página (padrão 10, máximo 100)"
+// @Param        cursor query  string  false  "next_cursor da página anterior"
+// @Success      200  {object}  LoginHistoryResponseDto
+// @Failure      400  {object}  rest_err.RestErr  "Cursor ou tamanho inválido"
+// @Failure      401  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/me/logins [get]
+func (ctrl *controllerImpl) Logins(c *gin.Context) {
+	login, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	var req LoginHistoryRequestDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		e := rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "invalid query parameters")
+		c.JSON(e.Code, e)
+		return
+	}
+
+	page, err := ctrl.Service.LoginHistory(c.Request.Context(), login.User.UUID, req.options())
+	if err != nil {
+		e := restError(login, err)
+		c.JSON(e.Code, e)
+		return
+	}
+
+	response := LoginHistoryResponseDto{
+		Logins:     make([]LoginAttemptResponseDto, 0, len(page.Items)),
+		Total:      page.Total,
+		Size:       page.Size,
+		NextCursor: page.NextCursor,
+	}
+	for _, a := range page.Items {
+		response.Logins = append(response.Logins, toLoginAttemptResponse(a))
+	}
+	c.Header("Link", listing.LinkHeader(c.Request.URL, page.NextCursor))
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Exclui a própria conta
+// @Description  Exclui o usuário autenticado, confirmando a senha atual, e encerra todas as suas sessões. Depende da configuração 'allow_self_delete' do tenant; o último administrador (TENANT_ADMIN ou PARTNER_ADMIN) do tenant não pode se excluir. SYSTEM_ADMIN não pode usar esta rota.
+// @Tags         Me
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body DeleteAccountRequestDto true "Senha atual"
+// @Success      204  "Conta excluída"
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      401  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr  "Senha incorreta ou exclusão não permitida pelo tenant"
+// @Failure      409  {object}  rest_err.RestErr  "Último administrador do tenant"
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/me [delete]
+func (ctrl *controllerImpl) Delete(c *gin.Context) {
+	login, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	var req DeleteAccountRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		e := rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "invalid json body")
+		c.JSON(e.Code, e)
+		return
+	}
+
+	if err := ctrl.Service.DeleteAccount(c.Request.Context(), login.User.UUID, req.Password); err != nil {
+		e := restError(login, err)
+		ctrl.logAudit(c, login, "delete_account", "Delete", false, nil, err.Error())
+		c.JSON(e.Code, e)
+		return
+	}
+
+	ctrl.logAudit(c, login, "delete_account", "Delete", true, nil, gin.H{"status": "deleted"})
+	c.Status(http.StatusNoContent)
+}
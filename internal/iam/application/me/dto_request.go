@@ -0,0 +1,45 @@
+package me
+
+import (
+	"tenant-crud-simply/internal/iam/domain/user"
+	"tenant-crud-simply/internal/pkg/listing"
+)
+
+// UpdateProfileRequestDto altera apenas os campos enviados; "" limpa o campo (exceto name).
+type UpdateProfileRequestDto struct {
+	Name      *string `json:"name"`
+	Phone     *string `json:"phone"`
+	AvatarURL *string `json:"avatar_url"`
+	// Locale (BCP 47) e Timezone (IANA) vazios usam a configuração do tenant
+	Locale   *string `json:"locale"`
+	Timezone *string `json:"timezone"`
+}
+
+func (r UpdateProfileRequestDto) profile() user.Profile {
+	return user.Profile{
+		Name:      r.Name,
+		Phone:     r.Phone,
+		AvatarURL: r.AvatarURL,
+		Locale:    r.Locale,
+		Timezone:  r.Timezone,
+	}
+}
+
+type ChangePasswordRequestDto struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
+// DeleteAccountRequestDto confirma a exclusão com a senha atual.
+type DeleteAccountRequestDto struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type LoginHistoryRequestDto struct {
+	Size   int    `form:"size"`
+	Cursor string `form:"cursor"`
+}
+
+func (r LoginHistoryRequestDto) options() listing.Options {
+	return listing.Options{Size: r.Size, Cursor: r.Cursor}
+}
@@ -0,0 +1,58 @@
+package me
+
+import (
+	"time"
+
+	"tenant-crud-simply/internal/iam/application/auth"
+	"tenant-crud-simply/internal/iam/domain/user"
+
+	"github.com/google/uuid"
+)
+
+func toUserResponse(u user.User) user.UserResponseDto {
+	return user.UserResponseDto{
+		UUID:        u.UUID,
+		TenantUUID:  u.TenantUUID,
+		Name:        u.Name,
+		Email:       u.Email,
+		Role:        u.Role,
+		Live:        u.Live,
+		Metadata:    u.Metadata,
+		CreateAt:    u.CreateAt,
+		UpdateAt:    u.UpdateAt,
+		LastLoginAt: u.LastLoginAt,
+		Phone:       u.Phone,
+		AvatarURL:   u.AvatarURL,
+		Locale:      u.Locale,
+		Timezone:    u.Timezone,
+	}
+}
+
+type LoginAttemptResponseDto struct {
+	UUID    uuid.UUID `json:"uuid"`
+	Success bool      `json:"success"`
+	// Reason é o motivo da falha: invalid_password, tenant_disabled ou ip_denied
+	Reason    string    `json:"reason,omitempty"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LoginHistoryResponseDto é uma página de /me/logins. next_cursor ausente indica a última página.
+type LoginHistoryResponseDto struct {
+	Logins     []LoginAttemptResponseDto `json:"logins"`
+	Total      int64                     `json:"total"`
+	Size       int                       `json:"size"`
+	NextCursor string                    `json:"next_cursor,omitempty"`
+}
+
+func toLoginAttemptResponse(a auth.LoginAttempt) LoginAttemptResponseDto {
+	return LoginAttemptResponseDto{
+		UUID:      a.UUID,
+		Success:   a.Success,
+		Reason:    a.Reason,
+		IP:        a.IP,
+		UserAgent: a.UserAgent,
+		CreatedAt: a.CreatedAt,
+	}
+}
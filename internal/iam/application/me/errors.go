@@ -0,0 +1,11 @@
+package me
+
+import "errors"
+
+var (
+	ErrWrongPassword      = errors.New("current password is wrong")
+	ErrSamePassword       = errors.New("new password must differ from the current one")
+	ErrInvalidProfile     = errors.New("invalid profile data")
+	ErrSelfDeleteDisabled = errors.New("account deletion not allowed by tenant policy")
+	ErrLastAdmin          = errors.New("the last administrator of the tenant cannot delete the account")
+)
@@ -0,0 +1,17 @@
+package me
+
+import (
+	"tenant-crud-simply/internal/iam/domain/settings"
+)
+
+// KeyAllowSelfDelete é a política do tenant que permite ao usuário excluir a própria conta.
+const KeyAllowSelfDelete = "allow_self_delete"
+
+func init() {
+	settings.Register(settings.Definition{
+		Key:         KeyAllowSelfDelete,
+		Kind:        settings.KindBool,
+		Description: "Permite que os usuários do tenant excluam a própria conta em DELETE /api/me. O último administrador nunca pode se excluir.",
+		Default:     false,
+	})
+}
@@ -0,0 +1,172 @@
+package me
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"tenant-crud-simply/internal/iam/application/auth"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/settings"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/domain/user"
+	"tenant-crud-simply/internal/pkg/listing"
+	"tenant-crud-simply/internal/pkg/util"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Profile(ctx context.Context, userUUID uuid.UUID) (user.User, error)
+	// UpdateProfile valida e grava os dados de perfil informados.
+	UpdateProfile(ctx context.Context, userUUID uuid.UUID, profile user.Profile) (user.User, error)
+	// ChangePassword troca a senha após conferir a atual e encerra as demais sessões do usuário,
+	// mantendo a do token keepToken.
+	ChangePassword(ctx context.Context, userUUID uuid.UUID, currentPwd, newPwd, keepToken string) error
+	LoginHistory(ctx context.Context, userUUID uuid.UUID, opts listing.Options) (listing.Page[auth.LoginAttempt], error)
+	// DeleteAccount exclui a conta do usuário quando a política do tenant permite (KeyAllowSelfDelete)
+	// e encerra todas as suas sessões.
+	DeleteAccount(ctx context.Context, userUUID uuid.UUID, password string) error
+}
+
+type serviceImpl struct{}
+
+func NewService() Service {
+	return &serviceImpl{}
+}
+
+func (s *serviceImpl) Profile(ctx context.Context, userUUID uuid.UUID) (user.User, error) {
+	return user.MustUse().Service.Read(ctx, user.User{UUID: userUUID})
+}
+
+func (s *serviceImpl) UpdateProfile(ctx context.Context, userUUID uuid.UUID, profile user.Profile) (user.User, error) {
+	if err := validateProfile(&profile); err != nil {
+		return user.User{}, err
+	}
+	return user.MustUse().Service.UpdateProfile(ctx, userUUID, profile)
+}
+
+var phoneRegex = regexp.MustCompile(`^\+?[0-9 ().-]+$`)
+
+// validateProfile normaliza (trim) e valida os campos informados. Locale e timezone seguem as
+// mesmas regras das configurações do tenant.
+func validateProfile(p *user.Profile) error {
+	for _, field := range []*string{p.Name, p.Phone, p.AvatarURL, p.Locale, p.Timezone} {
+		if field != nil {
+			*field = strings.TrimSpace(*field)
+		}
+	}
+
+	if p.Name != nil && (*p.Name == "" || len(*p.Name) > 255) {
+		return fmt.Errorf("%w: o nome é obrigatório e deve ter no máximo 255 caracteres", ErrInvalidProfile)
+	}
+	if p.Phone != nil && *p.Phone != "" {
+		digits := 0
+		for _, r := range *p.Phone {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		if len(*p.Phone) > 30 || !phoneRegex.MatchString(*p.Phone) || digits < 8 || digits > 15 {
+			return fmt.Errorf("%w: telefone '%s' inválido", ErrInvalidProfile, *p.Phone)
+		}
+	}
+	if p.AvatarURL != nil && *p.AvatarURL != "" {
+		u, err := url.Parse(*p.AvatarURL)
+		if err != nil || u.Scheme != "https" || u.Host == "" || len(*p.AvatarURL) > 2048 {
+			return fmt.Errorf("%w: o avatar deve ser uma URL https válida", ErrInvalidProfile)
+		}
+	}
+	for key, value := range map[string]*string{settings.KeyLocale: p.Locale, settings.KeyTimezone: p.Timezone} {
+		if value == nil || *value == "" {
+			continue
+		}
+		def, ok := settings.Lookup(key)
+		if !ok {
+			continue
+		}
+		raw, _ := json.Marshal(*value)
+		if err := def.Check(raw); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidProfile, err)
+		}
+	}
+	return nil
+}
+
+func (s *serviceImpl) ChangePassword(ctx context.Context, userUUID uuid.UUID, currentPwd, newPwd, keepToken string) error {
+	u, err := s.checkPassword(ctx, userUUID, currentPwd)
+	if err != nil {
+		return err
+	}
+	if util.UsePassword().Compare(u.Password, newPwd) == nil {
+		return ErrSamePassword
+	}
+	if _, err := user.MustUse().Service.Update(ctx, user.User{UUID: u.UUID, Password: newPwd}); err != nil {
+		return err
+	}
+	if err := auth.MustUse().Service.RevokeOtherSessions(ctx, u.UUID, keepToken); err != nil {
+		return fmt.Errorf("senha alterada, mas as outras sessões não foram encerradas: %w", err)
+	}
+	return nil
+}
+
+// checkPassword lê o usuário e confere a senha informada.
+func (s *serviceImpl) checkPassword(ctx context.Context, userUUID uuid.UUID, pwd string) (user.User, error) {
+	u, err := user.MustUse().Service.Read(ctx, user.User{UUID: userUUID})
+	if err != nil {
+		return user.User{}, err
+	}
+	if err := util.UsePassword().Compare(u.Password, pwd); err != nil {
+		return user.User{}, ErrWrongPassword
+	}
+	return u, nil
+}
+
+func (s *serviceImpl) LoginHistory(ctx context.Context, userUUID uuid.UUID, opts listing.Options) (listing.Page[auth.LoginAttempt], error) {
+	return auth.MustUse().Service.LoginHistory(ctx, userUUID, opts)
+}
+
+func (s *serviceImpl) DeleteAccount(ctx context.Context, userUUID uuid.UUID, password string) error {
+	u, err := s.checkPassword(ctx, userUUID, password)
+	if err != nil {
+		return err
+	}
+	// SYSTEM_ADMIN não pertence a um tenant e não tem política de exclusão
+	if u.TenantUUID == nil {
+		return ErrSelfDeleteDisabled
+	}
+	allowed, err := settings.Get[bool](ctx, *u.TenantUUID, KeyAllowSelfDelete)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrSelfDeleteDisabled
+	}
+
+	// O tenant não pode ficar sem um administrador ativo com o mesmo perfil
+	if u.Role == model.RoleTenantAdmin || u.Role == model.RolePartnerAdmin {
+		live := true
+		admins, err := user.MustUse().Service.ListByTenant(ctx, tenant.Tenant{UUID: *u.TenantUUID}, listing.Options{
+			Filters: listing.Filters{Role: string(u.Role), Live: &live},
+			Size:    1,
+		})
+		if err != nil {
+			return err
+		}
+		if admins.Total <= 1 {
+			return ErrLastAdmin
+		}
+	}
+
+	if err := user.MustUse().Service.Delete(ctx, u); err != nil {
+		return err
+	}
+	// A conta já foi excluída: a falha ao revogar os tokens apenas é registrada
+	if err := auth.MustUse().Service.RevokeOtherSessions(ctx, u.UUID, ""); err != nil {
+		log.Printf("[ME] falha ao encerrar as sessões do usuário excluído %s: %v", u.UUID, err)
+	}
+	return nil
+}
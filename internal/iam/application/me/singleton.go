@@ -0,0 +1,51 @@
+package me
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	once               sync.Once
+	ErrNotInitialized  = errors.New("me controller not initialized")
+)
+
+// UseMe agrupa as camadas (Service, Controller). Não há repositório próprio: os dados vêm
+// dos serviços de user e auth.
+type UseMe struct {
+	Service    Service
+	Controller Controller
+}
+
+// New inicializa o singleton do controller de autoatendimento
+func New() Controller {
+	once.Do(func() {
+		serviceInstance = NewService()
+		controllerInstance = NewController(serviceInstance)
+	})
+
+	return controllerInstance
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UseMe {
+	if controllerInstance == nil || serviceInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UseMe{
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
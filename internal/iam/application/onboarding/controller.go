@@ -0,0 +1,207 @@
+package onboarding
+
+import (
+	"errors"
+	"net/http"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/document"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	Onboard(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+
+		if login.User.Role == model.RolePartnerAdmin {
+			if target, ok := middleware.GetTargetTenant(c); ok {
+				actingTenantUUID = &target
+			}
+		}
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "onboarding",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	routes.POST("/tenant/onboard", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin), ctrl.Onboard)
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	switch {
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrInvalidSetting):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrInvalidDocument):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "Documento inválido: informe um CPF ou CNPJ com dígitos verificadores válidos, ou document_type 'foreign' para identificadores estrangeiros.")
+	case errors.Is(err, ErrEmailDomain):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "O domínio do email do admin não é permitido para este tenant.")
+	case errors.Is(err, ErrParentNotFound), errors.Is(err, ErrPlanNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrDocumentDuplicated):
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, "Já existe um tenant com este documento.", nil)
+	case errors.Is(err, ErrEmailDuplicated):
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, "Já existe um usuário com o email do admin.", nil)
+	case errors.Is(err, ErrRequestMismatch):
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, "Este documento já passou por um onboarding com outros dados.", nil)
+	default:
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+}
+
+// @Summary      Onboarding de Tenant
+// @Description  Cria, em uma única transação, o tenant, seu primeiro TENANT_ADMIN, as configurações, o plano e as etapas de provisionamento configuradas. Sem 'admin.password' o admin recebe um convite por email para definir a senha. Idempotente pelo documento: repetir a mesma requisição retorna 200 com o onboarding existente (replayed = true).
+// @Tags         Tenant
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body OnboardRequestDto true "Tenant, admin, configurações e plano"
+// @Success      200  {object}  OnboardResponseDto "Onboarding já realizado com os mesmos dados"
+// @Success      201  {object}  OnboardResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr "Tenant fora da hierarquia ou plan_code enviado por quem não é SYSTEM_ADMIN."
+// @Failure      404  {object}  rest_err.RestErr "Tenant pai ou plano não encontrado."
+// @Failure      409  {object}  rest_err.RestErr "Documento ou email já existentes."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/tenant/onboard [post]
+func (ctrl *controllerImpl) Onboard(c *gin.Context) {
+	var req OnboardRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	// A escolha do plano é restrita ao SYSTEM_ADMIN, como em PUT /api/plan/assign. Os demais
+	// recebem o plano padrão
+	if req.PlanCode != "" && ctxIdentify.User.Role != model.RoleSystemAdmin {
+		restError := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Apenas SYSTEM_ADMIN pode escolher o 'plan_code'.")
+		ctrl.logAudit(c, ctxIdentify, "onboard", "Onboard", false, req.auditInput(), restError.Message)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	documentType, err := document.ParseKind(req.DocumentType)
+	if err != nil {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "O 'document_type' deve ser cpf, cnpj ou foreign.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	var parentUUID *uuid.UUID
+	if req.ParentUUID != "" {
+		parsed, err := uuid.Parse(req.ParentUUID)
+		if err != nil {
+			restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "O 'parent_uuid' fornecido não é um formato válido.")
+			c.JSON(restError.Code, restError)
+			return
+		}
+		parentUUID = &parsed
+	}
+
+	// PARTNER_ADMIN cria tenants apenas dentro da sua subárvore (por padrão, como filho direto)
+	if ctxIdentify.User.Role == model.RolePartnerAdmin {
+		if parentUUID == nil {
+			parentUUID = ctxIdentify.User.TenantUUID
+		}
+		if parentUUID == nil {
+			e := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+			c.AbortWithStatusJSON(e.Code, e)
+			return
+		}
+		inSubtree, err := tenant.MustUse().Service.InSubtree(c.Request.Context(), *ctxIdentify.User.TenantUUID, *parentUUID)
+		if err != nil {
+			restError := rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao verificar hierarquia de tenants", nil)
+			c.JSON(restError.Code, restError)
+			return
+		}
+		if !inSubtree {
+			restError := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Tenant fora da sua hierarquia.")
+			c.JSON(restError.Code, restError)
+			return
+		}
+		middleware.SetTargetTenant(c, *parentUUID)
+	}
+
+	result, err := ctrl.Service.Onboard(c.Request.Context(), Request{
+		Name:          req.Name,
+		Document:      req.Document,
+		DocumentType:  documentType,
+		ParentUUID:    parentUUID,
+		AdminName:     req.Admin.Name,
+		AdminEmail:    req.Admin.Email,
+		AdminPassword: req.Admin.Password,
+		Settings:      req.Settings,
+		PlanCode:      req.PlanCode,
+		RequestedBy:   &ctxIdentify.User.UUID,
+	})
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "onboard", "Onboard", false, req.auditInput(), err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	resp := toOnboardResponse(result)
+	if result.Replayed {
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	// Um único registro de auditoria cobre todo o onboarding
+	ctrl.logAudit(c, ctxIdentify, "onboard", "Onboard", true, req.auditInput(), resp)
+	c.JSON(http.StatusCreated, resp)
+}
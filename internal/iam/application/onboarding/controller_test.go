@@ -0,0 +1,64 @@
+package onboarding
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type fakeService struct {
+	called bool
+}
+
+func (f *fakeService) Onboard(ctx context.Context, req Request) (Result, error) {
+	f.called = true
+	return Result{}, nil
+}
+
+func TestOnboardPlanCodeRequiresSystemAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	partnerTenant := uuid.New()
+
+	tests := []struct {
+		name string
+		role model.UserRole
+		body string
+	}{
+		{name: "partner com plan_code", role: model.RolePartnerAdmin,
+			body: `{"name":"Acme","document":"11222333000181","admin":{"name":"Ana","email":"ana@acme.com"},"plan_code":"enterprise"}`},
+		{name: "tenant admin com plan_code", role: model.RoleTenantAdmin,
+			body: `{"name":"Acme","document":"11222333000181","admin":{"name":"Ana","email":"ana@acme.com"},"plan_code":"free"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &fakeService{}
+			ctrl := &controllerImpl{Service: service}
+			router := gin.New()
+			router.POST("/api/tenant/onboard", func(c *gin.Context) {
+				middleware.SetAuthenticatedUser(c, &middleware.Login{
+					User: model.User{UUID: uuid.New(), TenantUUID: &partnerTenant, Role: tt.role},
+				})
+			}, ctrl.Onboard)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/api/tenant/onboard", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusForbidden {
+				t.Fatalf("status = %d, esperado 403: %s", w.Code, w.Body.String())
+			}
+			if service.called {
+				t.Fatal("onboarding executado com plan_code escolhido por quem não é SYSTEM_ADMIN")
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package onboarding
+
+import "encoding/json"
+
+type OnboardAdminRequestDto struct {
+	Name  string `json:"name" binding:"required"`
+	Email string `json:"email" binding:"required,email"`
+	// Senha inicial. Se omitida, o admin recebe um convite por email para defini-la.
+	Password string `json:"password" binding:"omitempty,min=8"`
+}
+
+type OnboardRequestDto struct {
+	Name     string `json:"name" binding:"required"`
+	Document string `json:"document" binding:"required"`
+	// DocumentType: cpf, cnpj ou foreign. Vazio = detecta CPF/CNPJ pelo formato
+	DocumentType string                 `json:"document_type"`
+	ParentUUID   string                 `json:"parent_uuid"`
+	Admin        OnboardAdminRequestDto `json:"admin" binding:"required"`
+	// Configurações do tenant (chave → valor), como em PATCH /api/settings
+	Settings map[string]json.RawMessage `json:"settings" swaggertype:"object"`
+	PlanCode string                     `json:"plan_code"`
+}
+
+// auditInput remove a senha do registro de auditoria.
+func (r OnboardRequestDto) auditInput() OnboardRequestDto {
+	if r.Admin.Password != "" {
+		r.Admin.Password = "***"
+	}
+	return r
+}
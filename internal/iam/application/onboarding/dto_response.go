@@ -0,0 +1,64 @@
+package onboarding
+
+import (
+	"time"
+
+	"tenant-crud-simply/internal/pkg/document"
+
+	"github.com/google/uuid"
+)
+
+type OnboardTenantResponseDto struct {
+	UUID              uuid.UUID  `json:"uuid"`
+	ParentUUID        *uuid.UUID `json:"parent_uuid,omitempty"`
+	Name              string     `json:"name"`
+	Document          string     `json:"document"`
+	DocumentType      string     `json:"document_type,omitempty"`
+	DocumentFormatted string     `json:"document_formatted"`
+	CreateAt          time.Time  `json:"create_at"`
+}
+
+type OnboardAdminResponseDto struct {
+	UUID  uuid.UUID `json:"uuid"`
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+}
+
+type OnboardResponseDto struct {
+	Tenant OnboardTenantResponseDto `json:"tenant"`
+	Admin  OnboardAdminResponseDto  `json:"admin"`
+	// Invited indica que o admin foi convidado por email (sem senha inicial)
+	Invited    bool `json:"invited"`
+	InviteSent bool `json:"invite_sent"`
+	// Steps lista as etapas de provisionamento executadas
+	Steps []string `json:"steps"`
+	// Replayed indica que o onboarding já havia sido feito com os mesmos dados
+	Replayed bool `json:"replayed"`
+}
+
+func toOnboardResponse(r Result) OnboardResponseDto {
+	resp := OnboardResponseDto{
+		Tenant: OnboardTenantResponseDto{
+			UUID:              r.Tenant.UUID,
+			ParentUUID:        r.Tenant.ParentUUID,
+			Name:              r.Tenant.Name,
+			Document:          r.Tenant.Document,
+			DocumentType:      string(r.Tenant.DocumentType),
+			DocumentFormatted: document.Format(r.Tenant.DocumentType, r.Tenant.Document),
+			CreateAt:          r.Tenant.CreateAt,
+		},
+		Admin: OnboardAdminResponseDto{
+			UUID:  r.Admin.UUID,
+			Name:  r.Admin.Name,
+			Email: r.Admin.Email,
+		},
+		Invited:    r.Invited,
+		InviteSent: r.InviteSent,
+		Steps:      r.Steps,
+		Replayed:   r.Replayed,
+	}
+	if resp.Steps == nil {
+		resp.Steps = []string{}
+	}
+	return resp
+}
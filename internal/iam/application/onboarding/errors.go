@@ -0,0 +1,16 @@
+package onboarding
+
+import "errors"
+
+var (
+	ErrInvalidInput       = errors.New("invalid input data")
+	ErrInvalidSetting     = errors.New("invalid setting")
+	ErrInvalidDocument    = errors.New("invalid tenant document")
+	ErrEmailDomain        = errors.New("admin email domain not allowed")
+	ErrParentNotFound     = errors.New("parent tenant not found")
+	ErrPlanNotFound       = errors.New("plan not found")
+	ErrDocumentDuplicated = errors.New("document already exists")
+	ErrEmailDuplicated    = errors.New("admin email already exists")
+	// ErrRequestMismatch indica que o documento já passou por um onboarding com outros dados.
+	ErrRequestMismatch = errors.New("document already onboarded with different data")
+)
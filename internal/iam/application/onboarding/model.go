@@ -0,0 +1,87 @@
+package onboarding
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/pkg/document"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Onboarding registra um onboarding concluído, para que a repetição da requisição seja idempotente.
+type Onboarding struct {
+	TenantUUID    uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Document      string     `gorm:"type:varchar(100);not null;unique"`
+	AdminUserUUID *uuid.UUID `gorm:"type:uuid"`
+	RequestHash   string     `gorm:"type:varchar(64);not null"`
+	Invited       bool       `gorm:"not null"`
+	RequestedBy   *uuid.UUID `gorm:"type:uuid"`
+	CreateAt      time.Time  `gorm:"type:timestamp without time zone;not null"`
+}
+
+func (Onboarding) TableName() string {
+	return "tenant_onboardings"
+}
+
+// Request é o onboarding solicitado. Sem AdminPassword o admin é convidado por email.
+type Request struct {
+	Name          string
+	Document      string
+	DocumentType  document.Kind // Vazio = detecta CPF/CNPJ pelo formato
+	ParentUUID    *uuid.UUID
+	AdminName     string
+	AdminEmail    string
+	AdminPassword string
+	// Settings sobrescreve as configurações do tenant (somadas às padrão do onboarding).
+	Settings map[string]json.RawMessage
+	// PlanCode associa o tenant a um plano; vazio mantém o plano padrão.
+	PlanCode    string
+	RequestedBy *uuid.UUID
+}
+
+// Result descreve o onboarding. Replayed indica que ele já existia e nada foi criado.
+type Result struct {
+	Tenant     model.Tenant
+	Admin      model.User
+	Invited    bool
+	InviteSent bool
+	Steps      []string
+	Replayed   bool
+}
+
+// StepInput é entregue às etapas de provisionamento. Toda escrita deve usar Tx, para que uma
+// falha desfaça o onboarding inteiro.
+type StepInput struct {
+	Tx     *gorm.DB
+	Tenant model.Tenant
+	Admin  model.User
+}
+
+// Step é uma etapa de provisionamento executada na transação do onboarding, após a criação do
+// tenant, do admin, das configurações e do plano.
+type Step struct {
+	Name string
+	Run  func(ctx context.Context, in StepInput) error
+}
+
+// GroupConfig é um grupo criado em todo novo tenant.
+type GroupConfig struct {
+	Name        string `mapstructure:"name"`
+	Description string `mapstructure:"description"`
+	// AddAdmin inclui o primeiro admin no grupo.
+	AddAdmin bool `mapstructure:"add_admin"`
+}
+
+// onboardingPlan reúne os registros a inserir na transação.
+type onboardingPlan struct {
+	Tenant     model.Tenant
+	Admin      model.User
+	Settings   []model.TenantSetting
+	Plan       *model.TenantPlan
+	Onboarding Onboarding
+	Steps      []Step
+}
@@ -0,0 +1,132 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// ParentExists verifica se o tenant pai existe e não foi excluído.
+	ParentExists(ctx context.Context, parentUUID uuid.UUID) (bool, error)
+	// Onboard grava o plano em uma única transação. Se o documento já passou por um onboarding,
+	// nada é gravado e o registro existente é retornado com existing = true.
+	Onboard(ctx context.Context, plan onboardingPlan) (existing *Onboarding, err error)
+	ReadTenant(ctx context.Context, tenantUUID uuid.UUID) (model.Tenant, error)
+	ReadUser(ctx context.Context, userUUID uuid.UUID) (model.User, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) ParentExists(ctx context.Context, parentUUID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.Tenant{}).
+		Where("uuid = ? AND deleted_at IS NULL", parentUUID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repositoryImpl) Onboard(ctx context.Context, plan onboardingPlan) (*Onboarding, error) {
+	var existing *Onboarding
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serializa onboardings concorrentes do mesmo documento
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "onboarding:"+plan.Tenant.Document).Error; err != nil {
+			return err
+		}
+
+		var found Onboarding
+		result := tx.Where("document = ?", plan.Tenant.Document).Limit(1).Find(&found)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			existing = &found
+			return nil
+		}
+
+		if err := tx.Create(&plan.Tenant).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&model.TenantStatusChange{
+			TenantUUID: plan.Tenant.UUID,
+			ToStatus:   plan.Tenant.Status,
+			Reason:     "Onboarding do tenant",
+			ChangedBy:  plan.Onboarding.RequestedBy,
+			CreateAt:   plan.Tenant.CreateAt,
+		}).Error; err != nil {
+			return err
+		}
+		plan.Admin.TenantUUID = &plan.Tenant.UUID
+		if err := tx.Omit(clause.Associations).Create(&plan.Admin).Error; err != nil {
+			return err
+		}
+		for i := range plan.Settings {
+			plan.Settings[i].TenantUUID = plan.Tenant.UUID
+		}
+		if len(plan.Settings) > 0 {
+			if err := tx.Create(&plan.Settings).Error; err != nil {
+				return err
+			}
+		}
+		if plan.Plan != nil {
+			plan.Plan.TenantUUID = plan.Tenant.UUID
+			if err := tx.Create(plan.Plan).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, step := range plan.Steps {
+			if err := step.Run(ctx, StepInput{Tx: tx, Tenant: plan.Tenant, Admin: plan.Admin}); err != nil {
+				return fmt.Errorf("etapa de provisionamento '%s': %w", step.Name, err)
+			}
+		}
+
+		plan.Onboarding.TenantUUID = plan.Tenant.UUID
+		plan.Onboarding.AdminUserUUID = &plan.Admin.UUID
+		return tx.Create(&plan.Onboarding).Error
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return existing, nil
+}
+
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "23505" && pgErr.ConstraintName == "tenant_document_key":
+			return ErrDocumentDuplicated
+		case pgErr.Code == "23505" && pgErr.ConstraintName == "users_email_key":
+			return ErrEmailDuplicated
+		case pgErr.Code == "23503" && pgErr.ConstraintName == "fk_tenant_plans_plan":
+			return ErrPlanNotFound
+		}
+	}
+	return err
+}
+
+func (r *repositoryImpl) ReadTenant(ctx context.Context, tenantUUID uuid.UUID) (model.Tenant, error) {
+	var tenant model.Tenant
+	err := r.db.WithContext(ctx).Where("uuid = ?", tenantUUID).Take(&tenant).Error
+	return tenant, err
+}
+
+func (r *repositoryImpl) ReadUser(ctx context.Context, userUUID uuid.UUID) (model.User, error) {
+	var user model.User
+	err := r.db.WithContext(ctx).Where("uuid = ?", userUUID).Take(&user).Error
+	return user, err
+}
@@ -0,0 +1,293 @@
+package onboarding
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"tenant-crud-simply/internal/iam/application/auth"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/plan"
+	"tenant-crud-simply/internal/iam/domain/settings"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/pkg/document"
+	"tenant-crud-simply/internal/pkg/util"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	// Onboard cria o tenant, seu primeiro TENANT_ADMIN, as configurações, o plano e as etapas de
+	// provisionamento em uma única transação. É idempotente pelo documento: a mesma requisição
+	// repetida retorna o onboarding existente (Result.Replayed).
+	Onboard(ctx context.Context, req Request) (Result, error)
+}
+
+type serviceImpl struct {
+	Repository Repository
+	cfg        Config
+}
+
+func NewService(repository Repository, cfg Config) Service {
+	if cfg.InviteTTL <= 0 {
+		cfg.InviteTTL = 72 * time.Hour
+	}
+	return &serviceImpl{
+		Repository: repository,
+		cfg:        cfg,
+	}
+}
+
+// requestHash identifica a requisição para a idempotência. A senha não faz parte do hash.
+func requestHash(req Request, tenantSettings map[string]json.RawMessage) (string, error) {
+	canonical := struct {
+		Name       string                     `json:"name"`
+		Document   string                     `json:"document"`
+		ParentUUID *uuid.UUID                 `json:"parent_uuid"`
+		AdminName  string                     `json:"admin_name"`
+		AdminEmail string                     `json:"admin_email"`
+		Invite     bool                       `json:"invite"`
+		Settings   map[string]json.RawMessage `json:"settings"`
+		PlanCode   string                     `json:"plan_code"`
+	}{
+		Name:       req.Name,
+		Document:   req.Document,
+		ParentUUID: req.ParentUUID,
+		AdminName:  req.AdminName,
+		AdminEmail: req.AdminEmail,
+		Invite:     req.AdminPassword == "",
+		Settings:   tenantSettings,
+		PlanCode:   req.PlanCode,
+	}
+	raw, err := json.Marshal(canonical)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// mergeSettings combina as configurações padrão do onboarding com as da requisição, validando-as.
+func (s *serviceImpl) mergeSettings(overrides map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	merged := make(map[string]json.RawMessage, len(s.cfg.Settings)+len(overrides))
+	for key, value := range s.cfg.Settings {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidSetting, key)
+		}
+		merged[key] = raw
+	}
+	for key, raw := range overrides {
+		merged[key] = raw
+	}
+
+	for key, raw := range merged {
+		def, ok := settings.Lookup(key)
+		if !ok {
+			return nil, fmt.Errorf("%w: chave '%s' desconhecida", ErrInvalidSetting, key)
+		}
+		if err := def.Check(raw); err != nil {
+			return nil, fmt.Errorf("%w: '%s': %v", ErrInvalidSetting, key, err)
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, raw); err != nil {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidSetting, key)
+		}
+		merged[key] = compact.Bytes()
+	}
+	return merged, nil
+}
+
+// checkEmailDomain aplica ao admin a restrição de domínios que o novo tenant terá: a informada
+// no onboarding ou, na falta dela, a herdada do tenant pai (ou global).
+func (s *serviceImpl) checkEmailDomain(ctx context.Context, req Request, tenantSettings map[string]json.RawMessage) error {
+	var allowed []string
+	if raw, ok := tenantSettings[settings.KeyAllowedEmailDomains]; ok {
+		if err := json.Unmarshal(raw, &allowed); err != nil {
+			return fmt.Errorf("%w: '%s'", ErrInvalidSetting, settings.KeyAllowedEmailDomains)
+		}
+	} else {
+		inheritFrom := uuid.Nil
+		if req.ParentUUID != nil {
+			inheritFrom = *req.ParentUUID
+		}
+		var err error
+		allowed, err = settings.Get[[]string](ctx, inheritFrom, settings.KeyAllowedEmailDomains)
+		if err != nil {
+			return err
+		}
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	domain := req.AdminEmail[strings.LastIndex(req.AdminEmail, "@")+1:]
+	for _, d := range allowed {
+		if strings.EqualFold(d, domain) {
+			return nil
+		}
+	}
+	return ErrEmailDomain
+}
+
+// adminPassword retorna o hash da senha inicial ou, no convite, o hash de um segredo descartado,
+// que impede o login até a senha ser definida com o código do convite.
+func adminPassword(password string) (string, error) {
+	if password == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return "", err
+		}
+		password = hex.EncodeToString(secret)
+	}
+	return util.UsePassword().Hash(password)
+}
+
+func (s *serviceImpl) Onboard(ctx context.Context, req Request) (Result, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.AdminName = strings.TrimSpace(req.AdminName)
+	req.AdminEmail = strings.TrimSpace(req.AdminEmail)
+	req.PlanCode = strings.TrimSpace(req.PlanCode)
+	if req.Name == "" || req.Document == "" || req.AdminName == "" || !strings.Contains(req.AdminEmail, "@") {
+		return Result{}, ErrInvalidInput
+	}
+	// A forma canônica também torna a idempotência indiferente à formatação do documento
+	doc, err := document.Parse(req.Document, req.DocumentType)
+	if err != nil {
+		return Result{}, ErrInvalidDocument
+	}
+	req.Document, req.DocumentType = doc.Value, doc.Kind
+
+	tenantSettings, err := s.mergeSettings(req.Settings)
+	if err != nil {
+		return Result{}, err
+	}
+	hash, err := requestHash(req, tenantSettings)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if req.ParentUUID != nil {
+		exists, err := s.Repository.ParentExists(ctx, *req.ParentUUID)
+		if err != nil {
+			return Result{}, err
+		}
+		if !exists {
+			return Result{}, ErrParentNotFound
+		}
+	}
+	if err := s.checkEmailDomain(ctx, req, tenantSettings); err != nil {
+		return Result{}, err
+	}
+
+	now := time.Now().UTC()
+	p := onboardingPlan{
+		Tenant: model.Tenant{
+			ParentUUID:   req.ParentUUID,
+			Name:         req.Name,
+			Document:     req.Document,
+			DocumentType: req.DocumentType,
+			CreateAt:     now,
+			UpdateAt:     now,
+		},
+		Onboarding: Onboarding{
+			Document:    req.Document,
+			RequestHash: hash,
+			Invited:     req.AdminPassword == "",
+			RequestedBy: req.RequestedBy,
+			CreateAt:    now,
+		},
+		Steps: s.steps(),
+	}
+	p.Tenant.Status, p.Tenant.TrialEndsAt = tenant.MustUse().Service.InitialStatus(now)
+
+	if req.PlanCode != "" {
+		selected, err := plan.MustUse().Repository.ReadByCode(ctx, req.PlanCode)
+		if err != nil {
+			if errors.Is(err, plan.ErrNotFound) {
+				return Result{}, ErrPlanNotFound
+			}
+			return Result{}, err
+		}
+		p.Plan = &model.TenantPlan{PlanUUID: selected.UUID, AssignedAt: now}
+	}
+
+	for key, raw := range tenantSettings {
+		p.Settings = append(p.Settings, model.TenantSetting{Key: key, Value: string(raw), UpdateAt: now})
+	}
+
+	passwordHash, err := adminPassword(req.AdminPassword)
+	if err != nil {
+		return Result{}, err
+	}
+	p.Admin = model.User{
+		Name:     req.AdminName,
+		Email:    req.AdminEmail,
+		Password: passwordHash,
+		Role:     model.RoleTenantAdmin,
+		Live:     true,
+		CreateAt: now,
+		UpdateAt: now,
+	}
+
+	existing, err := s.Repository.Onboard(ctx, p)
+	if err != nil {
+		return Result{}, err
+	}
+	if existing != nil {
+		return s.replay(ctx, *existing, hash)
+	}
+
+	result := Result{Tenant: p.Tenant, Admin: p.Admin, Invited: p.Onboarding.Invited}
+	result.Admin.TenantUUID = &result.Tenant.UUID
+	for _, step := range p.Steps {
+		result.Steps = append(result.Steps, step.Name)
+	}
+
+	// O convite é enviado após o commit; uma falha no email não desfaz o onboarding
+	if result.Invited {
+		if err := auth.MustUse().Service.SendInvite(ctx, req.AdminEmail, result.Tenant.Name, s.cfg.InviteTTL); err != nil {
+			log.Printf("[ONBOARDING] Falha ao enviar convite para %s (tenant %s): %v", req.AdminEmail, result.Tenant.UUID, err)
+		} else {
+			result.InviteSent = true
+		}
+	}
+	return result, nil
+}
+
+// replay devolve o onboarding já realizado para o documento, se a requisição for a mesma.
+func (s *serviceImpl) replay(ctx context.Context, existing Onboarding, hash string) (Result, error) {
+	if existing.RequestHash != hash {
+		return Result{}, ErrRequestMismatch
+	}
+	tenant, err := s.Repository.ReadTenant(ctx, existing.TenantUUID)
+	if err != nil {
+		return Result{}, err
+	}
+	result := Result{Tenant: tenant, Invited: existing.Invited, Replayed: true}
+	if existing.AdminUserUUID != nil {
+		admin, err := s.Repository.ReadUser(ctx, *existing.AdminUserUUID)
+		if err != nil {
+			return Result{}, err
+		}
+		result.Admin = admin
+	}
+	return result, nil
+}
+
+// steps retorna as etapas de provisionamento: as configuradas seguidas das registradas.
+func (s *serviceImpl) steps() []Step {
+	var all []Step
+	if len(s.cfg.Groups) > 0 {
+		all = append(all, groupsStep(s.cfg.Groups))
+	}
+	return append(all, registeredSteps()...)
+}
@@ -0,0 +1,74 @@
+package onboarding
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	repositoryInstance Repository
+	once               sync.Once
+	initErr            error
+	ErrNotInitialized  = errors.New("onboarding controller not initialized")
+)
+
+// UseOnboarding agrupa todas as camadas (Repository, Service, Controller)
+type UseOnboarding struct {
+	Repository Repository
+	Service    Service
+	Controller Controller
+}
+
+// Config usada somente no New()
+type Config struct {
+	// Settings são as configurações gravadas em todo novo tenant (a requisição pode sobrescrevê-las).
+	Settings map[string]any
+	// Groups são os grupos criados em todo novo tenant.
+	Groups []GroupConfig
+	// InviteTTL é a validade do código de convite do admin (padrão 72h).
+	InviteTTL time.Duration
+}
+
+// New inicializa o singleton do controller de onboarding com todas as suas dependências
+func New(db *gorm.DB, cfg Config) (Controller, error) {
+	once.Do(func() {
+		if db == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+
+		// Inicializa as dependências em camadas
+		repositoryInstance = NewRepository(db)
+		serviceInstance = NewService(repositoryInstance, cfg)
+		controllerInstance = NewController(serviceInstance)
+	})
+
+	return controllerInstance, initErr
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Repository, Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UseOnboarding {
+	if controllerInstance == nil || serviceInstance == nil || repositoryInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UseOnboarding{
+		Repository: repositoryInstance,
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
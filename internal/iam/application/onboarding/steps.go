@@ -0,0 +1,59 @@
+package onboarding
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+)
+
+var (
+	stepsMu sync.RWMutex
+	steps   []Step
+)
+
+// RegisterStep adiciona uma etapa de provisionamento a todos os onboardings, na ordem de registro.
+// Deve ser chamado apenas na inicialização.
+func RegisterStep(step Step) {
+	stepsMu.Lock()
+	defer stepsMu.Unlock()
+	steps = append(steps, step)
+}
+
+func registeredSteps() []Step {
+	stepsMu.RLock()
+	defer stepsMu.RUnlock()
+	return append([]Step(nil), steps...)
+}
+
+// groupsStep cria os grupos padrão configurados em onboarding.groups.
+func groupsStep(groups []GroupConfig) Step {
+	return Step{
+		Name: "groups",
+		Run: func(ctx context.Context, in StepInput) error {
+			now := time.Now().UTC()
+			for _, cfg := range groups {
+				group := model.Group{
+					TenantUUID:  in.Tenant.UUID,
+					Name:        cfg.Name,
+					Description: cfg.Description,
+					CreateAt:    now,
+					UpdateAt:    now,
+				}
+				if err := in.Tx.WithContext(ctx).Create(&group).Error; err != nil {
+					return fmt.Errorf("falha ao criar grupo '%s': %w", cfg.Name, err)
+				}
+				if !cfg.AddAdmin {
+					continue
+				}
+				member := model.GroupMember{GroupUUID: group.UUID, UserUUID: in.Admin.UUID, CreateAt: now}
+				if err := in.Tx.WithContext(ctx).Create(&member).Error; err != nil {
+					return fmt.Errorf("falha ao incluir admin no grupo '%s': %w", cfg.Name, err)
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,394 @@
+package privacy
+
+import (
+	"errors"
+	"net/http"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	Anonymize(c *gin.Context)
+	Certificate(c *gin.Context)
+	CreateHold(c *gin.Context)
+	ListHolds(c *gin.Context)
+	ReleaseHold(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+
+		if login.User.Role == model.RolePartnerAdmin {
+			if target, ok := middleware.GetTargetTenant(c); ok {
+				actingTenantUUID = &target
+			}
+		}
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "privacy",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
+	})
+}
+
+// Routes registra as rotas de privacidade. O usuário é identificado pelo UUID na URL para que
+// nenhum email fique registrado no access_log.
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	privacyGroup := routes.Group("/privacy")
+	admins := []model.UserRole{model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin}
+
+	{
+		privacyGroup.POST("/users/:uuid/anonymize", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(admins...), ctrl.Anonymize)
+		privacyGroup.GET("/certificates/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(admins...), ctrl.Certificate)
+		privacyGroup.POST("/holds", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.CreateHold)
+		privacyGroup.GET("/holds", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.ListHolds)
+		privacyGroup.DELETE("/holds/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.ReleaseHold)
+	}
+}
+
+// authorizeTenant valida o tenant do usuário anonimizado: SYSTEM_ADMIN acessa qualquer tenant,
+// PARTNER_ADMIN apenas a sua hierarquia e TENANT_ADMIN somente o próprio tenant. Usuários sem
+// tenant (SYSTEM_ADMIN) só podem ser anonimizados por um SYSTEM_ADMIN.
+func (ctrl *controllerImpl) authorizeTenant(c *gin.Context, login *middleware.Login, target *uuid.UUID) *rest_err.RestErr {
+	if login.User.Role == model.RoleSystemAdmin {
+		return nil
+	}
+	if target == nil {
+		return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+
+	switch login.User.Role {
+	case model.RolePartnerAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		inSubtree, err := tenant.MustUse().Service.InSubtree(c.Request.Context(), *login.User.TenantUUID, *target)
+		if err != nil {
+			return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "Falha ao verificar hierarquia de tenants", nil)
+		}
+		if !inSubtree {
+			return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Tenant fora da sua hierarquia.")
+		}
+		middleware.SetTargetTenant(c, *target)
+		return nil
+
+	case model.RoleTenantAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID != *target {
+			return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Acesso permitido apenas ao próprio tenant.")
+		}
+		return nil
+
+	default:
+		return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	trace := &login.Metadata.RayTraceCode
+	switch {
+	case errors.Is(err, ErrUserNotFound), errors.Is(err, ErrCertificateNotFound), errors.Is(err, ErrHoldNotFound):
+		return rest_err.NewNotFoundError(trace, err.Error())
+	case errors.Is(err, ErrLegalHold), errors.Is(err, ErrFilesInProgress):
+		return rest_err.NewConflictValidationError(trace, err.Error(), nil)
+	case errors.Is(err, ErrInvalidHold):
+		return rest_err.NewBadRequestError(trace, err.Error())
+	default:
+		return rest_err.NewInternalServerError(trace, "internal server error", nil)
+	}
+}
+
+func parseUUIDParam(c *gin.Context, login *middleware.Login) (uuid.UUID, *rest_err.RestErr) {
+	id, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		return uuid.Nil, rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "O UUID fornecido na URL não é um formato válido.")
+	}
+	return id, nil
+}
+
+// @Summary      Anonimiza um Usuário (LGPD)
+// @Description  Substitui o nome, o email e o telefone do usuário por um pseudônimo no cadastro, no access log, no audit log e no histórico de login, mascara os seus IPs, revoga as sessões e torna a senha inutilizável. O registro do usuário é mantido para as referências e estatísticas. Retorna o certificado de eliminação. Uma anonimização interrompida é retomada ao repetir o pedido; um usuário já anonimizado retorna o certificado existente.
+// @Tags         Privacy
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do usuário"
+// @Param        request body AnonymizeRequestDto false "Motivo do pedido"
+// @Success      200  {object}  CertificateResponseDto "Usuário já anonimizado"
+// @Success      201  {object}  CertificateResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Usuário não encontrado."
+// @Failure      409  {object}  rest_err.RestErr "Usuário ou tenant sob retenção legal, ou exportação/importação em andamento."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/privacy/users/{uuid}/anonymize [post]
+func (ctrl *controllerImpl) Anonymize(c *gin.Context) {
+	login, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	userUUID, restError := parseUUIDParam(c, login)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	var req AnonymizeRequestDto
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			restError := rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "invalid json body")
+			c.JSON(restError.Code, restError)
+			return
+		}
+	}
+	input := map[string]any{"user_uuid": userUUID, "reason": req.Reason}
+
+	if userUUID == login.User.UUID {
+		restError := rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Use DELETE /api/me para excluir a própria conta.")
+		ctrl.logAudit(c, login, "anonymize", "Anonymize", false, input, restError)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	subject, err := ctrl.Service.Subject(c.Request.Context(), userUUID)
+	if err != nil {
+		restError := ctrl.restError(login, err)
+		ctrl.logAudit(c, login, "anonymize", "Anonymize", false, input, restError)
+		c.JSON(restError.Code, restError)
+		return
+	}
+	if restError := ctrl.authorizeTenant(c, login, subject.TenantUUID); restError != nil {
+		ctrl.logAudit(c, login, "anonymize", "Anonymize", false, input, restError)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	cert, err := ctrl.Service.Anonymize(c.Request.Context(), userUUID, Options{
+		RequestedBy: &login.User.UUID,
+		Source:      SourceAPI,
+		Reason:      req.Reason,
+	})
+	if errors.Is(err, ErrAlreadyAnonymized) {
+		c.JSON(http.StatusOK, toCertificateResponse(cert))
+		return
+	}
+	if err != nil {
+		restError := ctrl.restError(login, err)
+		ctrl.logAudit(c, login, "anonymize", "Anonymize", false, input, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	// Apenas o UUID do usuário e o certificado: o audit log não recebe os dados anonimizados
+	ctrl.logAudit(c, login, "anonymize", "Anonymize", true, input, map[string]any{
+		"certificate_uuid": cert.UUID,
+		"affected":         cert.Affected,
+		"sha256":           cert.SHA256,
+	})
+	c.JSON(http.StatusCreated, toCertificateResponse(cert))
+}
+
+// @Summary      Consulta um Certificado de Eliminação
+// @Description  Retorna o certificado emitido na anonimização de um usuário.
+// @Tags         Privacy
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do certificado"
+// @Success      200  {object}  CertificateResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Certificado não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/privacy/certificates/{uuid} [get]
+func (ctrl *controllerImpl) Certificate(c *gin.Context) {
+	login, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	certUUID, restError := parseUUIDParam(c, login)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	cert, err := ctrl.Service.Certificate(c.Request.Context(), certUUID)
+	if err != nil {
+		restError := ctrl.restError(login, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+	if restError := ctrl.authorizeTenant(c, login, cert.TenantUUID); restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+	c.JSON(http.StatusOK, toCertificateResponse(cert))
+}
+
+// @Summary      Cria uma Retenção Legal
+// @Description  Impede a anonimização do usuário ou de todos os usuários do tenant (informe apenas um) até que a retenção seja liberada. Apenas SYSTEM_ADMIN.
+// @Tags         Privacy
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body CreateHoldRequestDto true "Usuário ou tenant e motivo"
+// @Success      201  {object}  HoldResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/privacy/holds [post]
+func (ctrl *controllerImpl) CreateHold(c *gin.Context) {
+	login, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	var req CreateHoldRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	hold, err := ctrl.Service.PlaceHold(c.Request.Context(), Hold{
+		UserUUID:   req.UserUUID,
+		TenantUUID: req.TenantUUID,
+		Reason:     req.Reason,
+		CreatedBy:  &login.User.UUID,
+	})
+	if err != nil {
+		restError := ctrl.restError(login, err)
+		ctrl.logAudit(c, login, "create_hold", "CreateHold", false, req, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctrl.logAudit(c, login, "create_hold", "CreateHold", true, req, hold)
+	c.JSON(http.StatusCreated, toHoldResponse(hold))
+}
+
+// @Summary      Lista as Retenções Legais
+// @Description  Lista as retenções ativas; all=true inclui as já liberadas. Apenas SYSTEM_ADMIN.
+// @Tags         Privacy
+// @Produce      json
+// @Security     BearerAuth
+// @Param        all query bool false "Incluir as retenções liberadas"
+// @Success      200  {array}   HoldResponseDto
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/privacy/holds [get]
+func (ctrl *controllerImpl) ListHolds(c *gin.Context) {
+	login, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	var req ListHoldsRequestDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		restError := rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "Parâmetros de consulta inválidos.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	holds, err := ctrl.Service.ListHolds(c.Request.Context(), !req.All)
+	if err != nil {
+		restError := ctrl.restError(login, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := make([]HoldResponseDto, 0, len(holds))
+	for _, h := range holds {
+		response = append(response, toHoldResponse(h))
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Libera uma Retenção Legal
+// @Description  Encerra a retenção; o registro é mantido com a data e o autor da liberação. Apenas SYSTEM_ADMIN.
+// @Tags         Privacy
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID da retenção"
+// @Success      200  {object}  HoldResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Retenção não encontrada ou já liberada."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/privacy/holds/{uuid} [delete]
+func (ctrl *controllerImpl) ReleaseHold(c *gin.Context) {
+	login, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	holdUUID, restError := parseUUIDParam(c, login)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	hold, err := ctrl.Service.ReleaseHold(c.Request.Context(), holdUUID, &login.User.UUID)
+	if err != nil {
+		restError := ctrl.restError(login, err)
+		ctrl.logAudit(c, login, "release_hold", "ReleaseHold", false, holdUUID, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctrl.logAudit(c, login, "release_hold", "ReleaseHold", true, holdUUID, hold)
+	c.JSON(http.StatusOK, toHoldResponse(hold))
+}
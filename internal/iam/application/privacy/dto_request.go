@@ -0,0 +1,20 @@
+package privacy
+
+import "github.com/google/uuid"
+
+type AnonymizeRequestDto struct {
+	// Reason é o motivo do pedido (ex.: protocolo da solicitação do titular), gravado no certificado
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// CreateHoldRequestDto informa o usuário ou o tenant (apenas um) sob retenção legal.
+type CreateHoldRequestDto struct {
+	UserUUID   *uuid.UUID `json:"user_uuid"`
+	TenantUUID *uuid.UUID `json:"tenant_uuid"`
+	Reason     string     `json:"reason" binding:"required,max=500"`
+}
+
+type ListHoldsRequestDto struct {
+	// All inclui as retenções já liberadas
+	All bool `form:"all"`
+}
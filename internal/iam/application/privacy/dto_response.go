@@ -0,0 +1,68 @@
+package privacy
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CertificateResponseDto é o certificado de eliminação. sha256 é o hash do conteúdo do
+// certificado e permite verificar que ele não foi alterado depois de emitido.
+type CertificateResponseDto struct {
+	UUID        uuid.UUID  `json:"uuid"`
+	UserUUID    uuid.UUID  `json:"user_uuid"`
+	TenantUUID  *uuid.UUID `json:"tenant_uuid,omitempty"`
+	Pseudonym   string     `json:"pseudonym"`
+	RequestedBy *uuid.UUID `json:"requested_by,omitempty"`
+	// Source é api ou cli
+	Source string `json:"source"`
+	Reason string `json:"reason,omitempty"`
+	// Status é running (interrompida; repita o pedido para concluir) ou completed
+	Status string `json:"status"`
+	// Affected é o número de registros alterados por tabela
+	Affected    map[string]int64 `json:"affected"`
+	SHA256      string           `json:"sha256,omitempty"`
+	CreateAt    time.Time        `json:"create_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+}
+
+func toCertificateResponse(c Certificate) CertificateResponseDto {
+	return CertificateResponseDto{
+		UUID:        c.UUID,
+		UserUUID:    c.UserUUID,
+		TenantUUID:  c.TenantUUID,
+		Pseudonym:   c.Pseudonym,
+		RequestedBy: c.RequestedBy,
+		Source:      c.Source,
+		Reason:      c.Reason,
+		Status:      string(c.Status),
+		Affected:    c.Affected,
+		SHA256:      c.SHA256,
+		CreateAt:    c.CreateAt,
+		CompletedAt: c.CompletedAt,
+	}
+}
+
+type HoldResponseDto struct {
+	UUID       uuid.UUID  `json:"uuid"`
+	UserUUID   *uuid.UUID `json:"user_uuid,omitempty"`
+	TenantUUID *uuid.UUID `json:"tenant_uuid,omitempty"`
+	Reason     string     `json:"reason"`
+	CreatedBy  *uuid.UUID `json:"created_by,omitempty"`
+	CreateAt   time.Time  `json:"create_at"`
+	ReleasedAt *time.Time `json:"released_at,omitempty"`
+	ReleasedBy *uuid.UUID `json:"released_by,omitempty"`
+}
+
+func toHoldResponse(h Hold) HoldResponseDto {
+	return HoldResponseDto{
+		UUID:       h.UUID,
+		UserUUID:   h.UserUUID,
+		TenantUUID: h.TenantUUID,
+		Reason:     h.Reason,
+		CreatedBy:  h.CreatedBy,
+		CreateAt:   h.CreateAt,
+		ReleasedAt: h.ReleasedAt,
+		ReleasedBy: h.ReleasedBy,
+	}
+}
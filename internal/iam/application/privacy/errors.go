@@ -0,0 +1,13 @@
+package privacy
+
+import "errors"
+
+var (
+	ErrUserNotFound        = errors.New("user not found")
+	ErrCertificateNotFound = errors.New("erasure certificate not found")
+	ErrHoldNotFound        = errors.New("legal hold not found")
+	ErrLegalHold           = errors.New("user data is under legal hold")
+	ErrAlreadyAnonymized   = errors.New("user already anonymized")
+	ErrInvalidHold         = errors.New("invalid legal hold")
+	ErrFilesInProgress     = errors.New("tenant export or user import in progress")
+)
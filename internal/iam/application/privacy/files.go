@@ -0,0 +1,176 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// discardReason é gravado no erro das exportações removidas por conterem o usuário anonimizado.
+const discardReason = "arquivo removido: continha dados de um usuário anonimizado"
+
+// scanChunkSize é o tamanho dos blocos lidos na busca pelo usuário dentro dos pacotes.
+const scanChunkSize = 64 * 1024
+
+// scrubFiles trata os arquivos gerados no disco que podem conter os dados originais: os pacotes
+// de exportação que citam o usuário são removidos (não podem ser regenerados com o mesmo
+// conteúdo) e os resultados de importação do tenant são regravados com o pseudônimo.
+func (s *serviceImpl) scrubFiles(ctx context.Context, subject Subject, rep *replacer) (Affected, error) {
+	affected := Affected{"tenant_exports": 0, "user_imports": 0}
+
+	exports, err := s.Repository.ReadyExports(ctx)
+	if err != nil {
+		return nil, err
+	}
+	needles := [][]byte{[]byte(strings.ToLower(subject.Email)), []byte(subject.UUID.String())}
+	for _, export := range exports {
+		found, err := archiveMentions(export.Path, needles)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("falha ao verificar a exportação %s: %w", export.UUID, err)
+		}
+		if !found {
+			continue
+		}
+		// Expirada antes da remoção, para que não seja entregue em um download
+		if err := s.Repository.DiscardExport(ctx, export.UUID, discardReason); err != nil {
+			return nil, err
+		}
+		if err := os.Remove(export.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("falha ao remover a exportação %s: %w", export.UUID, err)
+		}
+		affected["tenant_exports"]++
+	}
+
+	if subject.TenantUUID == nil {
+		return affected, nil
+	}
+	results, err := s.Repository.ImportResults(ctx, *subject.TenantUUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		changed, err := rewriteCSV(result.Path, rep)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("falha ao regravar o resultado da importação %s: %w", result.UUID, err)
+		}
+		if changed {
+			affected["user_imports"]++
+		}
+	}
+	return affected, nil
+}
+
+// archiveMentions indica se algum arquivo do zip contém um dos termos (em minúsculas).
+func archiveMentions(path string, needles [][]byte) (bool, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return false, err
+	}
+	defer archive.Close()
+
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		if err != nil {
+			return false, err
+		}
+		found, err := readerMentions(rc, needles)
+		rc.Close()
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// readerMentions procura os termos sem diferenciar maiúsculas, em blocos, mantendo entre um
+// bloco e outro o final do anterior para encontrar termos divididos entre os dois.
+func readerMentions(r io.Reader, needles [][]byte) (bool, error) {
+	overlap := 0
+	for _, n := range needles {
+		overlap = max(overlap, len(n)-1)
+	}
+	buf := make([]byte, scanChunkSize)
+	var tail []byte
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			window := append(tail, bytes.ToLower(buf[:n])...)
+			for _, needle := range needles {
+				if len(needle) > 0 && bytes.Contains(window, needle) {
+					return true, nil
+				}
+			}
+			tail = append([]byte(nil), window[max(len(window)-overlap, 0):]...)
+		}
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// rewriteCSV substitui os dados pessoais em todas as células do CSV e só regrava o arquivo
+// (em um temporário publicado com rename) se algo mudou.
+func rewriteCSV(path string, rep *replacer) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	file.Close()
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	for _, record := range records {
+		for i, cell := range record {
+			if replaced := rep.text(cell); replaced != cell {
+				record[i] = replaced
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	tmp := path + ".part"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return false, err
+	}
+	w := csv.NewWriter(out)
+	if err := w.WriteAll(records); err != nil {
+		out.Close()
+		_ = os.Remove(tmp)
+		return false, err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return false, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return false, err
+	}
+	log.Printf("[PRIVACY] Resultado de importação regravado sem os dados do usuário: %s", path)
+	return true, nil
+}
@@ -0,0 +1,123 @@
+package privacy
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Origem do pedido de anonimização registrada no certificado.
+const (
+	SourceAPI = "api"
+	SourceCLI = "cli"
+)
+
+type CertificateStatus string
+
+const (
+	// CertificateRunning indica uma anonimização iniciada e não concluída; uma nova execução
+	// para o mesmo usuário a retoma com o mesmo pseudônimo.
+	CertificateRunning   CertificateStatus = "running"
+	CertificateCompleted CertificateStatus = "completed"
+)
+
+// Affected é o número de registros alterados por tabela.
+type Affected map[string]int64
+
+func (a Affected) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+	raw, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+func (a *Affected) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*a = Affected{}
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("affected: tipo %T não suportado", src)
+	}
+	return json.Unmarshal(raw, a)
+}
+
+// Certificate é o certificado de eliminação: registra quando, por quem e onde os dados pessoais
+// do usuário foram substituídos pelo pseudônimo. Não contém nenhum dado pessoal.
+type Certificate struct {
+	UUID        uuid.UUID         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserUUID    uuid.UUID         `gorm:"type:uuid;not null"`
+	TenantUUID  *uuid.UUID        `gorm:"type:uuid"`
+	Pseudonym   string            `gorm:"not null"`
+	RequestedBy *uuid.UUID        `gorm:"type:uuid"` // Nil quando executado pela linha de comando
+	Source      string            `gorm:"not null"`
+	Reason      string            `gorm:"not null;default:''"`
+	Status      CertificateStatus `gorm:"not null"`
+	Affected    Affected          `gorm:"type:jsonb;not null;default:'{}'"`
+	// SHA256 é o hash do conteúdo do certificado (veja seal), gravado na conclusão
+	SHA256      string     `gorm:"column:sha256;not null;default:''"`
+	CreateAt    time.Time  `gorm:"column:create_at;not null"`
+	CompletedAt *time.Time `gorm:"column:completed_at"`
+}
+
+func (Certificate) TableName() string {
+	return "erasure_certificates"
+}
+
+// Hold é uma retenção legal: enquanto ativa, os dados do usuário (UserUUID) ou de todos os
+// usuários do tenant (TenantUUID) não podem ser anonimizados.
+type Hold struct {
+	UUID       uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TenantUUID *uuid.UUID `gorm:"type:uuid"`
+	UserUUID   *uuid.UUID `gorm:"type:uuid"`
+	Reason     string     `gorm:"not null"`
+	CreatedBy  *uuid.UUID `gorm:"type:uuid"`
+	CreateAt   time.Time  `gorm:"column:create_at;not null"`
+	ReleasedAt *time.Time `gorm:"column:released_at"`
+	ReleasedBy *uuid.UUID `gorm:"type:uuid"`
+}
+
+func (Hold) TableName() string {
+	return "legal_holds"
+}
+
+// Subject são os dados pessoais do usuário a anonimizar.
+type Subject struct {
+	UUID       uuid.UUID
+	TenantUUID *uuid.UUID
+	Email      string
+	Name       string
+	Phone      string
+}
+
+// Pseudonyms são os valores que substituem os dados pessoais do usuário.
+type Pseudonyms struct {
+	Name  string
+	Email string
+}
+
+// Options descreve quem pediu a anonimização e por quê.
+type Options struct {
+	RequestedBy *uuid.UUID
+	Source      string
+	Reason      string
+}
+
+// StoredFile é um arquivo gerado no disco do servidor (pacote de exportação ou resultado de
+// importação) que pode conter os dados pessoais do usuário.
+type StoredFile struct {
+	UUID uuid.UUID
+	Path string
+}
@@ -0,0 +1,369 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tenant-crud-simply/internal/iam/application/tenant_export"
+	"tenant-crud-simply/internal/iam/application/user_import"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/infra/database/postgres"
+	"tenant-crud-simply/internal/pkg/listing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// unusablePassword não é um hash válido: qualquer tentativa de login do usuário anonimizado falha.
+const unusablePassword = "!anonymized"
+
+// auditBatchSize é a quantidade de registros do audit_log lidos por vez na anonimização.
+const auditBatchSize = 500
+
+type Repository interface {
+	// Subject lê os dados pessoais do usuário (no schema ou banco do seu tenant).
+	Subject(ctx context.Context, userUUID uuid.UUID) (Subject, error)
+	// ActiveHolds retorna as retenções legais ativas do usuário ou do seu tenant.
+	ActiveHolds(ctx context.Context, userUUID uuid.UUID, tenantUUID *uuid.UUID) ([]Hold, error)
+	// HoldTargetExists verifica se o usuário ou o tenant da retenção existe.
+	HoldTargetExists(ctx context.Context, hold Hold) (bool, error)
+	CreateHold(ctx context.Context, hold Hold) (Hold, error)
+	ListHolds(ctx context.Context, activeOnly bool) ([]Hold, error)
+	ReleaseHold(ctx context.Context, holdUUID uuid.UUID, releasedBy *uuid.UUID) (Hold, error)
+	GetCertificate(ctx context.Context, certUUID uuid.UUID) (Certificate, error)
+	// CertificateFor retorna o certificado (concluído ou em andamento) do usuário.
+	CertificateFor(ctx context.Context, userUUID uuid.UUID) (Certificate, error)
+	CreateCertificate(ctx context.Context, cert Certificate) (Certificate, error)
+	CompleteCertificate(ctx context.Context, cert Certificate) error
+	// ScrubLogs remove os tokens do usuário e substitui os seus dados pessoais nos logs, em uma
+	// única transação. Pode ser executado de novo sem efeito sobre o que já foi substituído.
+	ScrubLogs(ctx context.Context, s Subject, p Pseudonyms, masks ipMasks) (Affected, error)
+	// PseudonymizeUser substitui os dados pessoais do cadastro do usuário, mantendo o registro
+	// (UUID, tenant, role, datas) para as referências e estatísticas.
+	PseudonymizeUser(ctx context.Context, s Subject, p Pseudonyms) (Affected, error)
+	// FilesInProgress indica se há uma exportação de tenant (de qualquer tenant: o audit log
+	// exportado inclui ações de administradores de outros tenants) ou uma importação de usuários
+	// do tenant do usuário em andamento. Os arquivos gerados por elas teriam os dados originais.
+	FilesInProgress(ctx context.Context, tenantUUID *uuid.UUID) (bool, error)
+	// ReadyExports retorna as exportações com arquivo aguardando o download.
+	ReadyExports(ctx context.Context) ([]StoredFile, error)
+	// DiscardExport marca a exportação como expirada, antes da remoção do arquivo.
+	DiscardExport(ctx context.Context, exportUUID uuid.UUID, reason string) error
+	// ImportResults retorna os arquivos de resultado das importações de usuários do tenant.
+	ImportResults(ctx context.Context, tenantUUID uuid.UUID) ([]StoredFile, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) Subject(ctx context.Context, userUUID uuid.UUID) (Subject, error) {
+	// user_directory existe em public para todos os usuários, inclusive dos tenants isolados
+	var dir struct {
+		TenantUUID *uuid.UUID
+	}
+	result := r.db.WithContext(ctx).Table("user_directory").Select("tenant_uuid").Where("uuid = ?", userUUID).Limit(1).Scan(&dir)
+	if result.Error != nil {
+		return Subject{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Subject{}, ErrUserNotFound
+	}
+
+	ctx, release, err := middleware.TenantScope(ctx, dir.TenantUUID)
+	if err != nil {
+		return Subject{}, err
+	}
+	defer release()
+
+	var u model.User
+	if err := postgres.Conn(ctx, r.db).Where("uuid = ?", userUUID).Take(&u).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Subject{}, ErrUserNotFound
+		}
+		return Subject{}, err
+	}
+	return Subject{UUID: u.UUID, TenantUUID: u.TenantUUID, Email: u.Email, Name: u.Name, Phone: u.Phone}, nil
+}
+
+func (r *repositoryImpl) ActiveHolds(ctx context.Context, userUUID uuid.UUID, tenantUUID *uuid.UUID) ([]Hold, error) {
+	query := r.db.WithContext(ctx).Where("released_at IS NULL")
+	if tenantUUID != nil {
+		query = query.Where("user_uuid = ? OR tenant_uuid = ?", userUUID, *tenantUUID)
+	} else {
+		query = query.Where("user_uuid = ?", userUUID)
+	}
+	var holds []Hold
+	err := query.Order("create_at").Find(&holds).Error
+	return holds, err
+}
+
+func (r *repositoryImpl) HoldTargetExists(ctx context.Context, hold Hold) (bool, error) {
+	query := r.db.WithContext(ctx)
+	if hold.UserUUID != nil {
+		query = query.Table("user_directory").Where("uuid = ?", *hold.UserUUID)
+	} else {
+		query = query.Table("tenant").Where("uuid = ?", *hold.TenantUUID)
+	}
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repositoryImpl) CreateHold(ctx context.Context, hold Hold) (Hold, error) {
+	hold.CreateAt = time.Now().UTC()
+	if err := r.db.WithContext(ctx).Create(&hold).Error; err != nil {
+		// Usuário removido depois de HoldTargetExists (trigger trg_legal_holds_user)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return Hold{}, fmt.Errorf("%w: usuário ou tenant não encontrado", ErrInvalidHold)
+		}
+		return Hold{}, err
+	}
+	return hold, nil
+}
+
+func (r *repositoryImpl) ListHolds(ctx context.Context, activeOnly bool) ([]Hold, error) {
+	query := r.db.WithContext(ctx)
+	if activeOnly {
+		query = query.Where("released_at IS NULL")
+	}
+	holds := []Hold{}
+	err := query.Order("create_at DESC").Find(&holds).Error
+	return holds, err
+}
+
+func (r *repositoryImpl) ReleaseHold(ctx context.Context, holdUUID uuid.UUID, releasedBy *uuid.UUID) (Hold, error) {
+	var hold Hold
+	err := r.db.WithContext(ctx).Raw(`
+UPDATE legal_holds
+SET released_at = ?, released_by = ?
+WHERE uuid = ? AND released_at IS NULL
+RETURNING *`, time.Now().UTC(), releasedBy, holdUUID).Scan(&hold).Error
+	if err != nil {
+		return Hold{}, err
+	}
+	if hold.UUID == uuid.Nil {
+		return Hold{}, ErrHoldNotFound
+	}
+	return hold, nil
+}
+
+func (r *repositoryImpl) GetCertificate(ctx context.Context, certUUID uuid.UUID) (Certificate, error) {
+	return r.findCertificate(r.db.WithContext(ctx).Where("uuid = ?", certUUID))
+}
+
+func (r *repositoryImpl) CertificateFor(ctx context.Context, userUUID uuid.UUID) (Certificate, error) {
+	return r.findCertificate(r.db.WithContext(ctx).Where("user_uuid = ?", userUUID).Order("create_at DESC"))
+}
+
+func (r *repositoryImpl) findCertificate(query *gorm.DB) (Certificate, error) {
+	var cert Certificate
+	if err := query.Take(&cert).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Certificate{}, ErrCertificateNotFound
+		}
+		return Certificate{}, err
+	}
+	return cert, nil
+}
+
+func (r *repositoryImpl) CreateCertificate(ctx context.Context, cert Certificate) (Certificate, error) {
+	if err := r.db.WithContext(ctx).Create(&cert).Error; err != nil {
+		return Certificate{}, err
+	}
+	return cert, nil
+}
+
+func (r *repositoryImpl) CompleteCertificate(ctx context.Context, cert Certificate) error {
+	return r.db.WithContext(ctx).Model(&Certificate{}).
+		Where("uuid = ?", cert.UUID).
+		Updates(map[string]any{
+			"status":       CertificateCompleted,
+			"affected":     cert.Affected,
+			"sha256":       cert.SHA256,
+			"completed_at": cert.CompletedAt,
+		}).Error
+}
+
+// maskedIP reduz o IP à rede (/24 ou /48 por padrão): o registro continua útil para estatísticas
+// de origem sem identificar o dispositivo.
+const maskedIP = `host(network(set_masklen(ip, CASE WHEN family(ip) = 4 THEN @v4 ELSE @v6 END)))::inet`
+
+func (r *repositoryImpl) ScrubLogs(ctx context.Context, s Subject, p Pseudonyms, masks ipMasks) (Affected, error) {
+	affected := Affected{}
+	rep := newReplacer(s, p)
+	args := map[string]any{
+		"user":    s.UUID,
+		"email":   s.Email,
+		"pseudo":  p.Email,
+		"pattern": rep.emailPattern,
+		"v4":      masks.v4,
+		"v6":      masks.v6,
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		steps := []struct {
+			name  string
+			query string
+		}{
+			{"users_acess_tokens", `DELETE FROM users_acess_tokens WHERE user_uuid = @user`},
+			// Acessos do próprio usuário: identificador, IP e user agent. Acessos de terceiros (ex.: um admin
+			// consultando /api/user/<email>): apenas o email no path e no referer
+			{"access_log", `
+UPDATE access_log SET
+    identifier = CASE WHEN user_uuid = @user OR LOWER(identifier) = LOWER(@email) THEN @pseudo ELSE identifier END,
+    ip = CASE WHEN user_uuid = @user OR LOWER(identifier) = LOWER(@email) THEN ` + maskedIP + ` ELSE ip END,
+    user_agent = CASE WHEN user_uuid = @user OR LOWER(identifier) = LOWER(@email) THEN '' ELSE user_agent END,
+    path = regexp_replace(path, @pattern, @pseudo, 'gi'),
+    referer = regexp_replace(referer, @pattern, @pseudo, 'gi')
+WHERE user_uuid = @user OR LOWER(identifier) = LOWER(@email) OR path ~* @pattern OR referer ~* @pattern`},
+			{"user_login_history", `UPDATE user_login_history SET ip = ` + maskedIP + `, user_agent = '' WHERE user_uuid = @user`},
+		}
+		for _, step := range steps {
+			result := tx.Exec(step.query, args)
+			if result.Error != nil {
+				return result.Error
+			}
+			affected[step.name] = result.RowsAffected
+		}
+
+		n, err := scrubAuditLog(tx, s, rep)
+		if err != nil {
+			return err
+		}
+		affected["audit_log"] = n
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return affected, nil
+}
+
+type auditRow struct {
+	ID         uint
+	Identifier string
+	InputData  string
+	OutputData string
+}
+
+// scrubAuditLog substitui os dados pessoais do usuário nos registros de auditoria que o
+// referenciam (feitos por ele ou contendo o seu email ou UUID), inclusive dentro dos JSON
+// de input_data e output_data.
+func scrubAuditLog(tx *gorm.DB, s Subject, rep *replacer) (int64, error) {
+	var changed int64
+	like := listing.Contains(s.Email)
+	uuidLike := listing.Contains(s.UUID.String())
+	var rows []auditRow
+	result := tx.Table("audit_log").
+		Select("id, COALESCE(identifier, '') AS identifier, COALESCE(input_data, '') AS input_data, COALESCE(output_data, '') AS output_data").
+		Where("user_uuid = ? OR LOWER(identifier) = LOWER(?)", s.UUID, s.Email).
+		Or("input_data ILIKE ? OR output_data ILIKE ?", like, like).
+		Or("input_data LIKE ? OR output_data LIKE ?", uuidLike, uuidLike).
+		FindInBatches(&rows, auditBatchSize, func(batch *gorm.DB, _ int) error {
+			for _, row := range rows {
+				identifier := rep.text(row.Identifier)
+				input := rep.payload(row.InputData)
+				output := rep.payload(row.OutputData)
+				if identifier == row.Identifier && input == row.InputData && output == row.OutputData {
+					continue
+				}
+				err := tx.Table("audit_log").Where("id = ?", row.ID).Updates(map[string]any{
+					"identifier":  identifier,
+					"input_data":  input,
+					"output_data": output,
+				}).Error
+				if err != nil {
+					return err
+				}
+				changed++
+			}
+			return nil
+		})
+	return changed, result.Error
+}
+
+func (r *repositoryImpl) PseudonymizeUser(ctx context.Context, s Subject, p Pseudonyms) (Affected, error) {
+	ctx, release, err := middleware.TenantScope(ctx, s.TenantUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	affected := Affected{}
+	// Com banco dedicado não há trigger: o índice global é atualizado à parte
+	if postgres.HasTenantDatabase(ctx) {
+		result := r.db.WithContext(ctx).Exec("UPDATE user_directory SET email = ? WHERE uuid = ?", p.Email, s.UUID)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		affected["user_directory"] = result.RowsAffected
+	}
+
+	result := postgres.Conn(ctx, r.db).Model(&model.User{}).
+		Where("uuid = ?", s.UUID).
+		Updates(map[string]any{
+			"name":          p.Name,
+			"email":         p.Email,
+			"password_hash": unusablePassword,
+			"phone":         "",
+			"avatar_url":    "",
+			"metadata":      model.Metadata{},
+			"live":          false,
+			"update_at":     time.Now().UTC(),
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	affected["users"] = result.RowsAffected
+	return affected, nil
+}
+
+func (r *repositoryImpl) FilesInProgress(ctx context.Context, tenantUUID *uuid.UUID) (bool, error) {
+	var exports int64
+	err := r.db.WithContext(ctx).Model(&tenant_export.Export{}).
+		Where("status IN ?", []tenant_export.Status{tenant_export.StatusPending, tenant_export.StatusRunning}).
+		Count(&exports).Error
+	if err != nil || exports > 0 || tenantUUID == nil {
+		return exports > 0, err
+	}
+
+	var imports int64
+	err = r.db.WithContext(ctx).Model(&user_import.Job{}).
+		Where("tenant_uuid = ? AND status IN ?", *tenantUUID, []user_import.Status{user_import.StatusPending, user_import.StatusRunning}).
+		Count(&imports).Error
+	return imports > 0, err
+}
+
+func (r *repositoryImpl) ReadyExports(ctx context.Context) ([]StoredFile, error) {
+	var files []StoredFile
+	err := r.db.WithContext(ctx).Model(&tenant_export.Export{}).
+		Select("uuid, file_name AS path").
+		Where("status = ? AND file_name <> ''", tenant_export.StatusReady).
+		Scan(&files).Error
+	return files, err
+}
+
+func (r *repositoryImpl) DiscardExport(ctx context.Context, exportUUID uuid.UUID, reason string) error {
+	return r.db.WithContext(ctx).Model(&tenant_export.Export{}).
+		Where("uuid = ? AND status = ?", exportUUID, tenant_export.StatusReady).
+		Updates(map[string]any{"status": tenant_export.StatusExpired, "error": reason}).Error
+}
+
+func (r *repositoryImpl) ImportResults(ctx context.Context, tenantUUID uuid.UUID) ([]StoredFile, error) {
+	var files []StoredFile
+	err := r.db.WithContext(ctx).Model(&user_import.Job{}).
+		Select("uuid, result_file AS path").
+		Where("tenant_uuid = ? AND status = ? AND result_file <> ''", tenantUUID, user_import.StatusCompleted).
+		Scan(&files).Error
+	return files, err
+}
@@ -0,0 +1,110 @@
+package privacy
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// replacer substitui os dados pessoais de um usuário em textos e payloads JSON dos logs.
+type replacer struct {
+	email       *regexp.Regexp
+	name        *regexp.Regexp // nil se o nome for curto demais para ser substituído com segurança
+	phone       string
+	pseudoEmail string
+	pseudoName  string
+	// emailPattern é a mesma expressão de email, para o regexp_replace do PostgreSQL
+	emailPattern string
+}
+
+// minNameLength evita que nomes muito curtos sejam substituídos dentro de outros textos.
+const minNameLength = 4
+
+// notWordChar delimita o nome: o \b do Go não considera letras acentuadas parte da palavra.
+const notWordChar = `[^\p{L}\p{N}]`
+
+func newReplacer(s Subject, p Pseudonyms) *replacer {
+	// O email também aparece codificado em URLs (%40)
+	pattern := regexp.QuoteMeta(s.Email) + "|" + regexp.QuoteMeta(strings.Replace(s.Email, "@", "%40", 1))
+	r := &replacer{
+		email:        regexp.MustCompile("(?i)" + pattern),
+		pseudoEmail:  p.Email,
+		pseudoName:   p.Name,
+		emailPattern: pattern,
+	}
+	if name := strings.TrimSpace(s.Name); len([]rune(name)) >= minNameLength {
+		r.name = regexp.MustCompile(`(?i)(^|` + notWordChar + `)` + regexp.QuoteMeta(name) + `($|` + notWordChar + `)`)
+	}
+	if phone := strings.TrimSpace(s.Phone); phone != "" {
+		r.phone = phone
+	}
+	return r
+}
+
+// text substitui o email, o nome e o telefone do usuário em um texto livre.
+func (r *replacer) text(v string) string {
+	if v == "" {
+		return v
+	}
+	v = r.email.ReplaceAllLiteralString(v, r.pseudoEmail)
+	if r.name != nil {
+		v = r.name.ReplaceAllString(v, "${1}"+r.pseudoName+"${2}")
+	}
+	if r.phone != "" {
+		v = strings.ReplaceAll(v, r.phone, "")
+	}
+	return v
+}
+
+// payload substitui os dados pessoais dentro de um payload de log. JSON válido é percorrido
+// valor a valor (chaves e strings), o que trata corretamente os escapes; outros formatos
+// (fmt.Sprintf de auditoria_log.SerializeData) são tratados como texto.
+func (r *replacer) payload(v string) string {
+	if v == "" || !json.Valid([]byte(v)) {
+		return r.text(v)
+	}
+	decoder := json.NewDecoder(strings.NewReader(v))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return r.text(v)
+	}
+	doc, changed := r.walk(doc)
+	if !changed {
+		return v
+	}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(doc); err != nil {
+		return r.text(v)
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func (r *replacer) walk(node any) (any, bool) {
+	switch n := node.(type) {
+	case string:
+		replaced := r.text(n)
+		return replaced, replaced != n
+	case []any:
+		changed := false
+		for i, item := range n {
+			var c bool
+			n[i], c = r.walk(item)
+			changed = changed || c
+		}
+		return n, changed
+	case map[string]any:
+		changed := false
+		out := make(map[string]any, len(n))
+		for key, item := range n {
+			value, c := r.walk(item)
+			newKey := r.text(key)
+			out[newKey] = value
+			changed = changed || c || newKey != key
+		}
+		return out, changed
+	default:
+		return node, false
+	}
+}
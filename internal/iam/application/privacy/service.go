@@ -0,0 +1,205 @@
+package privacy
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	// Subject retorna os dados do usuário a anonimizar (usado na autorização do pedido).
+	Subject(ctx context.Context, userUUID uuid.UUID) (Subject, error)
+	// Anonymize substitui os dados pessoais do usuário por pseudônimos no cadastro e nos logs e
+	// emite o certificado de eliminação. Retorna ErrLegalHold se houver retenção legal ativa.
+	// Uma anonimização interrompida é retomada com o mesmo pseudônimo.
+	Anonymize(ctx context.Context, userUUID uuid.UUID, opts Options) (Certificate, error)
+	Certificate(ctx context.Context, certUUID uuid.UUID) (Certificate, error)
+	PlaceHold(ctx context.Context, hold Hold) (Hold, error)
+	ListHolds(ctx context.Context, activeOnly bool) ([]Hold, error)
+	ReleaseHold(ctx context.Context, holdUUID uuid.UUID, releasedBy *uuid.UUID) (Hold, error)
+}
+
+type serviceImpl struct {
+	Repository Repository
+	cfg        Config
+}
+
+func NewService(repository Repository, cfg Config) Service {
+	if cfg.EmailDomain == "" {
+		cfg.EmailDomain = DefaultEmailDomain
+	}
+	if cfg.IPv4Prefix <= 0 || cfg.IPv4Prefix > 32 {
+		cfg.IPv4Prefix = DefaultIPv4Prefix
+	}
+	if cfg.IPv6Prefix <= 0 || cfg.IPv6Prefix > 128 {
+		cfg.IPv6Prefix = DefaultIPv6Prefix
+	}
+	return &serviceImpl{Repository: repository, cfg: cfg}
+}
+
+// ipMasks são os prefixos de rede mantidos dos IPs do usuário.
+type ipMasks struct {
+	v4 int
+	v6 int
+}
+
+func (s *serviceImpl) Subject(ctx context.Context, userUUID uuid.UUID) (Subject, error) {
+	return s.Repository.Subject(ctx, userUUID)
+}
+
+func (s *serviceImpl) Anonymize(ctx context.Context, userUUID uuid.UUID, opts Options) (Certificate, error) {
+	subject, err := s.Repository.Subject(ctx, userUUID)
+	if err != nil {
+		return Certificate{}, err
+	}
+	holds, err := s.Repository.ActiveHolds(ctx, subject.UUID, subject.TenantUUID)
+	if err != nil {
+		return Certificate{}, err
+	}
+	if len(holds) > 0 {
+		// O motivo da retenção não é exposto a quem pediu a anonimização
+		return Certificate{}, ErrLegalHold
+	}
+	// Exportações e importações em andamento ainda vão gravar arquivos com os dados originais
+	busy, err := s.Repository.FilesInProgress(ctx, subject.TenantUUID)
+	if err != nil {
+		return Certificate{}, err
+	}
+	if busy {
+		return Certificate{}, ErrFilesInProgress
+	}
+
+	cert, err := s.Repository.CertificateFor(ctx, subject.UUID)
+	switch {
+	case err == nil && cert.Status == CertificateCompleted:
+		return cert, ErrAlreadyAnonymized
+	case err == nil:
+		// Execução anterior interrompida: retoma com o mesmo pseudônimo
+	case errors.Is(err, ErrCertificateNotFound):
+		pseudonym, err := newPseudonym()
+		if err != nil {
+			return Certificate{}, err
+		}
+		cert, err = s.Repository.CreateCertificate(ctx, Certificate{
+			UserUUID:    subject.UUID,
+			TenantUUID:  subject.TenantUUID,
+			Pseudonym:   pseudonym,
+			RequestedBy: opts.RequestedBy,
+			Source:      opts.Source,
+			Reason:      opts.Reason,
+			Status:      CertificateRunning,
+			Affected:    Affected{},
+			CreateAt:    now(),
+		})
+		if err != nil {
+			return Certificate{}, err
+		}
+	default:
+		return Certificate{}, err
+	}
+
+	pseudonyms := Pseudonyms{Name: cert.Pseudonym, Email: cert.Pseudonym + "@" + s.cfg.EmailDomain}
+
+	// Os logs primeiro: enquanto o cadastro mantém o email original, uma falha aqui pode ser
+	// corrigida executando a anonimização de novo
+	affected, err := s.Repository.ScrubLogs(ctx, subject, pseudonyms, ipMasks{v4: s.cfg.IPv4Prefix, v6: s.cfg.IPv6Prefix})
+	if err != nil {
+		return Certificate{}, fmt.Errorf("falha ao anonimizar os logs: %w", err)
+	}
+	fileAffected, err := s.scrubFiles(ctx, subject, newReplacer(subject, pseudonyms))
+	if err != nil {
+		return Certificate{}, fmt.Errorf("falha ao anonimizar os arquivos: %w", err)
+	}
+	for table, n := range fileAffected {
+		affected[table] = n
+	}
+	userAffected, err := s.Repository.PseudonymizeUser(ctx, subject, pseudonyms)
+	if err != nil {
+		return Certificate{}, fmt.Errorf("falha ao anonimizar o cadastro: %w", err)
+	}
+	for table, n := range userAffected {
+		affected[table] = n
+	}
+
+	completedAt := now()
+	cert.Status = CertificateCompleted
+	cert.Affected = affected
+	cert.CompletedAt = &completedAt
+	cert.SHA256 = seal(cert)
+	if err := s.Repository.CompleteCertificate(ctx, cert); err != nil {
+		return Certificate{}, err
+	}
+	return cert, nil
+}
+
+// now é truncado em microssegundos, a precisão do PostgreSQL: o hash do certificado pode ser
+// recalculado a partir do que está gravado.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// newPseudonym gera um pseudônimo aleatório, sem relação com os dados originais.
+func newPseudonym() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "anon-" + hex.EncodeToString(b), nil
+}
+
+// seal calcula o SHA-256 do conteúdo do certificado: o JSON dos campos abaixo, nesta ordem,
+// com as datas em RFC 3339 (UTC).
+func seal(c Certificate) string {
+	content, _ := json.Marshal(struct {
+		UUID        uuid.UUID  `json:"uuid"`
+		UserUUID    uuid.UUID  `json:"user_uuid"`
+		TenantUUID  *uuid.UUID `json:"tenant_uuid"`
+		Pseudonym   string     `json:"pseudonym"`
+		RequestedBy *uuid.UUID `json:"requested_by"`
+		Source      string     `json:"source"`
+		Reason      string     `json:"reason"`
+		Affected    Affected   `json:"affected"`
+		CreateAt    time.Time  `json:"create_at"`
+		CompletedAt *time.Time `json:"completed_at"`
+	}{c.UUID, c.UserUUID, c.TenantUUID, c.Pseudonym, c.RequestedBy, c.Source, c.Reason, c.Affected, c.CreateAt.UTC(), c.CompletedAt})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *serviceImpl) Certificate(ctx context.Context, certUUID uuid.UUID) (Certificate, error) {
+	return s.Repository.GetCertificate(ctx, certUUID)
+}
+
+func (s *serviceImpl) PlaceHold(ctx context.Context, hold Hold) (Hold, error) {
+	hold.Reason = strings.TrimSpace(hold.Reason)
+	if (hold.UserUUID == nil) == (hold.TenantUUID == nil) {
+		return Hold{}, fmt.Errorf("%w: informe o usuário ou o tenant (apenas um)", ErrInvalidHold)
+	}
+	if hold.Reason == "" || len(hold.Reason) > 500 {
+		return Hold{}, fmt.Errorf("%w: o motivo é obrigatório e deve ter no máximo 500 caracteres", ErrInvalidHold)
+	}
+	exists, err := s.Repository.HoldTargetExists(ctx, hold)
+	if err != nil {
+		return Hold{}, err
+	}
+	if !exists {
+		return Hold{}, fmt.Errorf("%w: usuário ou tenant não encontrado", ErrInvalidHold)
+	}
+	return s.Repository.CreateHold(ctx, hold)
+}
+
+func (s *serviceImpl) ListHolds(ctx context.Context, activeOnly bool) ([]Hold, error) {
+	return s.Repository.ListHolds(ctx, activeOnly)
+}
+
+func (s *serviceImpl) ReleaseHold(ctx context.Context, holdUUID uuid.UUID, releasedBy *uuid.UUID) (Hold, error) {
+	return s.Repository.ReleaseHold(ctx, holdUUID, releasedBy)
+}
@@ -0,0 +1,78 @@
+package privacy
+
+import (
+	"errors"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	repositoryInstance Repository
+	once               sync.Once
+	initErr            error
+	ErrNotInitialized  = errors.New("privacy controller not initialized")
+)
+
+const (
+	DefaultEmailDomain = "anonymized.invalid"
+	DefaultIPv4Prefix  = 24
+	DefaultIPv6Prefix  = 48
+)
+
+// UsePrivacy agrupa todas as camadas (Repository, Service, Controller)
+type UsePrivacy struct {
+	Repository Repository
+	Service    Service
+	Controller Controller
+}
+
+// Config da anonimização, usada no New() e no NewService()
+type Config struct {
+	// EmailDomain é o domínio dos emails pseudônimos (padrão "anonymized.invalid").
+	EmailDomain string
+	// IPv4Prefix e IPv6Prefix são os prefixos mantidos dos IPs do usuário nos logs (padrão /24 e /48).
+	IPv4Prefix int
+	IPv6Prefix int
+}
+
+// New inicializa o singleton do controller de privacidade com todas as suas dependências
+func New(db *gorm.DB, cfg Config) (Controller, error) {
+	once.Do(func() {
+		if db == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+
+		// Inicializa as dependências em camadas
+		repositoryInstance = NewRepository(db)
+		serviceInstance = NewService(repositoryInstance, cfg)
+		controllerInstance = NewController(serviceInstance)
+	})
+
+	return controllerInstance, initErr
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Repository, Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UsePrivacy {
+	if controllerInstance == nil || serviceInstance == nil || repositoryInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UsePrivacy{
+		Repository: repositoryInstance,
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
@@ -0,0 +1,781 @@
+package scim
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/group"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/acess_log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Suíte de conformidade SCIM 2.0 (RFC 7643/7644) executada contra os handlers HTTP, com um
+// Service em memória no lugar do banco. O Service e o repositório reais são testados em
+// service_test.go e repository_test.go.
+
+const (
+	tokenA        = "scim_tenant-a"
+	tokenB        = "scim_tenant-b"
+	tokenReadOnly = "scim_read-only"
+	tokenBlocked  = "scim_blocked"
+	tokenIPDenied = "scim_ip-denied"
+)
+
+var (
+	tenantA = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	tenantB = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+)
+
+type fakeService struct {
+	mu     sync.Mutex
+	clock  time.Time
+	users  map[uuid.UUID]User
+	groups map[uuid.UUID]Group
+}
+
+func newFakeService() *fakeService {
+	return &fakeService{
+		clock:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		users:  map[uuid.UUID]User{},
+		groups: map[uuid.UUID]Group{},
+	}
+}
+
+func (f *fakeService) tick() time.Time {
+	f.clock = f.clock.Add(time.Second)
+	return f.clock
+}
+
+func (f *fakeService) Authenticate(_ context.Context, token, _ string) (Client, error) {
+	switch token {
+	case tokenA:
+		return Client{TokenUUID: uuid.New(), TenantUUID: tenantA}, nil
+	case tokenB:
+		return Client{TokenUUID: uuid.New(), TenantUUID: tenantB}, nil
+	case tokenReadOnly:
+		return Client{TokenUUID: uuid.New(), TenantUUID: tenantA, ReadOnly: true}, nil
+	case tokenBlocked:
+		return Client{}, ErrTenantBlocked
+	case tokenIPDenied:
+		return Client{TokenUUID: uuid.New(), TenantUUID: tenantA}, middleware.ErrIPNotAllowed
+	}
+	return Client{}, ErrUnauthorized
+}
+
+func (f *fakeService) IssueToken(context.Context, uuid.UUID, *uuid.UUID) (Token, string, error) {
+	return Token{}, "", ErrTokenNotFound
+}
+
+func (f *fakeService) ActiveToken(context.Context, uuid.UUID) (Token, error) {
+	return Token{}, ErrTokenNotFound
+}
+
+func (f *fakeService) RevokeTokens(context.Context, uuid.UUID) error {
+	return ErrTokenNotFound
+}
+
+func page[T any](items []T, q Query) []T {
+	if q.Count == 0 || q.offset() >= len(items) {
+		return []T{}
+	}
+	return items[q.offset():min(q.offset()+q.Count, len(items))]
+}
+
+func (f *fakeService) ListUsers(_ context.Context, tenantUUID uuid.UUID, q Query) ([]User, int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var found []User
+	for _, u := range f.users {
+		if *u.TenantUUID != tenantUUID || !userMatches(u, q.Conditions) {
+			continue
+		}
+		found = append(found, u)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].CreateAt.Before(found[j].CreateAt) })
+	return page(found, q), int64(len(found)), nil
+}
+
+func userMatches(u User, conditions []Condition) bool {
+	for _, c := range conditions {
+		switch c.Attr {
+		case "id":
+			if c.Value != u.UUID.String() {
+				return false
+			}
+		case "username", "emails", "emails.value":
+			if !strings.EqualFold(c.Value.(string), u.Email) {
+				return false
+			}
+		case "active":
+			if c.Value != u.Live {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (f *fakeService) GetUser(_ context.Context, tenantUUID, userUUID uuid.UUID) (User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[userUUID]
+	if !ok || *u.TenantUUID != tenantUUID {
+		return User{}, ErrNotFound
+	}
+	return u, nil
+}
+
+func (f *fakeService) emailTaken(email string, except uuid.UUID) bool {
+	for _, u := range f.users {
+		if u.UUID != except && strings.EqualFold(u.Email, email) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeService) CreateUser(_ context.Context, tenantUUID uuid.UUID, u User) (User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.emailTaken(u.Email, uuid.Nil) {
+		return User{}, fmt.Errorf("%w: userName já cadastrado", ErrUniqueness)
+	}
+	u.UUID = uuid.New()
+	u.TenantUUID = &tenantUUID
+	u.Role = model.RoleTenantUser
+	u.Password = ""
+	u.CreateAt = f.tick()
+	u.UpdateAt = u.CreateAt
+	f.users[u.UUID] = u
+	return u, nil
+}
+
+func (f *fakeService) ReplaceUser(ctx context.Context, tenantUUID uuid.UUID, u User) (User, error) {
+	current, err := f.GetUser(ctx, tenantUUID, u.UUID)
+	if err != nil {
+		return User{}, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.emailTaken(u.Email, u.UUID) {
+		return User{}, fmt.Errorf("%w: userName já cadastrado", ErrUniqueness)
+	}
+	current.Name, current.Email, current.Live = u.Name, u.Email, u.Live
+	current.Phone, current.Locale, current.Timezone = u.Phone, u.Locale, u.Timezone
+	current.UpdateAt = f.tick()
+	f.users[u.UUID] = current
+	return current, nil
+}
+
+func (f *fakeService) DeleteUser(ctx context.Context, tenantUUID, userUUID uuid.UUID) error {
+	if _, err := f.GetUser(ctx, tenantUUID, userUUID); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.users, userUUID)
+	return nil
+}
+
+func (f *fakeService) ListGroups(_ context.Context, tenantUUID uuid.UUID, q Query, withMembers bool) ([]Group, int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var found []Group
+	for _, g := range f.groups {
+		if g.TenantUUID != tenantUUID || !groupMatches(g, q.Conditions) {
+			continue
+		}
+		if !withMembers {
+			g.Members = nil
+		}
+		found = append(found, g)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].CreateAt.Before(found[j].CreateAt) })
+	return page(found, q), int64(len(found)), nil
+}
+
+func groupMatches(g Group, conditions []Condition) bool {
+	for _, c := range conditions {
+		switch c.Attr {
+		case "id":
+			if c.Value != g.UUID.String() {
+				return false
+			}
+		case "displayname":
+			if !strings.EqualFold(c.Value.(string), g.Name) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (f *fakeService) GetGroup(_ context.Context, tenantUUID, groupUUID uuid.UUID, withMembers bool) (Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	g, ok := f.groups[groupUUID]
+	if !ok || g.TenantUUID != tenantUUID {
+		return Group{}, ErrNotFound
+	}
+	if !withMembers {
+		g.Members = nil
+	}
+	return g, nil
+}
+
+// members resolve os membros do grupo, que devem ser usuários do tenant.
+func (f *fakeService) members(tenantUUID uuid.UUID, desired []group.Member) ([]group.Member, error) {
+	members := []group.Member{}
+	for _, m := range desired {
+		u, ok := f.users[m.UserUUID]
+		if !ok || *u.TenantUUID != tenantUUID {
+			return nil, fmt.Errorf("%w: membro não encontrado no tenant", ErrInvalidValue)
+		}
+		members = append(members, group.Member{UserUUID: u.UUID, Name: u.Name, Email: u.Email})
+	}
+	return members, nil
+}
+
+func (f *fakeService) nameTaken(tenantUUID uuid.UUID, name string, except uuid.UUID) bool {
+	for _, g := range f.groups {
+		if g.UUID != except && g.TenantUUID == tenantUUID && strings.EqualFold(g.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeService) CreateGroup(_ context.Context, tenantUUID uuid.UUID, g Group) (Group, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.nameTaken(tenantUUID, g.Name, uuid.Nil) {
+		return Group{}, fmt.Errorf("%w: displayName já cadastrado", ErrUniqueness)
+	}
+	members, err := f.members(tenantUUID, g.Members)
+	if err != nil {
+		return Group{}, err
+	}
+	g.UUID = uuid.New()
+	g.TenantUUID = tenantUUID
+	g.Members = members
+	g.CreateAt = f.tick()
+	g.UpdateAt = g.CreateAt
+	f.groups[g.UUID] = g
+	return g, nil
+}
+
+func (f *fakeService) ReplaceGroup(ctx context.Context, tenantUUID uuid.UUID, g Group) (Group, error) {
+	current, err := f.GetGroup(ctx, tenantUUID, g.UUID, true)
+	if err != nil {
+		return Group{}, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.nameTaken(tenantUUID, g.Name, g.UUID) {
+		return Group{}, fmt.Errorf("%w: displayName já cadastrado", ErrUniqueness)
+	}
+	members, err := f.members(tenantUUID, g.Members)
+	if err != nil {
+		return Group{}, err
+	}
+	current.Name = g.Name
+	current.Members = members
+	current.UpdateAt = f.tick()
+	f.groups[g.UUID] = current
+	return current, nil
+}
+
+func (f *fakeService) DeleteGroup(ctx context.Context, tenantUUID, groupUUID uuid.UUID) error {
+	if _, err := f.GetGroup(ctx, tenantUUID, groupUUID, false); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.groups, groupUUID)
+	return nil
+}
+
+// server sobe os endpoints do protocolo com o Service em memória e captura o access log.
+type server struct {
+	t       *testing.T
+	router  *gin.Engine
+	service *fakeService
+	mu      sync.Mutex
+	access  []acess_log.AccessLog
+}
+
+func newServer(t *testing.T) *server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	s := &server{t: t, router: gin.New(), service: newFakeService()}
+	ctrl := NewController(s.service, nil).(*controllerImpl)
+	ctrl.logAccess = func(_ context.Context, entry acess_log.AccessLog) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.access = append(s.access, entry)
+	}
+	ctrl.ProvisioningRoutes(s.router.Group("/scim/v2"))
+	return s
+}
+
+type response struct {
+	*httptest.ResponseRecorder
+	body map[string]any
+}
+
+func (s *server) do(token, method, path string, body any) response {
+	s.t.Helper()
+	var reader *bytes.Reader
+	switch b := body.(type) {
+	case nil:
+		reader = bytes.NewReader(nil)
+	case string:
+		reader = bytes.NewReader([]byte(b))
+	default:
+		raw, err := json.Marshal(b)
+		if err != nil {
+			s.t.Fatal(err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Host = "iam.example.com"
+	req.Header.Set("Content-Type", "application/scim+json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+
+	resp := response{ResponseRecorder: rec}
+	if rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp.body); err != nil {
+			s.t.Fatalf("%s %s: resposta não é JSON: %s", method, path, rec.Body.String())
+		}
+	}
+	return resp
+}
+
+func (r response) expect(t *testing.T, status int) response {
+	t.Helper()
+	if r.Code != status {
+		t.Fatalf("status = %d, esperado %d: %s", r.Code, status, r.Body.String())
+	}
+	if r.Body.Len() > 0 && r.Header().Get("Content-Type") != contentType {
+		t.Fatalf("Content-Type = %q, esperado %q", r.Header().Get("Content-Type"), contentType)
+	}
+	return r
+}
+
+// expectError valida a resposta de erro SCIM: schema, status como string e scimType.
+func (r response) expectError(t *testing.T, status int, scimType string) {
+	t.Helper()
+	r.expect(t, status)
+	if schemas, _ := r.body["schemas"].([]any); len(schemas) != 1 || schemas[0] != schemaError {
+		t.Fatalf("schemas = %v, esperado %s", r.body["schemas"], schemaError)
+	}
+	if r.body["status"] != fmt.Sprint(status) {
+		t.Fatalf("status no corpo = %v, esperado \"%d\"", r.body["status"], status)
+	}
+	if got, _ := r.body["scimType"].(string); got != scimType {
+		t.Fatalf("scimType = %q, esperado %q", got, scimType)
+	}
+}
+
+func (r response) str(key string) string {
+	value, _ := r.body[key].(string)
+	return value
+}
+
+func (r response) resources() []map[string]any {
+	items, _ := r.body["Resources"].([]any)
+	result := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		result = append(result, item.(map[string]any))
+	}
+	return result
+}
+
+func (r response) members() []string {
+	items, _ := r.body["members"].([]any)
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		values = append(values, item.(map[string]any)["value"].(string))
+	}
+	sort.Strings(values)
+	return values
+}
+
+func userBody(email, given, family string) map[string]any {
+	return map[string]any{
+		"schemas":  []string{schemaUser},
+		"userName": email,
+		"name":     map[string]any{"givenName": given, "familyName": family},
+		"emails":   []map[string]any{{"value": email, "type": "work", "primary": true}},
+		"active":   true,
+	}
+}
+
+func (s *server) createUser(token, email string) string {
+	s.t.Helper()
+	return s.do(token, http.MethodPost, "/scim/v2/Users", userBody(email, "Ana", "Souza")).expect(s.t, http.StatusCreated).str("id")
+}
+
+func patchBody(ops ...map[string]any) map[string]any {
+	return map[string]any{"schemas": []string{schemaPatchOp}, "Operations": ops}
+}
+
+func TestAuthentication(t *testing.T) {
+	s := newServer(t)
+
+	s.do("", http.MethodGet, "/scim/v2/Users", nil).expectError(t, http.StatusUnauthorized, "")
+	r := s.do("scim_wrong", http.MethodGet, "/scim/v2/Users", nil)
+	r.expectError(t, http.StatusUnauthorized, "")
+	if r.Header().Get("WWW-Authenticate") == "" {
+		t.Fatal("401 sem o header WWW-Authenticate")
+	}
+	s.do(tokenBlocked, http.MethodGet, "/scim/v2/Users", nil).expectError(t, http.StatusForbidden, "")
+	s.do(tokenIPDenied, http.MethodGet, "/scim/v2/Users", nil).expectError(t, http.StatusForbidden, "")
+
+	// Pagamento pendente: somente leitura
+	s.do(tokenReadOnly, http.MethodGet, "/scim/v2/Users", nil).expect(t, http.StatusOK)
+	s.do(tokenReadOnly, http.MethodPost, "/scim/v2/Users", userBody("ana@a.com", "Ana", "Souza")).expectError(t, http.StatusForbidden, "")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := map[string]int{}
+	for _, entry := range s.access {
+		statuses[entry.Status]++
+		if entry.TenantUUID == nil || *entry.TenantUUID != tenantA {
+			t.Fatalf("access log sem o tenant do token: %+v", entry)
+		}
+	}
+	if statuses[acess_log.StatusIPDenied] != 1 || statuses[acess_log.StatusAllowed] != 2 {
+		t.Fatalf("access log = %v, esperado 1 ip_denied e 2 allowed", statuses)
+	}
+}
+
+func TestDiscovery(t *testing.T) {
+	s := newServer(t)
+
+	r := s.do(tokenA, http.MethodGet, "/scim/v2/ServiceProviderConfig", nil).expect(t, http.StatusOK)
+	if patch, _ := r.body["patch"].(map[string]any); patch["supported"] != true {
+		t.Fatalf("patch.supported = %v", r.body["patch"])
+	}
+	if filter, _ := r.body["filter"].(map[string]any); filter["supported"] != true {
+		t.Fatalf("filter.supported = %v", r.body["filter"])
+	}
+
+	r = s.do(tokenA, http.MethodGet, "/scim/v2/ResourceTypes", nil).expect(t, http.StatusOK)
+	if r.body["totalResults"] != float64(2) {
+		t.Fatalf("totalResults = %v", r.body["totalResults"])
+	}
+}
+
+func TestUserLifecycle(t *testing.T) {
+	s := newServer(t)
+
+	r := s.do(tokenA, http.MethodPost, "/scim/v2/Users", userBody("ana@empresa.com", "Ana", "Souza")).expect(t, http.StatusCreated)
+	id := r.str("id")
+	if _, err := uuid.Parse(id); err != nil {
+		t.Fatalf("id = %q", id)
+	}
+	if r.str("userName") != "ana@empresa.com" || r.body["active"] != true {
+		t.Fatalf("usuário criado = %v", r.body)
+	}
+	if name, _ := r.body["name"].(map[string]any); name["formatted"] != "Ana Souza" {
+		t.Fatalf("name = %v", r.body["name"])
+	}
+	meta, _ := r.body["meta"].(map[string]any)
+	location := "http://iam.example.com/scim/v2/Users/" + id
+	if meta["resourceType"] != "User" || meta["location"] != location || r.Header().Get("Location") != location {
+		t.Fatalf("meta = %v, Location = %q", meta, r.Header().Get("Location"))
+	}
+
+	s.do(tokenA, http.MethodPost, "/scim/v2/Users", userBody("ANA@empresa.com", "Ana", "Souza")).expectError(t, http.StatusConflict, "uniqueness")
+	s.do(tokenA, http.MethodPost, "/scim/v2/Users", userBody("not-an-email", "Ana", "Souza")).expectError(t, http.StatusBadRequest, "invalidValue")
+	s.do(tokenA, http.MethodPost, "/scim/v2/Users", `{"userName":`).expectError(t, http.StatusBadRequest, "invalidSyntax")
+
+	r = s.do(tokenA, http.MethodGet, "/scim/v2/Users/"+id, nil).expect(t, http.StatusOK)
+	if r.str("id") != id {
+		t.Fatalf("GET = %v", r.body)
+	}
+	s.do(tokenA, http.MethodGet, "/scim/v2/Users/"+uuid.NewString(), nil).expectError(t, http.StatusNotFound, "")
+	s.do(tokenA, http.MethodGet, "/scim/v2/Users/not-a-uuid", nil).expectError(t, http.StatusNotFound, "")
+
+	// PUT substitui o recurso inteiro: active ausente vale true
+	put := userBody("ana.souza@empresa.com", "Ana", "Lima")
+	put["active"] = false
+	put["timezone"] = "America/Sao_Paulo"
+	put["locale"] = "en_US"
+	put["phoneNumbers"] = []map[string]any{{"value": "+55 11 99999-0000", "type": "work"}}
+	r = s.do(tokenA, http.MethodPut, "/scim/v2/Users/"+id, put).expect(t, http.StatusOK)
+	if r.str("userName") != "ana.souza@empresa.com" || r.body["active"] != false || r.str("timezone") != "America/Sao_Paulo" || r.str("locale") != "en-US" {
+		t.Fatalf("PUT = %v", r.body)
+	}
+	put["timezone"] = "Mars/Olympus"
+	s.do(tokenA, http.MethodPut, "/scim/v2/Users/"+id, put).expectError(t, http.StatusBadRequest, "invalidValue")
+
+	s.do(tokenA, http.MethodDelete, "/scim/v2/Users/"+id, nil).expect(t, http.StatusNoContent)
+	s.do(tokenA, http.MethodGet, "/scim/v2/Users/"+id, nil).expectError(t, http.StatusNotFound, "")
+	s.do(tokenA, http.MethodDelete, "/scim/v2/Users/"+id, nil).expectError(t, http.StatusNotFound, "")
+}
+
+func TestUserFilter(t *testing.T) {
+	s := newServer(t)
+	id := s.createUser(tokenA, "ana@empresa.com")
+	s.createUser(tokenA, "bruno@empresa.com")
+
+	cases := []struct {
+		filter string
+		total  float64
+	}{
+		{`userName eq "ana@empresa.com"`, 1},
+		{`USERNAME EQ "ANA@EMPRESA.COM"`, 1},
+		{`urn:ietf:params:scim:schemas:core:2.0:User:userName eq "ana@empresa.com"`, 1},
+		{`emails.value eq "bruno@empresa.com"`, 1},
+		{`id eq "` + id + `"`, 1},
+		{`active eq true`, 2},
+		{`active eq true and userName eq "ana@empresa.com"`, 1},
+		{`userName eq "ninguem@empresa.com"`, 0},
+		{`externalId eq "00u1"`, 0},
+	}
+	for _, tc := range cases {
+		r := s.do(tokenA, http.MethodGet, "/scim/v2/Users?filter="+urlQuery(tc.filter), nil).expect(t, http.StatusOK)
+		if r.body["totalResults"] != tc.total {
+			t.Fatalf("filtro %s: totalResults = %v, esperado %v", tc.filter, r.body["totalResults"], tc.total)
+		}
+		if schemas, _ := r.body["schemas"].([]any); len(schemas) != 1 || schemas[0] != schemaListResponse {
+			t.Fatalf("schemas = %v", r.body["schemas"])
+		}
+	}
+
+	for _, filter := range []string{`userName co "ana"`, `userName eq`, `userName eq "a" or userName eq "b"`, `active eq "yes"`, `name.givenName eq "Ana"`, `emails[value eq "bruno@empresa.com"]`} {
+		s.do(tokenA, http.MethodGet, "/scim/v2/Users?filter="+urlQuery(filter), nil).expectError(t, http.StatusBadRequest, "invalidFilter")
+	}
+}
+
+func urlQuery(value string) string {
+	replacer := strings.NewReplacer("%", "%25", " ", "%20", `"`, "%22", "[", "%5B", "]", "%5D", "&", "%26", "+", "%2B", "#", "%23")
+	return replacer.Replace(value)
+}
+
+func TestUserPagination(t *testing.T) {
+	s := newServer(t)
+	for i := 0; i < 5; i++ {
+		s.createUser(tokenA, fmt.Sprintf("user%d@empresa.com", i))
+	}
+
+	r := s.do(tokenA, http.MethodGet, "/scim/v2/Users?startIndex=2&count=2", nil).expect(t, http.StatusOK)
+	resources := r.resources()
+	if r.body["totalResults"] != float64(5) || r.body["startIndex"] != float64(2) || r.body["itemsPerPage"] != float64(2) || len(resources) != 2 {
+		t.Fatalf("página = %v", r.body)
+	}
+	if resources[0]["userName"] != "user1@empresa.com" || resources[1]["userName"] != "user2@empresa.com" {
+		t.Fatalf("página fora de ordem: %v", resources)
+	}
+
+	// count=0 retorna apenas o total
+	r = s.do(tokenA, http.MethodGet, "/scim/v2/Users?count=0", nil).expect(t, http.StatusOK)
+	if r.body["totalResults"] != float64(5) || len(r.resources()) != 0 {
+		t.Fatalf("count=0 = %v", r.body)
+	}
+	// startIndex menor que 1 vale 1; além do total, a página vem vazia
+	r = s.do(tokenA, http.MethodGet, "/scim/v2/Users?startIndex=0", nil).expect(t, http.StatusOK)
+	if r.body["startIndex"] != float64(1) || len(r.resources()) != 5 {
+		t.Fatalf("startIndex=0 = %v", r.body)
+	}
+	r = s.do(tokenA, http.MethodGet, "/scim/v2/Users?startIndex=10", nil).expect(t, http.StatusOK)
+	if len(r.resources()) != 0 {
+		t.Fatalf("startIndex=10 = %v", r.body)
+	}
+	s.do(tokenA, http.MethodGet, "/scim/v2/Users?count=abc", nil).expectError(t, http.StatusBadRequest, "invalidValue")
+}
+
+func TestUserPatch(t *testing.T) {
+	s := newServer(t)
+	id := s.createUser(tokenA, "ana@empresa.com")
+	path := "/scim/v2/Users/" + id
+
+	// Okta: replace sem path
+	r := s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "replace", "value": map[string]any{"active": false}})).expect(t, http.StatusOK)
+	if r.body["active"] != false {
+		t.Fatalf("active = %v", r.body["active"])
+	}
+
+	// Entra ID: operação com maiúscula e booleano como string
+	r = s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "Replace", "path": "active", "value": "True"})).expect(t, http.StatusOK)
+	if r.body["active"] != true {
+		t.Fatalf("active = %v", r.body["active"])
+	}
+
+	r = s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "replace", "path": "name.familyName", "value": "Lima"})).expect(t, http.StatusOK)
+	if name, _ := r.body["name"].(map[string]any); name["formatted"] != "Ana Lima" {
+		t.Fatalf("name = %v", r.body["name"])
+	}
+
+	r = s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "replace", "path": "userName", "value": "ana.lima@empresa.com"})).expect(t, http.StatusOK)
+	if r.str("userName") != "ana.lima@empresa.com" {
+		t.Fatalf("userName = %v", r.body["userName"])
+	}
+
+	// Caminho com filtro e atributos sem caminho com chaves que são caminhos
+	r = s.do(tokenA, http.MethodPatch, path, patchBody(
+		map[string]any{"op": "add", "path": `phoneNumbers[type eq "work"].value`, "value": "+55 11 4000-0000"},
+		map[string]any{"op": "add", "value": map[string]any{"locale": "pt-BR", "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department": "TI"}},
+	)).expect(t, http.StatusOK)
+	if phones, _ := r.body["phoneNumbers"].([]any); len(phones) != 1 || phones[0].(map[string]any)["value"] != "+55 11 4000-0000" {
+		t.Fatalf("phoneNumbers = %v", r.body["phoneNumbers"])
+	}
+	if r.str("locale") != "pt-BR" {
+		t.Fatalf("locale = %v", r.body["locale"])
+	}
+
+	r = s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "remove", "path": "phoneNumbers"})).expect(t, http.StatusOK)
+	if _, ok := r.body["phoneNumbers"]; ok {
+		t.Fatalf("phoneNumbers = %v", r.body["phoneNumbers"])
+	}
+
+	// id e meta não mudam
+	r = s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "replace", "path": "id", "value": uuid.NewString()})).expect(t, http.StatusOK)
+	if r.str("id") != id {
+		t.Fatalf("id = %v", r.body["id"])
+	}
+
+	s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "move", "path": "active", "value": false})).expectError(t, http.StatusBadRequest, "invalidSyntax")
+	s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "remove"})).expectError(t, http.StatusBadRequest, "noTarget")
+	s.do(tokenA, http.MethodPatch, path, map[string]any{"Operations": []map[string]any{{"op": "replace", "path": "active", "value": false}}}).expectError(t, http.StatusBadRequest, "invalidSyntax")
+	s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "replace", "path": "userName", "value": "sem-arroba"})).expectError(t, http.StatusBadRequest, "invalidValue")
+	s.do(tokenA, http.MethodPatch, "/scim/v2/Users/"+uuid.NewString(), patchBody(map[string]any{"op": "replace", "path": "active", "value": false})).expectError(t, http.StatusNotFound, "")
+}
+
+func TestTenantIsolation(t *testing.T) {
+	s := newServer(t)
+	userA := s.createUser(tokenA, "ana@empresa.com")
+	userB := s.createUser(tokenB, "bruno@outra.com")
+
+	r := s.do(tokenB, http.MethodGet, "/scim/v2/Users?filter="+urlQuery(`userName eq "ana@empresa.com"`), nil).expect(t, http.StatusOK)
+	if r.body["totalResults"] != float64(0) {
+		t.Fatalf("tenant B enxerga usuários do tenant A: %v", r.body)
+	}
+	s.do(tokenB, http.MethodGet, "/scim/v2/Users/"+userA, nil).expectError(t, http.StatusNotFound, "")
+	s.do(tokenB, http.MethodPut, "/scim/v2/Users/"+userA, userBody("ana@empresa.com", "X", "Y")).expectError(t, http.StatusNotFound, "")
+	s.do(tokenB, http.MethodPatch, "/scim/v2/Users/"+userA, patchBody(map[string]any{"op": "replace", "path": "active", "value": false})).expectError(t, http.StatusNotFound, "")
+	s.do(tokenB, http.MethodDelete, "/scim/v2/Users/"+userA, nil).expectError(t, http.StatusNotFound, "")
+
+	// Usuário de outro tenant não pode ser membro
+	body := map[string]any{"schemas": []string{schemaGroup}, "displayName": "Vendas", "members": []map[string]any{{"value": userA}}}
+	s.do(tokenB, http.MethodPost, "/scim/v2/Groups", body).expectError(t, http.StatusBadRequest, "invalidValue")
+
+	groupA := s.do(tokenA, http.MethodPost, "/scim/v2/Groups", body).expect(t, http.StatusCreated).str("id")
+	s.do(tokenB, http.MethodGet, "/scim/v2/Groups/"+groupA, nil).expectError(t, http.StatusNotFound, "")
+	s.do(tokenB, http.MethodPatch, "/scim/v2/Groups/"+groupA, patchBody(map[string]any{"op": "add", "path": "members", "value": []map[string]any{{"value": userB}}})).expectError(t, http.StatusNotFound, "")
+	s.do(tokenB, http.MethodDelete, "/scim/v2/Groups/"+groupA, nil).expectError(t, http.StatusNotFound, "")
+}
+
+func TestGroupLifecycle(t *testing.T) {
+	s := newServer(t)
+	ana := s.createUser(tokenA, "ana@empresa.com")
+	bruno := s.createUser(tokenA, "bruno@empresa.com")
+	carla := s.createUser(tokenA, "carla@empresa.com")
+
+	body := map[string]any{
+		"schemas":     []string{schemaGroup},
+		"displayName": "Financeiro",
+		"members":     []map[string]any{{"value": ana}, {"value": bruno}, {"value": ana}},
+	}
+	r := s.do(tokenA, http.MethodPost, "/scim/v2/Groups", body).expect(t, http.StatusCreated)
+	id := r.str("id")
+	if got := r.members(); len(got) != 2 {
+		t.Fatalf("members = %v", got)
+	}
+	if r.Header().Get("Location") != "http://iam.example.com/scim/v2/Groups/"+id {
+		t.Fatalf("Location = %q", r.Header().Get("Location"))
+	}
+	s.do(tokenA, http.MethodPost, "/scim/v2/Groups", body).expectError(t, http.StatusConflict, "uniqueness")
+	s.do(tokenA, http.MethodPost, "/scim/v2/Groups", map[string]any{"displayName": " "}).expectError(t, http.StatusBadRequest, "invalidValue")
+	s.do(tokenA, http.MethodPost, "/scim/v2/Groups", map[string]any{"displayName": "RH", "members": []map[string]any{{"value": "x"}}}).expectError(t, http.StatusBadRequest, "invalidValue")
+
+	r = s.do(tokenA, http.MethodGet, "/scim/v2/Groups?filter="+urlQuery(`displayName eq "financeiro"`), nil).expect(t, http.StatusOK)
+	if r.body["totalResults"] != float64(1) || len(r.resources()[0]["members"].([]any)) != 2 {
+		t.Fatalf("filtro displayName = %v", r.body)
+	}
+	r = s.do(tokenA, http.MethodGet, "/scim/v2/Groups?excludedAttributes=members", nil).expect(t, http.StatusOK)
+	if _, ok := r.resources()[0]["members"]; ok {
+		t.Fatalf("excludedAttributes=members = %v", r.body)
+	}
+	r = s.do(tokenA, http.MethodGet, "/scim/v2/Groups/"+id+"?excludedAttributes=members", nil).expect(t, http.StatusOK)
+	if _, ok := r.body["members"]; ok {
+		t.Fatalf("excludedAttributes=members = %v", r.body)
+	}
+
+	path := "/scim/v2/Groups/" + id
+	r = s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "add", "path": "members", "value": []map[string]any{{"value": carla}, {"value": ana}}})).expect(t, http.StatusOK)
+	if got := r.members(); len(got) != 3 {
+		t.Fatalf("add members = %v", got)
+	}
+
+	// Okta: remove por filtro no path
+	r = s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "remove", "path": `members[value eq "` + ana + `"]`})).expect(t, http.StatusOK)
+	if got := r.members(); len(got) != 2 || contains(got, ana) {
+		t.Fatalf("remove por filtro = %v", got)
+	}
+
+	// Entra ID: remove com a lista em value
+	r = s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "Remove", "path": "members", "value": []map[string]any{{"value": bruno}}})).expect(t, http.StatusOK)
+	if got := r.members(); len(got) != 1 || got[0] != carla {
+		t.Fatalf("remove por value = %v", got)
+	}
+
+	r = s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "replace", "value": map[string]any{"displayName": "Finanças"}})).expect(t, http.StatusOK)
+	if r.str("displayName") != "Finanças" || len(r.members()) != 1 {
+		t.Fatalf("replace displayName = %v", r.body)
+	}
+
+	r = s.do(tokenA, http.MethodPut, path, map[string]any{"schemas": []string{schemaGroup}, "displayName": "Finanças", "members": []map[string]any{{"value": ana}}}).expect(t, http.StatusOK)
+	if got := r.members(); len(got) != 1 || got[0] != ana {
+		t.Fatalf("PUT members = %v", got)
+	}
+	r = s.do(tokenA, http.MethodPatch, path, patchBody(map[string]any{"op": "remove", "path": "members"})).expect(t, http.StatusOK)
+	if got := r.members(); len(got) != 0 {
+		t.Fatalf("remove members = %v", got)
+	}
+
+	s.do(tokenA, http.MethodDelete, path, nil).expect(t, http.StatusNoContent)
+	s.do(tokenA, http.MethodGet, path, nil).expectError(t, http.StatusNotFound, "")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGroupMemberLimit(t *testing.T) {
+	s := newServer(t)
+	members := make([]map[string]any, maxMembersPerChange+1)
+	for i := range members {
+		members[i] = map[string]any{"value": uuid.NewString()}
+	}
+	s.do(tokenA, http.MethodPost, "/scim/v2/Groups", map[string]any{"displayName": "Todos", "members": members}).expectError(t, http.StatusBadRequest, "invalidValue")
+}
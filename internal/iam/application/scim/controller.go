@@ -0,0 +1,1071 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/plan"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/acess_log"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// clientKey guarda no contexto do Gin o provedor de identidade autenticado.
+const clientKey = "scimClient"
+
+// maxBodyBytes limita o corpo das requisições SCIM.
+const maxBodyBytes = 1 << 20
+
+type Controller interface {
+	// Routes registra a administração do token SCIM (sob /api).
+	Routes(routes gin.IRouter)
+	// ProvisioningRoutes registra os endpoints do protocolo (ex.: sob /scim/v2).
+	ProvisioningRoutes(routes gin.IRouter)
+	IssueToken(c *gin.Context)
+	TokenStatus(c *gin.Context)
+	RevokeToken(c *gin.Context)
+	ServiceProviderConfig(c *gin.Context)
+	ResourceTypes(c *gin.Context)
+	ListUsers(c *gin.Context)
+	GetUser(c *gin.Context)
+	CreateUser(c *gin.Context)
+	ReplaceUser(c *gin.Context)
+	PatchUser(c *gin.Context)
+	DeleteUser(c *gin.Context)
+	ListGroups(c *gin.Context)
+	GetGroup(c *gin.Context)
+	CreateGroup(c *gin.Context)
+	ReplaceGroup(c *gin.Context)
+	PatchGroup(c *gin.Context)
+	DeleteGroup(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+	// basePath é o caminho em que os endpoints do protocolo foram registrados
+	basePath string
+	// logAccess grava o acesso do provedor de identidade no access_log
+	logAccess func(ctx context.Context, entry acess_log.AccessLog)
+}
+
+func NewController(service Service, mw middleware.Middleware) Controller {
+	return &controllerImpl{
+		Service:   service,
+		mw:        mw,
+		basePath:  "/scim/v2",
+		logAccess: middleware.LogAccess,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+
+		if login.User.Role == model.RolePartnerAdmin {
+			if target, ok := middleware.GetTargetTenant(c); ok {
+				actingTenantUUID = &target
+			}
+		}
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "scim",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
+	})
+}
+
+// logProvisioning registra no audit log as alterações feitas pelo provedor de identidade. O
+// identificador é o token usado, já que não há usuário autenticado.
+func (ctrl *controllerImpl) logProvisioning(c *gin.Context, action, function string, success bool, input, output interface{}) {
+	client := clientFrom(c)
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:   &client.TenantUUID,
+		Identifier:   "scim:" + client.TokenUUID.String(),
+		RayTraceCode: c.GetString("rayTraceCode"),
+		Domain:       "scim",
+		Action:       action,
+		Function:     function,
+		Success:      success,
+		InputData:    auditoria_log.SerializeData(input),
+		OutputData:   auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	tokenGroup := routes.Group("/tenant/:uuid/scim/token")
+
+	{
+		tokenGroup.POST("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.IssueToken)
+		tokenGroup.GET("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.TokenStatus)
+		tokenGroup.DELETE("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.RevokeToken)
+	}
+}
+
+func (ctrl *controllerImpl) ProvisioningRoutes(routes gin.IRouter) {
+	if group, ok := routes.(interface{ BasePath() string }); ok {
+		ctrl.basePath = group.BasePath()
+	}
+
+	routes.GET("/ServiceProviderConfig", ctrl.authenticate(), ctrl.ServiceProviderConfig)
+	routes.GET("/ResourceTypes", ctrl.authenticate(), ctrl.ResourceTypes)
+
+	usersGroup := routes.Group("/Users")
+
+	{
+		usersGroup.GET("", ctrl.authenticate(), ctrl.ListUsers)
+		usersGroup.POST("", ctrl.authenticate(), ctrl.CreateUser)
+		usersGroup.GET("/:id", ctrl.authenticate(), ctrl.GetUser)
+		usersGroup.PUT("/:id", ctrl.authenticate(), ctrl.ReplaceUser)
+		usersGroup.PATCH("/:id", ctrl.authenticate(), ctrl.PatchUser)
+		usersGroup.DELETE("/:id", ctrl.authenticate(), ctrl.DeleteUser)
+	}
+
+	groupsGroup := routes.Group("/Groups")
+
+	{
+		groupsGroup.GET("", ctrl.authenticate(), ctrl.ListGroups)
+		groupsGroup.POST("", ctrl.authenticate(), ctrl.CreateGroup)
+		groupsGroup.GET("/:id", ctrl.authenticate(), ctrl.GetGroup)
+		groupsGroup.PUT("/:id", ctrl.authenticate(), ctrl.ReplaceGroup)
+		groupsGroup.PATCH("/:id", ctrl.authenticate(), ctrl.PatchGroup)
+		groupsGroup.DELETE("/:id", ctrl.authenticate(), ctrl.DeleteGroup)
+	}
+}
+
+// authenticate valida o bearer token SCIM e abre o escopo do tenant (schema ou banco dedicado)
+// para a requisição inteira. O acesso é registrado no access_log com o tenant do token.
+func (ctrl *controllerImpl) authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		traceID := c.GetHeader("X-Request-ID")
+		if traceID == "" {
+			traceID = uuid.NewString()
+		}
+		c.Set("rayTraceCode", traceID)
+		c.Header("X-Request-ID", traceID)
+
+		token := ""
+		if scheme, value, ok := strings.Cut(c.GetHeader("Authorization"), " "); ok && strings.EqualFold(scheme, "Bearer") {
+			token = strings.TrimSpace(value)
+		}
+		if token == "" {
+			c.Header("WWW-Authenticate", `Bearer realm="SCIM"`)
+			ctrl.abort(c, ErrUnauthorized)
+			return
+		}
+
+		ctx := c.Request.Context()
+		client, err := ctrl.Service.Authenticate(ctx, token, c.ClientIP())
+		if err != nil && !errors.Is(err, middleware.ErrIPNotAllowed) {
+			if errors.Is(err, ErrUnauthorized) {
+				c.Header("WWW-Authenticate", `Bearer realm="SCIM"`)
+			}
+			ctrl.abort(c, err)
+			return
+		}
+
+		// Lista de IPs permitidos do tenant. A tentativa negada é registrada no access_log com status próprio
+		accessStatus := acess_log.StatusAllowed
+		if err != nil {
+			accessStatus = acess_log.StatusIPDenied
+			ctrl.abort(c, err)
+		} else if client.ReadOnly && c.Request.Method != http.MethodGet {
+			ctrl.abort(c, ErrReadOnly)
+		} else {
+			scoped, release, err := middleware.TenantScope(ctx, &client.TenantUUID)
+			if err != nil {
+				log.Printf("[SCIM] falha ao resolver o schema do tenant %s: %v", client.TenantUUID, err)
+				ctrl.abort(c, err)
+			} else {
+				defer release()
+				c.Request = c.Request.WithContext(scoped)
+				c.Set(clientKey, client)
+				c.Next()
+			}
+		}
+
+		ctrl.logAccess(ctx, acess_log.AccessLog{
+			TenantUUID:   &client.TenantUUID,
+			Identifier:   "scim:" + client.TokenUUID.String(),
+			RayTraceCode: traceID,
+			Method:       c.Request.Method,
+			Path:         c.Request.URL.Path,
+			Route:        c.FullPath(),
+			Host:         c.Request.Host,
+			StatusCode:   c.Writer.Status(),
+			Status:       accessStatus,
+			IP:           c.ClientIP(),
+			UserAgent:    c.Request.UserAgent(),
+			Referer:      c.Request.Referer(),
+			ContentType:  c.ContentType(),
+			UserLanguage: c.GetHeader("Accept-Language"),
+			RequestTime:  start.UTC(),
+			LatencyMs:    float64(time.Since(start).Microseconds()) / 1000.0,
+		})
+	}
+}
+
+func clientFrom(c *gin.Context) Client {
+	client, _ := c.Get(clientKey)
+	found, _ := client.(Client)
+	return found
+}
+
+// baseURL é a URL base dos endpoints do protocolo, usada em meta.location.
+func (ctrl *controllerImpl) baseURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = strings.TrimSpace(strings.Split(proto, ",")[0])
+	}
+	return scheme + "://" + c.Request.Host + ctrl.basePath
+}
+
+// render responde com o content type do protocolo.
+func render(c *gin.Context, status int, body any) {
+	c.Header("Content-Type", contentType)
+	c.JSON(status, body)
+}
+
+// scimError traduz o erro para o status HTTP e o scimType da resposta de erro (RFC 7644, seção 3.12).
+func scimError(err error) (int, ErrorResponse) {
+	status, scimType, detail := http.StatusInternalServerError, "", "internal server error"
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		status, detail = http.StatusUnauthorized, "Token SCIM ausente ou inválido."
+	case errors.Is(err, ErrTenantBlocked):
+		status, detail = http.StatusForbidden, "Tenant com acesso bloqueado."
+	case errors.Is(err, ErrReadOnly):
+		status, detail = http.StatusForbidden, "Tenant com pagamento pendente: acesso somente leitura."
+	case errors.Is(err, middleware.ErrIPNotAllowed):
+		status, detail = http.StatusForbidden, "Acesso não permitido a partir deste endereço IP."
+	case errors.Is(err, ErrNotFound):
+		status, detail = http.StatusNotFound, "Recurso não encontrado."
+	case errors.Is(err, ErrUniqueness):
+		status, scimType, detail = http.StatusConflict, "uniqueness", err.Error()
+	case errors.Is(err, ErrLastAdmin):
+		status, detail = http.StatusConflict, "O tenant deve manter ao menos um administrador ativo."
+	case errors.Is(err, ErrQuotaExceeded):
+		status, detail = http.StatusPaymentRequired, err.Error()
+	case errors.Is(err, ErrInvalidFilter):
+		status, scimType, detail = http.StatusBadRequest, "invalidFilter", err.Error()
+	case errors.Is(err, ErrInvalidValue):
+		status, scimType, detail = http.StatusBadRequest, "invalidValue", err.Error()
+	case errors.Is(err, ErrInvalidSyntax):
+		status, scimType, detail = http.StatusBadRequest, "invalidSyntax", err.Error()
+	case errors.Is(err, ErrInvalidPath):
+		status, scimType, detail = http.StatusBadRequest, "invalidPath", err.Error()
+	case errors.Is(err, ErrNoTarget):
+		status, scimType, detail = http.StatusBadRequest, "noTarget", err.Error()
+	}
+	return status, ErrorResponse{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+func (ctrl *controllerImpl) fail(c *gin.Context, err error) {
+	status, body := scimError(err)
+	if status == http.StatusInternalServerError {
+		log.Printf("[SCIM] %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+	render(c, status, body)
+}
+
+func (ctrl *controllerImpl) abort(c *gin.Context, err error) {
+	ctrl.fail(c, err)
+	c.Abort()
+}
+
+// bind decodifica o corpo JSON da requisição (o content type application/scim+json é aceito).
+func bind(c *gin.Context, dst any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+	if err := decoder.Decode(dst); err != nil {
+		return fmt.Errorf("%w: corpo JSON inválido", ErrInvalidSyntax)
+	}
+	return nil
+}
+
+// resourceID lê o id da URL. Um id que não é UUID não corresponde a nenhum recurso.
+func resourceID(c *gin.Context) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return uuid.Nil, ErrNotFound
+	}
+	return id, nil
+}
+
+// listQuery lê filter, startIndex e count. startIndex menor que 1 é tratado como 1; count
+// negativo como 0 e acima do máximo como o máximo (RFC 7644, seção 3.4.2.4).
+func listQuery(c *gin.Context, attrs map[string]valueKind) (Query, error) {
+	q := Query{StartIndex: 1, Count: defaultCount}
+	conditions, err := parseFilter(c.Query("filter"), attrs)
+	if err != nil {
+		return Query{}, err
+	}
+	q.Conditions = conditions
+
+	if raw := c.Query("startIndex"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return Query{}, fmt.Errorf("%w: startIndex deve ser um número", ErrInvalidValue)
+		}
+		q.StartIndex = max(value, 1)
+	}
+	if raw := c.Query("count"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return Query{}, fmt.Errorf("%w: count deve ser um número", ErrInvalidValue)
+		}
+		q.Count = min(max(value, 0), maxCount)
+	}
+	return q, nil
+}
+
+// excluded indica se o atributo foi pedido em excludedAttributes.
+func excluded(c *gin.Context, attr string) bool {
+	for _, name := range strings.Split(c.Query("excludedAttributes"), ",") {
+		if normalizeAttr(strings.TrimSpace(name)) == attr {
+			return true
+		}
+	}
+	return false
+}
+
+func listResponse(q Query, total int64, resources []any) ListResponse {
+	return ListResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   q.StartIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// @Summary      Emite o Token SCIM do Tenant
+// @Description  Gera o bearer token usado pelo provedor de identidade nos endpoints /scim/v2, revogando o token anterior. O token é exibido apenas nesta resposta.
+// @Tags         SCIM
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do tenant"
+// @Success      201  {object}  TokenResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      402  {object}  rest_err.RestErr "Limite de chaves de API do plano atingido (tokens ativos >= max_api_keys)."
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/tenant/{uuid}/scim/token [post]
+func (ctrl *controllerImpl) IssueToken(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.authorizeTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	input := map[string]string{"tenant_uuid": tenantUUID.String()}
+	token, value, err := ctrl.Service.IssueToken(c.Request.Context(), tenantUUID, &ctxIdentify.User.UUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "issue_token", "IssueToken", false, input, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	resp := toTokenResponse(token, value, ctrl.baseURL(c))
+	// O token não vai para o audit log
+	ctrl.logAudit(c, ctxIdentify, "issue_token", "IssueToken", true, input, toTokenResponse(token, "", resp.BaseURL))
+	c.JSON(http.StatusCreated, resp)
+}
+
+// @Summary      Consulta o Token SCIM do Tenant
+// @Description  Retorna os dados do token ativo (sem o valor): emissão, autor e último uso.
+// @Tags         SCIM
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do tenant"
+// @Success      200  {object}  TokenResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Nenhum token ativo."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/tenant/{uuid}/scim/token [get]
+func (ctrl *controllerImpl) TokenStatus(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.authorizeTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	token, err := ctrl.Service.ActiveToken(c.Request.Context(), tenantUUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTokenResponse(token, "", ctrl.baseURL(c)))
+}
+
+// @Summary      Revoga o Token SCIM do Tenant
+// @Description  Revoga o token ativo. O provedor de identidade deixa de conseguir provisionar usuários até que um novo token seja emitido.
+// @Tags         SCIM
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do tenant"
+// @Success      204  "Token revogado."
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Nenhum token ativo."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/tenant/{uuid}/scim/token [delete]
+func (ctrl *controllerImpl) RevokeToken(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.authorizeTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	input := map[string]string{"tenant_uuid": tenantUUID.String()}
+	if err := ctrl.Service.RevokeTokens(c.Request.Context(), tenantUUID); err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "revoke_token", "RevokeToken", false, input, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctrl.logAudit(c, ctxIdentify, "revoke_token", "RevokeToken", true, input, nil)
+	c.Status(http.StatusNoContent)
+}
+
+// authorizeTenant valida o tenant da URL: SYSTEM_ADMIN acessa qualquer tenant, PARTNER_ADMIN
+// apenas a sua hierarquia e TENANT_ADMIN somente o próprio tenant.
+func (ctrl *controllerImpl) authorizeTenant(c *gin.Context, login *middleware.Login) (uuid.UUID, *rest_err.RestErr) {
+	target, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		return uuid.Nil, rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "O UUID fornecido na URL não é um formato válido.")
+	}
+
+	switch login.User.Role {
+	case model.RoleSystemAdmin:
+		return target, nil
+
+	case model.RolePartnerAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		inSubtree, err := tenant.MustUse().Service.InSubtree(c.Request.Context(), *login.User.TenantUUID, target)
+		if err != nil {
+			return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "Falha ao verificar hierarquia de tenants", nil)
+		}
+		if !inSubtree {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Tenant fora da sua hierarquia.")
+		}
+		middleware.SetTargetTenant(c, target)
+		return target, nil
+
+	case model.RoleTenantAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID != target {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Acesso permitido apenas ao próprio tenant.")
+		}
+		return target, nil
+
+	default:
+		return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	if quotaErr := plan.QuotaRestError(&login.Metadata.RayTraceCode, err); quotaErr != nil {
+		return quotaErr
+	}
+	switch {
+	case errors.Is(err, ErrTenantNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "Tenant não encontrado.")
+	case errors.Is(err, ErrTokenNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "Nenhum token SCIM ativo para este tenant.")
+	default:
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+}
+
+// @Summary      SCIM: Configuração do Provedor
+// @Description  Recursos suportados (RFC 7643, seção 5): PATCH e filtros "eq" unidos por "and"; bulk, sort, etag e troca de senha não são suportados.
+// @Tags         SCIM
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  ErrorResponse
+// @Router       /scim/v2/ServiceProviderConfig [get]
+func (ctrl *controllerImpl) ServiceProviderConfig(c *gin.Context) {
+	render(c, http.StatusOK, gin.H{
+		"schemas":        []string{schemaSPConfig},
+		"patch":          gin.H{"supported": true},
+		"bulk":           gin.H{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         gin.H{"supported": true, "maxResults": maxCount},
+		"changePassword": gin.H{"supported": false},
+		"sort":           gin.H{"supported": false},
+		"etag":           gin.H{"supported": false},
+		"authenticationSchemes": []gin.H{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "Token SCIM do tenant, emitido em POST /api/tenant/{uuid}/scim/token",
+			"primary":     true,
+		}},
+		"meta": gin.H{
+			"resourceType": "ServiceProviderConfig",
+			"location":     ctrl.baseURL(c) + "/ServiceProviderConfig",
+		},
+	})
+}
+
+// @Summary      SCIM: Tipos de Recurso
+// @Description  Lista os tipos de recurso suportados (User e Group).
+// @Tags         SCIM
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  ListResponse
+// @Failure      401  {object}  ErrorResponse
+// @Router       /scim/v2/ResourceTypes [get]
+func (ctrl *controllerImpl) ResourceTypes(c *gin.Context) {
+	base := ctrl.baseURL(c)
+	resources := []any{
+		gin.H{
+			"schemas":  []string{schemaResourceType},
+			"id":       resourceTypeUser,
+			"name":     resourceTypeUser,
+			"endpoint": "/Users",
+			"schema":   schemaUser,
+			"meta":     gin.H{"resourceType": "ResourceType", "location": base + "/ResourceTypes/" + resourceTypeUser},
+		},
+		gin.H{
+			"schemas":  []string{schemaResourceType},
+			"id":       resourceTypeGroup,
+			"name":     resourceTypeGroup,
+			"endpoint": "/Groups",
+			"schema":   schemaGroup,
+			"meta":     gin.H{"resourceType": "ResourceType", "location": base + "/ResourceTypes/" + resourceTypeGroup},
+		},
+	}
+	render(c, http.StatusOK, listResponse(Query{StartIndex: 1}, int64(len(resources)), resources))
+}
+
+// @Summary      SCIM: Lista Usuários
+// @Description  Lista os usuários do tenant do token. Filtros: comparações "eq" unidas por "and" sobre userName, emails.value, id e active (ex.: userName eq "ana@empresa.com"). Paginação por startIndex (a partir de 1) e count (máximo 100).
+// @Tags         SCIM
+// @Produce      json
+// @Security     BearerAuth
+// @Param        filter query string false "Filtro (ex.: userName eq \"ana@empresa.com\")"
+// @Param        startIndex query int false "Posição do primeiro resultado (padrão 1)"
+// @Param        count query int false "Resultados por página (padrão e máximo 100)"
+// @Success      200  {object}  ListResponse
+// @Failure      400  {object}  ErrorResponse "invalidFilter"
+// @Failure      401  {object}  ErrorResponse
+// @Router       /scim/v2/Users [get]
+func (ctrl *controllerImpl) ListUsers(c *gin.Context) {
+	q, err := listQuery(c, userFilterAttrs)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	users, total, err := ctrl.Service.ListUsers(c.Request.Context(), clientFrom(c).TenantUUID, q)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	base := ctrl.baseURL(c)
+	resources := make([]any, 0, len(users))
+	for _, u := range users {
+		resources = append(resources, toUserResource(u, base))
+	}
+	render(c, http.StatusOK, listResponse(q, total, resources))
+}
+
+// @Summary      SCIM: Consulta Usuário
+// @Tags         SCIM
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "UUID do usuário"
+// @Success      200  {object}  UserResource
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /scim/v2/Users/{id} [get]
+func (ctrl *controllerImpl) GetUser(c *gin.Context) {
+	id, err := resourceID(c)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	u, err := ctrl.Service.GetUser(c.Request.Context(), clientFrom(c).TenantUUID, id)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	render(c, http.StatusOK, toUserResource(u, ctrl.baseURL(c)))
+}
+
+// @Summary      SCIM: Cria Usuário
+// @Description  Cria o usuário no tenant do token com o perfil TENANT_USER. userName deve ser o email. Sem password, o usuário define a senha pela redefinição de senha.
+// @Tags         SCIM
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body UserResource true "Usuário"
+// @Success      201  {object}  UserResource
+// @Failure      400  {object}  ErrorResponse "invalidValue ou invalidSyntax"
+// @Failure      401  {object}  ErrorResponse
+// @Failure      402  {object}  ErrorResponse "Limite do plano atingido."
+// @Failure      409  {object}  ErrorResponse "uniqueness"
+// @Router       /scim/v2/Users [post]
+func (ctrl *controllerImpl) CreateUser(c *gin.Context) {
+	var req UserResource
+	if err := bind(c, &req); err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	// A senha não vai para o audit log
+	input := req
+	input.Password = ""
+
+	u, err := fromUserResource(req)
+	if err == nil {
+		u, err = ctrl.Service.CreateUser(c.Request.Context(), clientFrom(c).TenantUUID, u)
+	}
+	if err != nil {
+		ctrl.logProvisioning(c, "create_user", "CreateUser", false, input, err.Error())
+		ctrl.fail(c, err)
+		return
+	}
+
+	resp := toUserResource(u, ctrl.baseURL(c))
+	ctrl.logProvisioning(c, "create_user", "CreateUser", true, input, resp)
+	c.Header("Location", resp.Meta.Location)
+	render(c, http.StatusCreated, resp)
+}
+
+// @Summary      SCIM: Substitui Usuário
+// @Description  Substitui o usuário: nome, userName (email), active, telefone, locale e timezone. Desativar (active=false) encerra as sessões do usuário.
+// @Tags         SCIM
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "UUID do usuário"
+// @Param        request body UserResource true "Usuário"
+// @Success      200  {object}  UserResource
+// @Failure      400  {object}  ErrorResponse "invalidValue ou invalidSyntax"
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse "uniqueness ou último administrador"
+// @Router       /scim/v2/Users/{id} [put]
+func (ctrl *controllerImpl) ReplaceUser(c *gin.Context) {
+	id, err := resourceID(c)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	var req UserResource
+	if err := bind(c, &req); err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	u, err := fromUserResource(req)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	u.UUID = id
+	ctrl.replaceUser(c, "ReplaceUser", u)
+}
+
+// @Summary      SCIM: Altera Usuário
+// @Description  Aplica operações add, replace e remove (RFC 7644, seção 3.5.2), com ou sem path, incluindo filtros no path (ex.: emails[type eq "work"].value). A ativação e a desativação usam o atributo active.
+// @Tags         SCIM
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "UUID do usuário"
+// @Param        request body PatchRequest true "Operações"
+// @Success      200  {object}  UserResource
+// @Failure      400  {object}  ErrorResponse "invalidSyntax, invalidPath, invalidValue ou noTarget"
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse "uniqueness ou último administrador"
+// @Router       /scim/v2/Users/{id} [patch]
+func (ctrl *controllerImpl) PatchUser(c *gin.Context) {
+	id, err := resourceID(c)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	var req PatchRequest
+	if err := bind(c, &req); err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	current, err := ctrl.Service.GetUser(c.Request.Context(), clientFrom(c).TenantUUID, id)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	before := toUserResource(current, ctrl.baseURL(c))
+	doc, err := applyPatch(before, req)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	var after UserResource
+	if err := fromDocument(doc, &after); err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	u, err := fromUserResource(after)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	u.UUID = id
+	u.Name = patchedName(before, after)
+	ctrl.replaceUser(c, "PatchUser", u)
+}
+
+func (ctrl *controllerImpl) replaceUser(c *gin.Context, function string, u User) {
+	input := toUserResource(u, ctrl.baseURL(c))
+	updated, err := ctrl.Service.ReplaceUser(c.Request.Context(), clientFrom(c).TenantUUID, u)
+	if err != nil {
+		ctrl.logProvisioning(c, "update_user", function, false, input, err.Error())
+		ctrl.fail(c, err)
+		return
+	}
+
+	resp := toUserResource(updated, ctrl.baseURL(c))
+	ctrl.logProvisioning(c, "update_user", function, true, input, resp)
+	render(c, http.StatusOK, resp)
+}
+
+// @Summary      SCIM: Exclui Usuário
+// @Description  Exclui o usuário e encerra as suas sessões.
+// @Tags         SCIM
+// @Security     BearerAuth
+// @Param        id path string true "UUID do usuário"
+// @Success      204  "Usuário excluído."
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse "Último administrador do tenant."
+// @Router       /scim/v2/Users/{id} [delete]
+func (ctrl *controllerImpl) DeleteUser(c *gin.Context) {
+	id, err := resourceID(c)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	input := map[string]string{"id": id.String()}
+	if err := ctrl.Service.DeleteUser(c.Request.Context(), clientFrom(c).TenantUUID, id); err != nil {
+		ctrl.logProvisioning(c, "delete_user", "DeleteUser", false, input, err.Error())
+		ctrl.fail(c, err)
+		return
+	}
+
+	ctrl.logProvisioning(c, "delete_user", "DeleteUser", true, input, nil)
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      SCIM: Lista Grupos
+// @Description  Lista os grupos do tenant do token. Filtros: comparações "eq" unidas por "and" sobre displayName e id. Use excludedAttributes=members para omitir os membros.
+// @Tags         SCIM
+// @Produce      json
+// @Security     BearerAuth
+// @Param        filter query string false "Filtro (ex.: displayName eq \"Financeiro\")"
+// @Param        startIndex query int false "Posição do primeiro resultado (padrão 1)"
+// @Param        count query int false "Resultados por página (padrão e máximo 100)"
+// @Param        excludedAttributes query string false "members para omitir os membros"
+// @Success      200  {object}  ListResponse
+// @Failure      400  {object}  ErrorResponse "invalidFilter"
+// @Failure      401  {object}  ErrorResponse
+// @Router       /scim/v2/Groups [get]
+func (ctrl *controllerImpl) ListGroups(c *gin.Context) {
+	q, err := listQuery(c, groupFilterAttrs)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	groups, total, err := ctrl.Service.ListGroups(c.Request.Context(), clientFrom(c).TenantUUID, q, !excluded(c, "members"))
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	base := ctrl.baseURL(c)
+	resources := make([]any, 0, len(groups))
+	for _, g := range groups {
+		resources = append(resources, toGroupResource(g, base))
+	}
+	render(c, http.StatusOK, listResponse(q, total, resources))
+}
+
+// @Summary      SCIM: Consulta Grupo
+// @Tags         SCIM
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "UUID do grupo"
+// @Param        excludedAttributes query string false "members para omitir os membros"
+// @Success      200  {object}  GroupResource
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /scim/v2/Groups/{id} [get]
+func (ctrl *controllerImpl) GetGroup(c *gin.Context) {
+	id, err := resourceID(c)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	g, err := ctrl.Service.GetGroup(c.Request.Context(), clientFrom(c).TenantUUID, id, !excluded(c, "members"))
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	render(c, http.StatusOK, toGroupResource(g, ctrl.baseURL(c)))
+}
+
+// checkMembers limita os membros enviados de uma vez em POST e PUT.
+func checkMembers(r GroupResource) error {
+	if len(r.Members) > maxMembersPerChange {
+		return fmt.Errorf("%w: informe no máximo %d membros por requisição", ErrInvalidValue, maxMembersPerChange)
+	}
+	return nil
+}
+
+// @Summary      SCIM: Cria Grupo
+// @Description  Cria o grupo no tenant do token. Os membros (value = id do usuário) devem pertencer ao tenant.
+// @Tags         SCIM
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body GroupResource true "Grupo"
+// @Success      201  {object}  GroupResource
+// @Failure      400  {object}  ErrorResponse "invalidValue ou invalidSyntax"
+// @Failure      401  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse "uniqueness"
+// @Router       /scim/v2/Groups [post]
+func (ctrl *controllerImpl) CreateGroup(c *gin.Context) {
+	var req GroupResource
+	if err := bind(c, &req); err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	err := checkMembers(req)
+	var g Group
+	if err == nil {
+		g, err = fromGroupResource(req)
+	}
+	if err == nil {
+		g, err = ctrl.Service.CreateGroup(c.Request.Context(), clientFrom(c).TenantUUID, g)
+	}
+	if err != nil {
+		ctrl.logProvisioning(c, "create_group", "CreateGroup", false, req, err.Error())
+		ctrl.fail(c, err)
+		return
+	}
+
+	resp := toGroupResource(g, ctrl.baseURL(c))
+	ctrl.logProvisioning(c, "create_group", "CreateGroup", true, req, resp)
+	c.Header("Location", resp.Meta.Location)
+	render(c, http.StatusCreated, resp)
+}
+
+// @Summary      SCIM: Substitui Grupo
+// @Description  Substitui o nome e o conjunto completo de membros do grupo.
+// @Tags         SCIM
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "UUID do grupo"
+// @Param        request body GroupResource true "Grupo"
+// @Success      200  {object}  GroupResource
+// @Failure      400  {object}  ErrorResponse "invalidValue ou invalidSyntax"
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse "uniqueness"
+// @Router       /scim/v2/Groups/{id} [put]
+func (ctrl *controllerImpl) ReplaceGroup(c *gin.Context) {
+	id, err := resourceID(c)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	var req GroupResource
+	if err := bind(c, &req); err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	if err := checkMembers(req); err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	g, err := fromGroupResource(req)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	g.UUID = id
+	ctrl.replaceGroup(c, "ReplaceGroup", g)
+}
+
+// @Summary      SCIM: Altera Grupo
+// @Description  Aplica operações add, replace e remove (RFC 7644, seção 3.5.2). Membros podem ser removidos por filtro (members[value eq "id"]) ou por uma lista em value.
+// @Tags         SCIM
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "UUID do grupo"
+// @Param        request body PatchRequest true "Operações"
+// @Success      200  {object}  GroupResource
+// @Failure      400  {object}  ErrorResponse "invalidSyntax, invalidPath, invalidValue ou noTarget"
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Failure      409  {object}  ErrorResponse "uniqueness"
+// @Router       /scim/v2/Groups/{id} [patch]
+func (ctrl *controllerImpl) PatchGroup(c *gin.Context) {
+	id, err := resourceID(c)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	var req PatchRequest
+	if err := bind(c, &req); err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	current, err := ctrl.Service.GetGroup(c.Request.Context(), clientFrom(c).TenantUUID, id, true)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	doc, err := applyPatch(toGroupResource(current, ctrl.baseURL(c)), req)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	var after GroupResource
+	if err := fromDocument(doc, &after); err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	g, err := fromGroupResource(after)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+	g.UUID = id
+	ctrl.replaceGroup(c, "PatchGroup", g)
+}
+
+func (ctrl *controllerImpl) replaceGroup(c *gin.Context, function string, g Group) {
+	input := toGroupResource(g, ctrl.baseURL(c))
+	updated, err := ctrl.Service.ReplaceGroup(c.Request.Context(), clientFrom(c).TenantUUID, g)
+	if err != nil {
+		ctrl.logProvisioning(c, "update_group", function, false, input, err.Error())
+		ctrl.fail(c, err)
+		return
+	}
+
+	resp := toGroupResource(updated, ctrl.baseURL(c))
+	ctrl.logProvisioning(c, "update_group", function, true, input, resp)
+	render(c, http.StatusOK, resp)
+}
+
+// @Summary      SCIM: Exclui Grupo
+// @Tags         SCIM
+// @Security     BearerAuth
+// @Param        id path string true "UUID do grupo"
+// @Success      204  "Grupo excluído."
+// @Failure      401  {object}  ErrorResponse
+// @Failure      404  {object}  ErrorResponse
+// @Router       /scim/v2/Groups/{id} [delete]
+func (ctrl *controllerImpl) DeleteGroup(c *gin.Context) {
+	id, err := resourceID(c)
+	if err != nil {
+		ctrl.fail(c, err)
+		return
+	}
+
+	input := map[string]string{"id": id.String()}
+	if err := ctrl.Service.DeleteGroup(c.Request.Context(), clientFrom(c).TenantUUID, id); err != nil {
+		ctrl.logProvisioning(c, "delete_group", "DeleteGroup", false, input, err.Error())
+		ctrl.fail(c, err)
+		return
+	}
+
+	ctrl.logProvisioning(c, "delete_group", "DeleteGroup", true, input, nil)
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,32 @@
+package scim
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenResponseDto descreve o token SCIM do tenant. token só é preenchido na emissão: guarde-o,
+// ele não pode ser consultado depois.
+type TokenResponseDto struct {
+	UUID       uuid.UUID `json:"uuid"`
+	TenantUUID uuid.UUID `json:"tenant_uuid"`
+	Token      string    `json:"token,omitempty"`
+	// BaseURL é a URL a configurar no provedor de identidade
+	BaseURL    string     `json:"base_url"`
+	CreatedBy  *uuid.UUID `json:"created_by,omitempty"`
+	CreateAt   time.Time  `json:"create_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+func toTokenResponse(t Token, value, baseURL string) TokenResponseDto {
+	return TokenResponseDto{
+		UUID:       t.UUID,
+		TenantUUID: t.TenantUUID,
+		Token:      value,
+		BaseURL:    baseURL,
+		CreatedBy:  t.CreatedBy,
+		CreateAt:   t.CreateAt,
+		LastUsedAt: t.LastUsedAt,
+	}
+}
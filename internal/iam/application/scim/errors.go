@@ -0,0 +1,23 @@
+package scim
+
+import "errors"
+
+var (
+	ErrUnauthorized   = errors.New("invalid scim token")
+	ErrTenantBlocked  = errors.New("tenant access blocked")
+	ErrReadOnly       = errors.New("tenant is read only")
+	ErrTokenNotFound  = errors.New("scim token not found")
+	ErrTenantNotFound = errors.New("tenant not found")
+	ErrNotFound       = errors.New("resource not found")
+	ErrUniqueness     = errors.New("resource already exists")
+	ErrQuotaExceeded  = errors.New("plan limit exceeded")
+	// Erros de protocolo: viram o scimType da resposta (RFC 7644, seção 3.12)
+	ErrInvalidFilter = errors.New("invalid filter")
+	ErrInvalidValue  = errors.New("invalid value")
+	ErrInvalidSyntax = errors.New("invalid syntax")
+	ErrInvalidPath   = errors.New("invalid path")
+	ErrNoTarget      = errors.New("no target")
+)
+
+// ErrLastAdmin impede que o provedor de identidade exclua ou desative o último administrador ativo do tenant.
+var ErrLastAdmin = errors.New("tenant must keep at least one active admin")
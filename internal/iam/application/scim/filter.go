@@ -0,0 +1,163 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Atributos aceitos nos filtros de cada recurso, com o tipo do valor comparado.
+var (
+	userFilterAttrs = map[string]valueKind{
+		"id":           kindString,
+		"username":     kindString,
+		"emails.value": kindString,
+		"emails":       kindString,
+		"externalid":   kindString,
+		"active":       kindBool,
+	}
+	groupFilterAttrs = map[string]valueKind{
+		"id":          kindString,
+		"displayname": kindString,
+		"externalid":  kindString,
+	}
+)
+
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindBool
+	// kindAny é usado nos filtros de caminho do PATCH (ex.: emails[type eq "work"])
+	kindAny
+)
+
+// parseFilter interpreta o parâmetro filter (RFC 7644, seção 3.4.2.2). São suportadas
+// comparações "eq" unidas por "and", que cobrem as consultas dos provedores de identidade
+// (ex.: userName eq "ana@empresa.com"). Nomes de atributo e operadores não diferenciam
+// maiúsculas; o prefixo do schema (urn:...:User:userName) é aceito.
+func parseFilter(filter string, attrs map[string]valueKind) ([]Condition, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	var conditions []Condition
+	for i := 0; ; {
+		if len(tokens)-i < 3 {
+			return nil, fmt.Errorf("%w: expressão incompleta", ErrInvalidFilter)
+		}
+		attr := normalizeAttr(tokens[i].text)
+		if tokens[i].quoted || attr == "" {
+			return nil, fmt.Errorf("%w: atributo esperado em '%s'", ErrInvalidFilter, tokens[i].text)
+		}
+		op := strings.ToLower(tokens[i+1].text)
+		if tokens[i+1].quoted || op != "eq" {
+			return nil, fmt.Errorf("%w: operador '%s' não suportado (use eq)", ErrInvalidFilter, tokens[i+1].text)
+		}
+		// Sem a lista de atributos (filtros de caminho do PATCH), qualquer atributo e valor são aceitos
+		kind := kindAny
+		if attrs != nil {
+			var ok bool
+			if kind, ok = attrs[attr]; !ok {
+				return nil, fmt.Errorf("%w: atributo '%s' não suportado no filtro", ErrInvalidFilter, tokens[i].text)
+			}
+		}
+		value, err := tokens[i+2].value(kind)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, Condition{Attr: attr, Value: value})
+
+		i += 3
+		if i == len(tokens) {
+			return conditions, nil
+		}
+		if tokens[i].quoted || !strings.EqualFold(tokens[i].text, "and") {
+			return nil, fmt.Errorf("%w: apenas 'and' é suportado entre as comparações", ErrInvalidFilter)
+		}
+		i++
+	}
+}
+
+// coreSchemas são os prefixos aceitos antes do nome do atributo.
+var coreSchemas = []string{
+	schemaUser + ":",
+	schemaGroup + ":",
+}
+
+// normalizeAttr remove o prefixo do schema e converte o nome para minúsculas.
+func normalizeAttr(attr string) string {
+	lower := strings.ToLower(attr)
+	for _, prefix := range coreSchemas {
+		if strings.HasPrefix(lower, strings.ToLower(prefix)) {
+			return lower[len(prefix):]
+		}
+	}
+	return lower
+}
+
+type token struct {
+	text   string
+	quoted bool
+}
+
+// value converte o token no valor da comparação, conforme o tipo do atributo.
+func (t token) value(kind valueKind) (any, error) {
+	if t.quoted {
+		if kind == kindBool {
+			return nil, fmt.Errorf("%w: valor booleano esperado", ErrInvalidFilter)
+		}
+		return t.text, nil
+	}
+	switch strings.ToLower(t.text) {
+	case "true", "false":
+		if kind == kindString {
+			return nil, fmt.Errorf("%w: valor entre aspas esperado", ErrInvalidFilter)
+		}
+		return strings.EqualFold(t.text, "true"), nil
+	}
+	return nil, fmt.Errorf("%w: valor inválido '%s'", ErrInvalidFilter, t.text)
+}
+
+// tokenize separa o filtro em palavras e strings JSON entre aspas.
+func tokenize(filter string) ([]token, error) {
+	var tokens []token
+	runes := []rune(strings.TrimSpace(filter))
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == '[' || r == ']':
+			return nil, fmt.Errorf("%w: agrupamentos não são suportados", ErrInvalidFilter)
+		case r == '"':
+			end := i + 1
+			for ; end < len(runes) && runes[end] != '"'; end++ {
+				if runes[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("%w: aspas não fechadas", ErrInvalidFilter)
+			}
+			var text string
+			if err := json.Unmarshal([]byte(string(runes[i:end+1])), &text); err != nil {
+				return nil, fmt.Errorf("%w: string inválida", ErrInvalidFilter)
+			}
+			tokens = append(tokens, token{text: text, quoted: true})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '"' {
+				end++
+			}
+			tokens = append(tokens, token{text: string(runes[i:end])})
+			i = end
+		}
+	}
+	return tokens, nil
+}
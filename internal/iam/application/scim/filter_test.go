@@ -0,0 +1,101 @@
+package scim
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		attrs  map[string]valueKind
+		want   []Condition
+		err    error
+	}{
+		{name: "vazio", filter: "  ", attrs: userFilterAttrs},
+		{name: "userName", filter: `userName eq "Ana@Empresa.com"`, attrs: userFilterAttrs,
+			want: []Condition{{Attr: "username", Value: "Ana@Empresa.com"}}},
+		{name: "operador e atributo sem diferenciar maiúsculas", filter: `USERNAME EQ "ana@empresa.com"`, attrs: userFilterAttrs,
+			want: []Condition{{Attr: "username", Value: "ana@empresa.com"}}},
+		{name: "prefixo do schema", filter: `urn:ietf:params:scim:schemas:core:2.0:User:userName eq "a@b.com"`, attrs: userFilterAttrs,
+			want: []Condition{{Attr: "username", Value: "a@b.com"}}},
+		{name: "and e booleano", filter: `emails.value eq "a@b.com" and active eq False`, attrs: userFilterAttrs,
+			want: []Condition{{Attr: "emails.value", Value: "a@b.com"}, {Attr: "active", Value: false}}},
+		{name: "aspas escapadas", filter: `displayName eq "Time \"A\""`, attrs: groupFilterAttrs,
+			want: []Condition{{Attr: "displayname", Value: `Time "A"`}}},
+		{name: "caminho do PATCH aceita qualquer atributo", filter: `type eq "work"`,
+			want: []Condition{{Attr: "type", Value: "work"}}},
+
+		{name: "operador não suportado", filter: `userName co "ana"`, attrs: userFilterAttrs, err: ErrInvalidFilter},
+		{name: "or não suportado", filter: `userName eq "a@b.com" or active eq true`, attrs: userFilterAttrs, err: ErrInvalidFilter},
+		{name: "agrupamento", filter: `(userName eq "a@b.com")`, attrs: userFilterAttrs, err: ErrInvalidFilter},
+		{name: "atributo desconhecido", filter: `title eq "x"`, attrs: userFilterAttrs, err: ErrInvalidFilter},
+		{name: "atributo de outro recurso", filter: `displayName eq "x"`, attrs: userFilterAttrs, err: ErrInvalidFilter},
+		{name: "booleano entre aspas", filter: `active eq "true"`, attrs: userFilterAttrs, err: ErrInvalidFilter},
+		{name: "texto sem aspas", filter: `userName eq true`, attrs: userFilterAttrs, err: ErrInvalidFilter},
+		{name: "aspas não fechadas", filter: `userName eq "a@b.com`, attrs: userFilterAttrs, err: ErrInvalidFilter},
+		{name: "incompleto", filter: `userName eq`, attrs: userFilterAttrs, err: ErrInvalidFilter},
+		{name: "and sem comparação", filter: `userName eq "a@b.com" and`, attrs: userFilterAttrs, err: ErrInvalidFilter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.filter, tt.attrs)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("parseFilter(%q) erro = %v, esperado %v", tt.filter, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFilter(%q) erro inesperado: %v", tt.filter, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseFilter(%q) = %#v, esperado %#v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		startIndex int
+		count      int
+		err        error
+	}{
+		{name: "padrão", query: "", startIndex: 1, count: defaultCount},
+		{name: "página informada", query: "?startIndex=11&count=10", startIndex: 11, count: 10},
+		{name: "startIndex menor que 1", query: "?startIndex=-5", startIndex: 1, count: defaultCount},
+		{name: "count acima do máximo", query: "?count=5000", startIndex: 1, count: maxCount},
+		{name: "count negativo", query: "?count=-1", startIndex: 1, count: 0},
+		{name: "count zero", query: "?count=0", startIndex: 1, count: 0},
+		{name: "startIndex inválido", query: "?startIndex=a", err: ErrInvalidValue},
+		{name: "count inválido", query: "?count=1.5", err: ErrInvalidValue},
+		{name: "filtro inválido", query: "?filter=title+eq+%22x%22", err: ErrInvalidFilter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/scim/v2/Users"+tt.query, nil)
+			q, err := listQuery(c, userFilterAttrs)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("erro = %v, esperado %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if q.StartIndex != tt.startIndex || q.Count != tt.count {
+				t.Fatalf("startIndex=%d count=%d, esperado startIndex=%d count=%d", q.StartIndex, q.Count, tt.startIndex, tt.count)
+			}
+		})
+	}
+}
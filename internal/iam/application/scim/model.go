@@ -0,0 +1,66 @@
+package scim
+
+import (
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/group"
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type User = model.User
+
+// Group é o grupo do tenant com os seus membros. Members é nil quando os membros não foram
+// carregados (excludedAttributes=members).
+type Group struct {
+	model.Group
+	Members []group.Member
+}
+
+// Token é o bearer token SCIM de um tenant. Apenas o hash SHA-256 é gravado; o valor é exibido
+// uma única vez, na emissão.
+type Token struct {
+	UUID       uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TenantUUID uuid.UUID  `gorm:"type:uuid;not null"`
+	TokenHash  string     `gorm:"not null;unique"`
+	CreatedBy  *uuid.UUID `gorm:"type:uuid"`
+	CreateAt   time.Time  `gorm:"column:create_at;not null"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at"`
+}
+
+func (Token) TableName() string {
+	return "scim_tokens"
+}
+
+// Client é o provedor de identidade autenticado pelo token.
+type Client struct {
+	TokenUUID  uuid.UUID
+	TenantUUID uuid.UUID
+	// ReadOnly indica tenant com pagamento pendente: apenas consultas são permitidas
+	ReadOnly bool
+}
+
+// Condition é uma comparação "eq" do filtro. Attr é o nome do atributo em minúsculas
+// (ex.: "username", "emails.value").
+type Condition struct {
+	Attr  string
+	Value any
+}
+
+// Query é uma consulta de listagem: as condições (unidas por "and") e a página, com
+// StartIndex a partir de 1.
+type Query struct {
+	Conditions []Condition
+	StartIndex int
+	Count      int
+}
+
+// offset é o deslocamento da página (StartIndex é 1-based).
+func (q Query) offset() int {
+	if q.StartIndex < 1 {
+		return 0
+	}
+	return q.StartIndex - 1
+}
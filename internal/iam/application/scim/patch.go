@@ -0,0 +1,399 @@
+package scim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type PatchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty" swaggertype:"object"`
+}
+
+// maxPatchOperations limita as operações de um único PATCH.
+const maxPatchOperations = 1000
+
+// readOnlyAttrs não são alterados pelo PATCH (o id e o meta vêm sempre do cadastro).
+var readOnlyAttrs = map[string]bool{"id": true, "meta": true, "schemas": true}
+
+// applyPatch aplica as operações (RFC 7644, seção 3.5.2) sobre o recurso atual, representado
+// como JSON genérico. O resultado é validado depois, na conversão para o usuário ou o grupo;
+// atributos que o cadastro não guarda (externalId, extensões) são aceitos e descartados ali.
+func applyPatch(resource any, req PatchRequest) (map[string]any, error) {
+	if !hasSchema(req.Schemas, schemaPatchOp) {
+		return nil, fmt.Errorf("%w: schema %s ausente", ErrInvalidSyntax, schemaPatchOp)
+	}
+	if len(req.Operations) == 0 || len(req.Operations) > maxPatchOperations {
+		return nil, fmt.Errorf("%w: informe de 1 a %d operações", ErrInvalidSyntax, maxPatchOperations)
+	}
+
+	doc, err := toDocument(resource)
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range req.Operations {
+		if err := applyOperation(doc, op); err != nil {
+			return nil, err
+		}
+	}
+	normalizeBooleans(doc)
+	return doc, nil
+}
+
+func hasSchema(schemas []string, schema string) bool {
+	for _, s := range schemas {
+		if strings.EqualFold(s, schema) {
+			return true
+		}
+	}
+	return false
+}
+
+func toDocument(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	err = json.Unmarshal(raw, &doc)
+	return doc, err
+}
+
+// fromDocument converte o documento alterado de volta no recurso tipado.
+func fromDocument(doc map[string]any, dst any) error {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if err := decoder.Decode(dst); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	}
+	return nil
+}
+
+func applyOperation(doc map[string]any, op PatchOperation) error {
+	kind := strings.ToLower(op.Op)
+	if kind != "add" && kind != "replace" && kind != "remove" {
+		return fmt.Errorf("%w: operação '%s' desconhecida", ErrInvalidSyntax, op.Op)
+	}
+
+	var value any
+	if len(op.Value) > 0 {
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return fmt.Errorf("%w: value inválido", ErrInvalidSyntax)
+		}
+	}
+
+	if strings.TrimSpace(op.Path) == "" {
+		if kind == "remove" {
+			return fmt.Errorf("%w: remove exige path", ErrNoTarget)
+		}
+		// Sem path, value é um objeto com os atributos a alterar (as chaves podem ser caminhos)
+		attrs, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: sem path, value deve ser um objeto", ErrInvalidValue)
+		}
+		for key, v := range attrs {
+			if err := applyPath(doc, kind, key, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if kind != "remove" && value == nil {
+		return fmt.Errorf("%w: value é obrigatório em %s", ErrInvalidValue, kind)
+	}
+	return applyPath(doc, kind, op.Path, value)
+}
+
+// attrPath é um caminho de PATCH: attr[filtro].sub, com filtro e sub opcionais.
+type attrPath struct {
+	attr   string
+	filter []Condition
+	sub    string
+}
+
+func parsePath(path string) (attrPath, bool, error) {
+	path = strings.TrimSpace(path)
+	lower := strings.ToLower(path)
+	for _, prefix := range coreSchemas {
+		if strings.HasPrefix(lower, strings.ToLower(prefix)) {
+			path = path[len(prefix):]
+			lower = lower[len(prefix):]
+			break
+		}
+	}
+	// Extensões (ex.: enterprise) não são guardadas pelo cadastro
+	if strings.HasPrefix(lower, "urn:") {
+		return attrPath{}, false, nil
+	}
+
+	var p attrPath
+	if open := strings.IndexByte(path, '['); open >= 0 {
+		end := strings.LastIndexByte(path, ']')
+		if end < open {
+			return attrPath{}, false, fmt.Errorf("%w: '%s'", ErrInvalidPath, path)
+		}
+		filter, err := parseFilter(path[open+1:end], nil)
+		if err != nil {
+			return attrPath{}, false, fmt.Errorf("%w: '%s'", ErrInvalidPath, path)
+		}
+		p.attr, p.filter = path[:open], filter
+		rest := path[end+1:]
+		if rest != "" {
+			if !strings.HasPrefix(rest, ".") || len(rest) == 1 {
+				return attrPath{}, false, fmt.Errorf("%w: '%s'", ErrInvalidPath, path)
+			}
+			p.sub = rest[1:]
+		}
+	} else {
+		p.attr, p.sub, _ = strings.Cut(path, ".")
+	}
+	if p.attr == "" || strings.ContainsAny(p.attr+p.sub, " []\"") {
+		return attrPath{}, false, fmt.Errorf("%w: '%s'", ErrInvalidPath, path)
+	}
+	return p, true, nil
+}
+
+func applyPath(doc map[string]any, kind, path string, value any) error {
+	p, ok, err := parsePath(path)
+	if err != nil || !ok {
+		return err
+	}
+	key := findKey(doc, p.attr)
+	if readOnlyAttrs[strings.ToLower(key)] {
+		return nil
+	}
+
+	if p.filter != nil {
+		return applyFiltered(doc, kind, key, p, value)
+	}
+
+	if p.sub == "" {
+		current, exists := doc[key]
+		switch kind {
+		case "remove":
+			// remove com value (ex.: members) retira apenas os itens informados
+			if items, ok := current.([]any); ok && value != nil {
+				doc[key] = withoutItems(items, asList(value))
+			} else {
+				delete(doc, key)
+			}
+		case "add":
+			if items, ok := current.([]any); ok && exists {
+				doc[key] = appendItems(items, asList(value))
+			} else {
+				doc[key] = mergeValue(current, value)
+			}
+		case "replace":
+			doc[key] = mergeValue(current, value)
+		}
+		return nil
+	}
+
+	// attr.sub: no objeto (name.givenName) ou em todos os itens de um multivalorado
+	switch current := doc[key].(type) {
+	case []any:
+		for _, item := range current {
+			if m, ok := item.(map[string]any); ok {
+				setSub(m, kind, p.sub, value)
+			}
+		}
+	case map[string]any:
+		setSub(current, kind, p.sub, value)
+	default:
+		if kind != "remove" {
+			doc[key] = map[string]any{p.sub: value}
+		}
+	}
+	return nil
+}
+
+// applyFiltered trata caminhos como emails[type eq "work"].value e members[value eq "<id>"].
+func applyFiltered(doc map[string]any, kind, key string, p attrPath, value any) error {
+	items, _ := doc[key].([]any)
+	var kept []any
+	matched := false
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok || !matches(m, p.filter) {
+			kept = append(kept, item)
+			continue
+		}
+		matched = true
+		switch {
+		case kind == "remove" && p.sub == "":
+			continue
+		case kind == "remove":
+			delete(m, findKey(m, p.sub))
+		case p.sub == "":
+			if v, ok := value.(map[string]any); ok {
+				for k, val := range v {
+					m[findKey(m, k)] = val
+				}
+			}
+		default:
+			m[findKey(m, p.sub)] = value
+		}
+		kept = append(kept, m)
+	}
+
+	if !matched {
+		if kind == "remove" {
+			return nil
+		}
+		// Sem item correspondente, o item é criado a partir das condições do filtro
+		// (ex.: phoneNumbers[type eq "work"].value em um usuário sem telefone)
+		item := map[string]any{}
+		for _, c := range p.filter {
+			item[c.Attr] = c.Value
+		}
+		if p.sub == "" {
+			if v, ok := value.(map[string]any); ok {
+				for k, val := range v {
+					item[k] = val
+				}
+			}
+		} else {
+			item[p.sub] = value
+		}
+		kept = append(kept, item)
+	}
+	if kept == nil {
+		kept = []any{}
+	}
+	doc[key] = kept
+	return nil
+}
+
+// matches compara as condições do filtro com o item (atributos sem diferenciar maiúsculas).
+func matches(item map[string]any, conditions []Condition) bool {
+	for _, c := range conditions {
+		v, ok := item[findKey(item, c.Attr)]
+		if !ok || !equalValues(v, c.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalValues(a, b any) bool {
+	as, aok := a.(string)
+	bs, bok := b.(string)
+	if aok && bok {
+		return strings.EqualFold(as, bs)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func setSub(m map[string]any, kind, sub string, value any) {
+	key := findKey(m, sub)
+	if kind == "remove" {
+		delete(m, key)
+		return
+	}
+	m[key] = value
+}
+
+// findKey retorna a chave existente com o mesmo nome (sem diferenciar maiúsculas) ou o próprio nome.
+func findKey(m map[string]any, name string) string {
+	if _, ok := m[name]; ok {
+		return name
+	}
+	for k := range m {
+		if strings.EqualFold(k, name) {
+			return k
+		}
+	}
+	return name
+}
+
+// mergeValue combina objetos (replace de name altera apenas os subatributos enviados);
+// os demais valores são substituídos.
+func mergeValue(current, value any) any {
+	cur, ok := current.(map[string]any)
+	v, vok := value.(map[string]any)
+	if !ok || !vok {
+		return value
+	}
+	for k, val := range v {
+		cur[findKey(cur, k)] = val
+	}
+	return cur
+}
+
+func asList(v any) []any {
+	if items, ok := v.([]any); ok {
+		return items
+	}
+	return []any{v}
+}
+
+// itemValue é a identidade de um item multivalorado: o subatributo value.
+func itemValue(item any) (string, bool) {
+	m, ok := item.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	v, ok := m[findKey(m, "value")].(string)
+	return v, ok
+}
+
+func appendItems(items, added []any) []any {
+	for _, a := range added {
+		av, ok := itemValue(a)
+		duplicate := false
+		for _, item := range items {
+			if iv, iok := itemValue(item); ok && iok && strings.EqualFold(av, iv) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			items = append(items, a)
+		}
+	}
+	return items
+}
+
+func withoutItems(items, removed []any) []any {
+	kept := []any{}
+	for _, item := range items {
+		iv, iok := itemValue(item)
+		drop := false
+		for _, r := range removed {
+			if rv, ok := itemValue(r); ok && iok && strings.EqualFold(iv, rv) {
+				drop = true
+				break
+			}
+		}
+		if !drop {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// normalizeBooleans aceita "True"/"False" em active, enviados como string por alguns provedores.
+func normalizeBooleans(doc map[string]any) {
+	key := findKey(doc, "active")
+	if s, ok := doc[key].(string); ok {
+		switch strings.ToLower(s) {
+		case "true":
+			doc[key] = true
+		case "false":
+			doc[key] = false
+		}
+	}
+}
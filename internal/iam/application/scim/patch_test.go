@@ -0,0 +1,210 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/group"
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// patchRequest monta um PatchRequest a partir das operações em JSON.
+func patchRequest(t *testing.T, operations string) PatchRequest {
+	t.Helper()
+	var ops []PatchOperation
+	if err := json.Unmarshal([]byte(operations), &ops); err != nil {
+		t.Fatalf("operações inválidas: %v", err)
+	}
+	return PatchRequest{Schemas: []string{schemaPatchOp}, Operations: ops}
+}
+
+// patchUser segue o fluxo do PatchUser do controller: recurso atual, operações e conversão de volta.
+func patchUser(t *testing.T, u User, operations string) (User, error) {
+	t.Helper()
+	before := toUserResource(u, "https://example.com/scim/v2")
+	doc, err := applyPatch(before, patchRequest(t, operations))
+	if err != nil {
+		return User{}, err
+	}
+	var after UserResource
+	if err := fromDocument(doc, &after); err != nil {
+		return User{}, err
+	}
+	patched, err := fromUserResource(after)
+	if err != nil {
+		return User{}, err
+	}
+	patched.Name = patchedName(before, after)
+	return patched, nil
+}
+
+func TestApplyPatchUser(t *testing.T) {
+	current := User{
+		UUID:     uuid.New(),
+		Name:     "Ana Souza",
+		Email:    "ana@empresa.com",
+		Live:     true,
+		Locale:   "pt-BR",
+		CreateAt: testEpoch,
+		UpdateAt: testEpoch,
+	}
+
+	tests := []struct {
+		name       string
+		operations string
+		check      func(User) bool
+		err        error
+	}{
+		{name: "replace sem path (Azure AD)", operations: `[{"op":"Replace","value":{"active":"False"}}]`,
+			check: func(u User) bool { return !u.Live && u.Name == "Ana Souza" }},
+		{name: "replace de active", operations: `[{"op":"replace","path":"active","value":false}]`,
+			check: func(u User) bool { return !u.Live }},
+		{name: "replace de givenName altera o nome", operations: `[{"op":"replace","path":"name.givenName","value":"Maria"}]`,
+			check: func(u User) bool { return u.Name == "Maria Souza" }},
+		{name: "replace de name mantém os demais subatributos", operations: `[{"op":"replace","path":"name","value":{"familyName":"Lima"}}]`,
+			check: func(u User) bool { return u.Name == "Ana Lima" }},
+		{name: "replace de displayName", operations: `[{"op":"replace","path":"displayName","value":"Ana S."}]`,
+			check: func(u User) bool { return u.Name == "Ana S." }},
+		{name: "userName com prefixo do schema", operations: `[{"op":"replace","path":"urn:ietf:params:scim:schemas:core:2.0:User:userName","value":"ana.souza@empresa.com"}]`,
+			check: func(u User) bool { return u.Email == "ana.souza@empresa.com" }},
+		{name: "telefone por filtro sem item existente", operations: `[{"op":"add","path":"phoneNumbers[type eq \"work\"].value","value":"+5511999990000"}]`,
+			check: func(u User) bool { return u.Phone == "+5511999990000" }},
+		{name: "locale no formato en_US", operations: `[{"op":"replace","path":"locale","value":"en_US"}]`,
+			check: func(u User) bool { return u.Locale == "en-US" }},
+		{name: "extensão é ignorada", operations: `[{"op":"add","path":"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department","value":"TI"}]`,
+			check: func(u User) bool { return u.Name == "Ana Souza" && u.Email == "ana@empresa.com" }},
+		{name: "id e meta não são alterados", operations: `[{"op":"replace","path":"id","value":"outro"}]`,
+			check: func(u User) bool { return u.Email == "ana@empresa.com" }},
+
+		{name: "remove sem path", operations: `[{"op":"remove"}]`, err: ErrNoTarget},
+		{name: "operação desconhecida", operations: `[{"op":"move","path":"active","value":true}]`, err: ErrInvalidSyntax},
+		{name: "replace sem value", operations: `[{"op":"replace","path":"active"}]`, err: ErrInvalidValue},
+		{name: "sem path com value que não é objeto", operations: `[{"op":"replace","value":"x"}]`, err: ErrInvalidValue},
+		{name: "path com filtro inválido", operations: `[{"op":"replace","path":"emails[type co \"work\"].value","value":"x@y.com"}]`, err: ErrInvalidPath},
+		{name: "userName que não é email", operations: `[{"op":"replace","path":"userName","value":"ana"}]`, err: ErrInvalidValue},
+		{name: "timezone inválido", operations: `[{"op":"replace","path":"timezone","value":"Marte/Olympus"}]`, err: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := patchUser(t, current, tt.operations)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("erro = %v, esperado %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(got) {
+				t.Fatalf("resultado inesperado: %+v", got)
+			}
+		})
+	}
+}
+
+func TestApplyPatchSchemaAndLimits(t *testing.T) {
+	resource := toUserResource(User{UUID: uuid.New(), Name: "Ana", Email: "ana@empresa.com", Live: true}, "")
+
+	if _, err := applyPatch(resource, PatchRequest{Operations: []PatchOperation{{Op: "remove", Path: "locale"}}}); !errors.Is(err, ErrInvalidSyntax) {
+		t.Fatalf("sem o schema PatchOp: %v", err)
+	}
+	if _, err := applyPatch(resource, PatchRequest{Schemas: []string{schemaPatchOp}}); !errors.Is(err, ErrInvalidSyntax) {
+		t.Fatalf("sem operações: %v", err)
+	}
+	ops := make([]PatchOperation, maxPatchOperations+1)
+	for i := range ops {
+		ops[i] = PatchOperation{Op: "remove", Path: "locale"}
+	}
+	if _, err := applyPatch(resource, PatchRequest{Schemas: []string{schemaPatchOp}, Operations: ops}); !errors.Is(err, ErrInvalidSyntax) {
+		t.Fatalf("acima do limite de operações: %v", err)
+	}
+}
+
+func TestApplyPatchGroupMembers(t *testing.T) {
+	ana, bruno, carla := uuid.New(), uuid.New(), uuid.New()
+	current := Group{
+		Group: model.Group{UUID: uuid.New(), TenantUUID: tenantA, Name: "Admins", CreateAt: testEpoch, UpdateAt: testEpoch},
+		Members: []group.Member{
+			{UserUUID: ana, Name: "Ana", Email: "ana@empresa.com", JoinedAt: testEpoch},
+			{UserUUID: bruno, Name: "Bruno", Email: "bruno@empresa.com", JoinedAt: testEpoch.Add(time.Minute)},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		operations string
+		wantName   string
+		want       []uuid.UUID
+		err        error
+	}{
+		{name: "add de membro", operations: `[{"op":"add","path":"members","value":[{"value":"` + carla.String() + `"}]}]`,
+			wantName: "Admins", want: []uuid.UUID{ana, bruno, carla}},
+		{name: "add de membro existente não duplica", operations: `[{"op":"add","path":"members","value":[{"value":"` + ana.String() + `"}]}]`,
+			wantName: "Admins", want: []uuid.UUID{ana, bruno}},
+		{name: "remove por filtro", operations: `[{"op":"remove","path":"members[value eq \"` + ana.String() + `\"]"}]`,
+			wantName: "Admins", want: []uuid.UUID{bruno}},
+		{name: "remove com value (Azure AD)", operations: `[{"op":"Remove","path":"members","value":[{"value":"` + bruno.String() + `"}]}]`,
+			wantName: "Admins", want: []uuid.UUID{ana}},
+		{name: "remove de todos", operations: `[{"op":"remove","path":"members"}]`,
+			wantName: "Admins", want: []uuid.UUID{}},
+		{name: "replace de membros e nome", operations: `[{"op":"replace","value":{"displayName":"Gestores","members":[{"value":"` + carla.String() + `"}]}}]`,
+			wantName: "Gestores", want: []uuid.UUID{carla}},
+		{name: "membro com id inválido", operations: `[{"op":"add","path":"members","value":[{"value":"nao-e-uuid"}]}]`, err: ErrInvalidValue},
+		{name: "displayName vazio", operations: `[{"op":"replace","path":"displayName","value":" "}]`, err: ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := applyPatch(toGroupResource(current, "https://example.com/scim/v2"), patchRequest(t, tt.operations))
+			var after GroupResource
+			if err == nil {
+				err = fromDocument(doc, &after)
+			}
+			var got Group
+			if err == nil {
+				got, err = fromGroupResource(after)
+			}
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("erro = %v, esperado %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != tt.wantName {
+				t.Fatalf("nome = %q, esperado %q", got.Name, tt.wantName)
+			}
+			ids := make([]uuid.UUID, 0, len(got.Members))
+			for _, m := range got.Members {
+				ids = append(ids, m.UserUUID)
+			}
+			assertUUIDs(t, ids, tt.want)
+		})
+	}
+}
+
+func TestFromGroupResourceMembers(t *testing.T) {
+	ana := uuid.New()
+	got, err := fromGroupResource(GroupResource{
+		DisplayName: "  Admins ",
+		Members:     []GroupMember{{Value: ana.String()}, {Value: " " + strings.ToUpper(ana.String()) + " "}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Admins" || len(got.Members) != 1 || got.Members[0].UserUUID != ana {
+		t.Fatalf("grupo = %+v", got)
+	}
+
+	got, err = fromGroupResource(GroupResource{DisplayName: "Vazio"})
+	if err != nil || got.Members == nil {
+		t.Fatalf("grupo sem membros = %+v, %v (esperada lista vazia)", got.Members, err)
+	}
+}
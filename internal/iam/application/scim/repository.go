@@ -0,0 +1,215 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/group"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/infra/database/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// touchInterval evita gravar last_used_at a cada requisição do provedor de identidade.
+const touchInterval = time.Minute
+
+// TokenTenant é o token com o estado do seu tenant, lido na autenticação.
+type TokenTenant struct {
+	Token
+	Status    model.TenantStatus
+	DeletedAt *time.Time
+}
+
+type Repository interface {
+	// CreateToken grava o novo token e revoga os anteriores do tenant, em uma transação. Com limit,
+	// as chaves ativas são contadas com a linha do tenant bloqueada, antes da revogação, e limit
+	// decide se a emissão segue: emissões simultâneas não passam do limite do plano.
+	CreateToken(ctx context.Context, token Token, limit func(keys int64) error) (Token, error)
+	RevokeTokens(ctx context.Context, tenantUUID uuid.UUID) (int64, error)
+	ActiveToken(ctx context.Context, tenantUUID uuid.UUID) (Token, error)
+	TokenByHash(ctx context.Context, hash string) (TokenTenant, error)
+	TouchToken(ctx context.Context, tokenUUID uuid.UUID, at time.Time) error
+	// ListUsers e ListGroups consultam o schema ou o banco do tenant (escopo do ctx).
+	ListUsers(ctx context.Context, tenantUUID uuid.UUID, q Query) ([]User, int64, error)
+	ListGroups(ctx context.Context, tenantUUID uuid.UUID, q Query) ([]model.Group, int64, error)
+	// Members retorna todos os membros dos grupos informados.
+	Members(ctx context.Context, groupUUIDs []uuid.UUID) (map[uuid.UUID][]group.Member, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) CreateToken(ctx context.Context, token Token, limit func(keys int64) error) (Token, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if limit != nil {
+			// SELECT ... FOR UPDATE (o bloqueio é ignorado pelo SQLite dos testes)
+			var locked []uuid.UUID
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Table("tenant").
+				Where("uuid = ?", token.TenantUUID).
+				Pluck("uuid", &locked).Error
+			if err != nil {
+				return err
+			}
+			var keys int64
+			err = tx.Model(&Token{}).
+				Where("tenant_uuid = ? AND revoked_at IS NULL", token.TenantUUID).
+				Count(&keys).Error
+			if err != nil {
+				return err
+			}
+			if err := limit(keys); err != nil {
+				return err
+			}
+		}
+
+		err := tx.Model(&Token{}).
+			Where("tenant_uuid = ? AND revoked_at IS NULL", token.TenantUUID).
+			Update("revoked_at", token.CreateAt).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&token).Error
+	})
+	if err != nil {
+		return Token{}, err
+	}
+	return token, nil
+}
+
+func (r *repositoryImpl) RevokeTokens(ctx context.Context, tenantUUID uuid.UUID) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&Token{}).
+		Where("tenant_uuid = ? AND revoked_at IS NULL", tenantUUID).
+		Update("revoked_at", time.Now().UTC())
+	return result.RowsAffected, result.Error
+}
+
+func (r *repositoryImpl) ActiveToken(ctx context.Context, tenantUUID uuid.UUID) (Token, error) {
+	var token Token
+	err := r.db.WithContext(ctx).
+		Where("tenant_uuid = ? AND revoked_at IS NULL", tenantUUID).
+		Order("create_at DESC").
+		Take(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Token{}, ErrTokenNotFound
+	}
+	return token, err
+}
+
+func (r *repositoryImpl) TokenByHash(ctx context.Context, hash string) (TokenTenant, error) {
+	var token TokenTenant
+	result := r.db.WithContext(ctx).Table("scim_tokens AS st").
+		Select("st.*, t.status, t.deleted_at").
+		Joins("INNER JOIN tenant AS t ON t.uuid = st.tenant_uuid").
+		Where("st.token_hash = ? AND st.revoked_at IS NULL", hash).
+		Limit(1).
+		Scan(&token)
+	if result.Error != nil {
+		return TokenTenant{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return TokenTenant{}, ErrUnauthorized
+	}
+	return token, nil
+}
+
+func (r *repositoryImpl) TouchToken(ctx context.Context, tokenUUID uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&Token{}).
+		Where("uuid = ? AND (last_used_at IS NULL OR last_used_at < ?)", tokenUUID, at.Add(-touchInterval)).
+		Update("last_used_at", at).Error
+}
+
+func (r *repositoryImpl) ListUsers(ctx context.Context, tenantUUID uuid.UUID, q Query) ([]User, int64, error) {
+	query := postgres.Conn(ctx, r.db).Model(&User{}).Where("tenant_uuid = ?", tenantUUID)
+	for _, c := range q.Conditions {
+		switch c.Attr {
+		case "id":
+			id, err := uuid.Parse(c.Value.(string))
+			if err != nil {
+				return []User{}, 0, nil
+			}
+			query = query.Where("uuid = ?", id)
+		case "username", "emails", "emails.value":
+			query = query.Where("LOWER(email) = LOWER(?)", c.Value)
+		case "active":
+			query = query.Where("live = ?", c.Value)
+		default:
+			// externalId não é guardado: nenhum usuário corresponde
+			return []User{}, 0, nil
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	users := []User{}
+	if q.Count == 0 || total == 0 {
+		return users, total, nil
+	}
+	err := query.Order("create_at, uuid").Offset(q.offset()).Limit(q.Count).Find(&users).Error
+	return users, total, err
+}
+
+func (r *repositoryImpl) ListGroups(ctx context.Context, tenantUUID uuid.UUID, q Query) ([]model.Group, int64, error) {
+	query := postgres.Conn(ctx, r.db).Model(&model.Group{}).Where("tenant_uuid = ?", tenantUUID)
+	for _, c := range q.Conditions {
+		switch c.Attr {
+		case "id":
+			id, err := uuid.Parse(c.Value.(string))
+			if err != nil {
+				return []model.Group{}, 0, nil
+			}
+			query = query.Where("uuid = ?", id)
+		case "displayname":
+			query = query.Where("LOWER(name) = LOWER(?)", c.Value)
+		default:
+			return []model.Group{}, 0, nil
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	groups := []model.Group{}
+	if q.Count == 0 || total == 0 {
+		return groups, total, nil
+	}
+	err := query.Order("create_at, uuid").Offset(q.offset()).Limit(q.Count).Find(&groups).Error
+	return groups, total, err
+}
+
+func (r *repositoryImpl) Members(ctx context.Context, groupUUIDs []uuid.UUID) (map[uuid.UUID][]group.Member, error) {
+	members := make(map[uuid.UUID][]group.Member, len(groupUUIDs))
+	if len(groupUUIDs) == 0 {
+		return members, nil
+	}
+	var rows []struct {
+		GroupUUID uuid.UUID
+		group.Member
+	}
+	err := postgres.Conn(ctx, r.db).
+		Table("tenant_group_members AS gm").
+		Select("gm.group_uuid, gm.user_uuid, u.name, u.email, gm.create_at AS joined_at").
+		Joins("INNER JOIN users AS u ON u.uuid = gm.user_uuid").
+		Where("gm.group_uuid IN ?", groupUUIDs).
+		Order("gm.create_at, gm.user_uuid").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		members[row.GroupUUID] = append(members[row.GroupUUID], row.Member)
+	}
+	return members, nil
+}
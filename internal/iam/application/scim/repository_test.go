@@ -0,0 +1,335 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// O repositório é testado contra um SQLite em memória com as colunas usadas pelas consultas
+// SCIM, no lugar do PostgreSQL.
+const testSchema = `
+CREATE TABLE tenant (
+    uuid TEXT PRIMARY KEY,
+    status TEXT NOT NULL,
+    deleted_at DATETIME
+);
+CREATE TABLE users (
+    uuid TEXT PRIMARY KEY,
+    tenant_uuid TEXT,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL DEFAULT '',
+    role TEXT NOT NULL DEFAULT 'TENANT_USER',
+    live BOOLEAN NOT NULL DEFAULT 1,
+    metadata TEXT NOT NULL DEFAULT '{}',
+    create_at DATETIME NOT NULL,
+    update_at DATETIME NOT NULL,
+    last_login_at DATETIME,
+    phone TEXT NOT NULL DEFAULT '',
+    avatar_url TEXT NOT NULL DEFAULT '',
+    locale TEXT NOT NULL DEFAULT '',
+    timezone TEXT NOT NULL DEFAULT ''
+);
+CREATE TABLE tenant_groups (
+    uuid TEXT PRIMARY KEY,
+    tenant_uuid TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    create_at DATETIME NOT NULL,
+    update_at DATETIME NOT NULL
+);
+CREATE TABLE tenant_group_members (
+    group_uuid TEXT NOT NULL,
+    user_uuid TEXT NOT NULL,
+    create_at DATETIME NOT NULL
+);
+CREATE TABLE scim_tokens (
+    uuid TEXT PRIMARY KEY DEFAULT (lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-' ||
+        hex(randomblob(2)) || '-' || hex(randomblob(2)) || '-' || hex(randomblob(6)))),
+    tenant_uuid TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_by TEXT,
+    create_at DATETIME NOT NULL,
+    last_used_at DATETIME,
+    revoked_at DATETIME
+);`
+
+var testEpoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("falha ao abrir o SQLite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cada conexão teria o seu próprio banco em memória
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.Exec(testSchema).Error; err != nil {
+		t.Fatalf("falha ao criar as tabelas: %v", err)
+	}
+	return db
+}
+
+// seedUsers cria n usuários no tenant, com create_at crescente (a ordem da listagem).
+func seedUsers(t *testing.T, db *gorm.DB, tenantUUID uuid.UUID, n int) []User {
+	t.Helper()
+	users := make([]User, 0, n)
+	for i := 0; i < n; i++ {
+		at := testEpoch.Add(time.Duration(i) * time.Minute)
+		live := i%2 == 0
+		u := User{
+			UUID:       uuid.New(),
+			TenantUUID: &tenantUUID,
+			Name:       fmt.Sprintf("Usuário %d", i),
+			Email:      fmt.Sprintf("user%d@t%x.example", i, tenantUUID[15]),
+			Role:       model.RoleTenantUser,
+			Live:       live,
+			Metadata:   model.Metadata{},
+			CreateAt:   at,
+			UpdateAt:   at,
+		}
+		if err := db.Omit(clause.Associations).Create(&u).Error; err != nil {
+			t.Fatalf("falha ao criar o usuário: %v", err)
+		}
+		// O GORM troca o false pelo default no INSERT
+		if !live {
+			if err := db.Model(&User{}).Where("uuid = ?", u.UUID).Update("live", false).Error; err != nil {
+				t.Fatalf("falha ao desativar o usuário: %v", err)
+			}
+			u.Live = false
+		}
+		users = append(users, u)
+	}
+	return users
+}
+
+func seedGroup(t *testing.T, db *gorm.DB, tenantUUID uuid.UUID, name string, at time.Time, members ...User) model.Group {
+	t.Helper()
+	g := model.Group{UUID: uuid.New(), TenantUUID: tenantUUID, Name: name, CreateAt: at, UpdateAt: at}
+	if err := db.Create(&g).Error; err != nil {
+		t.Fatalf("falha ao criar o grupo: %v", err)
+	}
+	for i, m := range members {
+		err := db.Exec("INSERT INTO tenant_group_members (group_uuid, user_uuid, create_at) VALUES (?, ?, ?)",
+			g.UUID, m.UUID, at.Add(time.Duration(i)*time.Second)).Error
+		if err != nil {
+			t.Fatalf("falha ao adicionar o membro: %v", err)
+		}
+	}
+	return g
+}
+
+func userQuery(t *testing.T, filter string, startIndex, count int) Query {
+	t.Helper()
+	conditions, err := parseFilter(filter, userFilterAttrs)
+	if err != nil {
+		t.Fatalf("parseFilter(%q): %v", filter, err)
+	}
+	return Query{Conditions: conditions, StartIndex: startIndex, Count: count}
+}
+
+func TestRepositoryListUsersFilters(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+	users := seedUsers(t, db, tenantA, 4)
+	seedUsers(t, db, tenantB, 2)
+
+	tests := []struct {
+		name   string
+		filter string
+		want   []uuid.UUID
+	}{
+		{"sem filtro lista apenas o tenant", "", []uuid.UUID{users[0].UUID, users[1].UUID, users[2].UUID, users[3].UUID}},
+		{"userName sem diferenciar maiúsculas", fmt.Sprintf(`userName eq "%s"`, strings.ToUpper(users[2].Email)), []uuid.UUID{users[2].UUID}},
+		{"emails.value", fmt.Sprintf(`emails.value eq "%s"`, users[1].Email), []uuid.UUID{users[1].UUID}},
+		{"emails com prefixo do schema", fmt.Sprintf(`urn:ietf:params:scim:schemas:core:2.0:User:emails eq "%s"`, users[3].Email), []uuid.UUID{users[3].UUID}},
+		{"id", fmt.Sprintf(`id eq "%s"`, users[0].UUID), []uuid.UUID{users[0].UUID}},
+		{"id inválido não encontra", `id eq "nao-e-uuid"`, nil},
+		{"active", "active eq false", []uuid.UUID{users[1].UUID, users[3].UUID}},
+		{"and entre condições", fmt.Sprintf(`active eq true and userName eq "%s"`, users[1].Email), nil},
+		{"externalId não é guardado", `externalId eq "abc"`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, total, err := repo.ListUsers(ctx, tenantA, userQuery(t, tt.filter, 1, maxCount))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != int64(len(tt.want)) {
+				t.Fatalf("total = %d, esperado %d", total, len(tt.want))
+			}
+			assertUUIDs(t, userUUIDs(found), tt.want)
+		})
+	}
+
+	// Usuários de outro tenant não são encontrados nem pelo id
+	found, total, err := repo.ListUsers(ctx, tenantB, userQuery(t, fmt.Sprintf(`id eq "%s"`, users[0].UUID), 1, maxCount))
+	if err != nil || total != 0 || len(found) != 0 {
+		t.Fatalf("usuário de outro tenant listado: %v %d %v", found, total, err)
+	}
+}
+
+func TestRepositoryListUsersPagination(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+	users := seedUsers(t, db, tenantA, 5)
+	ids := userUUIDs(users)
+
+	tests := []struct {
+		name       string
+		startIndex int
+		count      int
+		want       []uuid.UUID
+	}{
+		{"primeira página", 1, 2, ids[0:2]},
+		{"segunda página", 3, 2, ids[2:4]},
+		{"última página incompleta", 5, 2, ids[4:5]},
+		{"startIndex além do total", 9, 2, nil},
+		{"startIndex menor que 1 vale 1", 0, 2, ids[0:2]},
+		{"count 0 retorna só o total", 1, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, total, err := repo.ListUsers(ctx, tenantA, Query{StartIndex: tt.startIndex, Count: tt.count})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if total != 5 {
+				t.Fatalf("total = %d, esperado 5", total)
+			}
+			assertUUIDs(t, userUUIDs(found), tt.want)
+		})
+	}
+}
+
+func TestRepositoryListGroupsAndMembers(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+	users := seedUsers(t, db, tenantA, 3)
+	admins := seedGroup(t, db, tenantA, "Admins", testEpoch, users[0], users[2])
+	sales := seedGroup(t, db, tenantA, "Vendas", testEpoch.Add(time.Hour), users[1])
+	empty := seedGroup(t, db, tenantA, "Vazio", testEpoch.Add(2*time.Hour))
+	seedGroup(t, db, tenantB, "Admins", testEpoch)
+
+	conditions, err := parseFilter(`displayName eq "admins"`, groupFilterAttrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, total, err := repo.ListGroups(ctx, tenantA, Query{Conditions: conditions, StartIndex: 1, Count: maxCount})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(found) != 1 || found[0].UUID != admins.UUID {
+		t.Fatalf("filtro por displayName = %v (total %d), esperado só %s", found, total, admins.UUID)
+	}
+
+	found, total, err = repo.ListGroups(ctx, tenantA, Query{StartIndex: 2, Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(found) != 1 || found[0].UUID != sales.UUID {
+		t.Fatalf("página 2 = %v (total %d), esperado %s", found, total, sales.UUID)
+	}
+
+	members, err := repo.Members(ctx, []uuid.UUID{admins.UUID, sales.UUID, empty.UUID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]uuid.UUID, 0, len(members[admins.UUID]))
+	for _, m := range members[admins.UUID] {
+		got = append(got, m.UserUUID)
+		if m.Email == "" || m.Name == "" {
+			t.Fatalf("membro sem nome ou email: %+v", m)
+		}
+	}
+	assertUUIDs(t, got, []uuid.UUID{users[0].UUID, users[2].UUID})
+	if len(members[sales.UUID]) != 1 || members[sales.UUID][0].UserUUID != users[1].UUID {
+		t.Fatalf("membros de Vendas = %+v", members[sales.UUID])
+	}
+	if len(members[empty.UUID]) != 0 {
+		t.Fatalf("grupo vazio com membros: %+v", members[empty.UUID])
+	}
+}
+
+func TestRepositoryTokens(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+	if err := db.Exec("INSERT INTO tenant (uuid, status) VALUES (?, ?)", tenantA, model.TenantStatusPastDue).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := repo.CreateToken(ctx, Token{TenantUUID: tenantA, TokenHash: hashToken("scim_1"), CreateAt: testEpoch}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := repo.CreateToken(ctx, Token{TenantUUID: tenantA, TokenHash: hashToken("scim_2"), CreateAt: testEpoch.Add(time.Hour)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// O novo token revoga o anterior
+	if _, err := repo.TokenByHash(ctx, hashToken("scim_1")); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("token revogado aceito: %v", err)
+	}
+	found, err := repo.TokenByHash(ctx, hashToken("scim_2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.UUID != second.UUID || found.TenantUUID != tenantA || found.Status != model.TenantStatusPastDue {
+		t.Fatalf("TokenByHash = %+v", found)
+	}
+	active, err := repo.ActiveToken(ctx, tenantA)
+	if err != nil || active.UUID != second.UUID || active.UUID == first.UUID {
+		t.Fatalf("ActiveToken = %+v, %v", active, err)
+	}
+
+	revoked, err := repo.RevokeTokens(ctx, tenantA)
+	if err != nil || revoked != 1 {
+		t.Fatalf("RevokeTokens = %d, %v", revoked, err)
+	}
+	if _, err := repo.ActiveToken(ctx, tenantA); !errors.Is(err, ErrTokenNotFound) {
+		t.Fatalf("ActiveToken após revogar: %v", err)
+	}
+}
+
+func userUUIDs(users []User) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.UUID)
+	}
+	return ids
+}
+
+func assertUUIDs(t *testing.T, got, want []uuid.UUID) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("obtidos %v, esperados %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("obtidos %v, esperados %v", got, want)
+		}
+	}
+}
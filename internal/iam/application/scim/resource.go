@@ -0,0 +1,280 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/group"
+	"tenant-crud-simply/internal/iam/domain/settings"
+
+	"github.com/google/uuid"
+)
+
+// URNs dos schemas e mensagens SCIM 2.0 (RFC 7643 e 7644).
+const (
+	schemaUser          = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup         = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaListResponse  = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatchOp       = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError         = "urn:ietf:params:scim:api:messages:2.0:Error"
+	schemaSPConfig      = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaResourceType  = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	contentType         = "application/scim+json; charset=utf-8"
+	resourceTypeUser    = "User"
+	resourceTypeGroup   = "Group"
+	multiValueTypeWork  = "work"
+	defaultCount        = 100
+	maxCount            = 100
+	maxMembersPerChange = 1000
+)
+
+type Meta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// MultiValue é um item de atributo multivalorado (emails, phoneNumbers).
+type MultiValue struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// UserResource é o recurso User. userName é o email de login do usuário. externalId e os
+// atributos de extensão são aceitos e ignorados.
+type UserResource struct {
+	Schemas      []string     `json:"schemas"`
+	ID           string       `json:"id,omitempty"`
+	UserName     string       `json:"userName"`
+	Name         *Name        `json:"name,omitempty"`
+	DisplayName  string       `json:"displayName,omitempty"`
+	Emails       []MultiValue `json:"emails,omitempty"`
+	PhoneNumbers []MultiValue `json:"phoneNumbers,omitempty"`
+	Locale       string       `json:"locale,omitempty"`
+	Timezone     string       `json:"timezone,omitempty"`
+	Active       *bool        `json:"active,omitempty"`
+	Password     string       `json:"password,omitempty"`
+	Meta         *Meta        `json:"meta,omitempty"`
+}
+
+// GroupMember é um membro do recurso Group: value é o id do usuário.
+type GroupMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type GroupResource struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []GroupMember `json:"members,omitempty"`
+	Meta        *Meta         `json:"meta,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int64    `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type ErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+// location monta a URL do recurso a partir da URL base do SCIM (ex.: https://host/scim/v2).
+func location(base, endpoint, id string) string {
+	return strings.TrimSuffix(base, "/") + "/" + endpoint + "/" + id
+}
+
+func toUserResource(u User, base string) UserResource {
+	active := u.Live
+	given, family := splitName(u.Name)
+	r := UserResource{
+		Schemas:     []string{schemaUser},
+		ID:          u.UUID.String(),
+		UserName:    u.Email,
+		Name:        &Name{Formatted: u.Name, GivenName: given, FamilyName: family},
+		DisplayName: u.Name,
+		Emails:      []MultiValue{{Value: u.Email, Type: multiValueTypeWork, Primary: true}},
+		Locale:      u.Locale,
+		Timezone:    u.Timezone,
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: resourceTypeUser,
+			Created:      u.CreateAt,
+			LastModified: u.UpdateAt,
+			Location:     location(base, "Users", u.UUID.String()),
+		},
+	}
+	if u.Phone != "" {
+		r.PhoneNumbers = []MultiValue{{Value: u.Phone, Type: multiValueTypeWork, Primary: true}}
+	}
+	return r
+}
+
+// splitName separa o nome no primeiro espaço (givenName e familyName). O cadastro guarda o
+// nome em um único campo.
+func splitName(name string) (string, string) {
+	given, family, _ := strings.Cut(strings.TrimSpace(name), " ")
+	return given, strings.TrimSpace(family)
+}
+
+// fromUserResource valida o recurso recebido em POST ou PUT e o converte no usuário. O nome
+// vem de name.formatted, de givenName + familyName, de displayName ou, por último, do userName.
+func fromUserResource(r UserResource) (User, error) {
+	email := strings.TrimSpace(r.UserName)
+	if email == "" {
+		return User{}, fmt.Errorf("%w: userName é obrigatório", ErrInvalidValue)
+	}
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return User{}, fmt.Errorf("%w: userName deve ser um email", ErrInvalidValue)
+	}
+
+	name := ""
+	if r.Name != nil {
+		name = strings.TrimSpace(r.Name.Formatted)
+		if name == "" {
+			name = strings.TrimSpace(r.Name.GivenName + " " + r.Name.FamilyName)
+		}
+	}
+	if name == "" {
+		name = strings.TrimSpace(r.DisplayName)
+	}
+	if name == "" {
+		name = email
+	}
+	if len(name) > 255 {
+		return User{}, fmt.Errorf("%w: o nome deve ter no máximo 255 caracteres", ErrInvalidValue)
+	}
+
+	u := User{
+		Name:     name,
+		Email:    email,
+		Phone:    primaryValue(r.PhoneNumbers),
+		Locale:   strings.TrimSpace(r.Locale),
+		Timezone: strings.TrimSpace(r.Timezone),
+		Live:     r.Active == nil || *r.Active,
+		Password: r.Password,
+	}
+	if len(u.Phone) > 30 {
+		return User{}, fmt.Errorf("%w: o telefone deve ter no máximo 30 caracteres", ErrInvalidValue)
+	}
+	// Locale e timezone seguem as regras das configurações do tenant. O locale pode vir como en_US
+	u.Locale = strings.ReplaceAll(u.Locale, "_", "-")
+	for key, value := range map[string]string{settings.KeyLocale: u.Locale, settings.KeyTimezone: u.Timezone} {
+		if value == "" {
+			continue
+		}
+		def, ok := settings.Lookup(key)
+		if !ok {
+			continue
+		}
+		raw, _ := json.Marshal(value)
+		if err := def.Check(raw); err != nil {
+			return User{}, fmt.Errorf("%w: %v", ErrInvalidValue, err)
+		}
+	}
+	return u, nil
+}
+
+// patchedName escolhe o nome após um PATCH, comparando o recurso alterado com o original:
+// vale o primeiro atributo de nome alterado (formatted, givenName/familyName, displayName).
+func patchedName(before, after UserResource) string {
+	var b, a Name
+	if before.Name != nil {
+		b = *before.Name
+	}
+	if after.Name != nil {
+		a = *after.Name
+	}
+	switch {
+	case a.Formatted != b.Formatted && strings.TrimSpace(a.Formatted) != "":
+		return strings.TrimSpace(a.Formatted)
+	case a.GivenName != b.GivenName || a.FamilyName != b.FamilyName:
+		if name := strings.TrimSpace(a.GivenName + " " + a.FamilyName); name != "" {
+			return name
+		}
+	case after.DisplayName != before.DisplayName && strings.TrimSpace(after.DisplayName) != "":
+		return strings.TrimSpace(after.DisplayName)
+	}
+	return b.Formatted
+}
+
+// primaryValue retorna o valor marcado como primary ou, sem ele, o primeiro.
+func primaryValue(values []MultiValue) string {
+	for _, v := range values {
+		if v.Primary {
+			return strings.TrimSpace(v.Value)
+		}
+	}
+	if len(values) > 0 {
+		return strings.TrimSpace(values[0].Value)
+	}
+	return ""
+}
+
+func toGroupResource(g Group, base string) GroupResource {
+	r := GroupResource{
+		Schemas:     []string{schemaGroup},
+		ID:          g.UUID.String(),
+		DisplayName: g.Name,
+		Meta: &Meta{
+			ResourceType: resourceTypeGroup,
+			Created:      g.CreateAt,
+			LastModified: g.UpdateAt,
+			Location:     location(base, "Groups", g.UUID.String()),
+		},
+	}
+	for _, m := range g.Members {
+		r.Members = append(r.Members, GroupMember{
+			Value:   m.UserUUID.String(),
+			Display: m.Name,
+			Ref:     location(base, "Users", m.UserUUID.String()),
+		})
+	}
+	return r
+}
+
+// fromGroupResource valida o recurso recebido em POST, PUT ou após um PATCH. Membros repetidos
+// são considerados uma única vez. O limite de membros por requisição é verificado pelo controller.
+func fromGroupResource(r GroupResource) (Group, error) {
+	name := strings.TrimSpace(r.DisplayName)
+	if name == "" {
+		return Group{}, fmt.Errorf("%w: displayName é obrigatório", ErrInvalidValue)
+	}
+	if len(name) > 255 {
+		return Group{}, fmt.Errorf("%w: displayName deve ter no máximo 255 caracteres", ErrInvalidValue)
+	}
+
+	g := Group{Members: []group.Member{}}
+	g.Name = name
+	seen := map[uuid.UUID]bool{}
+	for _, m := range r.Members {
+		id, err := uuid.Parse(strings.TrimSpace(m.Value))
+		if err != nil {
+			return Group{}, fmt.Errorf("%w: membro '%s' não é um id de usuário", ErrInvalidValue, m.Value)
+		}
+		if !seen[id] {
+			seen[id] = true
+			g.Members = append(g.Members, group.Member{UserUUID: id, Name: m.Display})
+		}
+	}
+	return g, nil
+}
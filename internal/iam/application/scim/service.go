@@ -0,0 +1,424 @@
+package scim
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"tenant-crud-simply/internal/iam/application/auth"
+	"tenant-crud-simply/internal/iam/domain/group"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/plan"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/domain/user"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/listing"
+
+	"github.com/google/uuid"
+)
+
+// tokenPrefix identifica os tokens SCIM (e os distingue dos tokens de sessão).
+const tokenPrefix = "scim_"
+
+// Service é a camada usada pelo controller SCIM. Todas as operações recebem o tenant do token
+// e tratam recursos de outros tenants como inexistentes.
+type Service interface {
+	// Authenticate valida o bearer token e a origem da requisição (IPs permitidos do tenant). Com
+	// middleware.ErrIPNotAllowed, o cliente também é retornado.
+	Authenticate(ctx context.Context, token, clientIP string) (Client, error)
+	// IssueToken emite um novo token para o tenant, revogando os anteriores, se os tokens ativos
+	// não atingiram o limite de chaves de API do plano (*plan.QuotaError). O valor retornado
+	// não é gravado e não pode ser consultado depois.
+	IssueToken(ctx context.Context, tenantUUID uuid.UUID, createdBy *uuid.UUID) (Token, string, error)
+	ActiveToken(ctx context.Context, tenantUUID uuid.UUID) (Token, error)
+	RevokeTokens(ctx context.Context, tenantUUID uuid.UUID) error
+
+	ListUsers(ctx context.Context, tenantUUID uuid.UUID, q Query) ([]User, int64, error)
+	GetUser(ctx context.Context, tenantUUID, userUUID uuid.UUID) (User, error)
+	CreateUser(ctx context.Context, tenantUUID uuid.UUID, u User) (User, error)
+	// ReplaceUser grava o estado completo do usuário (PUT e PATCH): nome, email, senha (se
+	// informada), ativo, telefone, idioma e fuso horário.
+	ReplaceUser(ctx context.Context, tenantUUID uuid.UUID, u User) (User, error)
+	DeleteUser(ctx context.Context, tenantUUID, userUUID uuid.UUID) error
+
+	ListGroups(ctx context.Context, tenantUUID uuid.UUID, q Query, withMembers bool) ([]Group, int64, error)
+	GetGroup(ctx context.Context, tenantUUID, groupUUID uuid.UUID, withMembers bool) (Group, error)
+	CreateGroup(ctx context.Context, tenantUUID uuid.UUID, g Group) (Group, error)
+	// ReplaceGroup grava o nome e o conjunto completo de membros do grupo.
+	ReplaceGroup(ctx context.Context, tenantUUID uuid.UUID, g Group) (Group, error)
+	DeleteGroup(ctx context.Context, tenantUUID, groupUUID uuid.UUID) error
+}
+
+type serviceImpl struct {
+	Repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &serviceImpl{Repository: repository}
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *serviceImpl) Authenticate(ctx context.Context, token, clientIP string) (Client, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return Client{}, ErrUnauthorized
+	}
+	found, err := s.Repository.TokenByHash(ctx, hashToken(token))
+	if err != nil {
+		return Client{}, err
+	}
+	if found.DeletedAt != nil || !found.Status.AllowsAccess() {
+		return Client{}, ErrTenantBlocked
+	}
+	// Mesma lista de IPs permitidos dos usuários do tenant
+	client := Client{TokenUUID: found.UUID, TenantUUID: found.TenantUUID, ReadOnly: found.Status.ReadOnly()}
+	if err := middleware.CheckClientIP(ctx, model.User{TenantUUID: &found.TenantUUID, Role: model.RoleTenantUser}, clientIP); err != nil {
+		// O cliente é retornado para que a tentativa negada seja registrada no access_log do tenant
+		return client, err
+	}
+
+	if err := s.Repository.TouchToken(ctx, found.UUID, time.Now().UTC()); err != nil {
+		log.Printf("[SCIM] falha ao registrar o uso do token %s: %v", found.UUID, err)
+	}
+	return client, nil
+}
+
+func (s *serviceImpl) IssueToken(ctx context.Context, tenantUUID uuid.UUID, createdBy *uuid.UUID) (Token, string, error) {
+	if _, err := tenant.MustUse().Service.Read(ctx, model.Tenant{UUID: tenantUUID}); err != nil {
+		if errors.Is(err, tenant.ErrNotFound) {
+			return Token{}, "", ErrTenantNotFound
+		}
+		return Token{}, "", err
+	}
+
+	// O token SCIM é a chave de API do tenant: as chaves ativas são contadas na transação da
+	// emissão, antes de o novo token revogar os anteriores
+	plans := plan.MustUse().Service
+	tenantPlan, err := plans.PlanFor(ctx, tenantUUID)
+	if err != nil {
+		return Token{}, "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return Token{}, "", err
+	}
+	value := tokenPrefix + hex.EncodeToString(secret)
+	token, err := s.Repository.CreateToken(ctx, Token{
+		TenantUUID: tenantUUID,
+		TokenHash:  hashToken(value),
+		CreatedBy:  createdBy,
+		CreateAt:   time.Now().UTC(),
+	}, func(keys int64) error {
+		return plan.CheckQuota(tenantPlan, plan.MetricAPIKeys, keys)
+	})
+	if err != nil {
+		return Token{}, "", err
+	}
+	plans.NotifyUsage(ctx, tenantUUID, plan.MetricAPIKeys, 1)
+	return token, value, nil
+}
+
+func (s *serviceImpl) ActiveToken(ctx context.Context, tenantUUID uuid.UUID) (Token, error) {
+	return s.Repository.ActiveToken(ctx, tenantUUID)
+}
+
+func (s *serviceImpl) RevokeTokens(ctx context.Context, tenantUUID uuid.UUID) error {
+	revoked, err := s.Repository.RevokeTokens(ctx, tenantUUID)
+	if err != nil {
+		return err
+	}
+	if revoked == 0 {
+		return ErrTokenNotFound
+	}
+	return nil
+}
+
+func (s *serviceImpl) ListUsers(ctx context.Context, tenantUUID uuid.UUID, q Query) ([]User, int64, error) {
+	return s.Repository.ListUsers(ctx, tenantUUID, q)
+}
+
+func (s *serviceImpl) GetUser(ctx context.Context, tenantUUID, userUUID uuid.UUID) (User, error) {
+	u, err := user.MustUse().Service.Read(ctx, user.User{UUID: userUUID})
+	if err != nil {
+		return User{}, userError(err)
+	}
+	if u.TenantUUID == nil || *u.TenantUUID != tenantUUID {
+		return User{}, ErrNotFound
+	}
+	return u, nil
+}
+
+// userError traduz os erros do domínio de usuários para os erros do protocolo.
+func userError(err error) error {
+	switch {
+	case errors.Is(err, user.ErrNotFound):
+		return ErrNotFound
+	case errors.Is(err, user.ErrEmailDuplicated):
+		return fmt.Errorf("%w: userName já cadastrado", ErrUniqueness)
+	case errors.Is(err, user.ErrEmailDomain):
+		return fmt.Errorf("%w: domínio de email não permitido para o tenant", ErrInvalidValue)
+	case errors.Is(err, user.ErrInvalidInput), errors.Is(err, tenant.ErrInvalidMetadata):
+		return fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	case errors.Is(err, plan.ErrQuotaExceeded):
+		return fmt.Errorf("%w: %v", ErrQuotaExceeded, err)
+	default:
+		return err
+	}
+}
+
+func (s *serviceImpl) CreateUser(ctx context.Context, tenantUUID uuid.UUID, u User) (User, error) {
+	password := u.Password
+	if password == "" {
+		// Segredo descartado: o usuário define a senha pela redefinição de senha (OTP)
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return User{}, err
+		}
+		password = hex.EncodeToString(secret)
+	}
+
+	// Usuários provisionados entram como TENANT_USER; o perfil é alterado pela API administrativa
+	created, err := user.MustUse().Service.Create(ctx, user.User{
+		Tenant:   tenant.Tenant{UUID: tenantUUID},
+		Name:     u.Name,
+		Email:    u.Email,
+		Password: password,
+		Role:     model.RoleTenantUser,
+		Live:     u.Live,
+	})
+	if err != nil {
+		return User{}, userError(err)
+	}
+	if u.Phone == "" && u.Locale == "" && u.Timezone == "" {
+		return created, nil
+	}
+	updated, err := user.MustUse().Service.UpdateProfile(ctx, created.UUID, user.Profile{
+		Phone:    &u.Phone,
+		Locale:   &u.Locale,
+		Timezone: &u.Timezone,
+	})
+	return updated, userError(err)
+}
+
+func (s *serviceImpl) ReplaceUser(ctx context.Context, tenantUUID uuid.UUID, u User) (User, error) {
+	current, err := s.GetUser(ctx, tenantUUID, u.UUID)
+	if err != nil {
+		return User{}, err
+	}
+	users := user.MustUse().Service
+
+	update := user.User{UUID: current.UUID, Tenant: tenant.Tenant{UUID: tenantUUID}}
+	if u.Name != current.Name {
+		update.Name = u.Name
+	}
+	if u.Email != current.Email {
+		update.Email = u.Email
+	}
+	update.Password = u.Password
+	if update.Name != "" || update.Email != "" || update.Password != "" {
+		if _, err := users.Update(ctx, update); err != nil {
+			return User{}, userError(err)
+		}
+	}
+
+	if u.Live != current.Live {
+		if !u.Live {
+			if err := s.checkLastAdmin(ctx, current); err != nil {
+				return User{}, err
+			}
+		}
+		if _, err := users.SetLive(ctx, current.UUID, u.Live); err != nil {
+			return User{}, userError(err)
+		}
+		if !u.Live {
+			s.revokeSessions(ctx, current.UUID)
+		}
+	}
+
+	var profile user.Profile
+	if u.Phone != current.Phone {
+		profile.Phone = &u.Phone
+	}
+	if u.Locale != current.Locale {
+		profile.Locale = &u.Locale
+	}
+	if u.Timezone != current.Timezone {
+		profile.Timezone = &u.Timezone
+	}
+	if profile.Phone != nil || profile.Locale != nil || profile.Timezone != nil {
+		if _, err := users.UpdateProfile(ctx, current.UUID, profile); err != nil {
+			return User{}, userError(err)
+		}
+	}
+	return s.GetUser(ctx, tenantUUID, current.UUID)
+}
+
+// checkLastAdmin impede a exclusão ou desativação do último administrador ativo com o mesmo perfil.
+func (s *serviceImpl) checkLastAdmin(ctx context.Context, u User) error {
+	if u.Role != model.RoleTenantAdmin && u.Role != model.RolePartnerAdmin || !u.Live {
+		return nil
+	}
+	live := true
+	admins, err := user.MustUse().Service.ListByTenant(ctx, tenant.Tenant{UUID: *u.TenantUUID}, listing.Options{
+		Filters: listing.Filters{Role: string(u.Role), Live: &live},
+		Size:    1,
+	})
+	if err != nil {
+		return err
+	}
+	if admins.Total <= 1 {
+		return ErrLastAdmin
+	}
+	return nil
+}
+
+func (s *serviceImpl) revokeSessions(ctx context.Context, userUUID uuid.UUID) {
+	if err := auth.MustUse().Service.RevokeOtherSessions(ctx, userUUID, ""); err != nil {
+		log.Printf("[SCIM] falha ao encerrar as sessões do usuário %s: %v", userUUID, err)
+	}
+}
+
+func (s *serviceImpl) DeleteUser(ctx context.Context, tenantUUID, userUUID uuid.UUID) error {
+	current, err := s.GetUser(ctx, tenantUUID, userUUID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkLastAdmin(ctx, current); err != nil {
+		return err
+	}
+	if err := user.MustUse().Service.Delete(ctx, user.User{UUID: userUUID}); err != nil {
+		return userError(err)
+	}
+	s.revokeSessions(ctx, userUUID)
+	return nil
+}
+
+func (s *serviceImpl) ListGroups(ctx context.Context, tenantUUID uuid.UUID, q Query, withMembers bool) ([]Group, int64, error) {
+	found, total, err := s.Repository.ListGroups(ctx, tenantUUID, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	groups := make([]Group, 0, len(found))
+	ids := make([]uuid.UUID, 0, len(found))
+	for _, g := range found {
+		groups = append(groups, Group{Group: g})
+		ids = append(ids, g.UUID)
+	}
+	if !withMembers {
+		return groups, total, nil
+	}
+	members, err := s.Repository.Members(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range groups {
+		groups[i].Members = members[groups[i].UUID]
+	}
+	return groups, total, nil
+}
+
+func (s *serviceImpl) GetGroup(ctx context.Context, tenantUUID, groupUUID uuid.UUID, withMembers bool) (Group, error) {
+	g, err := group.MustUse().Service.Read(ctx, tenantUUID, groupUUID)
+	if err != nil {
+		return Group{}, groupError(err)
+	}
+	result := Group{Group: g}
+	if !withMembers {
+		return result, nil
+	}
+	members, err := s.Repository.Members(ctx, []uuid.UUID{g.UUID})
+	if err != nil {
+		return Group{}, err
+	}
+	result.Members = members[g.UUID]
+	return result, nil
+}
+
+// groupError traduz os erros do domínio de grupos para os erros do protocolo.
+func groupError(err error) error {
+	switch {
+	case errors.Is(err, group.ErrNotFound):
+		return ErrNotFound
+	case errors.Is(err, group.ErrNameDuplicated):
+		return fmt.Errorf("%w: displayName já cadastrado", ErrUniqueness)
+	case errors.Is(err, group.ErrInvalidInput):
+		return fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	case errors.Is(err, group.ErrUserOutsideTenant), errors.Is(err, user.ErrNotFound):
+		return fmt.Errorf("%w: membro não encontrado no tenant", ErrInvalidValue)
+	default:
+		return err
+	}
+}
+
+func (s *serviceImpl) CreateGroup(ctx context.Context, tenantUUID uuid.UUID, g Group) (Group, error) {
+	created, err := group.MustUse().Service.Create(ctx, model.Group{TenantUUID: tenantUUID, Name: g.Name})
+	if err != nil {
+		return Group{}, groupError(err)
+	}
+	if err := s.syncMembers(ctx, tenantUUID, created.UUID, nil, g.Members); err != nil {
+		return Group{}, err
+	}
+	return s.GetGroup(ctx, tenantUUID, created.UUID, true)
+}
+
+func (s *serviceImpl) ReplaceGroup(ctx context.Context, tenantUUID uuid.UUID, g Group) (Group, error) {
+	current, err := s.GetGroup(ctx, tenantUUID, g.UUID, true)
+	if err != nil {
+		return Group{}, err
+	}
+	if g.Name != current.Name {
+		update := current.Group
+		update.Name = g.Name
+		update.UpdateAt = time.Now().UTC()
+		if _, err := group.MustUse().Service.Update(ctx, update); err != nil {
+			return Group{}, groupError(err)
+		}
+	}
+	if err := s.syncMembers(ctx, tenantUUID, g.UUID, current.Members, g.Members); err != nil {
+		return Group{}, err
+	}
+	return s.GetGroup(ctx, tenantUUID, g.UUID, true)
+}
+
+// syncMembers adiciona e remove membros até que o grupo tenha exatamente os membros desejados.
+func (s *serviceImpl) syncMembers(ctx context.Context, tenantUUID, groupUUID uuid.UUID, current, desired []group.Member) error {
+	groups := group.MustUse().Service
+	keep := make(map[uuid.UUID]bool, len(desired))
+	for _, m := range desired {
+		keep[m.UserUUID] = true
+	}
+	existing := make(map[uuid.UUID]bool, len(current))
+	for _, m := range current {
+		existing[m.UserUUID] = true
+		if keep[m.UserUUID] {
+			continue
+		}
+		if err := groups.RemoveMember(ctx, tenantUUID, groupUUID, m.UserUUID); err != nil && !errors.Is(err, group.ErrMemberNotFound) {
+			return groupError(err)
+		}
+	}
+	for _, m := range desired {
+		if existing[m.UserUUID] {
+			continue
+		}
+		err := groups.AddMember(ctx, tenantUUID, groupUUID, user.User{UUID: m.UserUUID})
+		if err != nil && !errors.Is(err, group.ErrMemberDuplicated) {
+			return groupError(err)
+		}
+	}
+	return nil
+}
+
+func (s *serviceImpl) DeleteGroup(ctx context.Context, tenantUUID, groupUUID uuid.UUID) error {
+	return groupError(group.MustUse().Service.Delete(ctx, tenantUUID, groupUUID))
+}
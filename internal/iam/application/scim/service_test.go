@@ -0,0 +1,202 @@
+package scim
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/plan"
+	"tenant-crud-simply/internal/iam/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// O Service real sobre o repositório real (SQLite em memória). Os métodos que delegam aos
+// serviços de user, group e tenant ficam com a suíte de conformidade.
+
+func seedToken(t *testing.T, db *gorm.DB, tenantUUID uuid.UUID, status model.TenantStatus, deleted bool, value string) {
+	t.Helper()
+	var deletedAt *time.Time
+	if deleted {
+		deletedAt = &testEpoch
+	}
+	if err := db.Exec("INSERT INTO tenant (uuid, status, deleted_at) VALUES (?, ?, ?)", tenantUUID, status, deletedAt).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewRepository(db).CreateToken(context.Background(), Token{TenantUUID: tenantUUID, TokenHash: hashToken(value), CreateAt: testEpoch}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServiceAuthenticate(t *testing.T) {
+	db := newTestDB(t)
+	svc := NewService(NewRepository(db))
+	ctx := context.Background()
+
+	active, pastDue, suspended, deleted := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	seedToken(t, db, active, model.TenantStatusActive, false, "scim_active")
+	seedToken(t, db, pastDue, model.TenantStatusPastDue, false, "scim_pastdue")
+	seedToken(t, db, suspended, model.TenantStatusSuspended, false, "scim_suspended")
+	seedToken(t, db, deleted, model.TenantStatusActive, true, "scim_deleted")
+
+	tests := []struct {
+		name     string
+		token    string
+		tenant   uuid.UUID
+		readOnly bool
+		err      error
+	}{
+		{name: "ativo", token: "scim_active", tenant: active},
+		{name: "inadimplente é somente leitura", token: "scim_pastdue", tenant: pastDue, readOnly: true},
+		{name: "suspenso", token: "scim_suspended", err: ErrTenantBlocked},
+		{name: "tenant removido", token: "scim_deleted", err: ErrTenantBlocked},
+		{name: "sem o prefixo", token: "active", err: ErrUnauthorized},
+		{name: "desconhecido", token: "scim_outro", err: ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := svc.Authenticate(ctx, tt.token, "203.0.113.10")
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("erro = %v, esperado %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if client.TenantUUID != tt.tenant || client.ReadOnly != tt.readOnly {
+				t.Fatalf("cliente = %+v", client)
+			}
+		})
+	}
+
+	// O uso do token é registrado
+	var lastUsed *time.Time
+	if err := db.Raw("SELECT last_used_at FROM scim_tokens WHERE tenant_uuid = ?", active).Scan(&lastUsed).Error; err != nil {
+		t.Fatal(err)
+	}
+	if lastUsed == nil {
+		t.Fatal("last_used_at não registrado na autenticação")
+	}
+}
+
+func TestServiceRevokeTokens(t *testing.T) {
+	db := newTestDB(t)
+	svc := NewService(NewRepository(db))
+	ctx := context.Background()
+	seedToken(t, db, tenantA, model.TenantStatusActive, false, "scim_a")
+
+	if err := svc.RevokeTokens(ctx, tenantA); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RevokeTokens(ctx, tenantA); !errors.Is(err, ErrTokenNotFound) {
+		t.Fatalf("segunda revogação = %v, esperado ErrTokenNotFound", err)
+	}
+	if _, err := svc.Authenticate(ctx, "scim_a", ""); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("token revogado autenticado: %v", err)
+	}
+}
+
+func TestServiceListGroupsMembers(t *testing.T) {
+	db := newTestDB(t)
+	svc := NewService(NewRepository(db))
+	ctx := context.Background()
+	users := seedUsers(t, db, tenantA, 3)
+	admins := seedGroup(t, db, tenantA, "Admins", testEpoch, users[1], users[0])
+	empty := seedGroup(t, db, tenantA, "Vazio", testEpoch.Add(time.Hour))
+
+	groups, total, err := svc.ListGroups(ctx, tenantA, Query{StartIndex: 1, Count: maxCount}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(groups) != 2 || groups[0].UUID != admins.UUID || groups[1].UUID != empty.UUID {
+		t.Fatalf("grupos = %+v (total %d)", groups, total)
+	}
+	// Membros na ordem de entrada no grupo, com nome e email do usuário
+	got := groups[0].Members
+	if len(got) != 2 || got[0].UserUUID != users[1].UUID || got[1].UserUUID != users[0].UUID {
+		t.Fatalf("membros = %+v", got)
+	}
+	if got[0].Name != users[1].Name || got[0].Email != users[1].Email || got[0].JoinedAt.IsZero() {
+		t.Fatalf("membro sem os dados do usuário: %+v", got[0])
+	}
+	if len(groups[1].Members) != 0 {
+		t.Fatalf("grupo vazio com membros: %+v", groups[1].Members)
+	}
+	resource := toGroupResource(groups[0], "https://example.com/scim/v2")
+	if len(resource.Members) != 2 || resource.Members[0].Value != users[1].UUID.String() || resource.Members[0].Display != users[1].Name {
+		t.Fatalf("recurso = %+v", resource.Members)
+	}
+
+	// Com excludedAttributes=members a consulta de membros não é feita
+	groups, _, err = svc.ListGroups(ctx, tenantA, Query{StartIndex: 1, Count: maxCount}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if groups[0].Members != nil {
+		t.Fatalf("membros carregados sem withMembers: %+v", groups[0].Members)
+	}
+}
+
+// limitedTokens emite os tokens como o Service, pelo repositório real, com o limite de chaves de
+// API do plano.
+type limitedTokens struct {
+	*fakeService
+	repo Repository
+	plan plan.Plan
+}
+
+func (l *limitedTokens) IssueToken(ctx context.Context, tenantUUID uuid.UUID, createdBy *uuid.UUID) (Token, string, error) {
+	value := tokenPrefix + uuid.NewString()
+	token, err := l.repo.CreateToken(ctx, Token{TenantUUID: tenantUUID, TokenHash: hashToken(value), CreatedBy: createdBy, CreateAt: testEpoch},
+		func(keys int64) error { return plan.CheckQuota(l.plan, plan.MetricAPIKeys, keys) })
+	return token, value, err
+}
+
+func TestIssueTokenPlanLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := newTestDB(t)
+	repo := NewRepository(db)
+	if err := db.Exec("INSERT INTO tenant (uuid, status) VALUES (?, ?)", tenantA, model.TenantStatusActive).Error; err != nil {
+		t.Fatal(err)
+	}
+	maxKeys := int64(1)
+	ctrl := NewController(&limitedTokens{fakeService: newFakeService(), repo: repo, plan: plan.Plan{Code: "starter", MaxAPIKeys: &maxKeys}}, nil).(*controllerImpl)
+	router := gin.New()
+	router.POST("/api/tenant/:uuid/scim/token", func(c *gin.Context) {
+		middleware.SetAuthenticatedUser(c, &middleware.Login{
+			User: model.User{UUID: uuid.New(), TenantUUID: &tenantA, Role: model.RoleTenantAdmin},
+		})
+	}, ctrl.IssueToken)
+
+	issue := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/tenant/"+tenantA.String()+"/scim/token", nil))
+		return w
+	}
+
+	if w := issue(); w.Code != http.StatusCreated {
+		t.Fatalf("primeira emissão: status = %d, esperado 201: %s", w.Code, w.Body.String())
+	}
+	// A chave ativa ocupa o limite do plano: a nova emissão é recusada e o token atual segue válido
+	if w := issue(); w.Code != http.StatusPaymentRequired {
+		t.Fatalf("emissão acima do limite: status = %d, esperado 402: %s", w.Code, w.Body.String())
+	}
+	if _, err := repo.ActiveToken(context.Background(), tenantA); err != nil {
+		t.Fatalf("token ativo revogado pela emissão recusada: %v", err)
+	}
+
+	if _, err := repo.RevokeTokens(context.Background(), tenantA); err != nil {
+		t.Fatal(err)
+	}
+	if w := issue(); w.Code != http.StatusCreated {
+		t.Fatalf("emissão após revogar: status = %d, esperado 201: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,65 @@
+package scim
+
+import (
+	"errors"
+	"sync"
+
+	"tenant-crud-simply/internal/iam/middleware"
+
+	"gorm.io/gorm"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	repositoryInstance Repository
+	once               sync.Once
+	initErr            error
+	ErrNotInitialized  = errors.New("scim controller not initialized")
+)
+
+// UseScim agrupa todas as camadas (Repository, Service, Controller)
+type UseScim struct {
+	Repository Repository
+	Service    Service
+	Controller Controller
+}
+
+// New inicializa o singleton do controller SCIM com todas as suas dependências
+func New(db *gorm.DB) (Controller, error) {
+	once.Do(func() {
+		if db == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+
+		// Inicializa as dependências em camadas
+		repositoryInstance = NewRepository(db)
+		serviceInstance = NewService(repositoryInstance)
+		controllerInstance = NewController(serviceInstance, middleware.MustUse().Middleware)
+	})
+
+	return controllerInstance, initErr
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Repository, Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UseScim {
+	if controllerInstance == nil || serviceInstance == nil || repositoryInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UseScim{
+		Repository: repositoryInstance,
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
@@ -0,0 +1,145 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	Search(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID *uuid.UUID
+		userUUID   *uuid.UUID
+		identifier string
+		rayTrace   string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:   tenantUUID,
+		UserUUID:     userUUID,
+		Identifier:   identifier,
+		RayTraceCode: rayTrace,
+		Domain:       "search",
+		Action:       action,
+		Function:     function,
+		Success:      success,
+		InputData:    auditoria_log.SerializeData(input),
+		OutputData:   auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	routes.GET("/search", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Search)
+}
+
+// @Summary      Busca tenants e usuários
+// @Description  Busca aproximada (trigramas) e por palavras em nome e documento de tenants e em nome e email de usuários. Os resultados vêm agrupados por tipo, ordenados por relevância (score), com o trecho encontrado destacado entre <mark> e </mark>. SYSTEM_ADMIN pesquisa todos os tenants; PARTNER_ADMIN, o próprio tenant e seus descendentes; TENANT_ADMIN, apenas o próprio tenant.
+// @Tags         Search
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q      query  string  true   "Termo de busca (2 a 100 caracteres)"
+// @Param        types  query  string  false  "Tipos pesquisados, separados por vírgula: tenant, user (padrão: todos)"
+// @Param        limit  query  int     false  "Resultados por tipo (padrão 10, máximo 50)"
+// @Success      200  {object}  SearchResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/search [get]
+func (ctrl *controllerImpl) Search(c *gin.Context) {
+	var req SearchRequestDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O parâmetro 'q' é obrigatório.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	var types []Type
+	for _, t := range strings.Split(req.Types, ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			types = append(types, Type(t))
+		}
+	}
+
+	var scope Scope
+	switch ctxIdentify.User.Role {
+	case model.RoleSystemAdmin:
+	case model.RolePartnerAdmin, model.RoleTenantAdmin:
+		if ctxIdentify.User.TenantUUID == nil {
+			e := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+			c.AbortWithStatusJSON(e.Code, e)
+			return
+		}
+		scope = Scope{
+			TenantUUID:  ctxIdentify.User.TenantUUID,
+			Descendants: ctxIdentify.User.Role == model.RolePartnerAdmin,
+		}
+	default:
+		e := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Ação não permitida.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	results, err := ctrl.Service.Search(c.Request.Context(), Request{
+		Text:  req.Q,
+		Types: types,
+		Limit: req.Limit,
+		Scope: scope,
+	})
+	if err != nil {
+		var restError *rest_err.RestErr
+		switch {
+		case errors.Is(err, ErrQueryLength), errors.Is(err, ErrInvalidInput):
+			restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
+		case errors.Is(err, ErrInvalidType):
+			restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "O 'types' aceita apenas tenant e user.")
+		default:
+			restError = rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao realizar a busca", nil)
+		}
+		ctrl.logAudit(c, ctxIdentify, "search", "Search", false, req, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSearchResponse(strings.Join(strings.Fields(req.Q), " "), types, results))
+}
@@ -0,0 +1,8 @@
+package search
+
+type SearchRequestDto struct {
+	Q string `form:"q" binding:"required"`
+	// Types separados por vírgula (tenant,user). Vazio = todos
+	Types string `form:"types"`
+	Limit int    `form:"limit"`
+}
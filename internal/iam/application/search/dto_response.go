@@ -0,0 +1,74 @@
+package search
+
+import (
+	"tenant-crud-simply/internal/pkg/document"
+
+	"github.com/google/uuid"
+)
+
+type HighlightDto struct {
+	Field    string `json:"field"`
+	Fragment string `json:"fragment"` // HTML escapado, termos entre <mark> e </mark>
+}
+
+type ResultDto struct {
+	UUID       uuid.UUID    `json:"uuid"`
+	Title      string       `json:"title"`    // Nome do tenant ou do usuário
+	Subtitle   string       `json:"subtitle"` // Documento formatado ou email
+	Status     string       `json:"status,omitempty"`
+	Role       string       `json:"role,omitempty"`
+	TenantUUID *uuid.UUID   `json:"tenant_uuid,omitempty"`
+	TenantName *string      `json:"tenant_name,omitempty"`
+	Score      float64      `json:"score"`
+	Highlight  HighlightDto `json:"highlight"`
+}
+
+type GroupDto struct {
+	Type    Type        `json:"type"`
+	Count   int         `json:"count"`
+	Results []ResultDto `json:"results"`
+}
+
+type SearchResponseDto struct {
+	Query  string     `json:"query"`
+	Groups []GroupDto `json:"groups"`
+}
+
+func toSearchResponse(text string, types []Type, r Results) SearchResponseDto {
+	if len(types) == 0 {
+		types = AllTypes
+	}
+	resp := SearchResponseDto{Query: text, Groups: make([]GroupDto, 0, len(types))}
+	for _, t := range types {
+		group := GroupDto{Type: t, Results: []ResultDto{}}
+		switch t {
+		case TypeTenant:
+			for _, h := range r.Tenants {
+				group.Results = append(group.Results, ResultDto{
+					UUID:      h.UUID,
+					Title:     h.Name,
+					Subtitle:  document.Format(h.DocumentType, h.Document),
+					Status:    string(h.Status),
+					Score:     h.Score,
+					Highlight: HighlightDto{Field: h.Highlight.Field, Fragment: h.Highlight.Fragment},
+				})
+			}
+		case TypeUser:
+			for _, h := range r.Users {
+				group.Results = append(group.Results, ResultDto{
+					UUID:       h.UUID,
+					Title:      h.Name,
+					Subtitle:   h.Email,
+					Role:       string(h.Role),
+					TenantUUID: h.TenantUUID,
+					TenantName: h.TenantName,
+					Score:      h.Score,
+					Highlight:  HighlightDto{Field: h.Highlight.Field, Fragment: h.Highlight.Fragment},
+				})
+			}
+		}
+		group.Count = len(group.Results)
+		resp.Groups = append(resp.Groups, group)
+	}
+	return resp
+}
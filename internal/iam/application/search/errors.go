@@ -0,0 +1,10 @@
+package search
+
+import "errors"
+
+var (
+	ErrInvalidInput = errors.New("invalid input data")
+	// ErrQueryLength indica um termo de busca curto ou longo demais.
+	ErrQueryLength = errors.New("search query length out of range")
+	ErrInvalidType = errors.New("invalid search type")
+)
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/pkg/document"
+
+	"github.com/google/uuid"
+)
+
+// Type é o tipo de registro pesquisado; os resultados são agrupados por ele.
+type Type string
+
+const (
+	TypeTenant Type = "tenant"
+	TypeUser   Type = "user"
+)
+
+// AllTypes é a ordem dos grupos na resposta.
+var AllTypes = []Type{TypeTenant, TypeUser}
+
+const (
+	MinQueryLength = 2
+	MaxQueryLength = 100
+	DefaultLimit   = 10
+	MaxLimit       = 50
+)
+
+// Scope limita os tenants visíveis para quem pesquisa.
+type Scope struct {
+	// TenantUUID nil = todos os tenants (SYSTEM_ADMIN).
+	TenantUUID *uuid.UUID
+	// Descendants inclui os descendentes de TenantUUID (PARTNER_ADMIN).
+	Descendants bool
+}
+
+// Request é uma busca. Types vazio pesquisa todos os tipos.
+type Request struct {
+	Text  string
+	Types []Type
+	Limit int // Por tipo; 0 = DefaultLimit
+	Scope Scope
+}
+
+// query são os parâmetros já preparados para o repositório.
+type query struct {
+	Text     string // Termo informado
+	Like     string // Padrão ILIKE '%termo%'
+	Document string // Termo na forma canônica de documento; vazio se não parecer um documento
+	Limit    int
+	Scope    Scope
+}
+
+// Highlight é o trecho do campo que casou com a busca, com os termos entre <mark> e </mark>.
+// O restante do texto é escapado para HTML.
+type Highlight struct {
+	Field    string
+	Fragment string
+}
+
+type TenantHit struct {
+	UUID         uuid.UUID
+	Name         string
+	Document     string
+	DocumentType document.Kind
+	Status       model.TenantStatus
+	Score        float64
+	Highlight    Highlight `gorm:"-"`
+}
+
+type UserHit struct {
+	UUID       uuid.UUID
+	TenantUUID *uuid.UUID
+	TenantName *string
+	Name       string
+	Email      string
+	Role       model.UserRole
+	Live       bool
+	Score      float64
+	Highlight  Highlight `gorm:"-"`
+}
+
+// Results são os resultados agrupados por tipo, do mais para o menos relevante.
+// Tipos não pesquisados ficam nil.
+type Results struct {
+	Tenants []TenantHit
+	Users   []UserHit
+}
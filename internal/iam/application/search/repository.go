@@ -0,0 +1,126 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	SearchTenants(ctx context.Context, q query) ([]TenantHit, error)
+	SearchUsers(ctx context.Context, q query) ([]UserHit, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// Um registro entra no resultado se o termo estiver contido em algum campo (ILIKE), for
+// parecido com o campo inteiro (%) ou com uma de suas palavras (<%), ou se todas as palavras do
+// termo estiverem no nome (tsvector). O score é a maior das medidas; as buscas por trigramas e
+// tsvector usam os índices da migração 20261018011000_add_search_indexes.
+
+const tenantsQuery = `
+SELECT t.uuid, t.name, t.document, t.document_type, t.status,
+       GREATEST(
+           CASE WHEN t.name ILIKE @like THEN 0.9 ELSE 0 END,
+           CASE WHEN @doc <> '' AND t.document LIKE @doc_like THEN 1 ELSE 0 END,
+           word_similarity(@q, t.name),
+           similarity(t.name, @q),
+           ts_rank(to_tsvector('simple', t.name), websearch_to_tsquery('simple', @q))
+       ) AS score
+FROM tenant AS t
+WHERE t.deleted_at IS NULL
+  AND {scope}
+  AND (t.name ILIKE @like
+       OR (@doc <> '' AND t.document LIKE @doc_like)
+       OR @q <% t.name
+       OR t.name % @q
+       OR to_tsvector('simple', t.name) @@ websearch_to_tsquery('simple', @q))
+ORDER BY score DESC, t.name, t.uuid
+LIMIT @limit`
+
+const usersQuery = `
+SELECT u.uuid, u.tenant_uuid, t.name AS tenant_name, u.name, u.email, u.role, u.live,
+       GREATEST(
+           CASE WHEN u.email ILIKE @like THEN 0.95 ELSE 0 END,
+           CASE WHEN u.name ILIKE @like THEN 0.9 ELSE 0 END,
+           word_similarity(@q, u.name),
+           similarity(u.name, @q),
+           word_similarity(@q, u.email),
+           ts_rank(to_tsvector('simple', u.name), websearch_to_tsquery('simple', @q))
+       ) AS score
+FROM users AS u
+LEFT JOIN tenant AS t ON t.uuid = u.tenant_uuid
+WHERE (u.tenant_uuid IS NULL OR t.deleted_at IS NULL)
+  AND {scope}
+  AND (u.name ILIKE @like
+       OR u.email ILIKE @like
+       OR @q <% u.name
+       OR u.name % @q
+       OR @q <% u.email
+       OR to_tsvector('simple', u.name) @@ websearch_to_tsquery('simple', @q))
+ORDER BY score DESC, u.name, u.uuid
+LIMIT @limit`
+
+// withScope insere a condição de escopo na consulta. Não usa fmt.Sprintf porque as consultas
+// contêm os operadores % e <% do pg_trgm.
+func withScope(query, column string, scope Scope) string {
+	return strings.Replace(query, "{scope}", scopeCondition(column, scope), 1)
+}
+
+// scopeCondition restringe a coluna com o UUID do tenant ao escopo de quem pesquisa.
+func scopeCondition(column string, scope Scope) string {
+	switch {
+	case scope.TenantUUID == nil:
+		return "TRUE"
+	case scope.Descendants:
+		return fmt.Sprintf(`(%[1]s = @scope OR %[1]s IN (
+    WITH RECURSIVE subtree AS (
+        SELECT uuid FROM tenant WHERE parent_uuid = @scope
+        UNION
+        SELECT c.uuid FROM tenant AS c
+        INNER JOIN subtree AS s ON c.parent_uuid = s.uuid
+    )
+    SELECT uuid FROM subtree))`, column)
+	default:
+		return column + " = @scope"
+	}
+}
+
+func (q query) args() []interface{} {
+	args := []interface{}{
+		sql.Named("q", q.Text),
+		sql.Named("like", q.Like),
+		sql.Named("doc", q.Document),
+		sql.Named("doc_like", "%"+q.Document+"%"),
+		sql.Named("limit", q.Limit),
+	}
+	if q.Scope.TenantUUID != nil {
+		args = append(args, sql.Named("scope", *q.Scope.TenantUUID))
+	}
+	return args
+}
+
+func (r *repositoryImpl) SearchTenants(ctx context.Context, q query) ([]TenantHit, error) {
+	var hits []TenantHit
+	err := r.db.WithContext(ctx).
+		Raw(withScope(tenantsQuery, "t.uuid", q.Scope), q.args()...).
+		Scan(&hits).Error
+	return hits, err
+}
+
+func (r *repositoryImpl) SearchUsers(ctx context.Context, q query) ([]UserHit, error) {
+	var hits []UserHit
+	err := r.db.WithContext(ctx).
+		Raw(withScope(usersQuery, "u.tenant_uuid", q.Scope), q.args()...).
+		Scan(&hits).Error
+	return hits, err
+}
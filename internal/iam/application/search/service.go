@@ -0,0 +1,179 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"tenant-crud-simply/internal/pkg/document"
+	"tenant-crud-simply/internal/pkg/listing"
+)
+
+type Service interface {
+	// Search pesquisa tenants e usuários dentro do escopo informado. Os resultados vêm
+	// agrupados por tipo, ordenados por relevância, com o trecho encontrado destacado.
+	Search(ctx context.Context, req Request) (Results, error)
+}
+
+type serviceImpl struct {
+	Repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &serviceImpl{Repository: repository}
+}
+
+func (s *serviceImpl) Search(ctx context.Context, req Request) (Results, error) {
+	text := strings.Join(strings.Fields(req.Text), " ")
+	if n := utf8.RuneCountInString(text); n < MinQueryLength || n > MaxQueryLength {
+		return Results{}, fmt.Errorf("%w: o termo deve ter entre %d e %d caracteres", ErrQueryLength, MinQueryLength, MaxQueryLength)
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 0 || limit > MaxLimit {
+		return Results{}, fmt.Errorf("%w: 'limit' deve estar entre 1 e %d", ErrInvalidInput, MaxLimit)
+	}
+	types := req.Types
+	if len(types) == 0 {
+		types = AllTypes
+	}
+
+	q := query{
+		Text:  text,
+		Like:  listing.Contains(text),
+		Limit: limit,
+		Scope: req.Scope,
+	}
+	// Documentos são gravados na forma canônica; "11.222.333" também encontra "11222333000181"
+	if doc := document.Normalize(text); len(doc) >= 3 && strings.ContainsAny(doc, "0123456789") {
+		q.Document = doc
+	}
+
+	var results Results
+	for _, t := range types {
+		switch t {
+		case TypeTenant:
+			hits, err := s.Repository.SearchTenants(ctx, q)
+			if err != nil {
+				return Results{}, err
+			}
+			for i := range hits {
+				hits[i].Highlight = highlightFirst(q,
+					field{"name", hits[i].Name, nil},
+					field{"document", document.Format(hits[i].DocumentType, hits[i].Document), documentTerms(q)},
+				)
+			}
+			results.Tenants = append([]TenantHit{}, hits...)
+		case TypeUser:
+			hits, err := s.Repository.SearchUsers(ctx, q)
+			if err != nil {
+				return Results{}, err
+			}
+			for i := range hits {
+				hits[i].Highlight = highlightFirst(q,
+					field{"email", hits[i].Email, nil},
+					field{"name", hits[i].Name, nil},
+				)
+			}
+			results.Users = append([]UserHit{}, hits...)
+		default:
+			return Results{}, fmt.Errorf("%w: '%s'", ErrInvalidType, t)
+		}
+	}
+	return results, nil
+}
+
+type field struct {
+	name  string
+	value string
+	terms []string // nil = palavras do termo de busca
+}
+
+// documentTerms procura no documento formatado a forma canônica da busca, ignorando a
+// pontuação, para que "11222333" destaque "11.222.333" em "11.222.333/0001-81".
+// Sem documento na busca, retorna uma lista vazia (e não nil): o campo não é destacado.
+func documentTerms(q query) []string {
+	if q.Document == "" {
+		return []string{}
+	}
+	return []string{q.Document}
+}
+
+// highlightFirst destaca o primeiro campo que contém algum termo da busca. Em resultados
+// apenas aproximados (sem trecho literal), devolve o primeiro campo sem destaque.
+func highlightFirst(q query, fields ...field) Highlight {
+	words := strings.Fields(q.Text)
+	for _, f := range fields {
+		terms := f.terms
+		if terms == nil {
+			terms = words
+		}
+		if fragment, ok := highlight(f.value, terms, f.terms != nil); ok {
+			return Highlight{Field: f.name, Fragment: fragment}
+		}
+	}
+	return Highlight{Field: fields[0].name, Fragment: html.EscapeString(fields[0].value)}
+}
+
+// highlight envolve as ocorrências dos termos (sem diferenciar maiúsculas) com <mark>.
+// skipPunct ignora a pontuação do texto ao comparar, para documentos formatados.
+func highlight(text string, terms []string, skipPunct bool) (string, bool) {
+	runes := []rune(text)
+	marked := make([]bool, len(runes))
+	found := false
+	for _, term := range terms {
+		want := []rune(strings.ToLower(term))
+		if len(want) == 0 {
+			continue
+		}
+		for start := range runes {
+			if end, ok := matchAt(runes, start, want, skipPunct); ok {
+				for i := start; i < end; i++ {
+					marked[i] = true
+				}
+				found = true
+			}
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	var b strings.Builder
+	for i, r := range runes {
+		if marked[i] && (i == 0 || !marked[i-1]) {
+			b.WriteString("<mark>")
+		}
+		b.WriteString(html.EscapeString(string(r)))
+		if marked[i] && (i == len(runes)-1 || !marked[i+1]) {
+			b.WriteString("</mark>")
+		}
+	}
+	return b.String(), true
+}
+
+// matchAt compara want com o texto a partir de start e retorna o fim da ocorrência.
+func matchAt(runes []rune, start int, want []rune, skipPunct bool) (int, bool) {
+	if skipPunct && isPunct(runes[start]) {
+		return 0, false
+	}
+	i := start
+	for _, w := range want {
+		for skipPunct && i < len(runes) && isPunct(runes[i]) {
+			i++
+		}
+		if i >= len(runes) || strings.ToLower(string(runes[i])) != string(w) {
+			return 0, false
+		}
+		i++
+	}
+	return i, true
+}
+
+func isPunct(r rune) bool {
+	return r == '.' || r == '-' || r == '/' || r == ' '
+}
@@ -0,0 +1,133 @@
+package tenant_export
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// countingWriter conta os bytes escritos e calcula o SHA-256 do conteúdo.
+type countingWriter struct {
+	w     io.Writer
+	hash  hash.Hash
+	bytes int64
+}
+
+func newCountingWriter(w io.Writer) *countingWriter {
+	h := sha256.New()
+	return &countingWriter{w: io.MultiWriter(w, h), hash: h}
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.bytes += int64(n)
+	return n, err
+}
+
+func (cw *countingWriter) sum() string {
+	return hex.EncodeToString(cw.hash.Sum(nil))
+}
+
+// writeArchive gera o pacote zip em path: um arquivo por dataset e o manifest.json.
+// Retorna o tamanho e o SHA-256 do próprio zip.
+func (s *serviceImpl) writeArchive(ctx context.Context, path string, exportUUID, tenantUUID uuid.UUID, format Format) (int64, string, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
+
+	archive := newCountingWriter(file)
+	zw := zip.NewWriter(archive)
+
+	manifest := Manifest{
+		Version:     ManifestVersion,
+		ExportUUID:  exportUUID,
+		TenantUUID:  tenantUUID,
+		Format:      format,
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	for _, ds := range datasets {
+		entry, err := s.writeDataset(ctx, zw, ds, tenantUUID, format)
+		if err != nil {
+			return 0, "", fmt.Errorf("falha ao exportar %s: %w", ds.Name, err)
+		}
+		manifest.Files = append(manifest.Files, entry)
+	}
+
+	w, err := zw.Create("manifest.json")
+	if err != nil {
+		return 0, "", err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return 0, "", err
+	}
+
+	if err := zw.Close(); err != nil {
+		return 0, "", err
+	}
+	if err := file.Sync(); err != nil {
+		return 0, "", err
+	}
+	return archive.bytes, archive.sum(), nil
+}
+
+func (s *serviceImpl) writeDataset(ctx context.Context, zw *zip.Writer, ds dataset, tenantUUID uuid.UUID, format Format) (ManifestFile, error) {
+	name := ds.Name + "." + string(format)
+	w, err := zw.Create(name)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	cw := newCountingWriter(w)
+
+	if format == FormatJSON {
+		if _, err := io.WriteString(cw, "["); err != nil {
+			return ManifestFile{}, err
+		}
+	}
+
+	first := true
+	records, err := s.Repository.Stream(ctx, ds.Query, ds.TenantOwned, tenantUUID, func(record []byte) error {
+		if format == FormatJSON {
+			sep := ",\n"
+			if first {
+				sep = "\n"
+			}
+			if _, err := io.WriteString(cw, sep); err != nil {
+				return err
+			}
+		}
+		first = false
+		if _, err := cw.Write(record); err != nil {
+			return err
+		}
+		if format == FormatNDJSON {
+			_, err := io.WriteString(cw, "\n")
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return ManifestFile{}, err
+	}
+
+	if format == FormatJSON {
+		if _, err := io.WriteString(cw, "\n]\n"); err != nil {
+			return ManifestFile{}, err
+		}
+	}
+
+	return ManifestFile{Name: name, Records: records, Bytes: cw.bytes, SHA256: cw.sum()}, nil
+}
@@ -0,0 +1,401 @@
+package tenant_export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/plan"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/document"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	Create(c *gin.Context)
+	Status(c *gin.Context)
+	Download(c *gin.Context)
+	Import(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+
+		if login.User.Role == model.RolePartnerAdmin {
+			if target, ok := middleware.GetTargetTenant(c); ok {
+				actingTenantUUID = &target
+			}
+		}
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "tenant_export",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	routes.POST("/tenant/import", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Import)
+
+	exportGroup := routes.Group("/tenant/:uuid/export")
+
+	{
+		exportGroup.POST("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Create)
+		exportGroup.GET("/:export_uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Status)
+		exportGroup.GET("/:export_uuid/download", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Download)
+	}
+}
+
+// authorizeTenant valida o tenant da URL: SYSTEM_ADMIN acessa qualquer tenant, PARTNER_ADMIN
+// apenas a sua hierarquia e TENANT_ADMIN somente o próprio tenant.
+func (ctrl *controllerImpl) authorizeTenant(c *gin.Context, login *middleware.Login) (uuid.UUID, *rest_err.RestErr) {
+	target, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		return uuid.Nil, rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "O UUID fornecido na URL não é um formato válido.")
+	}
+
+	switch login.User.Role {
+	case model.RoleSystemAdmin:
+		return target, nil
+
+	case model.RolePartnerAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		inSubtree, err := tenant.MustUse().Service.InSubtree(c.Request.Context(), *login.User.TenantUUID, target)
+		if err != nil {
+			return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "Falha ao verificar hierarquia de tenants", nil)
+		}
+		if !inSubtree {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Tenant fora da sua hierarquia.")
+		}
+		middleware.SetTargetTenant(c, target)
+		return target, nil
+
+	case model.RoleTenantAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID != target {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Acesso permitido apenas ao próprio tenant.")
+		}
+		return target, nil
+
+	default:
+		return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	if quotaErr := plan.QuotaRestError(&login.Metadata.RayTraceCode, err); quotaErr != nil {
+		return quotaErr
+	}
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrTenantNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrInvalidFormat):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "Formato inválido. Use 'json' ou 'ndjson'.")
+	case errors.Is(err, ErrInProgress):
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, "Já existe uma exportação em andamento para este tenant.", nil)
+	case errors.Is(err, ErrInvalidArchive), errors.Is(err, ErrUnsupportedVersion), errors.Is(err, ErrChecksumMismatch), errors.Is(err, ErrInvalidOptions):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrParentNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrTenantExists), errors.Is(err, ErrDocumentExists):
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, err.Error(), nil)
+	case errors.Is(err, ErrEmailConflict):
+		var causes []rest_err.Causes
+		var conflict *EmailConflictError
+		if errors.As(err, &conflict) {
+			for _, email := range conflict.Emails {
+				causes = append(causes, rest_err.Causes{Field: "email", Message: email})
+			}
+		}
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, "Emails já cadastrados neste ambiente. Use email_conflict=skip ou rename.", causes)
+	case errors.Is(err, ErrNotReady):
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, "Exportação indisponível para download (em andamento, já baixada, expirada ou com falha).", nil)
+	default:
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+}
+
+func (ctrl *controllerImpl) parseExportUUID(c *gin.Context, login *middleware.Login) (uuid.UUID, *rest_err.RestErr) {
+	exportUUID, err := uuid.Parse(c.Param("export_uuid"))
+	if err != nil {
+		return uuid.Nil, rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "O UUID da exportação não é um formato válido.")
+	}
+	return exportUUID, nil
+}
+
+// @Summary      Exporta os Dados de um Tenant
+// @Description  Inicia em segundo plano a exportação completa do tenant (tenant, usuários sem senha, sessões, grupos, configurações, domínios, access log e audit log) em um zip com manifest.json e checksums SHA-256. Acompanhe pelo status; o arquivo pode ser baixado uma única vez.
+// @Tags         Tenant
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do tenant"
+// @Param        request body CreateExportRequestDto false "Formato (json ou ndjson)"
+// @Success      202  {object}  ExportResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      409  {object}  rest_err.RestErr "Exportação já em andamento."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/tenant/{uuid}/export [post]
+func (ctrl *controllerImpl) Create(c *gin.Context) {
+	var req CreateExportRequestDto
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			restError := rest_err.NewBadRequestError(nil, "invalid json body")
+			c.JSON(restError.Code, restError)
+			return
+		}
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.authorizeTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	format := Format(strings.ToLower(strings.TrimSpace(req.Format)))
+	export, err := ctrl.Service.Request(c.Request.Context(), tenantUUID, &ctxIdentify.User.UUID, format)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "request", "Create", false, req, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	resp := toExportResponse(export)
+	ctrl.logAudit(c, ctxIdentify, "request", "Create", true, req, resp)
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// @Summary      Status da Exportação
+// @Description  Retorna o andamento da exportação: pending, running, ready (download_url disponível), failed, downloaded ou expired.
+// @Tags         Tenant
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do tenant"
+// @Param        export_uuid path string true "UUID da exportação"
+// @Success      200  {object}  ExportResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Exportação não encontrada."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/tenant/{uuid}/export/{export_uuid} [get]
+func (ctrl *controllerImpl) Status(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.authorizeTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+	exportUUID, restError := ctrl.parseExportUUID(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	export, err := ctrl.Service.Get(c.Request.Context(), tenantUUID, exportUUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	c.JSON(http.StatusOK, toExportResponse(export))
+}
+
+// @Summary      Download da Exportação
+// @Description  Entrega o zip da exportação. O download é permitido uma única vez: o arquivo é removido do servidor após a entrega.
+// @Tags         Tenant
+// @Produce      application/zip
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do tenant"
+// @Param        export_uuid path string true "UUID da exportação"
+// @Success      200  {file}  file
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Exportação não encontrada."
+// @Failure      409  {object}  rest_err.RestErr "Exportação indisponível para download."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/tenant/{uuid}/export/{export_uuid}/download [get]
+func (ctrl *controllerImpl) Download(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.authorizeTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+	exportUUID, restError := ctrl.parseExportUUID(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	input := map[string]string{"tenant_uuid": tenantUUID.String(), "export_uuid": exportUUID.String()}
+	reader, export, err := ctrl.Service.Download(c.Request.Context(), tenantUUID, exportUUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "download", "Download", false, input, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+	defer reader.Close()
+
+	ctrl.logAudit(c, ctxIdentify, "download", "Download", true, input, toExportResponse(export))
+
+	c.DataFromReader(http.StatusOK, export.SizeBytes, "application/zip", reader, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="tenant-%s-export-%s.zip"`, export.TenantUUID, export.UUID),
+		"X-Checksum-SHA256":   export.SHA256,
+	})
+}
+
+// @Summary      Importa um Tenant
+// @Description  Cria um tenant a partir de um pacote gerado pela exportação. 'restore' mantém os UUIDs (ex.: produção → homologação); 'clone' gera novos UUIDs, nome e documento (ex.: tenant modelo). Usuários, grupos, configurações e domínios são inseridos em uma única transação; sessões e logs não são importados. Usuários importados precisam redefinir a senha. Com dry_run=true nada é gravado e o relatório mostra o que seria criado.
+// @Tags         Tenant
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file formData file true "Pacote .zip da exportação"
+// @Param        mode formData string false "restore (padrão) ou clone"
+// @Param        email_conflict formData string false "fail (padrão), skip ou rename"
+// @Param        dry_run formData bool false "Apenas simula a importação"
+// @Param        name formData string false "Nome do novo tenant (clone)"
+// @Param        document formData string false "Documento do novo tenant (clone)"
+// @Param        document_type formData string false "Tipo do documento do novo tenant: cpf, cnpj ou foreign (clone; vazio = detecção automática)"
+// @Param        parent_uuid formData string false "Tenant pai do tenant importado"
+// @Success      200  {object}  ImportResponseDto "Simulação (dry_run)"
+// @Success      201  {object}  ImportResponseDto
+// @Failure      400  {object}  rest_err.RestErr "Pacote, versão, checksum ou opções inválidos."
+// @Failure      402  {object}  rest_err.RestErr "Usuários importados acima do limite do plano."
+// @Failure      404  {object}  rest_err.RestErr "Tenant pai não encontrado."
+// @Failure      409  {object}  rest_err.RestErr "Tenant, documento ou emails já existentes."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/tenant/import [post]
+func (ctrl *controllerImpl) Import(c *gin.Context) {
+	var req ImportRequestDto
+	if err := c.ShouldBind(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid form data")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "Envie o pacote da exportação no campo 'file'.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao ler o arquivo enviado.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	defer file.Close()
+
+	opts := ImportOptions{
+		Mode:          ImportMode(strings.ToLower(strings.TrimSpace(req.Mode))),
+		EmailConflict: EmailConflict(strings.ToLower(strings.TrimSpace(req.EmailConflict))),
+		DryRun:        req.DryRun,
+		Name:          req.Name,
+		Document:      req.Document,
+		DocumentType:  document.Kind(strings.ToLower(strings.TrimSpace(req.DocumentType))),
+	}
+	if req.ParentUUID != "" {
+		parent, err := uuid.Parse(req.ParentUUID)
+		if err != nil {
+			restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "O 'parent_uuid' não é um UUID válido.")
+			c.JSON(restError.Code, restError)
+			return
+		}
+		opts.ParentUUID = &parent
+	}
+
+	report, err := ctrl.Service.Import(c.Request.Context(), file, header.Size, opts)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "import", "Import", false, req, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	resp := toImportResponse(report)
+	status := http.StatusCreated
+	if report.DryRun {
+		status = http.StatusOK
+	} else {
+		ctrl.logAudit(c, ctxIdentify, "import", "Import", true, req, resp)
+	}
+	c.JSON(status, resp)
+}
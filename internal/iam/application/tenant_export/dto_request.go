@@ -0,0 +1,21 @@
+package tenant_export
+
+type CreateExportRequestDto struct {
+	// json (padrão) ou ndjson
+	Format string `json:"format"`
+}
+
+// ImportRequestDto acompanha o upload (multipart, campo 'file') do pacote de exportação.
+type ImportRequestDto struct {
+	// restore (padrão) ou clone
+	Mode string `form:"mode"`
+	// fail (padrão), skip ou rename
+	EmailConflict string `form:"email_conflict"`
+	DryRun        bool   `form:"dry_run"`
+	// Nome e documento do novo tenant (obrigatórios no modo clone)
+	Name     string `form:"name"`
+	Document string `form:"document"`
+	// cpf, cnpj ou foreign. Vazio = detecta CPF/CNPJ pelo formato
+	DocumentType string `form:"document_type"`
+	ParentUUID   string `form:"parent_uuid"`
+}
@@ -0,0 +1,74 @@
+package tenant_export
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExportResponseDto struct {
+	UUID         uuid.UUID  `json:"uuid"`
+	TenantUUID   uuid.UUID  `json:"tenant_uuid"`
+	Format       string     `json:"format"`
+	Status       string     `json:"status"`
+	SizeBytes    int64      `json:"size_bytes,omitempty"`
+	SHA256       string     `json:"sha256,omitempty"`
+	Error        string     `json:"error,omitempty"`
+	DownloadURL  string     `json:"download_url,omitempty"`
+	CreateAt     time.Time  `json:"create_at"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty"`
+	DownloadedAt *time.Time `json:"downloaded_at,omitempty"`
+}
+
+func toExportResponse(e Export) ExportResponseDto {
+	resp := ExportResponseDto{
+		UUID:         e.UUID,
+		TenantUUID:   e.TenantUUID,
+		Format:       string(e.Format),
+		Status:       string(e.Status),
+		SizeBytes:    e.SizeBytes,
+		SHA256:       e.SHA256,
+		Error:        e.Error,
+		CreateAt:     e.CreateAt,
+		FinishedAt:   e.FinishedAt,
+		DownloadedAt: e.DownloadedAt,
+	}
+	if e.Status == StatusReady {
+		resp.DownloadURL = fmt.Sprintf("/api/tenant/%s/export/%s/download", e.TenantUUID, e.UUID)
+	}
+	return resp
+}
+
+type DatasetReportResponseDto struct {
+	Name    string `json:"name"`
+	Created int    `json:"created"`
+	Skipped int    `json:"skipped"`
+}
+
+type ImportResponseDto struct {
+	DryRun           bool                       `json:"dry_run"`
+	Mode             string                     `json:"mode"`
+	SourceTenantUUID uuid.UUID                  `json:"source_tenant_uuid"`
+	TenantUUID       uuid.UUID                  `json:"tenant_uuid"`
+	Datasets         []DatasetReportResponseDto `json:"datasets"`
+	Warnings         []string                   `json:"warnings"`
+}
+
+func toImportResponse(r ImportReport) ImportResponseDto {
+	resp := ImportResponseDto{
+		DryRun:           r.DryRun,
+		Mode:             string(r.Mode),
+		SourceTenantUUID: r.SourceTenantUUID,
+		TenantUUID:       r.TenantUUID,
+		Datasets:         make([]DatasetReportResponseDto, 0, len(r.Datasets)),
+		Warnings:         r.Warnings,
+	}
+	for _, d := range r.Datasets {
+		resp.Datasets = append(resp.Datasets, DatasetReportResponseDto{Name: d.Name, Created: d.Created, Skipped: d.Skipped})
+	}
+	if resp.Warnings == nil {
+		resp.Warnings = []string{}
+	}
+	return resp
+}
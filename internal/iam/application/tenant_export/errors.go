@@ -0,0 +1,40 @@
+package tenant_export
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNotFound       = errors.New("export not found")
+	ErrTenantNotFound = errors.New("tenant not found")
+	ErrInvalidFormat  = errors.New("invalid export format")
+	ErrInProgress     = errors.New("an export for this tenant is already in progress")
+	ErrNotReady       = errors.New("export is not available for download")
+)
+
+// Erros da importação
+var (
+	ErrInvalidArchive     = errors.New("invalid export archive")
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	ErrChecksumMismatch   = errors.New("archive checksum mismatch")
+	ErrInvalidOptions     = errors.New("invalid import options")
+	ErrTenantExists       = errors.New("tenant already exists")
+	ErrDocumentExists     = errors.New("tenant document already exists")
+	ErrParentNotFound     = errors.New("parent tenant not found")
+	ErrEmailConflict      = errors.New("user emails already exist")
+)
+
+// EmailConflictError lista os emails já existentes no destino. errors.Is(err, ErrEmailConflict) é verdadeiro.
+type EmailConflictError struct {
+	Emails []string
+}
+
+func (e *EmailConflictError) Error() string {
+	return fmt.Sprintf("user emails already exist: %s", strings.Join(e.Emails, ", "))
+}
+
+func (e *EmailConflictError) Is(target error) bool {
+	return target == ErrEmailConflict
+}
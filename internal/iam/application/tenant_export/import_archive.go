@@ -0,0 +1,126 @@
+package tenant_export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// requiredDatasets precisam estar em qualquer pacote importável.
+var requiredDatasets = []string{"tenant", "users"}
+
+// archiveReader dá acesso aos arquivos de um pacote já validado.
+type archiveReader struct {
+	zip      *zip.Reader
+	manifest Manifest
+	files    map[string]ManifestFile
+}
+
+// openArchive valida o pacote: manifest, versão do layout, formato e, para cada arquivo
+// listado, presença, tamanho e SHA-256.
+func openArchive(r io.ReaderAt, size int64) (*archiveReader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+
+	var manifest Manifest
+	if err := decodeEntry(zr, "manifest.json", &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Version < 1 || manifest.Version > ManifestVersion {
+		return nil, fmt.Errorf("%w: %d (suportada até %d)", ErrUnsupportedVersion, manifest.Version, ManifestVersion)
+	}
+	if manifest.Format != FormatJSON && manifest.Format != FormatNDJSON {
+		return nil, fmt.Errorf("%w: formato '%s'", ErrInvalidArchive, manifest.Format)
+	}
+
+	ar := &archiveReader{zip: zr, manifest: manifest, files: make(map[string]ManifestFile)}
+	for _, file := range manifest.Files {
+		if err := ar.verify(file); err != nil {
+			return nil, err
+		}
+		ar.files[file.Name] = file
+	}
+	for _, name := range requiredDatasets {
+		if _, ok := ar.files[ar.fileName(name)]; !ok {
+			return nil, fmt.Errorf("%w: arquivo '%s' ausente", ErrInvalidArchive, ar.fileName(name))
+		}
+	}
+	return ar, nil
+}
+
+func decodeEntry(zr *zip.Reader, name string, v any) error {
+	f, err := zr.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w: arquivo '%s' ausente", ErrInvalidArchive, name)
+	}
+	defer f.Close()
+	if err := json.NewDecoder(f).Decode(v); err != nil {
+		return fmt.Errorf("%w: '%s' inválido: %v", ErrInvalidArchive, name, err)
+	}
+	return nil
+}
+
+func (ar *archiveReader) fileName(dataset string) string {
+	return dataset + "." + string(ar.manifest.Format)
+}
+
+func (ar *archiveReader) verify(file ManifestFile) error {
+	f, err := ar.zip.Open(file.Name)
+	if err != nil {
+		return fmt.Errorf("%w: arquivo '%s' ausente", ErrInvalidArchive, file.Name)
+	}
+	defer f.Close()
+
+	cw := newCountingWriter(io.Discard)
+	if _, err := io.Copy(cw, f); err != nil {
+		return fmt.Errorf("%w: falha ao ler '%s': %v", ErrInvalidArchive, file.Name, err)
+	}
+	if cw.bytes != file.Bytes || cw.sum() != file.SHA256 {
+		return fmt.Errorf("%w: %s", ErrChecksumMismatch, file.Name)
+	}
+	return nil
+}
+
+// readDataset decodifica os registros do dataset. Datasets ausentes
+// do pacote resultam em slice vazio; a quantidade de registros precisa bater com o manifest.
+func readDataset[T any](ar *archiveReader, dataset string) ([]T, error) {
+	name := ar.fileName(dataset)
+	file, ok := ar.files[name]
+	if !ok {
+		return nil, nil
+	}
+
+	f, err := ar.zip.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: arquivo '%s' ausente", ErrInvalidArchive, name)
+	}
+	defer f.Close()
+
+	var records []T
+	decoder := json.NewDecoder(f)
+	if ar.manifest.Format == FormatJSON {
+		err = decoder.Decode(&records)
+	} else {
+		for {
+			var record T
+			if err = decoder.Decode(&record); err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				break
+			}
+			records = append(records, record)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: registro inválido em '%s': %v", ErrInvalidArchive, name, err)
+	}
+	if int64(len(records)) != file.Records {
+		return nil, fmt.Errorf("%w: '%s' contém %d registros, manifest informa %d", ErrInvalidArchive, name, len(records), file.Records)
+	}
+	return records, nil
+}
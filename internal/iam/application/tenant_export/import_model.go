@@ -0,0 +1,162 @@
+package tenant_export
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/pkg/document"
+
+	"github.com/google/uuid"
+)
+
+type ImportMode string
+
+const (
+	// ImportRestore recria o tenant com os mesmos UUIDs (ex.: produção → homologação).
+	ImportRestore ImportMode = "restore"
+	// ImportClone cria um novo tenant a partir do pacote, com novos UUIDs, nome e documento.
+	ImportClone ImportMode = "clone"
+)
+
+// EmailConflict define o tratamento de usuários cujo email já existe no ambiente de destino.
+type EmailConflict string
+
+const (
+	EmailConflictFail   EmailConflict = "fail"   // Aborta a importação
+	EmailConflictSkip   EmailConflict = "skip"   // Não importa o usuário (nem seus vínculos com grupos)
+	EmailConflictRename EmailConflict = "rename" // Importa como local+<sufixo>@domínio
+)
+
+type ImportOptions struct {
+	Mode          ImportMode
+	EmailConflict EmailConflict
+	DryRun        bool
+	// Name e Document do novo tenant; obrigatórios no modo clone.
+	Name     string
+	Document string
+	// DocumentType do novo tenant no modo clone. Vazio = detecta CPF/CNPJ pelo formato
+	DocumentType document.Kind
+	// ParentUUID define o tenant pai do tenant importado. No modo restore, sem ele é mantido
+	// o pai original quando existir no destino.
+	ParentUUID *uuid.UUID
+}
+
+type DatasetReport struct {
+	Name    string
+	Created int
+	Skipped int
+}
+
+// ImportReport resume o que foi (ou, em dry-run, seria) criado.
+type ImportReport struct {
+	DryRun           bool
+	Mode             ImportMode
+	SourceTenantUUID uuid.UUID
+	TenantUUID       uuid.UUID
+	Datasets         []DatasetReport
+	Warnings         []string
+}
+
+// importPlan reúne os registros já convertidos e remapeados, prontos para inserção.
+type importPlan struct {
+	Tenant   model.Tenant
+	Users    []model.User
+	Groups   []model.Group
+	Members  []model.GroupMember
+	Settings []model.TenantSetting
+	Domains  []model.TenantDomain
+}
+
+// timestamp aceita o formato do to_jsonb para colunas sem fuso (ex.: 2026-10-18T12:00:00.123456),
+// interpretado como UTC, além de RFC 3339.
+type timestamp struct {
+	time.Time
+}
+
+func (t *timestamp) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed.UTC()
+			return nil
+		}
+	}
+	return &time.ParseError{Layout: time.RFC3339Nano, Value: s}
+}
+
+// Registros do pacote, com os nomes das colunas exportadas. Campos desconhecidos são ignorados
+// para aceitar pacotes gerados por versões mais novas com o mesmo layout.
+type tenantRecord struct {
+	UUID         uuid.UUID          `json:"uuid"`
+	ParentUUID   *uuid.UUID         `json:"parent_uuid"`
+	Name         string             `json:"name"`
+	Document     string             `json:"document"`
+	DocumentType document.Kind      `json:"document_type"`
+	Status       model.TenantStatus `json:"status"`
+	TrialEndsAt  *timestamp         `json:"trial_ends_at"`
+	Metadata     model.Metadata     `json:"metadata"`
+	// Live vem de pacotes anteriores ao ciclo de vida; usado apenas quando Status está ausente
+	Live     bool      `json:"live"`
+	CreateAt timestamp `json:"create_at"`
+	UpdateAt timestamp `json:"update_at"`
+}
+
+// status retorna o status do tenant no pacote, convertendo o antigo campo live.
+func (r tenantRecord) status() model.TenantStatus {
+	switch {
+	case r.Status != "":
+		return r.Status
+	case r.Live:
+		return model.TenantStatusActive
+	default:
+		return model.TenantStatusSuspended
+	}
+}
+
+type userRecord struct {
+	UUID       uuid.UUID      `json:"uuid"`
+	TenantUUID *uuid.UUID     `json:"tenant_uuid"`
+	Name       string         `json:"name"`
+	Email      string         `json:"email"`
+	Role       model.UserRole `json:"role"`
+	Live       bool           `json:"live"`
+	Metadata   model.Metadata `json:"metadata"`
+	CreateAt   timestamp      `json:"create_at"`
+	UpdateAt   timestamp      `json:"update_at"`
+}
+
+type groupRecord struct {
+	UUID        uuid.UUID `json:"uuid"`
+	TenantUUID  uuid.UUID `json:"tenant_uuid"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	CreateAt    timestamp `json:"create_at"`
+	UpdateAt    timestamp `json:"update_at"`
+}
+
+type memberRecord struct {
+	GroupUUID uuid.UUID `json:"group_uuid"`
+	UserUUID  uuid.UUID `json:"user_uuid"`
+	CreateAt  timestamp `json:"create_at"`
+}
+
+type settingRecord struct {
+	TenantUUID uuid.UUID       `json:"tenant_uuid"`
+	Key        string          `json:"key"`
+	Value      json.RawMessage `json:"value"`
+	UpdateAt   timestamp       `json:"update_at"`
+}
+
+type domainRecord struct {
+	UUID       uuid.UUID        `json:"uuid"`
+	TenantUUID uuid.UUID        `json:"tenant_uuid"`
+	Kind       model.DomainKind `json:"kind"`
+	Hostname   string           `json:"hostname"`
+	VerifiedAt *timestamp       `json:"verified_at"`
+	CreateAt   timestamp        `json:"create_at"`
+}
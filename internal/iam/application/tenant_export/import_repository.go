@@ -0,0 +1,122 @@
+package tenant_export
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errDryRun desfaz a transação de importação em modo de simulação.
+var errDryRun = errors.New("dry run")
+
+// importBatchSize é a quantidade de registros por INSERT na importação.
+const importBatchSize = 500
+
+func (r *repositoryImpl) DocumentExists(ctx context.Context, document string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Table("tenant").Where("document = ?", document).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repositoryImpl) ExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	var existing []string
+	// user_directory cobre também os usuários dos tenants isolados por schema
+	err := r.db.WithContext(ctx).Table("user_directory").Where("email IN ?", emails).Order("email").Pluck("email", &existing).Error
+	return existing, err
+}
+
+func (r *repositoryImpl) ExistingHostnames(ctx context.Context, domains []model.TenantDomain) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(domains) == 0 {
+		return existing, nil
+	}
+	hostnames := make([]string, 0, len(domains))
+	for _, d := range domains {
+		hostnames = append(hostnames, d.Hostname)
+	}
+
+	var rows []model.TenantDomain
+	if err := r.db.WithContext(ctx).Select("kind, hostname").Where("hostname IN ?", hostnames).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, d := range rows {
+		existing[string(d.Kind)+"|"+d.Hostname] = true
+	}
+	return existing, nil
+}
+
+// Import insere o plano em uma única transação. Em dry-run a transação é desfeita ao final,
+// de modo que as restrições do banco também são verificadas na simulação.
+func (r *repositoryImpl) Import(ctx context.Context, plan importPlan, dryRun bool) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&plan.Tenant).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&model.TenantStatusChange{
+			TenantUUID: plan.Tenant.UUID,
+			ToStatus:   plan.Tenant.Status,
+			Reason:     "Importação do tenant",
+			CreateAt:   time.Now().UTC(),
+		}).Error; err != nil {
+			return err
+		}
+		if len(plan.Users) > 0 {
+			if err := tx.Omit(clause.Associations).CreateInBatches(&plan.Users, importBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(plan.Groups) > 0 {
+			if err := tx.CreateInBatches(&plan.Groups, importBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(plan.Members) > 0 {
+			if err := tx.CreateInBatches(&plan.Members, importBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(plan.Settings) > 0 {
+			if err := tx.CreateInBatches(&plan.Settings, importBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(plan.Domains) > 0 {
+			if err := tx.CreateInBatches(&plan.Domains, importBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		return nil
+	}
+	return mapImportError(err)
+}
+
+// mapImportError traduz violações de unicidade ocorridas entre a verificação prévia e a inserção.
+func mapImportError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		switch pgErr.ConstraintName {
+		case "tenant_pkey":
+			return ErrTenantExists
+		case "tenant_document_key":
+			return ErrDocumentExists
+		case "users_email_key":
+			return ErrEmailConflict
+		}
+	}
+	return err
+}
@@ -0,0 +1,512 @@
+package tenant_export
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/plan"
+	"tenant-crud-simply/internal/iam/domain/settings"
+	"tenant-crud-simply/internal/pkg/document"
+	"tenant-crud-simply/internal/pkg/util"
+
+	"github.com/google/uuid"
+)
+
+// notImported são os datasets do pacote que não são importados: sessões não carregam o token
+// e os logs são o histórico do ambiente de origem.
+var notImported = []string{"sessions", "access_log", "audit_log"}
+
+func normalizeImportOptions(opts ImportOptions) (ImportOptions, error) {
+	if opts.Mode == "" {
+		opts.Mode = ImportRestore
+	}
+	if opts.EmailConflict == "" {
+		opts.EmailConflict = EmailConflictFail
+	}
+	opts.Name = strings.TrimSpace(opts.Name)
+	opts.Document = strings.TrimSpace(opts.Document)
+
+	switch opts.Mode {
+	case ImportRestore:
+	case ImportClone:
+		if opts.Name == "" || opts.Document == "" {
+			return opts, fmt.Errorf("%w: 'name' e 'document' são obrigatórios no modo clone", ErrInvalidOptions)
+		}
+		doc, err := document.Parse(opts.Document, opts.DocumentType)
+		if err != nil {
+			return opts, fmt.Errorf("%w: documento do clone: %v", ErrInvalidOptions, err)
+		}
+		opts.Document, opts.DocumentType = doc.Value, doc.Kind
+	default:
+		return opts, fmt.Errorf("%w: modo '%s'", ErrInvalidOptions, opts.Mode)
+	}
+
+	switch opts.EmailConflict {
+	case EmailConflictFail, EmailConflictSkip, EmailConflictRename:
+	default:
+		return opts, fmt.Errorf("%w: estratégia de email '%s'", ErrInvalidOptions, opts.EmailConflict)
+	}
+	return opts, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// renameEmail gera local+<sufixo>@domínio.
+func renameEmail(email, suffix string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return email + "+" + suffix
+	}
+	return email[:at] + "+" + suffix + email[at:]
+}
+
+// Import valida o pacote e cria o tenant com seus usuários, grupos, configurações e domínios.
+func (s *serviceImpl) Import(ctx context.Context, r io.ReaderAt, size int64, opts ImportOptions) (ImportReport, error) {
+	opts, err := normalizeImportOptions(opts)
+	if err != nil {
+		return ImportReport{}, err
+	}
+
+	ar, err := openArchive(r, size)
+	if err != nil {
+		return ImportReport{}, err
+	}
+
+	tenants, err := readDataset[tenantRecord](ar, "tenant")
+	if err != nil {
+		return ImportReport{}, err
+	}
+	if len(tenants) != 1 || tenants[0].UUID != ar.manifest.TenantUUID {
+		return ImportReport{}, fmt.Errorf("%w: o pacote deve conter exatamente o tenant %s", ErrInvalidArchive, ar.manifest.TenantUUID)
+	}
+	source := tenants[0]
+
+	users, err := readDataset[userRecord](ar, "users")
+	if err != nil {
+		return ImportReport{}, err
+	}
+	groups, err := readDataset[groupRecord](ar, "groups")
+	if err != nil {
+		return ImportReport{}, err
+	}
+	members, err := readDataset[memberRecord](ar, "group_members")
+	if err != nil {
+		return ImportReport{}, err
+	}
+	tenantSettings, err := readDataset[settingRecord](ar, "settings")
+	if err != nil {
+		return ImportReport{}, err
+	}
+	domains, err := readDataset[domainRecord](ar, "domains")
+	if err != nil {
+		return ImportReport{}, err
+	}
+
+	report := ImportReport{DryRun: opts.DryRun, Mode: opts.Mode, SourceTenantUUID: source.UUID}
+	warn := func(format string, args ...any) {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	// No clone todos os UUIDs são novos; na restauração são mantidos
+	ids := make(map[uuid.UUID]uuid.UUID)
+	remap := func(id uuid.UUID) uuid.UUID {
+		if opts.Mode == ImportRestore {
+			return id
+		}
+		if mapped, ok := ids[id]; ok {
+			return mapped
+		}
+		mapped := uuid.New()
+		ids[id] = mapped
+		return mapped
+	}
+
+	plan, err := s.planTenant(ctx, source, opts, remap, warn)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	tenantUUID := plan.Tenant.UUID
+	report.TenantUUID = tenantUUID
+	report.Datasets = append(report.Datasets, DatasetReport{Name: "tenant", Created: 1})
+
+	allowedDomains, err := importedEmailDomains(ctx, plan.Tenant.ParentUUID, tenantSettings)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	imported, skipped, err := s.planUsers(ctx, &plan, users, source.UUID, allowedDomains, opts, remap, warn)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	report.Datasets = append(report.Datasets, DatasetReport{Name: "users", Created: len(plan.Users), Skipped: skipped})
+
+	// Grupos
+	knownGroups := make(map[uuid.UUID]bool)
+	for _, g := range groups {
+		if g.TenantUUID != source.UUID || g.Name == "" {
+			return ImportReport{}, fmt.Errorf("%w: grupo %s inválido", ErrInvalidArchive, g.UUID)
+		}
+		knownGroups[g.UUID] = true
+		group := model.Group{
+			UUID:       remap(g.UUID),
+			TenantUUID: tenantUUID,
+			Name:       g.Name,
+			CreateAt:   g.CreateAt.Time,
+			UpdateAt:   g.UpdateAt.Time,
+		}
+		if g.Description != nil {
+			group.Description = *g.Description
+		}
+		plan.Groups = append(plan.Groups, group)
+	}
+	report.Datasets = append(report.Datasets, DatasetReport{Name: "groups", Created: len(plan.Groups)})
+
+	// Vínculos de usuários ignorados também são ignorados
+	memberSkipped := 0
+	for _, m := range members {
+		if !knownGroups[m.GroupUUID] {
+			return ImportReport{}, fmt.Errorf("%w: vínculo com grupo desconhecido %s", ErrInvalidArchive, m.GroupUUID)
+		}
+		if !imported[m.UserUUID] {
+			memberSkipped++
+			continue
+		}
+		plan.Members = append(plan.Members, model.GroupMember{
+			GroupUUID: remap(m.GroupUUID),
+			UserUUID:  remap(m.UserUUID),
+			CreateAt:  m.CreateAt.Time,
+		})
+	}
+	report.Datasets = append(report.Datasets, DatasetReport{Name: "group_members", Created: len(plan.Members), Skipped: memberSkipped})
+
+	// Configurações: chaves desconhecidas ou valores inválidos neste ambiente são ignorados
+	settingSkipped := 0
+	for _, st := range tenantSettings {
+		def, ok := settings.Lookup(st.Key)
+		if !ok {
+			settingSkipped++
+			warn("Configuração '%s' desconhecida neste ambiente; ignorada.", st.Key)
+			continue
+		}
+		if err := def.Check(st.Value); err != nil {
+			settingSkipped++
+			warn("Configuração '%s' com valor inválido (%v); ignorada.", st.Key, err)
+			continue
+		}
+		plan.Settings = append(plan.Settings, model.TenantSetting{
+			TenantUUID: tenantUUID,
+			Key:        st.Key,
+			Value:      string(st.Value),
+			UpdateAt:   st.UpdateAt.Time,
+		})
+	}
+	report.Datasets = append(report.Datasets, DatasetReport{Name: "settings", Created: len(plan.Settings), Skipped: settingSkipped})
+
+	domainSkipped, err := s.planDomains(ctx, &plan, domains, opts, remap, warn)
+	if err != nil {
+		return ImportReport{}, err
+	}
+	report.Datasets = append(report.Datasets, DatasetReport{Name: "domains", Created: len(plan.Domains), Skipped: domainSkipped})
+
+	for _, name := range notImported {
+		if file, ok := ar.files[ar.fileName(name)]; ok && file.Records > 0 {
+			report.Datasets = append(report.Datasets, DatasetReport{Name: name, Skipped: int(file.Records)})
+		}
+	}
+
+	if err := s.Repository.Import(ctx, plan, opts.DryRun); err != nil {
+		return ImportReport{}, err
+	}
+	return report, nil
+}
+
+func (s *serviceImpl) planTenant(ctx context.Context, source tenantRecord, opts ImportOptions, remap func(uuid.UUID) uuid.UUID, warn func(string, ...any)) (importPlan, error) {
+	if source.Name == "" || source.Document == "" {
+		return importPlan{}, fmt.Errorf("%w: tenant sem nome ou documento", ErrInvalidArchive)
+	}
+
+	now := time.Now().UTC()
+	tenant := model.Tenant{
+		UUID:         remap(source.UUID),
+		Name:         source.Name,
+		Document:     source.Document,
+		DocumentType: source.DocumentType,
+		Status:       source.status(),
+		Metadata:     source.Metadata,
+		CreateAt:     source.CreateAt.Time,
+		UpdateAt:     source.UpdateAt.Time,
+	}
+	if source.TrialEndsAt != nil && tenant.Status == model.TenantStatusTrial {
+		tenant.TrialEndsAt = &source.TrialEndsAt.Time
+	}
+	if opts.Mode == ImportClone {
+		tenant.Name = opts.Name
+		tenant.Document, tenant.DocumentType = opts.Document, opts.DocumentType
+		tenant.Status, tenant.TrialEndsAt = model.TenantStatusActive, nil
+		tenant.CreateAt = now
+		tenant.UpdateAt = now
+	} else {
+		if !tenant.Status.Valid() {
+			warn("Status '%s' do tenant desconhecido; restaurado como suspended.", tenant.Status)
+			tenant.Status, tenant.TrialEndsAt = model.TenantStatusSuspended, nil
+		}
+		// Pacotes de antes da validação de documentos podem trazer a forma não canônica;
+		// documentos inválidos são restaurados como legados (sem tipo), como faz a migração
+		if doc, err := document.Parse(source.Document, source.DocumentType); err == nil {
+			tenant.Document, tenant.DocumentType = doc.Value, doc.Kind
+		} else {
+			tenant.DocumentType = ""
+			warn("Documento '%s' do tenant não é um CPF/CNPJ válido; restaurado sem tipo.", source.Document)
+		}
+		exists, err := s.Repository.TenantExists(ctx, tenant.UUID)
+		if err != nil {
+			return importPlan{}, err
+		}
+		if exists {
+			return importPlan{}, ErrTenantExists
+		}
+	}
+
+	exists, err := s.Repository.DocumentExists(ctx, tenant.Document)
+	if err != nil {
+		return importPlan{}, err
+	}
+	if exists {
+		return importPlan{}, ErrDocumentExists
+	}
+
+	switch {
+	case opts.ParentUUID != nil:
+		exists, err := s.Repository.TenantExists(ctx, *opts.ParentUUID)
+		if err != nil {
+			return importPlan{}, err
+		}
+		if !exists {
+			return importPlan{}, ErrParentNotFound
+		}
+		tenant.ParentUUID = opts.ParentUUID
+	case opts.Mode == ImportRestore && source.ParentUUID != nil:
+		exists, err := s.Repository.TenantExists(ctx, *source.ParentUUID)
+		if err != nil {
+			return importPlan{}, err
+		}
+		if exists {
+			tenant.ParentUUID = source.ParentUUID
+		} else {
+			warn("Tenant pai %s não existe neste ambiente; o tenant foi importado como raiz.", *source.ParentUUID)
+		}
+	}
+
+	return importPlan{Tenant: tenant}, nil
+}
+
+// planUsers converte os usuários aplicando a estratégia de conflito de email. Usuários fora dos
+// domínios permitidos são ignorados e o total importado deve caber no plano do tenant. Retorna
+// os UUIDs de origem importados e a quantidade ignorada.
+func (s *serviceImpl) planUsers(ctx context.Context, plan *importPlan, users []userRecord, sourceTenant uuid.UUID, allowedDomains []string, opts ImportOptions, remap func(uuid.UUID) uuid.UUID, warn func(string, ...any)) (map[uuid.UUID]bool, int, error) {
+	imported := make(map[uuid.UUID]bool)
+	skipped := 0
+
+	candidates := make([]userRecord, 0, len(users))
+	emails := make([]string, 0, len(users))
+	for _, u := range users {
+		if u.Email == "" || u.Name == "" || u.TenantUUID == nil || *u.TenantUUID != sourceTenant {
+			return nil, 0, fmt.Errorf("%w: usuário %s inválido", ErrInvalidArchive, u.UUID)
+		}
+		if u.Role == model.RoleSystemAdmin {
+			skipped++
+			warn("Usuário %s é SYSTEM_ADMIN e não foi importado.", u.Email)
+			continue
+		}
+		if !emailDomainAllowed(u.Email, allowedDomains) {
+			skipped++
+			warn("Usuário %s está fora dos domínios de email permitidos (allowed_email_domains) e não foi importado.", u.Email)
+			continue
+		}
+		candidates = append(candidates, u)
+		emails = append(emails, u.Email)
+	}
+
+	existing, err := s.Repository.ExistingEmails(ctx, emails)
+	if err != nil {
+		return nil, 0, err
+	}
+	conflicts := make(map[string]bool, len(existing))
+	for _, email := range existing {
+		conflicts[email] = true
+	}
+	if len(conflicts) > 0 && opts.EmailConflict == EmailConflictFail {
+		return nil, 0, &EmailConflictError{Emails: existing}
+	}
+
+	suffix := plan.Tenant.UUID.String()[:8]
+	var renamed []string
+	for _, u := range candidates {
+		email := u.Email
+		if conflicts[email] {
+			if opts.EmailConflict == EmailConflictSkip {
+				skipped++
+				warn("Usuário %s já existe neste ambiente; ignorado.", email)
+				continue
+			}
+			email = renameEmail(email, suffix)
+			renamed = append(renamed, email)
+			warn("Usuário %s já existe neste ambiente; importado como %s.", u.Email, email)
+		}
+		imported[u.UUID] = true
+		plan.Users = append(plan.Users, model.User{
+			UUID:       remap(u.UUID),
+			TenantUUID: &plan.Tenant.UUID,
+			Name:       u.Name,
+			Email:      email,
+			Role:       u.Role,
+			Live:       u.Live,
+			Metadata:   u.Metadata,
+			CreateAt:   u.CreateAt.Time,
+			UpdateAt:   u.UpdateAt.Time,
+		})
+	}
+
+	// Os emails renomeados também precisam estar livres
+	if stillTaken, err := s.Repository.ExistingEmails(ctx, renamed); err != nil {
+		return nil, 0, err
+	} else if len(stillTaken) > 0 {
+		return nil, 0, &EmailConflictError{Emails: stillTaken}
+	}
+	if err := checkUserQuota(ctx, plan.Tenant.UUID, len(plan.Users)); err != nil {
+		return nil, 0, err
+	}
+
+	if len(plan.Users) > 0 {
+		// As senhas não são exportadas: um hash de segredo descartado impede o login
+		// até que o usuário redefina a senha pelo fluxo de OTP.
+		secret, err := randomHex(32)
+		if err != nil {
+			return nil, 0, err
+		}
+		hash, err := util.UsePassword().Hash(secret)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := range plan.Users {
+			plan.Users[i].Password = hash
+		}
+		warn("Os %d usuário(s) importado(s) precisam redefinir a senha (esqueci minha senha).", len(plan.Users))
+	}
+
+	return imported, skipped, nil
+}
+
+// importedEmailDomains retorna os domínios de email permitidos no tenant importado: o valor do
+// pacote ou, sem ele (ou com valor inválido, que não é importado), o herdado do tenant pai ou global.
+func importedEmailDomains(ctx context.Context, parent *uuid.UUID, records []settingRecord) ([]string, error) {
+	for _, st := range records {
+		if st.Key != settings.KeyAllowedEmailDomains {
+			continue
+		}
+		def, _ := settings.Lookup(st.Key)
+		var domains []string
+		if def.Check(st.Value) == nil && json.Unmarshal(st.Value, &domains) == nil {
+			return domains, nil
+		}
+	}
+	inherited := uuid.Nil
+	if parent != nil {
+		inherited = *parent
+	}
+	return settings.Get[[]string](ctx, inherited, settings.KeyAllowedEmailDomains)
+}
+
+// emailDomainAllowed indica se o domínio do email está na lista (vazia = qualquer domínio).
+func emailDomainAllowed(email string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	domain := email[strings.LastIndex(email, "@")+1:]
+	for _, d := range allowed {
+		if strings.EqualFold(d, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkUserQuota verifica se os usuários importados cabem no limite de usuários do plano do
+// tenant. O tenant ainda não existe, então vale o plano padrão (plans.default_code).
+func checkUserQuota(ctx context.Context, tenantUUID uuid.UUID, users int) error {
+	tenantPlan, err := plan.MustUse().Service.PlanFor(ctx, tenantUUID)
+	if err != nil {
+		return err
+	}
+	if limit := tenantPlan.MaxUsers; limit != nil && int64(users) > *limit {
+		return &plan.QuotaError{Metric: plan.MetricUsers, Used: int64(users), Limit: *limit}
+	}
+	return nil
+}
+
+// planDomains importa os domínios apenas na restauração. Domínios próprios precisam ser verificados
+// novamente no novo ambiente; domínios já cadastrados são ignorados.
+func (s *serviceImpl) planDomains(ctx context.Context, plan *importPlan, domains []domainRecord, opts ImportOptions, remap func(uuid.UUID) uuid.UUID, warn func(string, ...any)) (int, error) {
+	if len(domains) == 0 {
+		return 0, nil
+	}
+	if opts.Mode == ImportClone {
+		warn("Domínios não são clonados; cadastre os domínios do novo tenant.")
+		return len(domains), nil
+	}
+
+	candidates := make([]model.TenantDomain, 0, len(domains))
+	for _, d := range domains {
+		if d.Hostname == "" || (d.Kind != model.DomainKindSubdomain && d.Kind != model.DomainKindCustom) {
+			return 0, fmt.Errorf("%w: domínio %s inválido", ErrInvalidArchive, d.UUID)
+		}
+		token, err := randomHex(16)
+		if err != nil {
+			return 0, err
+		}
+		domain := model.TenantDomain{
+			UUID:              remap(d.UUID),
+			TenantUUID:        plan.Tenant.UUID,
+			Kind:              d.Kind,
+			Hostname:          d.Hostname,
+			VerificationToken: token,
+			CreateAt:          d.CreateAt.Time,
+		}
+		if d.Kind == model.DomainKindSubdomain && d.VerifiedAt != nil {
+			verifiedAt := d.VerifiedAt.Time
+			domain.VerifiedAt = &verifiedAt
+		}
+		candidates = append(candidates, domain)
+	}
+
+	existing, err := s.Repository.ExistingHostnames(ctx, candidates)
+	if err != nil {
+		return 0, err
+	}
+
+	skipped := 0
+	for _, d := range candidates {
+		if existing[string(d.Kind)+"|"+d.Hostname] {
+			skipped++
+			warn("Domínio %s já cadastrado neste ambiente; ignorado.", d.Hostname)
+			continue
+		}
+		if d.Kind == model.DomainKindCustom {
+			warn("Domínio %s importado como não verificado; verifique-o novamente.", d.Hostname)
+		}
+		plan.Domains = append(plan.Domains, d)
+	}
+	return skipped, nil
+}
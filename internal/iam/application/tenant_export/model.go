@@ -0,0 +1,130 @@
+package tenant_export
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Format string
+
+const (
+	FormatJSON   Format = "json"   // Cada arquivo é um array JSON de registros
+	FormatNDJSON Format = "ndjson" // Um registro JSON por linha
+)
+
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusRunning    Status = "running"
+	StatusReady      Status = "ready" // Arquivo disponível para o único download
+	StatusFailed     Status = "failed"
+	StatusDownloaded Status = "downloaded" // Arquivo já entregue e removido do disco
+	StatusExpired    Status = "expired"    // Não baixado dentro do prazo; arquivo removido
+)
+
+// ManifestVersion é a versão do layout do arquivo. Deve ser incrementada sempre que
+// arquivos ou campos forem removidos ou mudarem de significado.
+const ManifestVersion = 1
+
+// Export é uma solicitação de exportação dos dados de um tenant.
+type Export struct {
+	UUID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TenantUUID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	RequestedBy  *uuid.UUID `gorm:"type:uuid"`
+	Format       Format     `gorm:"type:varchar(10);not null"`
+	Status       Status     `gorm:"type:varchar(20);not null"`
+	FileName     string     `gorm:"type:text"`
+	SizeBytes    int64      `gorm:"type:bigint"`
+	SHA256       string     `gorm:"column:sha256;type:varchar(64)"`
+	Error        string     `gorm:"type:text"`
+	CreateAt     time.Time  `gorm:"type:timestamp without time zone;not null"`
+	FinishedAt   *time.Time `gorm:"type:timestamp without time zone"`
+	DownloadedAt *time.Time `gorm:"type:timestamp without time zone"`
+}
+
+func (Export) TableName() string {
+	return "tenant_exports"
+}
+
+// ManifestFile descreve um arquivo de dados do pacote. SHA256 é calculado sobre o conteúdo descompactado.
+type ManifestFile struct {
+	Name    string `json:"name"`
+	Records int64  `json:"records"`
+	Bytes   int64  `json:"bytes"`
+	SHA256  string `json:"sha256"`
+}
+
+// Manifest é gravado como manifest.json na raiz do pacote.
+type Manifest struct {
+	Version     int            `json:"version"`
+	ExportUUID  uuid.UUID      `json:"export_uuid"`
+	TenantUUID  uuid.UUID      `json:"tenant_uuid"`
+	Format      Format         `json:"format"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Files       []ManifestFile `json:"files"`
+}
+
+// dataset é um conjunto de registros exportado em um arquivo próprio. A consulta recebe
+// o tenant no parâmetro nomeado @tenant e retorna uma coluna JSON por registro.
+type dataset struct {
+	Name  string
+	Query string
+	// TenantOwned indica uma tabela do tenant, lida do banco dedicado quando houver
+	TenantOwned bool
+}
+
+// datasets define o conteúdo do pacote. Segredos (hash de senha, tokens de sessão e de
+// verificação de domínio) nunca são exportados.
+var datasets = []dataset{
+	{
+		Name:  "tenant",
+		Query: `SELECT to_jsonb(t) FROM tenant AS t WHERE t.uuid = @tenant`,
+	},
+	{
+		Name:        "users",
+		Query:       `SELECT to_jsonb(u) - 'password_hash' FROM users AS u WHERE u.tenant_uuid = @tenant ORDER BY u.create_at, u.uuid`,
+		TenantOwned: true,
+	},
+	{
+		Name: "sessions",
+		Query: `
+SELECT jsonb_build_object('user_uuid', s.user_uuid, 'expire_date', s.expire_date, 'active', s.expire_date > NOW())
+FROM users_acess_tokens AS s
+INNER JOIN user_directory AS u ON u.uuid = s.user_uuid
+WHERE u.tenant_uuid = @tenant
+ORDER BY s.expire_date`,
+	},
+	{
+		Name:        "groups",
+		Query:       `SELECT to_jsonb(g) FROM tenant_groups AS g WHERE g.tenant_uuid = @tenant ORDER BY g.name`,
+		TenantOwned: true,
+	},
+	{
+		Name: "group_members",
+		Query: `
+SELECT to_jsonb(m)
+FROM tenant_group_members AS m
+INNER JOIN tenant_groups AS g ON g.uuid = m.group_uuid
+WHERE g.tenant_uuid = @tenant
+ORDER BY m.group_uuid, m.user_uuid`,
+		TenantOwned: true,
+	},
+	{
+		Name:  "settings",
+		Query: `SELECT to_jsonb(s) FROM tenant_settings AS s WHERE s.tenant_uuid = @tenant ORDER BY s.key`,
+	},
+	{
+		Name:  "domains",
+		Query: `SELECT to_jsonb(d) - 'verification_token' FROM tenant_domains AS d WHERE d.tenant_uuid = @tenant ORDER BY d.create_at`,
+	},
+	{
+		Name:  "access_log",
+		Query: `SELECT to_jsonb(a) FROM access_log AS a WHERE a.tenant_uuid = @tenant ORDER BY a.id`,
+	},
+	{
+		Name:  "audit_log",
+		Query: `SELECT to_jsonb(a) FROM audit_log AS a WHERE a.tenant_uuid = @tenant OR a.acting_tenant_uuid = @tenant ORDER BY a.id`,
+	},
+}
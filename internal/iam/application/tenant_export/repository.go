@@ -0,0 +1,189 @@
+package tenant_export
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/infra/database/postgres"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// TenantExists considera também tenants com exclusão lógica, que ainda podem ser exportados.
+	TenantExists(ctx context.Context, tenantUUID uuid.UUID) (bool, error)
+	// Create registra a exportação como pendente. Retorna ErrInProgress se o tenant já
+	// possui uma exportação pendente ou em execução.
+	Create(ctx context.Context, export Export) (Export, error)
+	Get(ctx context.Context, exportUUID uuid.UUID) (Export, error)
+	MarkRunning(ctx context.Context, exportUUID uuid.UUID) error
+	MarkReady(ctx context.Context, exportUUID uuid.UUID, fileName string, size int64, sha string) error
+	MarkFailed(ctx context.Context, exportUUID uuid.UUID, reason string) error
+	// ClaimDownload marca a exportação como baixada se ainda estiver disponível.
+	// Apenas uma chamada concorrente obtém sucesso.
+	ClaimDownload(ctx context.Context, exportUUID uuid.UUID) (Export, error)
+	// ExpireReady marca como expiradas as exportações prontas e não baixadas antes de 'before'.
+	ExpireReady(ctx context.Context, before time.Time) ([]Export, error)
+	// FailInterrupted marca como falhas as exportações que estavam em andamento quando o servidor parou.
+	FailInterrupted(ctx context.Context, reason string) (int64, error)
+	DocumentExists(ctx context.Context, document string) (bool, error)
+	// ExistingEmails retorna, dentre os emails informados, os que já pertencem a algum usuário.
+	ExistingEmails(ctx context.Context, emails []string) ([]string, error)
+	// ExistingHostnames retorna os domínios já cadastrados, indexados por "kind|hostname".
+	ExistingHostnames(ctx context.Context, domains []model.TenantDomain) (map[string]bool, error)
+	// Import insere o tenant e seus dados em uma única transação (desfeita em dry-run).
+	Import(ctx context.Context, plan importPlan, dryRun bool) error
+	// Stream executa a consulta do dataset e entrega cada registro JSON a fn, em ordem.
+	// Stream executa a consulta de um dataset; tenantOwned lê do banco dedicado do tenant, quando houver.
+	Stream(ctx context.Context, query string, tenantOwned bool, tenantUUID uuid.UUID, fn func(record []byte) error) (int64, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) TenantExists(ctx context.Context, tenantUUID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Table("tenant").Where("uuid = ?", tenantUUID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, export Export) (Export, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serializa as solicitações do mesmo tenant
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "tenant_export:"+export.TenantUUID.String()).Error; err != nil {
+			return err
+		}
+
+		var inProgress int64
+		err := tx.Model(&Export{}).
+			Where("tenant_uuid = ? AND status IN ?", export.TenantUUID, []Status{StatusPending, StatusRunning}).
+			Count(&inProgress).Error
+		if err != nil {
+			return err
+		}
+		if inProgress > 0 {
+			return ErrInProgress
+		}
+
+		export.Status = StatusPending
+		export.CreateAt = time.Now().UTC()
+		return tx.Create(&export).Error
+	})
+	if err != nil {
+		return Export{}, err
+	}
+	return export, nil
+}
+
+func (r *repositoryImpl) Get(ctx context.Context, exportUUID uuid.UUID) (Export, error) {
+	var export Export
+	if err := r.db.WithContext(ctx).Where("uuid = ?", exportUUID).Take(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Export{}, ErrNotFound
+		}
+		return Export{}, err
+	}
+	return export, nil
+}
+
+func (r *repositoryImpl) MarkRunning(ctx context.Context, exportUUID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&Export{}).
+		Where("uuid = ?", exportUUID).
+		Update("status", StatusRunning).Error
+}
+
+func (r *repositoryImpl) MarkReady(ctx context.Context, exportUUID uuid.UUID, fileName string, size int64, sha string) error {
+	return r.db.WithContext(ctx).Model(&Export{}).
+		Where("uuid = ?", exportUUID).
+		Updates(map[string]any{
+			"status":      StatusReady,
+			"file_name":   fileName,
+			"size_bytes":  size,
+			"sha256":      sha,
+			"finished_at": time.Now().UTC(),
+		}).Error
+}
+
+func (r *repositoryImpl) MarkFailed(ctx context.Context, exportUUID uuid.UUID, reason string) error {
+	return r.db.WithContext(ctx).Model(&Export{}).
+		Where("uuid = ?", exportUUID).
+		Updates(map[string]any{
+			"status":      StatusFailed,
+			"error":       reason,
+			"finished_at": time.Now().UTC(),
+		}).Error
+}
+
+func (r *repositoryImpl) ClaimDownload(ctx context.Context, exportUUID uuid.UUID) (Export, error) {
+	var export Export
+	result := r.db.WithContext(ctx).Raw(`
+UPDATE tenant_exports
+SET status = ?, downloaded_at = ?
+WHERE uuid = ? AND status = ?
+RETURNING *`, StatusDownloaded, time.Now().UTC(), exportUUID, StatusReady).Scan(&export)
+	if result.Error != nil {
+		return Export{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Export{}, ErrNotReady
+	}
+	return export, nil
+}
+
+func (r *repositoryImpl) ExpireReady(ctx context.Context, before time.Time) ([]Export, error) {
+	var expired []Export
+	err := r.db.WithContext(ctx).Raw(`
+UPDATE tenant_exports
+SET status = ?
+WHERE status = ? AND finished_at < ?
+RETURNING *`, StatusExpired, StatusReady, before).Scan(&expired).Error
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+func (r *repositoryImpl) FailInterrupted(ctx context.Context, reason string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&Export{}).
+		Where("status IN ?", []Status{StatusPending, StatusRunning}).
+		Updates(map[string]any{
+			"status":      StatusFailed,
+			"error":       reason,
+			"finished_at": time.Now().UTC(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *repositoryImpl) Stream(ctx context.Context, query string, tenantOwned bool, tenantUUID uuid.UUID, fn func(record []byte) error) (int64, error) {
+	conn := r.db.WithContext(ctx)
+	if tenantOwned {
+		conn = postgres.Conn(ctx, r.db)
+	}
+	rows, err := conn.Raw(query, sql.Named("tenant", tenantUUID)).Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		var record []byte
+		if err := rows.Scan(&record); err != nil {
+			return count, err
+		}
+		if err := fn(record); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, rows.Err()
+}
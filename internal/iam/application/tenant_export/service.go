@@ -0,0 +1,231 @@
+package tenant_export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"tenant-crud-simply/internal/iam/middleware"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	// Request registra a exportação e a executa em segundo plano. Acompanhe pelo Get.
+	Request(ctx context.Context, tenantUUID uuid.UUID, requestedBy *uuid.UUID, format Format) (Export, error)
+	// Run executa a exportação de forma síncrona (linha de comando).
+	Run(ctx context.Context, tenantUUID uuid.UUID, requestedBy *uuid.UUID, format Format) (Export, error)
+	// Get retorna a exportação se ela pertencer ao tenant informado.
+	Get(ctx context.Context, tenantUUID, exportUUID uuid.UUID) (Export, error)
+	// Download entrega o arquivo uma única vez. O arquivo é removido do disco ao fechar o reader.
+	Download(ctx context.Context, tenantUUID, exportUUID uuid.UUID) (io.ReadCloser, Export, error)
+	// ExpireOld remove os arquivos prontos e não baixados dentro do prazo (Config.TTL).
+	ExpireOld(ctx context.Context) error
+	// FailInterrupted marca como falhas as exportações interrompidas por uma parada do servidor.
+	FailInterrupted(ctx context.Context) error
+	// Import cria um tenant a partir de um pacote de exportação, restaurando-o ou clonando-o.
+	// Tudo é inserido em uma única transação; em dry-run nada é gravado.
+	Import(ctx context.Context, r io.ReaderAt, size int64, opts ImportOptions) (ImportReport, error)
+}
+
+type serviceImpl struct {
+	Repository Repository
+	cfg        Config
+	// slots limita as exportações executadas em paralelo
+	slots chan struct{}
+}
+
+func NewService(repository Repository, cfg Config) Service {
+	if cfg.Directory == "" {
+		cfg.Directory = "exports"
+	}
+	if cfg.MaxConcurrent <= 0 {
+		cfg.MaxConcurrent = 2
+	}
+	return &serviceImpl{
+		Repository: repository,
+		cfg:        cfg,
+		slots:      make(chan struct{}, cfg.MaxConcurrent),
+	}
+}
+
+func (s *serviceImpl) create(ctx context.Context, tenantUUID uuid.UUID, requestedBy *uuid.UUID, format Format) (Export, error) {
+	if format == "" {
+		format = FormatJSON
+	}
+	if format != FormatJSON && format != FormatNDJSON {
+		return Export{}, ErrInvalidFormat
+	}
+
+	exists, err := s.Repository.TenantExists(ctx, tenantUUID)
+	if err != nil {
+		return Export{}, err
+	}
+	if !exists {
+		return Export{}, ErrTenantNotFound
+	}
+
+	return s.Repository.Create(ctx, Export{
+		TenantUUID:  tenantUUID,
+		RequestedBy: requestedBy,
+		Format:      format,
+	})
+}
+
+func (s *serviceImpl) Request(ctx context.Context, tenantUUID uuid.UUID, requestedBy *uuid.UUID, format Format) (Export, error) {
+	export, err := s.create(ctx, tenantUUID, requestedBy, format)
+	if err != nil {
+		return Export{}, err
+	}
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("[TENANT-EXPORT] Panic na exportação %s: %v", export.UUID, r)
+				_ = s.Repository.MarkFailed(context.Background(), export.UUID, "erro interno")
+			}
+		}()
+
+		s.slots <- struct{}{}
+		defer func() { <-s.slots }()
+
+		if _, err := s.execute(context.Background(), export); err != nil {
+			log.Printf("[TENANT-EXPORT] Falha na exportação %s do tenant %s: %v", export.UUID, export.TenantUUID, err)
+		}
+	}()
+
+	return export, nil
+}
+
+func (s *serviceImpl) Run(ctx context.Context, tenantUUID uuid.UUID, requestedBy *uuid.UUID, format Format) (Export, error) {
+	export, err := s.create(ctx, tenantUUID, requestedBy, format)
+	if err != nil {
+		return Export{}, err
+	}
+	return s.execute(ctx, export)
+}
+
+// execute gera o pacote em um arquivo temporário e só o publica (rename) quando completo.
+func (s *serviceImpl) execute(ctx context.Context, export Export) (Export, error) {
+	if err := s.Repository.MarkRunning(ctx, export.UUID); err != nil {
+		return export, err
+	}
+
+	fail := func(err error) (Export, error) {
+		if markErr := s.Repository.MarkFailed(context.WithoutCancel(ctx), export.UUID, err.Error()); markErr != nil {
+			log.Printf("[TENANT-EXPORT] Falha ao registrar erro da exportação %s: %v", export.UUID, markErr)
+		}
+		return export, err
+	}
+
+	dir, err := filepath.Abs(s.cfg.Directory)
+	if err != nil {
+		return fail(err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fail(fmt.Errorf("falha ao criar diretório de exportação: %w", err))
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("tenant-%s-%s.zip", export.TenantUUID, export.UUID))
+	tmp := path + ".part"
+
+	// Em tenants isolados por schema ou banco, usuários e grupos são lidos do schema (ou banco) do tenant
+	archiveCtx, release, err := middleware.TenantScope(ctx, &export.TenantUUID)
+	if err != nil {
+		return fail(err)
+	}
+	size, sum, err := s.writeArchive(archiveCtx, tmp, export.UUID, export.TenantUUID, export.Format)
+	release()
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fail(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fail(err)
+	}
+
+	if err := s.Repository.MarkReady(ctx, export.UUID, path, size, sum); err != nil {
+		_ = os.Remove(path)
+		return export, err
+	}
+	return s.Repository.Get(ctx, export.UUID)
+}
+
+func (s *serviceImpl) Get(ctx context.Context, tenantUUID, exportUUID uuid.UUID) (Export, error) {
+	export, err := s.Repository.Get(ctx, exportUUID)
+	if err != nil {
+		return Export{}, err
+	}
+	// Exportações de outro tenant são tratadas como inexistentes
+	if export.TenantUUID != tenantUUID {
+		return Export{}, ErrNotFound
+	}
+	return export, nil
+}
+
+// oneShotFile remove o arquivo de exportação ao ser fechado.
+type oneShotFile struct {
+	*os.File
+}
+
+func (f oneShotFile) Close() error {
+	err := f.File.Close()
+	if rmErr := os.Remove(f.Name()); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
+		log.Printf("[TENANT-EXPORT] Falha ao remover arquivo %s: %v", f.Name(), rmErr)
+	}
+	return err
+}
+
+func (s *serviceImpl) Download(ctx context.Context, tenantUUID, exportUUID uuid.UUID) (io.ReadCloser, Export, error) {
+	if _, err := s.Get(ctx, tenantUUID, exportUUID); err != nil {
+		return nil, Export{}, err
+	}
+
+	export, err := s.Repository.ClaimDownload(ctx, exportUUID)
+	if err != nil {
+		return nil, Export{}, err
+	}
+
+	file, err := os.Open(export.FileName)
+	if err != nil {
+		// O download já foi consumido; o arquivo ausente não pode mais ser entregue
+		_ = s.Repository.MarkFailed(context.WithoutCancel(ctx), export.UUID, "arquivo de exportação não encontrado")
+		return nil, Export{}, fmt.Errorf("falha ao abrir arquivo de exportação: %w", err)
+	}
+	return oneShotFile{File: file}, export, nil
+}
+
+func (s *serviceImpl) ExpireOld(ctx context.Context) error {
+	if s.cfg.TTL <= 0 {
+		return nil
+	}
+	expired, err := s.Repository.ExpireReady(ctx, time.Now().UTC().Add(-s.cfg.TTL))
+	if err != nil {
+		return err
+	}
+	for _, export := range expired {
+		if err := os.Remove(export.FileName); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("[TENANT-EXPORT] Falha ao remover arquivo expirado %s: %v", export.FileName, err)
+		}
+	}
+	if len(expired) > 0 {
+		log.Printf("[TENANT-EXPORT] %d exportação(ões) expirada(s)", len(expired))
+	}
+	return nil
+}
+
+func (s *serviceImpl) FailInterrupted(ctx context.Context) error {
+	count, err := s.Repository.FailInterrupted(ctx, "exportação interrompida pela parada do servidor")
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		log.Printf("[TENANT-EXPORT] %d exportação(ões) interrompida(s) marcada(s) como falha", count)
+	}
+	return nil
+}
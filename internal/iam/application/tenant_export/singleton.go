@@ -0,0 +1,81 @@
+package tenant_export
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	repositoryInstance Repository
+	once               sync.Once
+	initErr            error
+	ErrNotInitialized  = errors.New("tenant export controller not initialized")
+)
+
+// UseTenantExport agrupa todas as camadas (Repository, Service, Controller)
+type UseTenantExport struct {
+	Repository Repository
+	Service    Service
+	Controller Controller
+}
+
+// Config usada somente no New()
+type Config struct {
+	// Directory é o diretório local onde os pacotes são gravados (padrão "exports").
+	Directory string
+	// TTL é o prazo para o download; depois dele o arquivo é removido. 0 = sem prazo.
+	TTL time.Duration
+	// MaxConcurrent limita as exportações executadas em paralelo (padrão 2).
+	MaxConcurrent int
+}
+
+// New inicializa o singleton do controller de exportação com todas as suas dependências
+func New(db *gorm.DB, cfg Config) (Controller, error) {
+	once.Do(func() {
+		if db == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+
+		// Inicializa as dependências em camadas
+		repositoryInstance = NewRepository(db)
+		serviceInstance = NewService(repositoryInstance, cfg)
+		controllerInstance = NewController(serviceInstance)
+
+		// Exportações em andamento não sobrevivem a um reinício do servidor
+		if err := serviceInstance.FailInterrupted(context.Background()); err != nil {
+			log.Printf("[TENANT-EXPORT] Falha ao encerrar exportações interrompidas: %v", err)
+		}
+	})
+
+	return controllerInstance, initErr
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Repository, Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UseTenantExport {
+	if controllerInstance == nil || serviceInstance == nil || repositoryInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UseTenantExport{
+		Repository: repositoryInstance,
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
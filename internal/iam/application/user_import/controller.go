@@ -0,0 +1,349 @@
+package user_import
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	Import(c *gin.Context)
+	Status(c *gin.Context)
+	Result(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service     Service
+	mw          middleware.Middleware
+	maxFileSize int64
+}
+
+func NewController(service Service, maxFileSize int64) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service:     service,
+		mw:          mw,
+		maxFileSize: maxFileSize,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+
+		// PARTNER_ADMIN atuando em um tenant descendente: o log pertence ao tenant alvo
+		// e registra o ancestral que executou a ação.
+		if target, ok := middleware.GetTargetTenant(c); ok && login.User.Role == model.RolePartnerAdmin &&
+			(tenantUUID == nil || *tenantUUID != target) {
+			actingTenantUUID = tenantUUID
+			tenantUUID = &target
+		}
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "user_import",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	importGroup := routes.Group("/user/import")
+
+	{
+		importGroup.POST("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Import)
+		importGroup.GET("/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Status)
+		importGroup.GET("/:uuid/result", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Result)
+	}
+}
+
+// assignableRoles são as roles que cada perfil pode atribuir, as mesmas de POST /api/user/:identifier.
+var assignableRoles = map[model.UserRole][]model.UserRole{
+	model.RoleSystemAdmin:  {model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin, model.RoleTenantUser},
+	model.RolePartnerAdmin: {model.RolePartnerAdmin, model.RoleTenantAdmin, model.RoleTenantUser},
+	model.RoleTenantAdmin:  {model.RoleTenantAdmin, model.RoleTenantUser},
+}
+
+// targetTenant resolve o tenant da importação: SYSTEM_ADMIN informa qualquer tenant, PARTNER_ADMIN
+// um tenant da sua hierarquia (padrão: o próprio) e TENANT_ADMIN importa apenas no próprio tenant.
+func (ctrl *controllerImpl) targetTenant(c *gin.Context, login *middleware.Login, identifier string) (uuid.UUID, *rest_err.RestErr) {
+	trace := &login.Metadata.RayTraceCode
+	if login.User.Role == model.RoleTenantAdmin || (login.User.Role == model.RolePartnerAdmin && identifier == "") {
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(trace, "Usuário não associado a um tenant.")
+		}
+		own := *login.User.TenantUUID
+		if identifier != "" && identifier != own.String() && identifier != login.User.Tenant.Document {
+			return uuid.Nil, rest_err.NewForbiddenError(trace, "Acesso permitido apenas ao próprio tenant.")
+		}
+		return own, nil
+	}
+	if identifier == "" {
+		return uuid.Nil, rest_err.NewBadRequestError(trace, "Informe o tenant em 'tenant_identifier'.")
+	}
+
+	t := tenant.Tenant{}
+	if id, err := uuid.Parse(identifier); err == nil {
+		t.UUID = id
+	} else {
+		t.Document = identifier
+	}
+	t, err := tenant.MustUse().Service.Read(c.Request.Context(), t)
+	if err != nil {
+		if errors.Is(err, tenant.ErrNotFound) {
+			return uuid.Nil, rest_err.NewNotFoundError(trace, "tenant not found")
+		}
+		return uuid.Nil, rest_err.NewInternalServerError(trace, "internal server error", nil)
+	}
+	if restError := ctrl.authorizeTenant(c, login, t.UUID); restError != nil {
+		return uuid.Nil, restError
+	}
+	return t.UUID, nil
+}
+
+// authorizeTenant verifica se o usuário pode acessar as importações do tenant.
+func (ctrl *controllerImpl) authorizeTenant(c *gin.Context, login *middleware.Login, target uuid.UUID) *rest_err.RestErr {
+	trace := &login.Metadata.RayTraceCode
+	switch login.User.Role {
+	case model.RoleSystemAdmin:
+		return nil
+
+	case model.RolePartnerAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return rest_err.NewForbiddenError(trace, "Usuário não associado a um tenant.")
+		}
+		inSubtree, err := tenant.MustUse().Service.InSubtree(c.Request.Context(), *login.User.TenantUUID, target)
+		if err != nil {
+			return rest_err.NewInternalServerError(trace, "Falha ao verificar hierarquia de tenants", nil)
+		}
+		if !inSubtree {
+			return rest_err.NewForbiddenError(trace, "Tenant fora da sua hierarquia.")
+		}
+		middleware.SetTargetTenant(c, target)
+		return nil
+
+	case model.RoleTenantAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID != target {
+			return rest_err.NewForbiddenError(trace, "Acesso permitido apenas ao próprio tenant.")
+		}
+		return nil
+
+	default:
+		return rest_err.NewForbiddenError(trace, "Ação não permitida.")
+	}
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	trace := &login.Metadata.RayTraceCode
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return rest_err.NewNotFoundError(trace, err.Error())
+	case errors.Is(err, ErrInvalidFile), errors.Is(err, ErrEmptyFile), errors.Is(err, ErrTooManyRows):
+		return rest_err.NewBadRequestError(trace, err.Error())
+	case errors.Is(err, ErrNotReady):
+		return rest_err.NewConflictValidationError(trace, "Resultado indisponível (importação em andamento, com falha ou expirada).", nil)
+	default:
+		return rest_err.NewInternalServerError(trace, "internal server error", nil)
+	}
+}
+
+// job carrega a importação da URL e verifica o acesso ao seu tenant. Importações de tenants
+// fora do alcance do usuário são tratadas como inexistentes.
+func (ctrl *controllerImpl) job(c *gin.Context, login *middleware.Login) (Job, *rest_err.RestErr) {
+	jobUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		return Job{}, rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "O UUID da importação não é um formato válido.")
+	}
+	job, err := ctrl.Service.Get(c.Request.Context(), jobUUID)
+	if err != nil {
+		return Job{}, ctrl.restError(login, err)
+	}
+	if restError := ctrl.authorizeTenant(c, login, job.TenantUUID); restError != nil {
+		return Job{}, ctrl.restError(login, ErrNotFound)
+	}
+	return job, nil
+}
+
+// @Summary      Importa Usuários em Lote
+// @Description  Recebe uma planilha CSV (separada por vírgula ou ponto e vírgula) ou XLSX com o cabeçalho name, email e, opcionalmente, role (padrão TENANT_USER), password e metadata.<chave>. Todas as linhas são validadas (formato do email, role permitida ao seu perfil, campos personalizados e emails repetidos no arquivo ou já cadastrados) e os erros são informados por linha. Com dry_run=true nada é criado. Caso contrário, os usuários são criados em lotes, em segundo plano: linhas sem senha recebem um convite por email para definir a senha. Acompanhe pelo status e baixe o resultado de cada linha ao final.
+// @Tags         User
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        file formData file true "Planilha .csv ou .xlsx"
+// @Param        tenant_identifier formData string false "UUID ou Documento do tenant (obrigatório para SYSTEM_ADMIN; PARTNER_ADMIN usa o próprio se vazio)"
+// @Param        dry_run formData bool false "Apenas valida o arquivo"
+// @Param        skip_invalid formData bool false "Importa as linhas válidas mesmo que outras tenham erros"
+// @Success      200  {object}  ImportResponseDto "Validação (dry_run)"
+// @Success      202  {object}  ImportResponseDto "Importação agendada (job)"
+// @Failure      400  {object}  rest_err.RestErr "Arquivo ilegível, sem cabeçalho válido ou grande demais."
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      422  {object}  ImportResponseDto "Linhas inválidas (sem skip_invalid) ou nenhuma linha válida."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/user/import [post]
+func (ctrl *controllerImpl) Import(c *gin.Context) {
+	var req ImportRequestDto
+	if err := c.ShouldBind(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid form data")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.targetTenant(c, ctxIdentify, req.TenantIdentifier)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "Envie a planilha no campo 'file'.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	if header.Size > ctrl.maxFileSize {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, fmt.Sprintf("O arquivo excede o tamanho máximo de %d MB.", ctrl.maxFileSize>>20))
+		c.JSON(restError.Code, restError)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao ler o arquivo enviado.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	defer file.Close()
+
+	input := map[string]any{"request": req, "tenant_uuid": tenantUUID, "file_name": header.Filename, "size": header.Size}
+	report, err := ctrl.Service.Import(c.Request.Context(), file, header.Size, header.Filename, Options{
+		TenantUUID:  tenantUUID,
+		RequestedBy: &ctxIdentify.User.UUID,
+		Roles:       assignableRoles[ctxIdentify.User.Role],
+		DryRun:      req.DryRun,
+		SkipInvalid: req.SkipInvalid,
+	})
+	if errors.Is(err, ErrInvalidRows) {
+		resp := toImportResponse(report)
+		ctrl.logAudit(c, ctxIdentify, "import", "Import", false, input, map[string]any{"errors": len(resp.Errors)})
+		c.JSON(http.StatusUnprocessableEntity, resp)
+		return
+	}
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "import", "Import", false, input, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	resp := toImportResponse(report)
+	if report.DryRun {
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+	ctrl.logAudit(c, ctxIdentify, "import", "Import", true, input, resp.Job)
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// @Summary      Status da Importação de Usuários
+// @Description  Retorna o andamento da importação: pending, running, completed (result_url disponível), failed ou expired, com os contadores de usuários criados, convidados e com falha.
+// @Tags         User
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID da importação"
+// @Success      200  {object}  JobResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Importação não encontrada."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/user/import/{uuid} [get]
+func (ctrl *controllerImpl) Status(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	job, restError := ctrl.job(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+	c.JSON(http.StatusOK, toJobResponse(job))
+}
+
+// @Summary      Resultado da Importação de Usuários
+// @Description  Entrega o CSV com o resultado de cada linha (line, email, status, user_uuid, message). status: created, invited, failed ou skipped (inválida na validação). Disponível até expirar (users.import.ttl_hours).
+// @Tags         User
+// @Produce      text/csv
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID da importação"
+// @Success      200  {file}  file
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Importação não encontrada."
+// @Failure      409  {object}  rest_err.RestErr "Resultado indisponível."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/user/import/{uuid}/result [get]
+func (ctrl *controllerImpl) Result(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	job, restError := ctrl.job(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	reader, job, err := ctrl.Service.Result(c.Request.Context(), job.UUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, -1, "text/csv; charset=utf-8", reader, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="user-import-%s.csv"`, job.UUID),
+	})
+}
@@ -0,0 +1,10 @@
+package user_import
+
+// ImportRequestDto acompanha o upload (multipart, campo 'file') da planilha de usuários.
+type ImportRequestDto struct {
+	// UUID ou documento do tenant (obrigatório para SYSTEM_ADMIN; PARTNER_ADMIN usa o próprio tenant se vazio)
+	TenantIdentifier string `form:"tenant_identifier"`
+	DryRun           bool   `form:"dry_run"`
+	// Importa as linhas válidas mesmo que outras tenham erros
+	SkipInvalid bool `form:"skip_invalid"`
+}
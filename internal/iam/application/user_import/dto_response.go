@@ -0,0 +1,82 @@
+package user_import
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobResponseDto struct {
+	UUID       uuid.UUID  `json:"uuid"`
+	TenantUUID uuid.UUID  `json:"tenant_uuid"`
+	FileName   string     `json:"file_name"`
+	Status     string     `json:"status"`
+	TotalRows  int        `json:"total_rows"`
+	Processed  int        `json:"processed"`
+	Created    int        `json:"created"`
+	Invited    int        `json:"invited"`
+	Failed     int        `json:"failed"`
+	Skipped    int        `json:"skipped"`
+	Error      string     `json:"error,omitempty"`
+	ResultURL  string     `json:"result_url,omitempty"`
+	CreateAt   time.Time  `json:"create_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+func toJobResponse(j Job) JobResponseDto {
+	resp := JobResponseDto{
+		UUID:       j.UUID,
+		TenantUUID: j.TenantUUID,
+		FileName:   j.FileName,
+		Status:     string(j.Status),
+		TotalRows:  j.TotalRows,
+		Processed:  j.Processed,
+		Created:    j.Created,
+		Invited:    j.Invited,
+		Failed:     j.Failed,
+		Skipped:    j.Skipped,
+		Error:      j.Error,
+		CreateAt:   j.CreateAt,
+		FinishedAt: j.FinishedAt,
+	}
+	if j.Status == StatusCompleted {
+		resp.ResultURL = fmt.Sprintf("/api/user/import/%s/result", j.UUID)
+	}
+	return resp
+}
+
+// RowErrorResponseDto é um erro de validação. line 0 indica um problema do arquivo.
+type RowErrorResponseDto struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ImportResponseDto struct {
+	DryRun    bool                  `json:"dry_run"`
+	TotalRows int                   `json:"total_rows"`
+	ValidRows int                   `json:"valid_rows"`
+	Invites   int                   `json:"invites"`
+	Errors    []RowErrorResponseDto `json:"errors"`
+	// Job é a importação agendada; ausente em dry-run ou quando a importação é recusada
+	Job *JobResponseDto `json:"job,omitempty"`
+}
+
+func toImportResponse(r Report) ImportResponseDto {
+	resp := ImportResponseDto{
+		DryRun:    r.DryRun,
+		TotalRows: r.TotalRows,
+		ValidRows: r.ValidRows,
+		Invites:   r.Invites,
+		Errors:    make([]RowErrorResponseDto, 0, len(r.Errors)),
+	}
+	for _, e := range r.Errors {
+		resp.Errors = append(resp.Errors, RowErrorResponseDto{Line: e.Line, Field: e.Field, Message: e.Message})
+	}
+	if r.Job != nil {
+		job := toJobResponse(*r.Job)
+		resp.Job = &job
+	}
+	return resp
+}
@@ -0,0 +1,14 @@
+package user_import
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("user import not found")
+	// ErrInvalidFile indica um arquivo ilegível, em formato não suportado ou sem o cabeçalho esperado.
+	ErrInvalidFile = errors.New("invalid import file")
+	ErrTooManyRows = errors.New("import file exceeds the maximum number of rows")
+	ErrEmptyFile   = errors.New("import file has no rows")
+	// ErrInvalidRows indica linhas com erro sem skip_invalid; o relatório traz os erros.
+	ErrInvalidRows = errors.New("import file has invalid rows")
+	ErrNotReady    = errors.New("import result is not available")
+)
@@ -0,0 +1,87 @@
+package user_import
+
+import (
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed" // Todas as linhas processadas; o resultado de cada uma está no arquivo
+	StatusFailed    Status = "failed"
+	StatusExpired   Status = "expired" // Arquivo de resultado removido após o prazo
+)
+
+// Job é uma importação de usuários executada em segundo plano.
+type Job struct {
+	UUID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TenantUUID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	RequestedBy *uuid.UUID `gorm:"type:uuid"`
+	FileName    string     `gorm:"type:text;not null"` // Nome do arquivo enviado
+	Status      Status     `gorm:"type:varchar(20);not null"`
+	TotalRows   int        `gorm:"not null"`
+	Processed   int        `gorm:"not null"`
+	Created     int        `gorm:"not null"`
+	Invited     int        `gorm:"not null"`
+	Failed      int        `gorm:"not null"`
+	Skipped     int        `gorm:"not null"` // Linhas inválidas na validação (skip_invalid)
+	ResultFile  string     `gorm:"type:text"`
+	Error       string     `gorm:"type:text"`
+	CreateAt    time.Time  `gorm:"type:timestamp without time zone;not null"`
+	FinishedAt  *time.Time `gorm:"type:timestamp without time zone"`
+}
+
+func (Job) TableName() string {
+	return "user_imports"
+}
+
+// Row é uma linha válida do arquivo. Line é o número da linha na planilha (o cabeçalho é a linha 1).
+type Row struct {
+	Line     int
+	Name     string
+	Email    string
+	Role     model.UserRole
+	Password string // Vazio = o usuário recebe um convite para definir a senha
+	Metadata model.Metadata
+}
+
+// RowError é um problema encontrado em uma linha. Line 0 indica um problema do arquivo (ex.: cabeçalho).
+type RowError struct {
+	Line    int
+	Field   string
+	Message string
+}
+
+// Options são as opções da importação. Roles são as roles que o solicitante pode atribuir.
+type Options struct {
+	TenantUUID  uuid.UUID
+	RequestedBy *uuid.UUID
+	Roles       []model.UserRole
+	DryRun      bool
+	// SkipInvalid importa as linhas válidas mesmo que outras tenham erros.
+	SkipInvalid bool
+}
+
+// Report é o resultado da validação. Job é nil em dry-run ou quando a importação é recusada.
+type Report struct {
+	DryRun    bool
+	TotalRows int
+	ValidRows int
+	Invites   int // Linhas válidas sem senha, que receberão convite
+	Errors    []RowError
+	Job       *Job
+}
+
+// Resultado de cada linha no arquivo de resultado
+const (
+	resultCreated = "created"
+	resultInvited = "invited"
+	resultFailed  = "failed"
+	resultSkipped = "skipped"
+)
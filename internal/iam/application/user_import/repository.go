@@ -0,0 +1,139 @@
+package user_import
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Progress são os contadores de uma importação, gravados a cada lote.
+type Progress struct {
+	Processed int
+	Created   int
+	Invited   int
+	Failed    int
+}
+
+type Repository interface {
+	Create(ctx context.Context, job Job) (Job, error)
+	Get(ctx context.Context, jobUUID uuid.UUID) (Job, error)
+	MarkRunning(ctx context.Context, jobUUID uuid.UUID) error
+	UpdateProgress(ctx context.Context, jobUUID uuid.UUID, p Progress) error
+	MarkCompleted(ctx context.Context, jobUUID uuid.UUID, resultFile string, p Progress) error
+	MarkFailed(ctx context.Context, jobUUID uuid.UUID, reason string) error
+	// ExpireCompleted marca como expiradas as importações concluídas antes de 'before' e
+	// retorna-as para que os arquivos de resultado sejam removidos.
+	ExpireCompleted(ctx context.Context, before time.Time) ([]Job, error)
+	// FailInterrupted marca como falhas as importações que estavam em andamento quando o servidor parou.
+	FailInterrupted(ctx context.Context, reason string) (int64, error)
+	// ExistingEmails retorna, dentre os emails informados (minúsculos), os que já pertencem a algum usuário.
+	ExistingEmails(ctx context.Context, emails []string) ([]string, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, job Job) (Job, error) {
+	job.Status = StatusPending
+	job.CreateAt = time.Now().UTC()
+	if err := r.db.WithContext(ctx).Create(&job).Error; err != nil {
+		return Job{}, err
+	}
+	return job, nil
+}
+
+func (r *repositoryImpl) Get(ctx context.Context, jobUUID uuid.UUID) (Job, error) {
+	var job Job
+	if err := r.db.WithContext(ctx).Where("uuid = ?", jobUUID).Take(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Job{}, ErrNotFound
+		}
+		return Job{}, err
+	}
+	return job, nil
+}
+
+func (r *repositoryImpl) MarkRunning(ctx context.Context, jobUUID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&Job{}).
+		Where("uuid = ?", jobUUID).
+		Update("status", StatusRunning).Error
+}
+
+func (r *repositoryImpl) UpdateProgress(ctx context.Context, jobUUID uuid.UUID, p Progress) error {
+	return r.db.WithContext(ctx).Model(&Job{}).
+		Where("uuid = ?", jobUUID).
+		Updates(progressFields(p)).Error
+}
+
+func (r *repositoryImpl) MarkCompleted(ctx context.Context, jobUUID uuid.UUID, resultFile string, p Progress) error {
+	fields := progressFields(p)
+	fields["status"] = StatusCompleted
+	fields["result_file"] = resultFile
+	fields["finished_at"] = time.Now().UTC()
+	return r.db.WithContext(ctx).Model(&Job{}).
+		Where("uuid = ?", jobUUID).
+		Updates(fields).Error
+}
+
+func progressFields(p Progress) map[string]any {
+	return map[string]any{
+		"processed": p.Processed,
+		"created":   p.Created,
+		"invited":   p.Invited,
+		"failed":    p.Failed,
+	}
+}
+
+func (r *repositoryImpl) MarkFailed(ctx context.Context, jobUUID uuid.UUID, reason string) error {
+	return r.db.WithContext(ctx).Model(&Job{}).
+		Where("uuid = ?", jobUUID).
+		Updates(map[string]any{
+			"status":      StatusFailed,
+			"error":       reason,
+			"finished_at": time.Now().UTC(),
+		}).Error
+}
+
+func (r *repositoryImpl) ExpireCompleted(ctx context.Context, before time.Time) ([]Job, error) {
+	var expired []Job
+	err := r.db.WithContext(ctx).Raw(`
+UPDATE user_imports
+SET status = ?
+WHERE status = ? AND finished_at < ?
+RETURNING *`, StatusExpired, StatusCompleted, before).Scan(&expired).Error
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+func (r *repositoryImpl) FailInterrupted(ctx context.Context, reason string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&Job{}).
+		Where("status IN ?", []Status{StatusPending, StatusRunning}).
+		Updates(map[string]any{
+			"status":      StatusFailed,
+			"error":       reason,
+			"finished_at": time.Now().UTC(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *repositoryImpl) ExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	if len(emails) == 0 {
+		return nil, nil
+	}
+	var existing []string
+	// user_directory cobre também os usuários dos tenants isolados por schema ou banco
+	err := r.db.WithContext(ctx).Table("user_directory").
+		Where("LOWER(email) IN ?", emails).
+		Pluck("LOWER(email)", &existing).Error
+	return existing, err
+}
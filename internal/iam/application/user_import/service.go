@@ -0,0 +1,382 @@
+package user_import
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"tenant-crud-simply/internal/iam/application/auth"
+	"tenant-crud-simply/internal/iam/domain/custom_field"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/plan"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/domain/user"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	// Import valida todas as linhas do arquivo (CSV ou XLSX) e, fora do dry-run, registra a
+	// importação e cria os usuários em segundo plano. Com linhas inválidas e sem SkipInvalid,
+	// retorna ErrInvalidRows junto com o relatório.
+	Import(ctx context.Context, r io.ReaderAt, size int64, fileName string, opts Options) (Report, error)
+	Get(ctx context.Context, jobUUID uuid.UUID) (Job, error)
+	// Result abre o arquivo de resultado (CSV) de uma importação concluída.
+	Result(ctx context.Context, jobUUID uuid.UUID) (io.ReadCloser, Job, error)
+	// ExpireOld remove os arquivos de resultado mais antigos que Config.TTL.
+	ExpireOld(ctx context.Context) error
+	// FailInterrupted marca como falhas as importações interrompidas por uma parada do servidor.
+	FailInterrupted(ctx context.Context) error
+}
+
+type serviceImpl struct {
+	Repository Repository
+	cfg        Config
+	// slots limita as importações executadas em paralelo
+	slots chan struct{}
+}
+
+func NewService(repository Repository, cfg Config) Service {
+	if cfg.Directory == "" {
+		cfg.Directory = "imports"
+	}
+	if cfg.MaxConcurrent <= 0 {
+		cfg.MaxConcurrent = 2
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.MaxRows <= 0 {
+		cfg.MaxRows = DefaultMaxRows
+	}
+	if cfg.InviteTTL <= 0 {
+		cfg.InviteTTL = 72 * time.Hour
+	}
+	return &serviceImpl{
+		Repository: repository,
+		cfg:        cfg,
+		slots:      make(chan struct{}, cfg.MaxConcurrent),
+	}
+}
+
+// entry é uma linha do arquivo no resultado: válida (row) ou recusada na validação (errs).
+type entry struct {
+	line int
+	row  *Row
+	errs []RowError
+}
+
+func (s *serviceImpl) Import(ctx context.Context, r io.ReaderAt, size int64, fileName string, opts Options) (Report, error) {
+	rows, err := readSheet(r, size, s.cfg.MaxRows)
+	if err != nil {
+		return Report{}, err
+	}
+	if len(rows) < 2 {
+		return Report{}, ErrEmptyFile
+	}
+	h, err := parseHeader(rows[0].Cells)
+	if err != nil {
+		return Report{}, err
+	}
+
+	entries, report, err := s.validate(ctx, h, rows[1:], opts)
+	if err != nil {
+		return Report{}, err
+	}
+	if opts.DryRun {
+		return report, nil
+	}
+	if len(report.Errors) > 0 && !opts.SkipInvalid {
+		return report, ErrInvalidRows
+	}
+	if report.ValidRows == 0 {
+		return report, ErrInvalidRows
+	}
+
+	job, err := s.Repository.Create(ctx, Job{
+		TenantUUID:  opts.TenantUUID,
+		RequestedBy: opts.RequestedBy,
+		FileName:    filepath.Base(fileName),
+		TotalRows:   report.TotalRows,
+		Skipped:     report.TotalRows - report.ValidRows,
+	})
+	if err != nil {
+		return Report{}, err
+	}
+	report.Job = &job
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("[USER-IMPORT] Panic na importação %s: %v", job.UUID, r)
+				_ = s.Repository.MarkFailed(context.Background(), job.UUID, "erro interno")
+			}
+		}()
+
+		s.slots <- struct{}{}
+		defer func() { <-s.slots }()
+
+		if err := s.execute(context.Background(), job, entries); err != nil {
+			log.Printf("[USER-IMPORT] Falha na importação %s do tenant %s: %v", job.UUID, job.TenantUUID, err)
+		}
+	}()
+
+	return report, nil
+}
+
+// validate valida as linhas (sem o cabeçalho) e retorna cada uma como entry, em ordem.
+func (s *serviceImpl) validate(ctx context.Context, h header, rows []sheetRow, opts Options) ([]entry, Report, error) {
+	defs, err := custom_field.MustUse().Service.Effective(ctx, &opts.TenantUUID, model.CustomFieldEntityUser)
+	if err != nil {
+		return nil, Report{}, err
+	}
+	validateMetadata, err := custom_field.MustUse().Service.Validator(ctx, model.CustomFieldEntityUser, &opts.TenantUUID)
+	if err != nil {
+		return nil, Report{}, err
+	}
+	v := validator{roles: opts.Roles, types: map[string]model.CustomFieldType{}, metadata: validateMetadata}
+	for _, def := range defs {
+		v.types[def.Key] = def.Type
+	}
+
+	entries := make([]entry, 0, len(rows))
+	firstLine := map[string]int{} // email -> primeira linha em que aparece
+	var emails []string
+	for _, r := range rows {
+		row, errs := v.row(h, r)
+		if row.Email != "" {
+			if line, dup := firstLine[row.Email]; dup {
+				errs = append(errs, RowError{Line: r.Line, Field: columnEmail, Message: fmt.Sprintf("email repetido no arquivo (linha %d)", line)})
+			} else {
+				firstLine[row.Email] = r.Line
+				emails = append(emails, row.Email)
+			}
+		}
+		entries = append(entries, entry{line: r.Line, row: &row, errs: errs})
+	}
+
+	existing := map[string]bool{}
+	for start := 0; start < len(emails); start += s.cfg.BatchSize {
+		end := min(start+s.cfg.BatchSize, len(emails))
+		found, err := s.Repository.ExistingEmails(ctx, emails[start:end])
+		if err != nil {
+			return nil, Report{}, err
+		}
+		for _, email := range found {
+			existing[email] = true
+		}
+	}
+
+	report := Report{DryRun: opts.DryRun, TotalRows: len(entries), Errors: []RowError{}}
+	for i := range entries {
+		e := &entries[i]
+		if existing[e.row.Email] {
+			e.errs = append(e.errs, RowError{Line: e.line, Field: columnEmail, Message: "email já cadastrado"})
+		}
+		if len(e.errs) > 0 {
+			e.row = nil
+			report.Errors = append(report.Errors, e.errs...)
+			continue
+		}
+		report.ValidRows++
+		if e.row.Password == "" {
+			report.Invites++
+		}
+	}
+	sort.SliceStable(report.Errors, func(i, j int) bool { return report.Errors[i].Line < report.Errors[j].Line })
+	return entries, report, nil
+}
+
+// execute cria os usuários em lotes, gravando o progresso a cada lote e o resultado de cada
+// linha no arquivo, que só é publicado (rename) quando completo.
+func (s *serviceImpl) execute(ctx context.Context, job Job, entries []entry) error {
+	if err := s.Repository.MarkRunning(ctx, job.UUID); err != nil {
+		return err
+	}
+
+	fail := func(err error) error {
+		if markErr := s.Repository.MarkFailed(context.WithoutCancel(ctx), job.UUID, err.Error()); markErr != nil {
+			log.Printf("[USER-IMPORT] Falha ao registrar erro da importação %s: %v", job.UUID, markErr)
+		}
+		return err
+	}
+
+	t, err := tenant.MustUse().Service.Read(ctx, tenant.Tenant{UUID: job.TenantUUID})
+	if err != nil {
+		return fail(err)
+	}
+
+	dir, err := filepath.Abs(s.cfg.Directory)
+	if err != nil {
+		return fail(err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fail(fmt.Errorf("falha ao criar diretório de importação: %w", err))
+	}
+	path := filepath.Join(dir, fmt.Sprintf("user-import-%s.csv", job.UUID))
+	tmp := path + ".part"
+
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fail(err)
+	}
+	w := csv.NewWriter(file)
+	_ = w.Write([]string{"line", "email", "status", "user_uuid", "message"})
+
+	var progress Progress
+	for start := 0; start < len(entries); start += s.cfg.BatchSize {
+		end := min(start+s.cfg.BatchSize, len(entries))
+		for _, e := range entries[start:end] {
+			if e.row == nil {
+				for _, re := range e.errs {
+					_ = w.Write([]string{fmt.Sprint(e.line), "", resultSkipped, "", re.Field + ": " + re.Message})
+				}
+				continue
+			}
+			status, userUUID, message := s.createUser(ctx, t, *e.row)
+			progress.Processed++
+			switch status {
+			case resultCreated:
+				progress.Created++
+			case resultInvited:
+				progress.Created++
+				progress.Invited++
+			default:
+				progress.Failed++
+			}
+			_ = w.Write([]string{fmt.Sprint(e.line), e.row.Email, status, userUUID, message})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			_ = file.Close()
+			_ = os.Remove(tmp)
+			return fail(err)
+		}
+		if err := s.Repository.UpdateProgress(ctx, job.UUID, progress); err != nil {
+			log.Printf("[USER-IMPORT] Falha ao gravar o progresso da importação %s: %v", job.UUID, err)
+		}
+	}
+
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fail(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fail(err)
+	}
+	if err := s.Repository.MarkCompleted(ctx, job.UUID, path, progress); err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	log.Printf("[USER-IMPORT] Importação %s do tenant %s concluída: %d criado(s), %d convite(s), %d falha(s).",
+		job.UUID, job.TenantUUID, progress.Created, progress.Invited, progress.Failed)
+	return nil
+}
+
+// createUser cria o usuário da linha pelo serviço de usuários (cota do plano, domínios de email
+// e campos personalizados continuam valendo) e envia o convite quando a linha não tem senha.
+func (s *serviceImpl) createUser(ctx context.Context, t tenant.Tenant, row Row) (status, userUUID, message string) {
+	password := row.Password
+	invite := password == ""
+	if invite {
+		// Segredo descartado: o login só é possível após definir a senha com o código do convite
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return resultFailed, "", "erro interno"
+		}
+		password = hex.EncodeToString(secret)
+	}
+
+	created, err := user.MustUse().Service.Create(ctx, user.User{
+		Tenant:   tenant.Tenant{UUID: t.UUID},
+		Name:     row.Name,
+		Email:    row.Email,
+		Password: password,
+		Role:     row.Role,
+		Live:     true,
+		Metadata: row.Metadata,
+	})
+	if err != nil {
+		return resultFailed, "", createErrorMessage(err)
+	}
+	if !invite {
+		return resultCreated, created.UUID.String(), ""
+	}
+	if err := auth.MustUse().Service.SendInvite(ctx, row.Email, t.Name, s.cfg.InviteTTL); err != nil {
+		log.Printf("[USER-IMPORT] Falha ao enviar convite para %s: %v", row.Email, err)
+		return resultCreated, created.UUID.String(), "convite não enviado; use a redefinição de senha"
+	}
+	return resultInvited, created.UUID.String(), ""
+}
+
+func createErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, user.ErrEmailDuplicated):
+		return "email já cadastrado"
+	case errors.Is(err, plan.ErrQuotaExceeded):
+		return "limite de usuários do plano atingido"
+	case errors.Is(err, user.ErrEmailDomain), errors.Is(err, custom_field.ErrInvalidMetadata), errors.Is(err, user.ErrInvalidInput):
+		return err.Error()
+	default:
+		return "erro interno"
+	}
+}
+
+func (s *serviceImpl) Get(ctx context.Context, jobUUID uuid.UUID) (Job, error) {
+	return s.Repository.Get(ctx, jobUUID)
+}
+
+func (s *serviceImpl) Result(ctx context.Context, jobUUID uuid.UUID) (io.ReadCloser, Job, error) {
+	job, err := s.Repository.Get(ctx, jobUUID)
+	if err != nil {
+		return nil, Job{}, err
+	}
+	if job.Status != StatusCompleted || job.ResultFile == "" {
+		return nil, Job{}, ErrNotReady
+	}
+	file, err := os.Open(job.ResultFile)
+	if err != nil {
+		return nil, Job{}, fmt.Errorf("falha ao abrir o resultado da importação: %w", err)
+	}
+	return file, job, nil
+}
+
+func (s *serviceImpl) ExpireOld(ctx context.Context) error {
+	if s.cfg.TTL <= 0 {
+		return nil
+	}
+	expired, err := s.Repository.ExpireCompleted(ctx, time.Now().UTC().Add(-s.cfg.TTL))
+	if err != nil {
+		return err
+	}
+	for _, job := range expired {
+		if err := os.Remove(job.ResultFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("[USER-IMPORT] Falha ao remover resultado expirado %s: %v", job.ResultFile, err)
+		}
+	}
+	if len(expired) > 0 {
+		log.Printf("[USER-IMPORT] %d resultado(s) de importação expirado(s)", len(expired))
+	}
+	return nil
+}
+
+func (s *serviceImpl) FailInterrupted(ctx context.Context) error {
+	count, err := s.Repository.FailInterrupted(ctx, "importação interrompida pela parada do servidor")
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		log.Printf("[USER-IMPORT] %d importação(ões) interrompida(s) marcada(s) como falha", count)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package user_import
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// sheetRow é uma linha da planilha. Line é o número da linha no arquivo (1 = cabeçalho).
+type sheetRow struct {
+	Line  int
+	Cells []string
+}
+
+var zipMagic = []byte("PK\x03\x04")
+
+// readSheet lê o arquivo enviado: XLSX (primeira planilha) ou CSV separado por vírgula ou
+// ponto e vírgula. O formato é reconhecido pelo conteúdo. Linhas vazias são ignoradas.
+func readSheet(r io.ReaderAt, size int64, maxRows int) ([]sheetRow, error) {
+	head := make([]byte, len(zipMagic))
+	if _, err := r.ReadAt(head, 0); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	if bytes.Equal(head, zipMagic) {
+		return readXLSX(r, size, maxRows)
+	}
+	return readCSV(io.NewSectionReader(r, 0, size), maxRows)
+}
+
+func readCSV(r io.Reader, maxRows int) ([]sheetRow, error) {
+	br := bufio.NewReader(r)
+	// BOM gravado pelo Excel ao salvar como "CSV UTF-8"
+	if bom, _ := br.Peek(3); bytes.Equal(bom, []byte("\xEF\xBB\xBF")) {
+		_, _ = br.Discard(3)
+	}
+	firstLine, _ := br.Peek(4096)
+	if i := bytes.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	// Planilhas exportadas em pt-BR usam ';' como separador
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	var rows []sheetRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if blank(record) {
+			continue
+		}
+		if len(rows) > maxRows { // cabeçalho + maxRows
+			return nil, fmt.Errorf("%w (máximo de %d linhas)", ErrTooManyRows, maxRows)
+		}
+		rows = append(rows, sheetRow{Line: line, Cells: record})
+	}
+	return rows, nil
+}
+
+func blank(cells []string) bool {
+	for _, c := range cells {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
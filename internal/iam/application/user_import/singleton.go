@@ -0,0 +1,98 @@
+package user_import
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	repositoryInstance Repository
+	once               sync.Once
+	initErr            error
+	ErrNotInitialized  = errors.New("user import controller not initialized")
+)
+
+const (
+	DefaultBatchSize   = 100
+	DefaultMaxRows     = 5000
+	DefaultMaxFileSize = 10 << 20
+)
+
+// UseUserImport agrupa todas as camadas (Repository, Service, Controller)
+type UseUserImport struct {
+	Repository Repository
+	Service    Service
+	Controller Controller
+}
+
+// Config usada somente no New()
+type Config struct {
+	// Directory é o diretório local dos arquivos de resultado (padrão "imports").
+	Directory string
+	// TTL é o prazo para baixar o resultado; depois dele o arquivo é removido. 0 = sem prazo.
+	TTL time.Duration
+	// MaxConcurrent limita as importações executadas em paralelo (padrão 2).
+	MaxConcurrent int
+	// BatchSize é a quantidade de usuários criados entre as gravações de progresso (padrão 100).
+	BatchSize int
+	// MaxRows limita as linhas de dados de um arquivo (padrão 5000).
+	MaxRows int
+	// MaxFileSize limita o tamanho do arquivo enviado, em bytes (padrão 10 MB).
+	MaxFileSize int64
+	// InviteTTL é a validade do código enviado aos usuários importados sem senha (padrão 72h).
+	InviteTTL time.Duration
+}
+
+// New inicializa o singleton do controller de importação com todas as suas dependências
+func New(db *gorm.DB, cfg Config) (Controller, error) {
+	once.Do(func() {
+		if db == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+		if cfg.MaxFileSize <= 0 {
+			cfg.MaxFileSize = DefaultMaxFileSize
+		}
+
+		// Inicializa as dependências em camadas
+		repositoryInstance = NewRepository(db)
+		serviceInstance = NewService(repositoryInstance, cfg)
+		controllerInstance = NewController(serviceInstance, cfg.MaxFileSize)
+
+		// Importações em andamento não sobrevivem a um reinício do servidor
+		if err := serviceInstance.FailInterrupted(context.Background()); err != nil {
+			log.Printf("[USER-IMPORT] Falha ao encerrar importações interrompidas: %v", err)
+		}
+	})
+
+	return controllerInstance, initErr
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Repository, Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UseUserImport {
+	if controllerInstance == nil || serviceInstance == nil || repositoryInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UseUserImport{
+		Repository: repositoryInstance,
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
@@ -0,0 +1,163 @@
+package user_import
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/user"
+)
+
+// Colunas aceitas no cabeçalho. Campos personalizados usam "metadata.<chave>".
+const (
+	columnName     = "name"
+	columnEmail    = "email"
+	columnRole     = "role"
+	columnPassword = "password"
+	metadataPrefix = "metadata."
+)
+
+const (
+	maxTextLength     = 255
+	minPasswordLength = 8
+)
+
+// header mapeia cada coluna da planilha para o seu campo.
+type header struct {
+	index    map[string]int
+	metadata map[string]int // chave do campo personalizado -> coluna
+}
+
+func parseHeader(cells []string) (header, error) {
+	h := header{index: map[string]int{}, metadata: map[string]int{}}
+	for i, cell := range cells {
+		name := strings.ToLower(strings.TrimSpace(cell))
+		if name == "" {
+			continue
+		}
+		if key, ok := strings.CutPrefix(name, metadataPrefix); ok {
+			if _, dup := h.metadata[key]; dup || key == "" {
+				return header{}, fmt.Errorf("%w: coluna '%s' repetida ou vazia", ErrInvalidFile, cell)
+			}
+			h.metadata[key] = i
+			continue
+		}
+		switch name {
+		case columnName, columnEmail, columnRole, columnPassword:
+		default:
+			return header{}, fmt.Errorf("%w: coluna desconhecida '%s' (use name, email, role, password e metadata.<chave>)", ErrInvalidFile, cell)
+		}
+		if _, dup := h.index[name]; dup {
+			return header{}, fmt.Errorf("%w: coluna '%s' repetida", ErrInvalidFile, cell)
+		}
+		h.index[name] = i
+	}
+	for _, required := range []string{columnName, columnEmail} {
+		if _, ok := h.index[required]; !ok {
+			return header{}, fmt.Errorf("%w: a coluna '%s' é obrigatória", ErrInvalidFile, required)
+		}
+	}
+	return h, nil
+}
+
+func (h header) value(cells []string, column string) string {
+	i, ok := h.index[column]
+	if !ok || i >= len(cells) {
+		return ""
+	}
+	return strings.TrimSpace(cells[i])
+}
+
+// validator valida as linhas da planilha. Os campos personalizados chegam como texto e são
+// convertidos para o tipo da definição antes da validação do custom_field.
+type validator struct {
+	roles    []model.UserRole
+	types    map[string]model.CustomFieldType
+	metadata func(patch model.Metadata) (model.Metadata, error)
+}
+
+// row valida uma linha isolada. Emails repetidos no arquivo e no banco são verificados depois.
+func (v validator) row(h header, r sheetRow) (Row, []RowError) {
+	var errs []RowError
+	fail := func(field, format string, args ...any) {
+		errs = append(errs, RowError{Line: r.Line, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	row := Row{
+		Line:     r.Line,
+		Name:     h.value(r.Cells, columnName),
+		Email:    strings.ToLower(h.value(r.Cells, columnEmail)),
+		Role:     model.UserRole(strings.ToUpper(h.value(r.Cells, columnRole))),
+		Password: h.value(r.Cells, columnPassword),
+	}
+
+	switch {
+	case row.Name == "":
+		fail(columnName, "nome é obrigatório")
+	case len(row.Name) > maxTextLength:
+		fail(columnName, "nome excede %d caracteres", maxTextLength)
+	}
+
+	if row.Email == "" {
+		fail(columnEmail, "email é obrigatório")
+	} else if addr, err := mail.ParseAddress(row.Email); err != nil || addr.Address != row.Email || len(row.Email) > maxTextLength {
+		fail(columnEmail, "email inválido")
+	}
+
+	if row.Role == "" {
+		row.Role = model.RoleTenantUser
+	}
+	if !user.IsValidUserRole(row.Role) {
+		fail(columnRole, "role '%s' desconhecida", row.Role)
+	} else if !slices.Contains(v.roles, row.Role) {
+		fail(columnRole, "role '%s' não permitida para o seu perfil", row.Role)
+	}
+
+	if row.Password != "" && len(row.Password) < minPasswordLength {
+		fail(columnPassword, "a senha deve ter ao menos %d caracteres", minPasswordLength)
+	}
+
+	patch := model.Metadata{}
+	for key, col := range h.metadata {
+		if col >= len(r.Cells) || strings.TrimSpace(r.Cells[col]) == "" {
+			continue
+		}
+		patch[key] = convertValue(v.types[key], strings.TrimSpace(r.Cells[col]))
+	}
+	metadata, err := v.metadata(patch)
+	if err != nil {
+		fail("metadata", "%s", err.Error())
+	}
+	row.Metadata = metadata
+
+	return row, errs
+}
+
+// excelEpoch é o dia 0 das datas seriais do Excel (sistema 1900, já compensado o 29/02/1900 inexistente).
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// convertValue converte o texto da célula para o tipo do campo. Valores que não convertem
+// seguem como texto, para que a validação informe o erro.
+func convertValue(t model.CustomFieldType, s string) any {
+	switch t {
+	case model.CustomFieldNumber:
+		if n, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64); err == nil {
+			return n
+		}
+	case model.CustomFieldBoolean:
+		if b, err := strconv.ParseBool(strings.ToLower(s)); err == nil {
+			return b
+		}
+	case model.CustomFieldDate:
+		// Datas do XLSX chegam como número serial
+		if n, err := strconv.ParseFloat(s, 64); err == nil && n > 0 && n < 2958466 {
+			return excelEpoch.AddDate(0, 0, int(math.Floor(n))).Format(time.DateOnly)
+		}
+	}
+	return s
+}
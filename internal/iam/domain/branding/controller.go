@@ -0,0 +1,240 @@
+package branding
+
+import (
+	"errors"
+	"net/http"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/settings"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	Read(c *gin.Context)
+	Patch(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+
+		if login.User.Role == model.RolePartnerAdmin {
+			if target, ok := middleware.GetTargetTenant(c); ok {
+				actingTenantUUID = &target
+			}
+		}
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "branding",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	brandingGroup := routes.Group("/branding")
+
+	{
+		brandingGroup.GET("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Read)
+		brandingGroup.PATCH("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Patch)
+	}
+}
+
+// resolveTenant determina o tenant cuja identidade visual será lida/alterada.
+// SYSTEM_ADMIN sem 'tenant_identifier' lê o padrão do sistema (uuid.Nil), mas precisa dele para alterar;
+// PARTNER_ADMIN pode informar um tenant da sua hierarquia (padrão: o próprio); TENANT_ADMIN usa o próprio tenant.
+func (ctrl *controllerImpl) resolveTenant(c *gin.Context, login *middleware.Login, write bool) (uuid.UUID, *rest_err.RestErr) {
+	var req TenantScopeRequestDto
+	_ = c.ShouldBindQuery(&req)
+
+	switch login.User.Role {
+	case model.RoleSystemAdmin:
+		if req.TenantIdentifier == "" {
+			if write {
+				return uuid.Nil, rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "É necessário informar o 'tenant_identifier' do tenant. O padrão do sistema é definido em settings.defaults no configs.json.")
+			}
+			return uuid.Nil, nil
+		}
+		return ctrl.findTenant(c, login, req.TenantIdentifier)
+
+	case model.RolePartnerAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		if req.TenantIdentifier == "" {
+			return *login.User.TenantUUID, nil
+		}
+		target, restError := ctrl.findTenant(c, login, req.TenantIdentifier)
+		if restError != nil {
+			return uuid.Nil, restError
+		}
+		inSubtree, err := tenant.MustUse().Service.InSubtree(c.Request.Context(), *login.User.TenantUUID, target)
+		if err != nil {
+			return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "Falha ao verificar hierarquia de tenants", nil)
+		}
+		if !inSubtree {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Tenant fora da sua hierarquia.")
+		}
+		middleware.SetTargetTenant(c, target)
+		return target, nil
+
+	case model.RoleTenantAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		return *login.User.TenantUUID, nil
+
+	default:
+		return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+}
+
+func (ctrl *controllerImpl) findTenant(c *gin.Context, login *middleware.Login, identifier string) (uuid.UUID, *rest_err.RestErr) {
+	t := tenant.Tenant{}
+	if err := uuid.Validate(identifier); err == nil {
+		t.UUID = uuid.MustParse(identifier)
+	} else {
+		t.Document = identifier
+	}
+	found, err := tenant.MustUse().Service.Read(c.Request.Context(), t)
+	if err != nil {
+		if errors.Is(err, tenant.ErrNotFound) {
+			return uuid.Nil, rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "tenant not found")
+		}
+		return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+	return found.UUID, nil
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	switch {
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrUnknownField),
+		errors.Is(err, settings.ErrInvalidValue), errors.Is(err, settings.ErrInvalidInput):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, err.Error())
+	default:
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+}
+
+// @Summary      Identidade visual dos emails
+// @Description  Retorna a identidade visual efetiva aplicada aos emails enviados aos usuários do tenant (remetente, logo, cor e rodapé) e a origem de cada campo: 'tenant', 'parent' (herdado de um tenant ancestral), 'global' (configs.json) ou 'default'. SystemAdmin sem 'tenant_identifier' vê o padrão do sistema.
+// @Tags         Branding
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (opcional para SystemAdmin e PartnerAdmin)"
+// @Success      200  {object}  BrandingResponseDto
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/branding [get]
+func (ctrl *controllerImpl) Read(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify, false)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	b, err := ctrl.Service.Get(c.Request.Context(), tenantUUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+	c.JSON(http.StatusOK, toBrandingResponse(b))
+}
+
+// @Summary      Altera a identidade visual dos emails
+// @Description  Altera os campos informados: 'sender_name', 'logo_url' (https), 'primary_color' (#RRGGBB) e 'footer' (texto puro). Informe null para voltar a herdar o valor do tenant pai ou do padrão do sistema. Os tenants filhos herdam a identidade visual que não sobrescreverem.
+// @Tags         Branding
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)"
+// @Param        request body object true "Campos a alterar, ex.: {\"sender_name\": \"Empresa XYZ\", \"primary_color\": \"#0055AA\", \"footer\": null}"
+// @Success      200  {object}  BrandingResponseDto
+// @Failure      400  {object}  rest_err.RestErr "Campo desconhecido ou valor inválido."
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/branding [patch]
+func (ctrl *controllerImpl) Patch(c *gin.Context) {
+	var req PatchBrandingRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil || len(req) == 0 {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify, true)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	b, err := ctrl.Service.Patch(c.Request.Context(), tenantUUID, req)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "update", "Patch", false, gin.H{"tenant": tenantUUID, "request": req}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toBrandingResponse(b)
+	ctrl.logAudit(c, ctxIdentify, "update", "Patch", true, gin.H{"tenant": tenantUUID, "request": req}, response)
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,11 @@
+package branding
+
+import "encoding/json"
+
+type TenantScopeRequestDto struct {
+	TenantIdentifier string `form:"tenant_identifier"`
+}
+
+// PatchBrandingRequestDto mapeia campo -> novo valor (sender_name, logo_url, primary_color, footer).
+// Use null para voltar a herdar o valor do tenant pai ou do padrão do sistema.
+type PatchBrandingRequestDto map[string]json.RawMessage
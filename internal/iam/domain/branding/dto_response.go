@@ -0,0 +1,29 @@
+package branding
+
+import "github.com/google/uuid"
+
+type BrandingResponseDto struct {
+	// TenantUUID vazio indica o padrão do sistema
+	TenantUUID   *uuid.UUID `json:"tenant_uuid,omitempty"`
+	SenderName   string     `json:"sender_name"`
+	LogoURL      string     `json:"logo_url"`
+	PrimaryColor string     `json:"primary_color"`
+	Footer       string     `json:"footer"`
+	// Sources indica a origem de cada campo: tenant, parent, global ou default
+	Sources map[string]string `json:"sources"`
+}
+
+func toBrandingResponse(b Branding) BrandingResponseDto {
+	resp := BrandingResponseDto{
+		SenderName:   b.SenderName,
+		LogoURL:      b.LogoURL,
+		PrimaryColor: b.PrimaryColor,
+		Footer:       b.Footer,
+		Sources:      b.Sources,
+	}
+	if b.TenantUUID != uuid.Nil {
+		tenantUUID := b.TenantUUID
+		resp.TenantUUID = &tenantUUID
+	}
+	return resp
+}
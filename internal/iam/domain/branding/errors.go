@@ -0,0 +1,8 @@
+package branding
+
+import "errors"
+
+var (
+	ErrInvalidInput = errors.New("invalid input data")
+	ErrUnknownField = errors.New("unknown branding field")
+)
@@ -0,0 +1,96 @@
+package branding
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"tenant-crud-simply/internal/iam/domain/settings"
+	"tenant-crud-simply/internal/pkg/mailer"
+
+	"github.com/google/uuid"
+)
+
+// A identidade visual é guardada como configurações do tenant: herda do tenant pai
+// (ex.: a revenda) e cai no padrão do sistema (configs.json: settings.defaults).
+const (
+	KeySenderName   = "branding_sender_name"
+	KeyLogoURL      = "branding_logo_url"
+	KeyPrimaryColor = "branding_primary_color"
+	KeyFooter       = "branding_footer"
+)
+
+// fields mapeia o nome do campo na API para a chave da configuração.
+var fields = map[string]string{
+	"sender_name":   KeySenderName,
+	"logo_url":      KeyLogoURL,
+	"primary_color": KeyPrimaryColor,
+	"footer":        KeyFooter,
+}
+
+var colorRegex = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+func init() {
+	settings.Register(textDefinition(KeySenderName, "Nome exibido como remetente dos emails. Vazio usa apenas o endereço do sistema.", "", func(v string) error {
+		if len(v) > 100 || strings.ContainsAny(v, "<>\"") || strings.IndexFunc(v, unicode.IsControl) >= 0 {
+			return fmt.Errorf("nome do remetente inválido (até 100 caracteres, sem < > \" ou quebras de linha)")
+		}
+		return nil
+	}))
+	settings.Register(textDefinition(KeyLogoURL, "URL HTTPS do logo exibido no cabeçalho dos emails.", "", func(v string) error {
+		if v == "" {
+			return nil
+		}
+		u, err := url.Parse(v)
+		if err != nil || u.Scheme != "https" || u.Host == "" || len(v) > 2048 {
+			return fmt.Errorf("o logo deve ser uma URL https válida")
+		}
+		return nil
+	}))
+	settings.Register(textDefinition(KeyPrimaryColor, "Cor principal dos emails (#RRGGBB).", "#1F2937", func(v string) error {
+		if !colorRegex.MatchString(v) {
+			return fmt.Errorf("cor '%s' inválida, use o formato #RRGGBB", v)
+		}
+		return nil
+	}))
+	settings.Register(textDefinition(KeyFooter, "Rodapé dos emails (texto puro, até 1000 caracteres).", "", func(v string) error {
+		if len(v) > 1000 {
+			return fmt.Errorf("o rodapé deve ter no máximo 1000 caracteres")
+		}
+		return nil
+	}))
+}
+
+// textDefinition monta a definição de uma configuração de texto da identidade visual.
+func textDefinition(key, description, def string, validate func(string) error) settings.Definition {
+	return settings.Definition{
+		Key:         key,
+		Kind:        settings.KindString,
+		Description: description,
+		Default:     def,
+		Validate:    func(value any) error { return validate(value.(string)) },
+	}
+}
+
+// Branding é a identidade visual efetiva de um tenant.
+type Branding struct {
+	TenantUUID   uuid.UUID
+	SenderName   string
+	LogoURL      string
+	PrimaryColor string
+	Footer       string
+	// Sources indica, por campo, a origem do valor (tenant, parent, global ou default).
+	Sources map[string]string
+}
+
+// Mail converte para a identidade usada pelo mailer.
+func (b Branding) Mail() mailer.Branding {
+	return mailer.Branding{
+		SenderName:   b.SenderName,
+		LogoURL:      b.LogoURL,
+		PrimaryColor: b.PrimaryColor,
+		Footer:       b.Footer,
+	}
+}
@@ -0,0 +1,40 @@
+package branding
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// TenantOfEmail retorna o tenant do usuário com o email informado (nil se não houver).
+	TenantOfEmail(ctx context.Context, email string) (*uuid.UUID, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) TenantOfEmail(ctx context.Context, email string) (*uuid.UUID, error) {
+	var row struct {
+		TenantUUID *uuid.UUID
+	}
+	err := r.db.WithContext(ctx).
+		Table("user_directory"). // índice global: o usuário pode estar no schema de um tenant isolado
+		Select("tenant_uuid").
+		Where("lower(email) = lower(?)", email).
+		Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return row.TenantUUID, nil
+}
@@ -0,0 +1,85 @@
+package branding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"tenant-crud-simply/internal/iam/domain/settings"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	// Get retorna a identidade visual efetiva do tenant. uuid.Nil retorna o padrão do sistema.
+	Get(ctx context.Context, tenantUUID uuid.UUID) (Branding, error)
+	// Patch altera os campos informados (nome do campo na API -> valor). null volta a herdar o valor.
+	Patch(ctx context.Context, tenantUUID uuid.UUID, changes map[string]json.RawMessage) (Branding, error)
+	// ForRecipient resolve a identidade visual pelo email do destinatário, com fallback para o padrão do sistema.
+	ForRecipient(ctx context.Context, email string) (Branding, error)
+}
+
+type serviceImpl struct {
+	Repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &serviceImpl{Repository: repository}
+}
+
+func (s *serviceImpl) Get(ctx context.Context, tenantUUID uuid.UUID) (Branding, error) {
+	values, err := settings.MustUse().Service.List(ctx, tenantUUID)
+	if err != nil {
+		return Branding{}, err
+	}
+
+	b := Branding{TenantUUID: tenantUUID, Sources: map[string]string{}}
+	targets := map[string]*string{
+		KeySenderName:   &b.SenderName,
+		KeyLogoURL:      &b.LogoURL,
+		KeyPrimaryColor: &b.PrimaryColor,
+		KeyFooter:       &b.Footer,
+	}
+	for _, v := range values {
+		target, ok := targets[v.Key]
+		if !ok {
+			continue
+		}
+		*target, _ = v.Value.(string)
+		b.Sources[v.Key] = v.Source
+	}
+	for field, key := range fields {
+		b.Sources[field] = b.Sources[key]
+		delete(b.Sources, key)
+	}
+	return b, nil
+}
+
+func (s *serviceImpl) Patch(ctx context.Context, tenantUUID uuid.UUID, changes map[string]json.RawMessage) (Branding, error) {
+	if tenantUUID == uuid.Nil || len(changes) == 0 {
+		return Branding{}, ErrInvalidInput
+	}
+	mapped := make(map[string]json.RawMessage, len(changes))
+	for field, raw := range changes {
+		key, ok := fields[field]
+		if !ok {
+			return Branding{}, fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+		mapped[key] = raw
+	}
+	if _, err := settings.MustUse().Service.Patch(ctx, tenantUUID, mapped); err != nil {
+		return Branding{}, err
+	}
+	return s.Get(ctx, tenantUUID)
+}
+
+func (s *serviceImpl) ForRecipient(ctx context.Context, email string) (Branding, error) {
+	tenantUUID, err := s.Repository.TenantOfEmail(ctx, email)
+	if err != nil {
+		return Branding{}, err
+	}
+	if tenantUUID == nil {
+		return s.Get(ctx, uuid.Nil)
+	}
+	return s.Get(ctx, *tenantUUID)
+}
@@ -0,0 +1,85 @@
+package branding
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"tenant-crud-simply/internal/pkg/mailer"
+
+	"gorm.io/gorm"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	repositoryInstance Repository
+	once               sync.Once
+	initErr            error
+	ErrNotInitialized  = errors.New("branding controller not initialized")
+)
+
+// UseBranding agrupa todas as camadas (Repository, Service, Controller)
+type UseBranding struct {
+	Repository Repository
+	Service    Service
+	Controller Controller
+}
+
+// New inicializa o singleton do controller de identidade visual e registra no mailer
+// a resolução da identidade pelo email do destinatário. Depende de settings já inicializado.
+func New(db *gorm.DB) (Controller, error) {
+	once.Do(func() {
+		if db == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+
+		// Inicializa as dependências em camadas
+		repositoryInstance = NewRepository(db)
+		serviceInstance = NewService(repositoryInstance)
+		controllerInstance = NewController(serviceInstance)
+
+		mailer.SetBrandingResolver(resolveForMail)
+	})
+
+	return controllerInstance, initErr
+}
+
+// resolveForMail é o BrandingResolver do mailer. Falhas caem no visual padrão:
+// o email é enviado mesmo sem a identidade do tenant.
+func resolveForMail(to string) (mailer.Branding, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	b, err := serviceInstance.ForRecipient(ctx, to)
+	if err != nil {
+		log.Printf("[BRANDING] Falha ao resolver identidade visual para %s: %v", to, err)
+		return mailer.Branding{}, false
+	}
+	return b.Mail(), true
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Repository, Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UseBranding {
+	if controllerInstance == nil || serviceInstance == nil || repositoryInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UseBranding{
+		Repository: repositoryInstance,
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
@@ -0,0 +1,411 @@
+package custom_field
+
+import (
+	"errors"
+	"net/http"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	List(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+
+		if login.User.Role == model.RolePartnerAdmin {
+			if target, ok := middleware.GetTargetTenant(c); ok {
+				actingTenantUUID = &target
+			}
+		}
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "custom_field",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	fieldGroup := routes.Group("/custom-fields")
+
+	{
+		fieldGroup.GET("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.List)
+		fieldGroup.POST("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Create)
+		fieldGroup.PATCH("/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Update)
+		fieldGroup.DELETE("/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Delete)
+	}
+}
+
+// resolveOwner determina o tenant dono das definições consultadas ou criadas.
+// SYSTEM_ADMIN sem 'tenant_identifier' trabalha com as definições globais (nil); PARTNER_ADMIN
+// pode informar um tenant da sua hierarquia (padrão: o próprio); TENANT_ADMIN usa sempre o
+// próprio tenant.
+func (ctrl *controllerImpl) resolveOwner(c *gin.Context, login *middleware.Login) (*uuid.UUID, *rest_err.RestErr) {
+	var req ScopeRequestDto
+	_ = c.ShouldBindQuery(&req)
+
+	switch login.User.Role {
+	case model.RoleSystemAdmin:
+		if req.TenantIdentifier == "" {
+			return nil, nil
+		}
+		target, restError := ctrl.findTenant(c, login, req.TenantIdentifier)
+		if restError != nil {
+			return nil, restError
+		}
+		return &target, nil
+
+	case model.RolePartnerAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		if req.TenantIdentifier == "" {
+			return login.User.TenantUUID, nil
+		}
+		target, restError := ctrl.findTenant(c, login, req.TenantIdentifier)
+		if restError != nil {
+			return nil, restError
+		}
+		if restError := ctrl.checkOwner(c, login, &target); restError != nil {
+			return nil, restError
+		}
+		return &target, nil
+
+	case model.RoleTenantAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		return login.User.TenantUUID, nil
+
+	default:
+		return nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+}
+
+// checkOwner verifica se o usuário pode alterar as definições do dono informado: as globais
+// são exclusivas do SYSTEM_ADMIN; PARTNER_ADMIN altera as da sua hierarquia; TENANT_ADMIN,
+// apenas as do próprio tenant.
+func (ctrl *controllerImpl) checkOwner(c *gin.Context, login *middleware.Login, owner *uuid.UUID) *rest_err.RestErr {
+	if login.User.Role == model.RoleSystemAdmin {
+		return nil
+	}
+	if owner == nil {
+		return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Apenas o SystemAdmin altera definições globais.")
+	}
+	if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+		return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+	}
+
+	switch login.User.Role {
+	case model.RolePartnerAdmin:
+		inSubtree, err := tenant.MustUse().Service.InSubtree(c.Request.Context(), *login.User.TenantUUID, *owner)
+		if err != nil {
+			return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "Falha ao verificar hierarquia de tenants", nil)
+		}
+		if !inSubtree {
+			return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Tenant fora da sua hierarquia.")
+		}
+		middleware.SetTargetTenant(c, *owner)
+		return nil
+	case model.RoleTenantAdmin:
+		if *owner != *login.User.TenantUUID {
+			return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Definição de outro tenant.")
+		}
+		return nil
+	default:
+		return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+}
+
+func (ctrl *controllerImpl) findTenant(c *gin.Context, login *middleware.Login, identifier string) (uuid.UUID, *rest_err.RestErr) {
+	t := tenant.Tenant{}
+	if err := uuid.Validate(identifier); err == nil {
+		t.UUID = uuid.MustParse(identifier)
+	} else {
+		t.Document = identifier
+	}
+	found, err := tenant.MustUse().Service.Read(c.Request.Context(), t)
+	if err != nil {
+		if errors.Is(err, tenant.ErrNotFound) {
+			return uuid.Nil, rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "tenant not found")
+		}
+		return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+	return found.UUID, nil
+}
+
+// readOwned busca a definição do parâmetro :uuid e verifica se o usuário pode alterá-la.
+func (ctrl *controllerImpl) readOwned(c *gin.Context, login *middleware.Login) (model.CustomField, *rest_err.RestErr) {
+	fieldUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		return model.CustomField{}, rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "UUID inválido.")
+	}
+	field, err := ctrl.Service.Read(c.Request.Context(), fieldUUID)
+	if err != nil {
+		return model.CustomField{}, ctrl.restError(login, err)
+	}
+	if restError := ctrl.checkOwner(c, login, field.TenantUUID); restError != nil {
+		return model.CustomField{}, restError
+	}
+	return field, nil
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "Campo personalizado não encontrado.")
+	case errors.Is(err, ErrKeyDuplicated):
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, "Já existe um campo com esta chave para o tenant.", nil)
+	default:
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+}
+
+// @Summary      Lista os campos personalizados
+// @Description  Retorna as definições que valem para os registros do tenant: as do próprio tenant, as herdadas dos ancestrais e as globais. Em chaves repetidas, vale a do dono mais próximo. SystemAdmin sem 'tenant_identifier' recebe apenas as definições globais.
+// @Tags         CustomFields
+// @Produce      json
+// @Security     BearerAuth
+// @Param        entity             query  string  true   "Entidade: tenant ou user"
+// @Param        tenant_identifier  query  string  false  "UUID ou Documento do tenant (opcional para SystemAdmin e PartnerAdmin)"
+// @Success      200  {object}  CustomFieldsResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/custom-fields [get]
+func (ctrl *controllerImpl) List(c *gin.Context) {
+	var req ListCustomFieldsRequestDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O parâmetro 'entity' deve ser tenant ou user.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	owner, restError := ctrl.resolveOwner(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	fields, err := ctrl.Service.Effective(c.Request.Context(), owner, req.Entity)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	c.JSON(http.StatusOK, toListResponse(owner, req.Entity, fields))
+}
+
+// @Summary      Cria um campo personalizado
+// @Description  Define um campo aceito no 'metadata' de tenants ou usuários. A definição vale para o tenant dono e seus descendentes; SystemAdmin sem 'tenant_identifier' cria uma definição global. Tipos: string (com 'pattern' opcional), number, boolean, date (AAAA-MM-DD) e enum (com 'enum_values').
+// @Tags         CustomFields
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier  query  string                       false  "UUID ou Documento do tenant dono (opcional para SystemAdmin e PartnerAdmin)"
+// @Param        request            body   CreateCustomFieldRequestDto  true   "Definição do campo"
+// @Success      201  {object}  CustomFieldResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      409  {object}  rest_err.RestErr "Chave já definida para o tenant."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/custom-fields [post]
+func (ctrl *controllerImpl) Create(c *gin.Context) {
+	var req CreateCustomFieldRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	owner, restError := ctrl.resolveOwner(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	field, err := ctrl.Service.Create(c.Request.Context(), model.CustomField{
+		TenantUUID: owner,
+		Entity:     req.Entity,
+		Key:        req.Key,
+		Label:      req.Label,
+		Type:       req.Type,
+		Required:   req.Required,
+		EnumValues: req.EnumValues,
+		Pattern:    req.Pattern,
+	})
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "create", "Create", false, gin.H{"tenant": owner, "request": req}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toResponse(field)
+	ctrl.logAudit(c, ctxIdentify, "create", "Create", true, gin.H{"tenant": owner, "request": req}, response)
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary      Altera um campo personalizado
+// @Description  Altera rótulo, obrigatoriedade, valores do enum ou padrão de um campo. Chave, tipo e entidade não podem ser alterados. Os valores já gravados não são revalidados; a nova regra vale a partir da próxima alteração do registro.
+// @Tags         CustomFields
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid     path  string                       true  "UUID do campo"
+// @Param        request  body  UpdateCustomFieldRequestDto  true  "Atributos a alterar"
+// @Success      200  {object}  CustomFieldResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Campo não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/custom-fields/{uuid} [patch]
+func (ctrl *controllerImpl) Update(c *gin.Context) {
+	var req UpdateCustomFieldRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	field, restError := ctrl.readOwned(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	if req.Label != nil {
+		field.Label = *req.Label
+	}
+	if req.Required != nil {
+		field.Required = *req.Required
+	}
+	if req.EnumValues != nil {
+		field.EnumValues = *req.EnumValues
+	}
+	if req.Pattern != nil {
+		field.Pattern = *req.Pattern
+	}
+
+	updated, err := ctrl.Service.Update(c.Request.Context(), field)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "update", "Update", false, gin.H{"uuid": field.UUID, "request": req}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toResponse(updated)
+	ctrl.logAudit(c, ctxIdentify, "update", "Update", true, gin.H{"uuid": field.UUID, "request": req}, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Remove um campo personalizado
+// @Description  Remove a definição. Os valores já gravados no 'metadata' dos registros são mantidos, mas a chave deixa de ser aceita em novas alterações.
+// @Tags         CustomFields
+// @Security     BearerAuth
+// @Param        uuid  path  string  true  "UUID do campo"
+// @Success      204
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Campo não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/custom-fields/{uuid} [delete]
+func (ctrl *controllerImpl) Delete(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	field, restError := ctrl.readOwned(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	if err := ctrl.Service.Delete(c.Request.Context(), field.UUID); err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "delete", "Delete", false, field.UUID, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctrl.logAudit(c, ctxIdentify, "delete", "Delete", true, field.UUID, toResponse(field))
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,31 @@
+package custom_field
+
+import "tenant-crud-simply/internal/iam/domain/model"
+
+type ScopeRequestDto struct {
+	TenantIdentifier string `form:"tenant_identifier"`
+}
+
+type ListCustomFieldsRequestDto struct {
+	ScopeRequestDto
+	Entity model.CustomFieldEntity `form:"entity" binding:"required,oneof=tenant user"`
+}
+
+type CreateCustomFieldRequestDto struct {
+	Entity     model.CustomFieldEntity `json:"entity" binding:"required,oneof=tenant user"`
+	Key        string                  `json:"key" binding:"required"`
+	Label      string                  `json:"label" binding:"required"`
+	Type       model.CustomFieldType   `json:"type" binding:"required,oneof=string number boolean date enum"`
+	Required   bool                    `json:"required"`
+	EnumValues []string                `json:"enum_values"`
+	Pattern    string                  `json:"pattern"`
+}
+
+// UpdateCustomFieldRequestDto altera apenas os atributos informados; chave, tipo e entidade
+// não podem ser alterados.
+type UpdateCustomFieldRequestDto struct {
+	Label      *string   `json:"label"`
+	Required   *bool     `json:"required"`
+	EnumValues *[]string `json:"enum_values"`
+	Pattern    *string   `json:"pattern"`
+}
@@ -0,0 +1,61 @@
+package custom_field
+
+import (
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type CustomFieldResponseDto struct {
+	UUID       uuid.UUID               `json:"uuid"`
+	TenantUUID *uuid.UUID              `json:"tenant_uuid"` // null = definição global
+	Entity     model.CustomFieldEntity `json:"entity"`
+	Key        string                  `json:"key"`
+	Label      string                  `json:"label"`
+	Type       model.CustomFieldType   `json:"type"`
+	Required   bool                    `json:"required"`
+	EnumValues []string                `json:"enum_values"`
+	Pattern    string                  `json:"pattern,omitempty"`
+	CreateAt   time.Time               `json:"create_at"`
+	UpdateAt   time.Time               `json:"update_at"`
+}
+
+type CustomFieldsResponseDto struct {
+	TenantUUID *uuid.UUID               `json:"tenant_uuid"`
+	Entity     model.CustomFieldEntity  `json:"entity"`
+	Fields     []CustomFieldResponseDto `json:"fields"`
+}
+
+func toResponse(field model.CustomField) CustomFieldResponseDto {
+	enumValues := []string(field.EnumValues)
+	if enumValues == nil {
+		enumValues = []string{}
+	}
+	return CustomFieldResponseDto{
+		UUID:       field.UUID,
+		TenantUUID: field.TenantUUID,
+		Entity:     field.Entity,
+		Key:        field.Key,
+		Label:      field.Label,
+		Type:       field.Type,
+		Required:   field.Required,
+		EnumValues: enumValues,
+		Pattern:    field.Pattern,
+		CreateAt:   field.CreateAt,
+		UpdateAt:   field.UpdateAt,
+	}
+}
+
+func toListResponse(tenantUUID *uuid.UUID, entity model.CustomFieldEntity, fields []model.CustomField) CustomFieldsResponseDto {
+	resp := CustomFieldsResponseDto{
+		TenantUUID: tenantUUID,
+		Entity:     entity,
+		Fields:     make([]CustomFieldResponseDto, 0, len(fields)),
+	}
+	for _, f := range fields {
+		resp.Fields = append(resp.Fields, toResponse(f))
+	}
+	return resp
+}
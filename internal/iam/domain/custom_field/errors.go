@@ -0,0 +1,16 @@
+package custom_field
+
+import (
+	"errors"
+
+	"tenant-crud-simply/internal/iam/domain/tenant"
+)
+
+var (
+	ErrNotFound      = errors.New("custom field not found")
+	ErrInvalidInput  = errors.New("invalid input data")
+	ErrKeyDuplicated = errors.New("custom field key already defined")
+	// ErrInvalidMetadata é o mesmo erro do tenant, para que o gancho de validação não precise
+	// traduzi-lo.
+	ErrInvalidMetadata = tenant.ErrInvalidMetadata
+)
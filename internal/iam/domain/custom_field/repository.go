@@ -0,0 +1,126 @@
+package custom_field
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, field model.CustomField) (model.CustomField, error)
+	Read(ctx context.Context, fieldUUID uuid.UUID) (model.CustomField, error)
+	Update(ctx context.Context, field model.CustomField) (model.CustomField, error)
+	Delete(ctx context.Context, fieldUUID uuid.UUID) error
+	// Effective retorna as definições que valem para os registros do tenant: as dele, as dos
+	// ancestrais e as globais; em chaves repetidas, a do dono mais próximo. tenantUUID nil
+	// retorna apenas as globais.
+	Effective(ctx context.Context, tenantUUID *uuid.UUID, entity model.CustomFieldEntity) ([]model.CustomField, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, field model.CustomField) (model.CustomField, error) {
+	if err := r.db.WithContext(ctx).Create(&field).Error; err != nil {
+		return model.CustomField{}, mapError(err)
+	}
+	return field, nil
+}
+
+func (r *repositoryImpl) Read(ctx context.Context, fieldUUID uuid.UUID) (model.CustomField, error) {
+	var field model.CustomField
+	err := r.db.WithContext(ctx).Where("uuid = ?", fieldUUID).First(&field).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.CustomField{}, ErrNotFound
+		}
+		return model.CustomField{}, err
+	}
+	return field, nil
+}
+
+// Update altera apenas os atributos mutáveis; chave, tipo e entidade são fixos.
+func (r *repositoryImpl) Update(ctx context.Context, field model.CustomField) (model.CustomField, error) {
+	field.UpdateAt = time.Now().UTC()
+	result := r.db.WithContext(ctx).
+		Model(&model.CustomField{}).
+		Where("uuid = ?", field.UUID).
+		Select("Label", "Required", "EnumValues", "Pattern", "UpdateAt").
+		Updates(field)
+	if result.Error != nil {
+		return model.CustomField{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return model.CustomField{}, ErrNotFound
+	}
+	return r.Read(ctx, field.UUID)
+}
+
+func (r *repositoryImpl) Delete(ctx context.Context, fieldUUID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("uuid = ?", fieldUUID).Delete(&model.CustomField{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// effectiveQuery segue a mesma cadeia de ancestrais das configurações (settings); as
+// definições globais ficam por último na precedência.
+const effectiveQuery = `
+WITH RECURSIVE chain AS (
+        SELECT uuid, parent_uuid, 0 AS depth FROM tenant WHERE uuid = ?
+        UNION ALL
+        SELECT t.uuid, t.parent_uuid, c.depth + 1 FROM tenant AS t
+        INNER JOIN chain AS c ON t.uuid = c.parent_uuid
+        WHERE c.depth < 32
+)
+SELECT DISTINCT ON (f.key) f.*
+FROM custom_fields AS f
+LEFT JOIN chain AS c ON c.uuid = f.tenant_uuid
+WHERE f.entity = ? AND (f.tenant_uuid IS NULL OR c.uuid IS NOT NULL)
+ORDER BY f.key, c.depth ASC NULLS LAST`
+
+func (r *repositoryImpl) Effective(ctx context.Context, tenantUUID *uuid.UUID, entity model.CustomFieldEntity) ([]model.CustomField, error) {
+	var fields []model.CustomField
+	var err error
+	if tenantUUID == nil {
+		err = r.db.WithContext(ctx).
+			Where("tenant_uuid IS NULL AND entity = ?", entity).
+			Order("key").
+			Find(&fields).Error
+	} else {
+		err = r.db.WithContext(ctx).Raw(effectiveQuery, *tenantUUID, entity).Scan(&fields).Error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("falha ao consultar campos personalizados: %w", err)
+	}
+	return fields, nil
+}
+
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == "23505":
+			return ErrKeyDuplicated
+		case pgErr.Code == "23503":
+			return ErrNotFound
+		}
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package custom_field
+
+import (
+	"context"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	// Effective retorna as definições que valem para os registros do tenant (próprias,
+	// herdadas dos ancestrais e globais). tenantUUID nil retorna apenas as globais.
+	Effective(ctx context.Context, tenantUUID *uuid.UUID, entity model.CustomFieldEntity) ([]model.CustomField, error)
+	Read(ctx context.Context, fieldUUID uuid.UUID) (model.CustomField, error)
+	Create(ctx context.Context, field model.CustomField) (model.CustomField, error)
+	Update(ctx context.Context, field model.CustomField) (model.CustomField, error)
+	Delete(ctx context.Context, fieldUUID uuid.UUID) error
+	// Validate aplica o patch sobre os campos atuais de um registro do tenant e valida o
+	// resultado. Um valor null remove o campo. Os erros embrulham ErrInvalidMetadata.
+	Validate(ctx context.Context, entity model.CustomFieldEntity, tenantUUID *uuid.UUID, current, patch model.Metadata) (model.Metadata, error)
+	// Validator carrega as definições uma única vez e retorna a validação de Validate para novos
+	// registros do tenant. Usado na validação de vários registros (importação de usuários).
+	Validator(ctx context.Context, entity model.CustomFieldEntity, tenantUUID *uuid.UUID) (func(patch model.Metadata) (model.Metadata, error), error)
+}
+
+type serviceImpl struct {
+	Repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &serviceImpl{Repository: repository}
+}
+
+func (s *serviceImpl) Effective(ctx context.Context, tenantUUID *uuid.UUID, entity model.CustomFieldEntity) ([]model.CustomField, error) {
+	return s.Repository.Effective(ctx, tenantUUID, entity)
+}
+
+func (s *serviceImpl) Read(ctx context.Context, fieldUUID uuid.UUID) (model.CustomField, error) {
+	return s.Repository.Read(ctx, fieldUUID)
+}
+
+func (s *serviceImpl) Create(ctx context.Context, field model.CustomField) (model.CustomField, error) {
+	if err := checkDefinition(field); err != nil {
+		return model.CustomField{}, err
+	}
+	now := time.Now().UTC()
+	field.UUID = uuid.New()
+	field.CreateAt = now
+	field.UpdateAt = now
+	if field.EnumValues == nil {
+		field.EnumValues = model.StringList{}
+	}
+	return s.Repository.Create(ctx, field)
+}
+
+func (s *serviceImpl) Update(ctx context.Context, field model.CustomField) (model.CustomField, error) {
+	if err := checkDefinition(field); err != nil {
+		return model.CustomField{}, err
+	}
+	if field.EnumValues == nil {
+		field.EnumValues = model.StringList{}
+	}
+	return s.Repository.Update(ctx, field)
+}
+
+func (s *serviceImpl) Delete(ctx context.Context, fieldUUID uuid.UUID) error {
+	return s.Repository.Delete(ctx, fieldUUID)
+}
+
+func (s *serviceImpl) Validate(ctx context.Context, entity model.CustomFieldEntity, tenantUUID *uuid.UUID, current, patch model.Metadata) (model.Metadata, error) {
+	defs, err := s.Repository.Effective(ctx, tenantUUID, entity)
+	if err != nil {
+		return nil, err
+	}
+	return merge(defs, current, patch)
+}
+
+func (s *serviceImpl) Validator(ctx context.Context, entity model.CustomFieldEntity, tenantUUID *uuid.UUID) (func(patch model.Metadata) (model.Metadata, error), error) {
+	defs, err := s.Repository.Effective(ctx, tenantUUID, entity)
+	if err != nil {
+		return nil, err
+	}
+	return func(patch model.Metadata) (model.Metadata, error) {
+		return merge(defs, nil, patch)
+	}, nil
+}
@@ -0,0 +1,75 @@
+package custom_field
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	repositoryInstance Repository
+	once               sync.Once
+	initErr            error
+	ErrNotInitialized  = errors.New("custom field controller not initialized")
+)
+
+// UseCustomField agrupa todas as camadas (Repository, Service, Controller)
+type UseCustomField struct {
+	Repository Repository
+	Service    Service
+	Controller Controller
+}
+
+// New inicializa o singleton do controller de campos personalizados com todas as suas
+// dependências e registra no tenant a validação do metadata.
+func New(db *gorm.DB) (Controller, error) {
+	once.Do(func() {
+		if db == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+
+		// Inicializa as dependências em camadas
+		repositoryInstance = NewRepository(db)
+		serviceInstance = NewService(repositoryInstance)
+		controllerInstance = NewController(serviceInstance)
+
+		// O tenant não importa este pacote (ciclo); a validação entra por um gancho
+		service := serviceInstance
+		tenant.SetMetadataValidator(func(ctx context.Context, ownerUUID *uuid.UUID, current, patch model.Metadata) (model.Metadata, error) {
+			return service.Validate(ctx, model.CustomFieldEntityTenant, ownerUUID, current, patch)
+		})
+	})
+
+	return controllerInstance, initErr
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Repository, Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UseCustomField {
+	if controllerInstance == nil || serviceInstance == nil || repositoryInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UseCustomField{
+		Repository: repositoryInstance,
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
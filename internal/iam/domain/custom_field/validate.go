@@ -0,0 +1,152 @@
+package custom_field
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+)
+
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+const dateLayout = "2006-01-02"
+
+// checkDefinition valida uma definição antes de gravá-la.
+func checkDefinition(field model.CustomField) error {
+	if !keyPattern.MatchString(field.Key) {
+		return fmt.Errorf("%w: a chave deve começar com letra minúscula e conter apenas a-z, 0-9 e _ (até 63 caracteres)", ErrInvalidInput)
+	}
+	switch field.Entity {
+	case model.CustomFieldEntityTenant, model.CustomFieldEntityUser:
+	default:
+		return fmt.Errorf("%w: 'entity' deve ser tenant ou user", ErrInvalidInput)
+	}
+	if strings.TrimSpace(field.Label) == "" {
+		return fmt.Errorf("%w: 'label' é obrigatório", ErrInvalidInput)
+	}
+	switch field.Type {
+	case model.CustomFieldString, model.CustomFieldNumber, model.CustomFieldBoolean, model.CustomFieldDate:
+		if len(field.EnumValues) > 0 {
+			return fmt.Errorf("%w: 'enum_values' vale apenas para o tipo enum", ErrInvalidInput)
+		}
+	case model.CustomFieldEnum:
+		if len(field.EnumValues) == 0 {
+			return fmt.Errorf("%w: o tipo enum exige 'enum_values'", ErrInvalidInput)
+		}
+		seen := map[string]bool{}
+		for _, v := range field.EnumValues {
+			if strings.TrimSpace(v) == "" || seen[v] {
+				return fmt.Errorf("%w: 'enum_values' não aceita valores vazios ou repetidos", ErrInvalidInput)
+			}
+			seen[v] = true
+		}
+	default:
+		return fmt.Errorf("%w: 'type' deve ser string, number, boolean, date ou enum", ErrInvalidInput)
+	}
+	if field.Pattern != "" {
+		if field.Type != model.CustomFieldString {
+			return fmt.Errorf("%w: 'pattern' vale apenas para o tipo string", ErrInvalidInput)
+		}
+		if _, err := regexp.Compile(field.Pattern); err != nil {
+			return fmt.Errorf("%w: 'pattern' inválido: %v", ErrInvalidInput, err)
+		}
+	}
+	return nil
+}
+
+// merge aplica o patch sobre os campos atuais e valida o resultado contra as definições.
+// Um valor null remove o campo; chaves sem definição são recusadas no patch, mas as já
+// gravadas (de uma definição removida depois) são mantidas.
+func merge(defs []model.CustomField, current, patch model.Metadata) (model.Metadata, error) {
+	byKey := make(map[string]model.CustomField, len(defs))
+	for _, def := range defs {
+		byKey[def.Key] = def
+	}
+
+	merged := model.Metadata{}
+	for k, v := range current {
+		merged[k] = v
+	}
+
+	keys := make([]string, 0, len(patch))
+	for k := range patch {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := patch[key]
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		def, ok := byKey[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: campo '%s' não definido", ErrInvalidMetadata, key)
+		}
+		normalized, err := checkValue(def, value)
+		if err != nil {
+			return nil, err
+		}
+		merged[key] = normalized
+	}
+
+	for _, def := range defs {
+		if _, ok := merged[def.Key]; def.Required && !ok {
+			return nil, fmt.Errorf("%w: campo '%s' é obrigatório", ErrInvalidMetadata, def.Key)
+		}
+	}
+	return merged, nil
+}
+
+func checkValue(def model.CustomField, value any) (any, error) {
+	invalid := func(expected string) error {
+		return fmt.Errorf("%w: campo '%s' deve ser %s", ErrInvalidMetadata, def.Key, expected)
+	}
+	switch def.Type {
+	case model.CustomFieldString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, invalid("um texto")
+		}
+		if def.Pattern != "" {
+			re, err := regexp.Compile(def.Pattern)
+			if err != nil || !re.MatchString(s) {
+				return nil, fmt.Errorf("%w: campo '%s' fora do formato esperado", ErrInvalidMetadata, def.Key)
+			}
+		}
+		return s, nil
+	case model.CustomFieldNumber:
+		n, ok := value.(float64)
+		if !ok || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, invalid("um número")
+		}
+		return n, nil
+	case model.CustomFieldBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, invalid("true ou false")
+		}
+		return b, nil
+	case model.CustomFieldDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, invalid("uma data no formato AAAA-MM-DD")
+		}
+		if _, err := time.Parse(dateLayout, s); err != nil {
+			return nil, invalid("uma data no formato AAAA-MM-DD")
+		}
+		return s, nil
+	case model.CustomFieldEnum:
+		s, ok := value.(string)
+		if !ok || !slices.Contains(def.EnumValues, s) {
+			return nil, invalid("um de: " + strings.Join(def.EnumValues, ", "))
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("%w: campo '%s' com tipo desconhecido", ErrInvalidMetadata, def.Key)
+}
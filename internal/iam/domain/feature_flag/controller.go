@@ -0,0 +1,484 @@
+package feature_flag
+
+import (
+	"errors"
+	"net/http"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	List(c *gin.Context)
+	Create(c *gin.Context)
+	Read(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	SetTenantOverride(c *gin.Context)
+	RemoveTenantOverride(c *gin.Context)
+	SetUserOverride(c *gin.Context)
+	RemoveUserOverride(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID *uuid.UUID
+		userUUID   *uuid.UUID
+		identifier string
+		rayTrace   string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:   tenantUUID,
+		UserUUID:     userUUID,
+		Identifier:   identifier,
+		RayTraceCode: rayTrace,
+		Domain:       "feature_flag",
+		Action:       action,
+		Function:     function,
+		Success:      success,
+		InputData:    auditoria_log.SerializeData(input),
+		OutputData:   auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	flagGroup := routes.Group("/feature-flags")
+
+	{
+		flagGroup.GET("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.List)
+		flagGroup.POST("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Create)
+		flagGroup.GET("/:key", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Read)
+		flagGroup.PATCH("/:key", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Update)
+		flagGroup.DELETE("/:key", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Delete)
+		flagGroup.PUT("/:key/tenants/:identifier", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.SetTenantOverride)
+		flagGroup.DELETE("/:key/tenants/:identifier", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.RemoveTenantOverride)
+		flagGroup.PUT("/:key/users/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.SetUserOverride)
+		flagGroup.DELETE("/:key/users/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.RemoveUserOverride)
+	}
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	switch {
+	case errors.Is(err, ErrInvalidInput):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "Feature flag não encontrada.")
+	case errors.Is(err, ErrOverrideNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "Override não encontrado.")
+	case errors.Is(err, ErrSubjectNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "Tenant ou usuário não encontrado.")
+	case errors.Is(err, ErrKeyDuplicated):
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, "Já existe uma feature flag com esta chave.", nil)
+	default:
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+}
+
+// login retorna o usuário autenticado ou responde 403.
+func (ctrl *controllerImpl) login(c *gin.Context) (*middleware.Login, bool) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+	}
+	return ctxIdentify, ok
+}
+
+// @Summary      Lista as feature flags
+// @Description  Retorna todas as feature flags globais, ordenadas pela chave. Apenas SystemAdmin.
+// @Tags         FeatureFlags
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  FeatureFlagsResponseDto
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/feature-flags [get]
+func (ctrl *controllerImpl) List(c *gin.Context) {
+	ctxIdentify, ok := ctrl.login(c)
+	if !ok {
+		return
+	}
+
+	flags, err := ctrl.Service.List(c.Request.Context())
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	resp := FeatureFlagsResponseDto{Flags: make([]FeatureFlagResponseDto, 0, len(flags))}
+	for _, f := range flags {
+		resp.Flags = append(resp.Flags, toResponse(f))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary      Cria uma feature flag
+// @Description  Cria uma flag global. Ligada, vale para 'rollout_percentage'% dos tenants ('rollout_by' = tenant) ou dos usuários ('rollout_by' = user), sorteados por um hash estável do UUID. Overrides por tenant ou usuário têm precedência. Apenas SystemAdmin.
+// @Tags         FeatureFlags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  CreateFeatureFlagRequestDto  true  "Dados da flag"
+// @Success      201  {object}  FeatureFlagResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      409  {object}  rest_err.RestErr "Chave já existe."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/feature-flags [post]
+func (ctrl *controllerImpl) Create(c *gin.Context) {
+	var req CreateFeatureFlagRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := ctrl.login(c)
+	if !ok {
+		return
+	}
+
+	flag := FeatureFlag{
+		Key:               req.Key,
+		Description:       req.Description,
+		Enabled:           req.Enabled,
+		RolloutPercentage: 100,
+		RolloutBy:         req.RolloutBy,
+	}
+	if req.RolloutPercentage != nil {
+		flag.RolloutPercentage = *req.RolloutPercentage
+	}
+
+	created, err := ctrl.Service.Create(c.Request.Context(), flag)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "create", "Create", false, req, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toResponse(created)
+	ctrl.logAudit(c, ctxIdentify, "create", "Create", true, req, response)
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary      Detalha uma feature flag
+// @Description  Retorna a flag e os seus overrides por tenant e por usuário. Apenas SystemAdmin.
+// @Tags         FeatureFlags
+// @Produce      json
+// @Security     BearerAuth
+// @Param        key  path  string  true  "Chave da flag"
+// @Success      200  {object}  FeatureFlagDetailResponseDto
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/feature-flags/{key} [get]
+func (ctrl *controllerImpl) Read(c *gin.Context) {
+	ctxIdentify, ok := ctrl.login(c)
+	if !ok {
+		return
+	}
+
+	detail, err := ctrl.Service.Read(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+	c.JSON(http.StatusOK, toDetailResponse(detail))
+}
+
+// @Summary      Altera uma feature flag
+// @Description  Altera descrição, estado global e liberação gradual. A chave não pode ser alterada. Aumentar o percentual mantém liberados os tenants ou usuários que já estavam. Apenas SystemAdmin.
+// @Tags         FeatureFlags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        key      path  string                       true  "Chave da flag"
+// @Param        request  body  UpdateFeatureFlagRequestDto  true  "Atributos a alterar"
+// @Success      200  {object}  FeatureFlagResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/feature-flags/{key} [patch]
+func (ctrl *controllerImpl) Update(c *gin.Context) {
+	var req UpdateFeatureFlagRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := ctrl.login(c)
+	if !ok {
+		return
+	}
+
+	key := c.Param("key")
+	detail, err := ctrl.Service.Read(c.Request.Context(), key)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	flag := detail.Flag
+	if req.Description != nil {
+		flag.Description = *req.Description
+	}
+	if req.Enabled != nil {
+		flag.Enabled = *req.Enabled
+	}
+	if req.RolloutPercentage != nil {
+		flag.RolloutPercentage = *req.RolloutPercentage
+	}
+	if req.RolloutBy != nil {
+		flag.RolloutBy = *req.RolloutBy
+	}
+
+	updated, err := ctrl.Service.Update(c.Request.Context(), flag)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "update", "Update", false, gin.H{"key": key, "request": req}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toResponse(updated)
+	ctrl.logAudit(c, ctxIdentify, "update", "Update", true, gin.H{"key": key, "request": req}, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Remove uma feature flag
+// @Description  Remove a flag e todos os seus overrides. Código que ainda consulta a flag passa a recebê-la desligada. Apenas SystemAdmin.
+// @Tags         FeatureFlags
+// @Security     BearerAuth
+// @Param        key  path  string  true  "Chave da flag"
+// @Success      204
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/feature-flags/{key} [delete]
+func (ctrl *controllerImpl) Delete(c *gin.Context) {
+	ctxIdentify, ok := ctrl.login(c)
+	if !ok {
+		return
+	}
+
+	key := c.Param("key")
+	if err := ctrl.Service.Delete(c.Request.Context(), key); err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "delete", "Delete", false, key, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctrl.logAudit(c, ctxIdentify, "delete", "Delete", true, key, nil)
+	c.Status(http.StatusNoContent)
+}
+
+// findTenant aceita o UUID ou o documento do tenant.
+func (ctrl *controllerImpl) findTenant(c *gin.Context, login *middleware.Login, identifier string) (uuid.UUID, *rest_err.RestErr) {
+	t := tenant.Tenant{}
+	if err := uuid.Validate(identifier); err == nil {
+		t.UUID = uuid.MustParse(identifier)
+	} else {
+		t.Document = identifier
+	}
+	found, err := tenant.MustUse().Service.Read(c.Request.Context(), t)
+	if err != nil {
+		if errors.Is(err, tenant.ErrNotFound) {
+			return uuid.Nil, rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "tenant not found")
+		}
+		return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+	return found.UUID, nil
+}
+
+// @Summary      Define o override de um tenant
+// @Description  Liga ou desliga a flag para o tenant e seus descendentes (que podem ter o próprio override), independentemente do estado global e do percentual. Overrides de usuário têm precedência. Apenas SystemAdmin.
+// @Tags         FeatureFlags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        key         path  string              true  "Chave da flag"
+// @Param        identifier  path  string              true  "UUID ou Documento do tenant"
+// @Param        request     body  OverrideRequestDto  true  "Estado da flag para o tenant"
+// @Success      200  {object}  OverrideResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/feature-flags/{key}/tenants/{identifier} [put]
+func (ctrl *controllerImpl) SetTenantOverride(c *gin.Context) {
+	var req OverrideRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O campo 'enabled' é obrigatório.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := ctrl.login(c)
+	if !ok {
+		return
+	}
+
+	tenantUUID, restError := ctrl.findTenant(c, ctxIdentify, c.Param("identifier"))
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+	ctrl.setOverride(c, ctxIdentify, "SetTenantOverride", &tenantUUID, nil, *req.Enabled)
+}
+
+// @Summary      Remove o override de um tenant
+// @Description  O tenant volta a herdar o override do ancestral mais próximo ou a seguir o estado global. Apenas SystemAdmin.
+// @Tags         FeatureFlags
+// @Security     BearerAuth
+// @Param        key         path  string  true  "Chave da flag"
+// @Param        identifier  path  string  true  "UUID ou Documento do tenant"
+// @Success      204
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/feature-flags/{key}/tenants/{identifier} [delete]
+func (ctrl *controllerImpl) RemoveTenantOverride(c *gin.Context) {
+	ctxIdentify, ok := ctrl.login(c)
+	if !ok {
+		return
+	}
+
+	tenantUUID, restError := ctrl.findTenant(c, ctxIdentify, c.Param("identifier"))
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+	ctrl.removeOverride(c, ctxIdentify, "RemoveTenantOverride", &tenantUUID, nil)
+}
+
+// @Summary      Define o override de um usuário
+// @Description  Liga ou desliga a flag para o usuário, com precedência sobre o override do tenant e o estado global. Apenas SystemAdmin.
+// @Tags         FeatureFlags
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        key      path  string              true  "Chave da flag"
+// @Param        uuid     path  string              true  "UUID do usuário"
+// @Param        request  body  OverrideRequestDto  true  "Estado da flag para o usuário"
+// @Success      200  {object}  OverrideResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/feature-flags/{key}/users/{uuid} [put]
+func (ctrl *controllerImpl) SetUserOverride(c *gin.Context) {
+	var req OverrideRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O campo 'enabled' é obrigatório.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := ctrl.login(c)
+	if !ok {
+		return
+	}
+
+	userUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "UUID inválido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	ctrl.setOverride(c, ctxIdentify, "SetUserOverride", nil, &userUUID, *req.Enabled)
+}
+
+// @Summary      Remove o override de um usuário
+// @Description  O usuário volta a seguir o override do tenant ou o estado global. Apenas SystemAdmin.
+// @Tags         FeatureFlags
+// @Security     BearerAuth
+// @Param        key   path  string  true  "Chave da flag"
+// @Param        uuid  path  string  true  "UUID do usuário"
+// @Success      204
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/feature-flags/{key}/users/{uuid} [delete]
+func (ctrl *controllerImpl) RemoveUserOverride(c *gin.Context) {
+	ctxIdentify, ok := ctrl.login(c)
+	if !ok {
+		return
+	}
+
+	userUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "UUID inválido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	ctrl.removeOverride(c, ctxIdentify, "RemoveUserOverride", nil, &userUUID)
+}
+
+func (ctrl *controllerImpl) setOverride(c *gin.Context, login *middleware.Login, function string, tenantUUID, userUUID *uuid.UUID, enabled bool) {
+	key := c.Param("key")
+	input := gin.H{"key": key, "tenant": tenantUUID, "user": userUUID, "enabled": enabled}
+
+	override, err := ctrl.Service.SetOverride(c.Request.Context(), key, tenantUUID, userUUID, enabled)
+	if err != nil {
+		restError := ctrl.restError(login, err)
+		ctrl.logAudit(c, login, "update", function, false, input, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toOverrideResponse(override)
+	ctrl.logAudit(c, login, "update", function, true, input, response)
+	c.JSON(http.StatusOK, response)
+}
+
+func (ctrl *controllerImpl) removeOverride(c *gin.Context, login *middleware.Login, function string, tenantUUID, userUUID *uuid.UUID) {
+	key := c.Param("key")
+	input := gin.H{"key": key, "tenant": tenantUUID, "user": userUUID}
+
+	if err := ctrl.Service.RemoveOverride(c.Request.Context(), key, tenantUUID, userUUID); err != nil {
+		restError := ctrl.restError(login, err)
+		ctrl.logAudit(c, login, "delete", function, false, input, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctrl.logAudit(c, login, "delete", function, true, input, nil)
+	c.Status(http.StatusNoContent)
+}
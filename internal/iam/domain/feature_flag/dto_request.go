@@ -0,0 +1,25 @@
+package feature_flag
+
+import "tenant-crud-simply/internal/iam/domain/model"
+
+type CreateFeatureFlagRequestDto struct {
+	Key         string `json:"key" binding:"required"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	// RolloutPercentage é o percentual liberado quando a flag está ligada (padrão 100)
+	RolloutPercentage *int `json:"rollout_percentage"`
+	// RolloutBy: tenant (padrão) ou user
+	RolloutBy model.RolloutSubject `json:"rollout_by"`
+}
+
+// UpdateFeatureFlagRequestDto altera apenas os atributos informados; a chave não pode ser alterada.
+type UpdateFeatureFlagRequestDto struct {
+	Description       *string               `json:"description"`
+	Enabled           *bool                 `json:"enabled"`
+	RolloutPercentage *int                  `json:"rollout_percentage"`
+	RolloutBy         *model.RolloutSubject `json:"rollout_by"`
+}
+
+type OverrideRequestDto struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
@@ -0,0 +1,71 @@
+package feature_flag
+
+import (
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type FeatureFlagResponseDto struct {
+	UUID              uuid.UUID            `json:"uuid"`
+	Key               string               `json:"key"`
+	Description       string               `json:"description"`
+	Enabled           bool                 `json:"enabled"`
+	RolloutPercentage int                  `json:"rollout_percentage"`
+	RolloutBy         model.RolloutSubject `json:"rollout_by"`
+	CreateAt          time.Time            `json:"create_at"`
+	UpdateAt          time.Time            `json:"update_at"`
+}
+
+type FeatureFlagsResponseDto struct {
+	Flags []FeatureFlagResponseDto `json:"flags"`
+}
+
+type OverrideResponseDto struct {
+	UUID       uuid.UUID  `json:"uuid"`
+	TenantUUID *uuid.UUID `json:"tenant_uuid,omitempty"`
+	UserUUID   *uuid.UUID `json:"user_uuid,omitempty"`
+	Enabled    bool       `json:"enabled"`
+	CreateAt   time.Time  `json:"create_at"`
+}
+
+type FeatureFlagDetailResponseDto struct {
+	FeatureFlagResponseDto
+	Overrides []OverrideResponseDto `json:"overrides"`
+}
+
+func toResponse(flag FeatureFlag) FeatureFlagResponseDto {
+	return FeatureFlagResponseDto{
+		UUID:              flag.UUID,
+		Key:               flag.Key,
+		Description:       flag.Description,
+		Enabled:           flag.Enabled,
+		RolloutPercentage: flag.RolloutPercentage,
+		RolloutBy:         flag.RolloutBy,
+		CreateAt:          flag.CreateAt,
+		UpdateAt:          flag.UpdateAt,
+	}
+}
+
+func toOverrideResponse(o Override) OverrideResponseDto {
+	return OverrideResponseDto{
+		UUID:       o.UUID,
+		TenantUUID: o.TenantUUID,
+		UserUUID:   o.UserUUID,
+		Enabled:    o.Enabled,
+		CreateAt:   o.CreateAt,
+	}
+}
+
+func toDetailResponse(detail FlagDetail) FeatureFlagDetailResponseDto {
+	resp := FeatureFlagDetailResponseDto{
+		FeatureFlagResponseDto: toResponse(detail.Flag),
+		Overrides:              make([]OverrideResponseDto, 0, len(detail.Overrides)),
+	}
+	for _, o := range detail.Overrides {
+		resp.Overrides = append(resp.Overrides, toOverrideResponse(o))
+	}
+	return resp
+}
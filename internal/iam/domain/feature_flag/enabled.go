@@ -0,0 +1,63 @@
+package feature_flag
+
+import (
+	"context"
+	"log"
+
+	"tenant-crud-simply/internal/iam/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// contextKey guarda no contexto do Gin as flags já avaliadas na requisição.
+const contextKey = "feature_flags"
+
+// Enabled informa se a flag está ligada para o usuário autenticado da requisição. Flags
+// desconhecidas e falhas de avaliação contam como desligadas.
+//
+//	if feature_flag.Enabled(c, "new-dashboard") { ... }
+func Enabled(c *gin.Context, key string) bool {
+	return Flags(c)[key]
+}
+
+// Flags retorna todas as flags avaliadas para o usuário autenticado da requisição (sem
+// usuário, para ninguém: apenas as flags ligadas para 100%). A avaliação é feita uma vez por
+// requisição e fica no contexto do Gin.
+func Flags(c *gin.Context) map[string]bool {
+	if cached, ok := c.Get(contextKey); ok {
+		if flags, ok := cached.(map[string]bool); ok {
+			return flags
+		}
+	}
+
+	var subject Subject
+	if login, ok := middleware.GetAuthenticatedUser(c); ok {
+		subject.TenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			subject.UserUUID = &login.User.UUID
+		}
+	}
+	flags := Evaluate(c.Request.Context(), subject)
+	c.Set(contextKey, flags)
+	return flags
+}
+
+// IsEnabled avalia a flag fora de uma requisição (jobs, workers).
+func IsEnabled(ctx context.Context, key string, subject Subject) bool {
+	return Evaluate(ctx, subject)[key]
+}
+
+// Evaluate avalia todas as flags para o subject. Em caso de falha, todas contam como desligadas.
+func Evaluate(ctx context.Context, subject Subject) map[string]bool {
+	evaluations, err := MustUse().Service.Evaluate(ctx, subject)
+	if err != nil {
+		log.Printf("[FEATURE-FLAG] Falha ao avaliar flags: %v", err)
+		return map[string]bool{}
+	}
+	flags := make(map[string]bool, len(evaluations))
+	for _, e := range evaluations {
+		flags[e.Key] = e.Enabled
+	}
+	return flags
+}
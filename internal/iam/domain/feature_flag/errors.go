@@ -0,0 +1,11 @@
+package feature_flag
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("feature flag not found")
+	ErrInvalidInput     = errors.New("invalid input data")
+	ErrKeyDuplicated    = errors.New("feature flag key already exists")
+	ErrSubjectNotFound  = errors.New("tenant or user not found")
+	ErrOverrideNotFound = errors.New("feature flag override not found")
+)
@@ -0,0 +1,49 @@
+package feature_flag
+
+import (
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type (
+	FeatureFlag = model.FeatureFlag
+	Override    = model.FeatureFlagOverride
+)
+
+// Origem do valor avaliado de uma flag.
+const (
+	SourceUser     = "user"     // Override do usuário
+	SourceTenant   = "tenant"   // Override do próprio tenant
+	SourceParent   = "parent"   // Override herdado de um tenant ancestral
+	SourceRollout  = "rollout"  // Liberação gradual (percentual)
+	SourceDisabled = "disabled" // Flag desligada globalmente
+)
+
+// Subject é para quem a flag é avaliada. Sem tenant (ex.: SYSTEM_ADMIN), a liberação por
+// tenant usa o usuário.
+type Subject struct {
+	TenantUUID *uuid.UUID
+	UserUUID   *uuid.UUID
+}
+
+// Evaluation é o valor de uma flag para um Subject.
+type Evaluation struct {
+	Key     string
+	Enabled bool
+	Source  string
+}
+
+// FlagDetail é a flag com os seus overrides.
+type FlagDetail struct {
+	Flag      FeatureFlag
+	Overrides []Override
+}
+
+// InheritedOverride é um override de tenant encontrado na cadeia de ancestrais.
+type InheritedOverride struct {
+	FlagUUID   uuid.UUID `gorm:"column:flag_uuid"`
+	TenantUUID uuid.UUID `gorm:"column:tenant_uuid"`
+	Enabled    bool      `gorm:"column:enabled"`
+	Depth      int       `gorm:"column:depth"`
+}
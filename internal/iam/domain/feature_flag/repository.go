@@ -0,0 +1,173 @@
+package feature_flag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	List(ctx context.Context) ([]FeatureFlag, error)
+	Read(ctx context.Context, key string) (FeatureFlag, error)
+	Create(ctx context.Context, flag FeatureFlag) (FeatureFlag, error)
+	Update(ctx context.Context, flag FeatureFlag) (FeatureFlag, error)
+	Delete(ctx context.Context, key string) error
+	ListOverrides(ctx context.Context, flagUUID uuid.UUID) ([]Override, error)
+	// SetOverride grava o override, substituindo o anterior do mesmo tenant ou usuário.
+	SetOverride(ctx context.Context, override Override) (Override, error)
+	DeleteOverride(ctx context.Context, flagUUID uuid.UUID, tenantUUID, userUUID *uuid.UUID) error
+	UserOverrides(ctx context.Context, userUUID uuid.UUID) ([]Override, error)
+	// TenantOverrides retorna, por flag, o override mais próximo na cadeia tenant -> pai -> avô...
+	TenantOverrides(ctx context.Context, tenantUUID uuid.UUID) ([]InheritedOverride, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) List(ctx context.Context) ([]FeatureFlag, error) {
+	var flags []FeatureFlag
+	if err := r.db.WithContext(ctx).Order("key").Find(&flags).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar feature flags: %w", err)
+	}
+	return flags, nil
+}
+
+func (r *repositoryImpl) Read(ctx context.Context, key string) (FeatureFlag, error) {
+	var flag FeatureFlag
+	err := r.db.WithContext(ctx).Where("key = ?", key).First(&flag).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return FeatureFlag{}, ErrNotFound
+		}
+		return FeatureFlag{}, err
+	}
+	return flag, nil
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, flag FeatureFlag) (FeatureFlag, error) {
+	if err := r.db.WithContext(ctx).Create(&flag).Error; err != nil {
+		return FeatureFlag{}, mapError(err)
+	}
+	return flag, nil
+}
+
+// Update altera tudo, menos a chave.
+func (r *repositoryImpl) Update(ctx context.Context, flag FeatureFlag) (FeatureFlag, error) {
+	flag.UpdateAt = time.Now().UTC()
+	result := r.db.WithContext(ctx).
+		Model(&FeatureFlag{}).
+		Where("uuid = ?", flag.UUID).
+		Select("Description", "Enabled", "RolloutPercentage", "RolloutBy", "UpdateAt").
+		Updates(flag)
+	if result.Error != nil {
+		return FeatureFlag{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return FeatureFlag{}, ErrNotFound
+	}
+	return r.Read(ctx, flag.Key)
+}
+
+// Delete remove a flag; os overrides são removidos em cascata.
+func (r *repositoryImpl) Delete(ctx context.Context, key string) error {
+	result := r.db.WithContext(ctx).Where("key = ?", key).Delete(&FeatureFlag{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *repositoryImpl) ListOverrides(ctx context.Context, flagUUID uuid.UUID) ([]Override, error) {
+	var overrides []Override
+	err := r.db.WithContext(ctx).
+		Where("flag_uuid = ?", flagUUID).
+		Order("create_at, uuid").
+		Find(&overrides).Error
+	return overrides, err
+}
+
+func (r *repositoryImpl) SetOverride(ctx context.Context, override Override) (Override, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := overrideScope(tx, override.FlagUUID, override.TenantUUID, override.UserUUID).Delete(&Override{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&override).Error
+	})
+	if err != nil {
+		return Override{}, mapError(err)
+	}
+	return override, nil
+}
+
+func (r *repositoryImpl) DeleteOverride(ctx context.Context, flagUUID uuid.UUID, tenantUUID, userUUID *uuid.UUID) error {
+	result := overrideScope(r.db.WithContext(ctx), flagUUID, tenantUUID, userUUID).Delete(&Override{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrOverrideNotFound
+	}
+	return nil
+}
+
+func overrideScope(db *gorm.DB, flagUUID uuid.UUID, tenantUUID, userUUID *uuid.UUID) *gorm.DB {
+	db = db.Where("flag_uuid = ?", flagUUID)
+	if tenantUUID != nil {
+		return db.Where("tenant_uuid = ?", *tenantUUID)
+	}
+	return db.Where("user_uuid = ?", userUUID)
+}
+
+func (r *repositoryImpl) UserOverrides(ctx context.Context, userUUID uuid.UUID) ([]Override, error) {
+	var overrides []Override
+	err := r.db.WithContext(ctx).Where("user_uuid = ?", userUUID).Find(&overrides).Error
+	return overrides, err
+}
+
+// tenantOverridesQuery segue a mesma cadeia de ancestrais das configurações (settings).
+const tenantOverridesQuery = `
+WITH RECURSIVE chain AS (
+        SELECT uuid, parent_uuid, 0 AS depth FROM tenant WHERE uuid = ?
+        UNION ALL
+        SELECT t.uuid, t.parent_uuid, c.depth + 1 FROM tenant AS t
+        INNER JOIN chain AS c ON t.uuid = c.parent_uuid
+        WHERE c.depth < 32
+)
+SELECT DISTINCT ON (o.flag_uuid) o.flag_uuid, o.tenant_uuid, o.enabled, c.depth
+FROM feature_flag_overrides AS o
+INNER JOIN chain AS c ON c.uuid = o.tenant_uuid
+ORDER BY o.flag_uuid, c.depth ASC`
+
+func (r *repositoryImpl) TenantOverrides(ctx context.Context, tenantUUID uuid.UUID) ([]InheritedOverride, error) {
+	var rows []InheritedOverride
+	if err := r.db.WithContext(ctx).Raw(tenantOverridesQuery, tenantUUID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("falha ao consultar overrides do tenant: %w", err)
+	}
+	return rows, nil
+}
+
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return ErrKeyDuplicated
+		case "23503":
+			return ErrSubjectNotFound
+		}
+	}
+	return err
+}
@@ -0,0 +1,196 @@
+package feature_flag
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	List(ctx context.Context) ([]FeatureFlag, error)
+	Read(ctx context.Context, key string) (FlagDetail, error)
+	Create(ctx context.Context, flag FeatureFlag) (FeatureFlag, error)
+	Update(ctx context.Context, flag FeatureFlag) (FeatureFlag, error)
+	Delete(ctx context.Context, key string) error
+	// SetOverride liga ou desliga a flag para um tenant ou um usuário (exatamente um deles).
+	SetOverride(ctx context.Context, key string, tenantUUID, userUUID *uuid.UUID, enabled bool) (Override, error)
+	RemoveOverride(ctx context.Context, key string, tenantUUID, userUUID *uuid.UUID) error
+	// Evaluate avalia todas as flags para o subject. Ordem de precedência: override do
+	// usuário, override do tenant (ou do ancestral mais próximo), flag global com rollout.
+	Evaluate(ctx context.Context, subject Subject) ([]Evaluation, error)
+}
+
+type serviceImpl struct {
+	Repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &serviceImpl{Repository: repository}
+}
+
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,62}$`)
+
+func checkFlag(flag FeatureFlag) error {
+	if !keyPattern.MatchString(flag.Key) {
+		return fmt.Errorf("%w: a chave deve começar com letra minúscula e conter apenas a-z, 0-9, _ e - (até 63 caracteres)", ErrInvalidInput)
+	}
+	if flag.RolloutPercentage < 0 || flag.RolloutPercentage > 100 {
+		return fmt.Errorf("%w: 'rollout_percentage' deve estar entre 0 e 100", ErrInvalidInput)
+	}
+	if flag.RolloutBy != model.RolloutByTenant && flag.RolloutBy != model.RolloutByUser {
+		return fmt.Errorf("%w: 'rollout_by' deve ser tenant ou user", ErrInvalidInput)
+	}
+	return nil
+}
+
+func (s *serviceImpl) List(ctx context.Context) ([]FeatureFlag, error) {
+	return s.Repository.List(ctx)
+}
+
+func (s *serviceImpl) Read(ctx context.Context, key string) (FlagDetail, error) {
+	flag, err := s.Repository.Read(ctx, key)
+	if err != nil {
+		return FlagDetail{}, err
+	}
+	overrides, err := s.Repository.ListOverrides(ctx, flag.UUID)
+	if err != nil {
+		return FlagDetail{}, err
+	}
+	return FlagDetail{Flag: flag, Overrides: overrides}, nil
+}
+
+func (s *serviceImpl) Create(ctx context.Context, flag FeatureFlag) (FeatureFlag, error) {
+	if flag.RolloutBy == "" {
+		flag.RolloutBy = model.RolloutByTenant
+	}
+	if err := checkFlag(flag); err != nil {
+		return FeatureFlag{}, err
+	}
+	now := time.Now().UTC()
+	flag.UUID = uuid.New()
+	flag.CreateAt = now
+	flag.UpdateAt = now
+	return s.Repository.Create(ctx, flag)
+}
+
+func (s *serviceImpl) Update(ctx context.Context, flag FeatureFlag) (FeatureFlag, error) {
+	if err := checkFlag(flag); err != nil {
+		return FeatureFlag{}, err
+	}
+	return s.Repository.Update(ctx, flag)
+}
+
+func (s *serviceImpl) Delete(ctx context.Context, key string) error {
+	return s.Repository.Delete(ctx, key)
+}
+
+func (s *serviceImpl) SetOverride(ctx context.Context, key string, tenantUUID, userUUID *uuid.UUID, enabled bool) (Override, error) {
+	if (tenantUUID == nil) == (userUUID == nil) {
+		return Override{}, ErrInvalidInput
+	}
+	flag, err := s.Repository.Read(ctx, key)
+	if err != nil {
+		return Override{}, err
+	}
+	return s.Repository.SetOverride(ctx, Override{
+		UUID:       uuid.New(),
+		FlagUUID:   flag.UUID,
+		TenantUUID: tenantUUID,
+		UserUUID:   userUUID,
+		Enabled:    enabled,
+		CreateAt:   time.Now().UTC(),
+	})
+}
+
+func (s *serviceImpl) RemoveOverride(ctx context.Context, key string, tenantUUID, userUUID *uuid.UUID) error {
+	if (tenantUUID == nil) == (userUUID == nil) {
+		return ErrInvalidInput
+	}
+	flag, err := s.Repository.Read(ctx, key)
+	if err != nil {
+		return err
+	}
+	return s.Repository.DeleteOverride(ctx, flag.UUID, tenantUUID, userUUID)
+}
+
+func (s *serviceImpl) Evaluate(ctx context.Context, subject Subject) ([]Evaluation, error) {
+	flags, err := s.Repository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(flags) == 0 {
+		return []Evaluation{}, nil
+	}
+
+	userOverrides := map[uuid.UUID]bool{}
+	if subject.UserUUID != nil {
+		rows, err := s.Repository.UserOverrides(ctx, *subject.UserUUID)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range rows {
+			userOverrides[o.FlagUUID] = o.Enabled
+		}
+	}
+	tenantOverrides := map[uuid.UUID]InheritedOverride{}
+	if subject.TenantUUID != nil {
+		rows, err := s.Repository.TenantOverrides(ctx, *subject.TenantUUID)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range rows {
+			tenantOverrides[o.FlagUUID] = o
+		}
+	}
+
+	evaluations := make([]Evaluation, 0, len(flags))
+	for _, flag := range flags {
+		eval := Evaluation{Key: flag.Key}
+		if enabled, ok := userOverrides[flag.UUID]; ok {
+			eval.Enabled, eval.Source = enabled, SourceUser
+		} else if o, ok := tenantOverrides[flag.UUID]; ok {
+			eval.Enabled, eval.Source = o.Enabled, SourceTenant
+			if o.Depth > 0 {
+				eval.Source = SourceParent
+			}
+		} else if !flag.Enabled {
+			eval.Source = SourceDisabled
+		} else {
+			eval.Enabled, eval.Source = inRollout(flag, subject), SourceRollout
+		}
+		evaluations = append(evaluations, eval)
+	}
+	return evaluations, nil
+}
+
+// inRollout sorteia o subject de forma estável: o mesmo tenant (ou usuário) cai sempre no
+// mesmo balde de 0 a 99 para a mesma flag, e aumentar o percentual só acrescenta subjects.
+// A chave da flag entra no hash para que cada flag libere um grupo diferente.
+func inRollout(flag FeatureFlag, subject Subject) bool {
+	if flag.RolloutPercentage >= 100 {
+		return true
+	}
+	if flag.RolloutPercentage <= 0 {
+		return false
+	}
+	id := subject.UserUUID
+	if flag.RolloutBy == model.RolloutByTenant && subject.TenantUUID != nil {
+		id = subject.TenantUUID
+	}
+	if id == nil {
+		return false
+	}
+	return bucket(flag.Key, *id) < flag.RolloutPercentage
+}
+
+func bucket(key string, id uuid.UUID) int {
+	sum := sha256.Sum256([]byte(key + ":" + id.String()))
+	return int(binary.BigEndian.Uint32(sum[:4]) % 100)
+}
@@ -0,0 +1,662 @@
+package group
+
+import (
+	"errors"
+	"net/http"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/domain/user"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	Create(c *gin.Context)
+	Read(c *gin.Context)
+	List(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	AddMember(c *gin.Context)
+	RemoveMember(c *gin.Context)
+	ListMembers(c *gin.Context)
+	ListByUser(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID *uuid.UUID
+		userUUID   *uuid.UUID
+		identifier string
+		rayTrace   string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:   tenantUUID,
+		UserUUID:     userUUID,
+		Identifier:   identifier,
+		RayTraceCode: rayTrace,
+		Domain:       "group",
+		Action:       action,
+		Function:     function,
+		Success:      success,
+		InputData:    auditoria_log.SerializeData(input),
+		OutputData:   auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	groupGroup := routes.Group("/group")
+
+	{
+		groupGroup.POST("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.Create)
+		groupGroup.GET("/list", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.List)
+		groupGroup.GET("/user/:identifier", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin, model.RoleTenantUser), ctrl.ListByUser)
+		groupGroup.GET("/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.Read)
+		groupGroup.PATCH("/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.Update)
+		groupGroup.DELETE("/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.Delete)
+		groupGroup.GET("/:uuid/members", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.ListMembers)
+		groupGroup.POST("/:uuid/members", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.AddMember)
+		groupGroup.DELETE("/:uuid/members/:user", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.RemoveMember)
+	}
+}
+
+// resolveTenant determina o tenant no qual a operação será executada.
+// SYSTEM_ADMIN precisa informar 'tenant_identifier' (UUID ou Documento); os demais usam o próprio tenant.
+func (ctrl *controllerImpl) resolveTenant(c *gin.Context, login *middleware.Login) (uuid.UUID, *rest_err.RestErr) {
+	switch login.User.Role {
+	case model.RoleSystemAdmin:
+		var req TenantScopeRequestDto
+		if err := c.ShouldBindQuery(&req); err != nil || req.TenantIdentifier == "" {
+			return uuid.Nil, rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "É necessário informar o 'tenant_identifier' do tenant.")
+		}
+		t := tenant.Tenant{}
+		if err := uuid.Validate(req.TenantIdentifier); err == nil {
+			t.UUID = uuid.MustParse(req.TenantIdentifier)
+		} else {
+			t.Document = req.TenantIdentifier
+		}
+		found, err := tenant.MustUse().Service.Read(c.Request.Context(), t)
+		if err != nil {
+			if errors.Is(err, tenant.ErrNotFound) {
+				return uuid.Nil, rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "tenant not found")
+			}
+			return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+		}
+		// Também direciona a requisição para o schema do tenant, se ele for isolado
+		middleware.SetTargetTenant(c, found.UUID)
+		return found.UUID, nil
+
+	case model.RoleTenantAdmin, model.RoleTenantUser:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		return *login.User.TenantUUID, nil
+
+	default:
+		return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrMemberNotFound), errors.Is(err, user.ErrNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrNameDuplicated), errors.Is(err, ErrMemberDuplicated):
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, err.Error(), nil)
+	case errors.Is(err, ErrUserOutsideTenant):
+		return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrInvalidInput):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, err.Error())
+	default:
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+}
+
+// @Summary      Cria um novo Grupo
+// @Description  Cria um grupo (time/departamento) dentro do tenant do usuário autenticado.
+// @Tags         Group
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin)"
+// @Param        request body CreateGroupRequestDto true "Dados do grupo"
+// @Success      201  {object}  GroupResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      409  {object}  rest_err.RestErr "Já existe um grupo com este nome no tenant."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/group [post]
+func (ctrl *controllerImpl) Create(c *gin.Context) {
+	var req CreateGroupRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	created, err := ctrl.Service.Create(c.Request.Context(), Group{
+		TenantUUID:  tenantUUID,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "create", "Create", false, req, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toGroupResponse(created)
+	ctrl.logAudit(c, ctxIdentify, "create", "Create", true, req, response)
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary      Busca um Grupo
+// @Description  Busca um grupo do tenant pelo UUID.
+// @Tags         Group
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do grupo"
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin)"
+// @Success      200  {object}  GroupResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/group/{uuid} [get]
+func (ctrl *controllerImpl) Read(c *gin.Context) {
+	groupUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	found, err := ctrl.Service.Read(c.Request.Context(), tenantUUID, groupUUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	c.JSON(http.StatusOK, toGroupResponse(found))
+}
+
+// @Summary      Lista Grupos
+// @Description  Retorna uma lista paginada dos grupos do tenant.
+// @Tags         Group
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page              query int    false "Número da página (padrão 1)"
+// @Param        size              query int    false "Tamanho da página (padrão 10, máximo 100)"
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin)"
+// @Success      200  {object}  GroupsResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/group/list [get]
+func (ctrl *controllerImpl) List(c *gin.Context) {
+	var req ListGroupRequestDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid query parameters")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	if req.PageSize > 100 {
+		restError := rest_err.NewBadRequestError(nil, "É permitido um máximo de 100 listagens por página.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	groups, err := ctrl.Service.List(c.Request.Context(), tenantUUID, req.Page, req.PageSize)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "list", "List", false, req, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := make([]GroupResponseDto, len(groups))
+	for i, g := range groups {
+		response[i] = toGroupResponse(g)
+	}
+	c.JSON(http.StatusOK, GroupsResponseDto{
+		Groups: response,
+		Page:   req.Page,
+		Size:   req.PageSize,
+	})
+}
+
+// @Summary      Atualiza um Grupo
+// @Description  Atualiza nome e/ou descrição de um grupo do tenant.
+// @Tags         Group
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do grupo"
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin)"
+// @Param        request body UpdateGroupRequestDto true "Campos a serem atualizados"
+// @Success      200  {object}  GroupResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      409  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/group/{uuid} [patch]
+func (ctrl *controllerImpl) Update(c *gin.Context) {
+	groupUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	var req UpdateGroupRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	current, err := ctrl.Service.Read(c.Request.Context(), tenantUUID, groupUUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	toUpdate := Group{
+		UUID:        current.UUID,
+		TenantUUID:  current.TenantUUID,
+		Name:        req.Name,
+		Description: current.Description,
+	}
+	if req.Description != nil {
+		toUpdate.Description = *req.Description
+	}
+
+	updated, err := ctrl.Service.Update(c.Request.Context(), toUpdate)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "update", "Update", false, map[string]interface{}{"uuid": groupUUID, "request": req}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toGroupResponse(updated)
+	ctrl.logAudit(c, ctxIdentify, "update", "Update", true, map[string]interface{}{"uuid": groupUUID, "request": req}, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Deleta um Grupo
+// @Description  Exclui um grupo do tenant. Os vínculos de membros são removidos junto.
+// @Tags         Group
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do grupo"
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin)"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/group/{uuid} [delete]
+func (ctrl *controllerImpl) Delete(c *gin.Context) {
+	groupUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	if err := ctrl.Service.Delete(c.Request.Context(), tenantUUID, groupUUID); err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "delete", "Delete", false, map[string]interface{}{"uuid": groupUUID}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctrl.logAudit(c, ctxIdentify, "delete", "Delete", true, map[string]interface{}{"uuid": groupUUID}, gin.H{"status": "deleted"})
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Adiciona um membro ao Grupo
+// @Description  Vincula um usuário (UUID ou Email) do mesmo tenant ao grupo.
+// @Tags         Group
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do grupo"
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin)"
+// @Param        request body AddMemberRequestDto true "Usuário a ser adicionado"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr "Usuário pertence a outro tenant."
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      409  {object}  rest_err.RestErr "Usuário já é membro do grupo."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/group/{uuid}/members [post]
+func (ctrl *controllerImpl) AddMember(c *gin.Context) {
+	groupUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	var req AddMemberRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	member := user.User{}
+	if err := uuid.Validate(req.User); err == nil {
+		member.UUID = uuid.MustParse(req.User)
+	} else {
+		member.Email = req.User
+	}
+
+	input := map[string]interface{}{"uuid": groupUUID, "request": req}
+	if err := ctrl.Service.AddMember(c.Request.Context(), tenantUUID, groupUUID, member); err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "add_member", "AddMember", false, input, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctrl.logAudit(c, ctxIdentify, "add_member", "AddMember", true, input, gin.H{"status": "added"})
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Remove um membro do Grupo
+// @Description  Remove o vínculo de um usuário com o grupo.
+// @Tags         Group
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do grupo"
+// @Param        user path string true "UUID do usuário"
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin)"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/group/{uuid}/members/{user} [delete]
+func (ctrl *controllerImpl) RemoveMember(c *gin.Context) {
+	groupUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	userUUID, err := uuid.Parse(c.Param("user"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID do usuário não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	input := map[string]interface{}{"uuid": groupUUID, "user": userUUID}
+	if err := ctrl.Service.RemoveMember(c.Request.Context(), tenantUUID, groupUUID, userUUID); err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "remove_member", "RemoveMember", false, input, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctrl.logAudit(c, ctxIdentify, "remove_member", "RemoveMember", true, input, gin.H{"status": "removed"})
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Lista membros do Grupo
+// @Description  Retorna uma lista paginada dos usuários vinculados ao grupo.
+// @Tags         Group
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid              path  string true  "UUID do grupo"
+// @Param        page              query int    false "Número da página (padrão 1)"
+// @Param        size              query int    false "Tamanho da página (padrão 10, máximo 100)"
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin)"
+// @Success      200  {object}  MembersResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/group/{uuid}/members [get]
+func (ctrl *controllerImpl) ListMembers(c *gin.Context) {
+	groupUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	var req ListGroupRequestDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid query parameters")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	if req.PageSize > 100 {
+		restError := rest_err.NewBadRequestError(nil, "É permitido um máximo de 100 listagens por página.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	members, err := ctrl.Service.ListMembers(c.Request.Context(), tenantUUID, groupUUID, req.Page, req.PageSize)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := make([]MemberResponseDto, len(members))
+	for i, m := range members {
+		response[i] = MemberResponseDto{
+			UserUUID: m.UserUUID,
+			Name:     m.Name,
+			Email:    m.Email,
+			JoinedAt: m.JoinedAt,
+		}
+	}
+	c.JSON(http.StatusOK, MembersResponseDto{
+		Members: response,
+		Page:    req.Page,
+		Size:    req.PageSize,
+	})
+}
+
+// @Summary      Lista os grupos de um Usuário
+// @Description  Retorna os grupos do tenant aos quais o usuário (UUID ou Email) pertence. TenantUser só pode consultar a si mesmo.
+// @Tags         Group
+// @Produce      json
+// @Security     BearerAuth
+// @Param        identifier        path  string true  "UUID ou Email do usuário"
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin)"
+// @Success      200  {array}   GroupResponseDto
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/group/user/{identifier} [get]
+func (ctrl *controllerImpl) ListByUser(c *gin.Context) {
+	identificador := c.Param("identifier")
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	userToFind := user.User{}
+	if err := uuid.Validate(identificador); err == nil {
+		userToFind.UUID = uuid.MustParse(identificador)
+	} else {
+		userToFind.Email = identificador
+	}
+
+	targetUser, err := user.MustUse().Service.Read(c.Request.Context(), userToFind)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	if targetUser.TenantUUID == nil || *targetUser.TenantUUID != tenantUUID {
+		e := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Você não tem permissão para visualizar usuários de outro tenant.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+	if ctxIdentify.User.Role == model.RoleTenantUser && targetUser.UUID != ctxIdentify.User.UUID {
+		e := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Você não tem permissão para visualizar dados de outros usuários.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	groups, err := ctrl.Service.ListByUser(c.Request.Context(), tenantUUID, targetUser.UUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := make([]GroupResponseDto, len(groups))
+	for i, g := range groups {
+		response[i] = toGroupResponse(g)
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,25 @@
+package group
+
+type CreateGroupRequestDto struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+type UpdateGroupRequestDto struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+type ListGroupRequestDto struct {
+	Page             int    `form:"page"`
+	PageSize         int    `form:"size"`
+	TenantIdentifier string `form:"tenant_identifier"`
+}
+
+type AddMemberRequestDto struct {
+	User string `json:"user" binding:"required"`
+}
+
+type TenantScopeRequestDto struct {
+	TenantIdentifier string `form:"tenant_identifier"`
+}
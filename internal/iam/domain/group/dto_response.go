@@ -0,0 +1,46 @@
+package group
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GroupResponseDto struct {
+	UUID        uuid.UUID `json:"uuid"`
+	TenantUUID  uuid.UUID `json:"tenant_uuid"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreateAt    time.Time `json:"create_at"`
+	UpdateAt    time.Time `json:"update_at"`
+}
+
+type GroupsResponseDto struct {
+	Groups []GroupResponseDto `json:"groups"`
+	Page   int                `json:"page"`
+	Size   int                `json:"size"`
+}
+
+type MemberResponseDto struct {
+	UserUUID uuid.UUID `json:"user_uuid"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type MembersResponseDto struct {
+	Members []MemberResponseDto `json:"members"`
+	Page    int                 `json:"page"`
+	Size    int                 `json:"size"`
+}
+
+func toGroupResponse(g Group) GroupResponseDto {
+	return GroupResponseDto{
+		UUID:        g.UUID,
+		TenantUUID:  g.TenantUUID,
+		Name:        g.Name,
+		Description: g.Description,
+		CreateAt:    g.CreateAt,
+		UpdateAt:    g.UpdateAt,
+	}
+}
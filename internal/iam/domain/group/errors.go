@@ -0,0 +1,12 @@
+package group
+
+import "errors"
+
+var (
+	ErrNameDuplicated    = errors.New("group name already exists")
+	ErrNotFound          = errors.New("group not found")
+	ErrInvalidInput      = errors.New("invalid input data")
+	ErrMemberDuplicated  = errors.New("user already member of group")
+	ErrMemberNotFound    = errors.New("user is not member of group")
+	ErrUserOutsideTenant = errors.New("user does not belong to group tenant")
+)
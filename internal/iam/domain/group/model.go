@@ -0,0 +1,19 @@
+package group
+
+import (
+	"tenant-crud-simply/internal/iam/domain/model"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Group = model.Group
+type GroupMember = model.GroupMember
+
+// Member representa um usuário pertencente a um grupo, com os dados básicos do usuário.
+type Member struct {
+	UserUUID uuid.UUID `gorm:"column:user_uuid"`
+	Name     string    `gorm:"column:name"`
+	Email    string    `gorm:"column:email"`
+	JoinedAt time.Time `gorm:"column:joined_at"`
+}
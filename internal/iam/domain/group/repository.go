@@ -0,0 +1,202 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tenant-crud-simply/internal/infra/database/postgres"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, group Group) (Group, error)
+	Read(ctx context.Context, tenantUUID, groupUUID uuid.UUID) (Group, error)
+	List(ctx context.Context, tenantUUID uuid.UUID, page, pageSize int) ([]Group, error)
+	Update(ctx context.Context, group Group) (Group, error)
+	Delete(ctx context.Context, tenantUUID, groupUUID uuid.UUID) error
+	AddMember(ctx context.Context, member GroupMember) error
+	RemoveMember(ctx context.Context, groupUUID, userUUID uuid.UUID) error
+	ListMembers(ctx context.Context, groupUUID uuid.UUID, page, pageSize int) ([]Member, error)
+	ListByUser(ctx context.Context, userUUID uuid.UUID) ([]Group, error)
+}
+
+// repositoryImpl consulta tabelas do tenant: postgres.Conn usa o banco dedicado do tenant, quando houver.
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	return pageSize, (page - 1) * pageSize
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, group Group) (Group, error) {
+	result := postgres.Conn(ctx, r.db).Create(&group)
+	if result.Error == nil {
+		return group, nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(result.Error, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return Group{}, ErrNameDuplicated
+		case "23503":
+			return Group{}, ErrInvalidInput
+		}
+	}
+	return Group{}, result.Error
+}
+
+func (r *repositoryImpl) Read(ctx context.Context, tenantUUID, groupUUID uuid.UUID) (Group, error) {
+	if tenantUUID == uuid.Nil || groupUUID == uuid.Nil {
+		return Group{}, ErrInvalidInput
+	}
+
+	var group Group
+	result := postgres.Conn(ctx, r.db).
+		Where("uuid = ? AND tenant_uuid = ?", groupUUID, tenantUUID).
+		First(&group)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return Group{}, ErrNotFound
+		}
+		return Group{}, fmt.Errorf("erro ao ler grupo: %w", result.Error)
+	}
+	return group, nil
+}
+
+func (r *repositoryImpl) List(ctx context.Context, tenantUUID uuid.UUID, page, pageSize int) ([]Group, error) {
+	var groups []Group
+	limit, offset := normalizePage(page, pageSize)
+	result := postgres.Conn(ctx, r.db).
+		Where("tenant_uuid = ?", tenantUUID).
+		Order("name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&groups)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return groups, nil
+}
+
+func (r *repositoryImpl) Update(ctx context.Context, group Group) (Group, error) {
+	if group.UUID == uuid.Nil || group.TenantUUID == uuid.Nil {
+		return Group{}, ErrInvalidInput
+	}
+
+	updateFields := map[string]interface{}{
+		"update_at": time.Now().UTC(),
+	}
+	if group.Name != "" {
+		updateFields["name"] = group.Name
+	}
+	updateFields["description"] = group.Description
+
+	result := postgres.Conn(ctx, r.db).
+		Model(&Group{}).
+		Where("uuid = ? AND tenant_uuid = ?", group.UUID, group.TenantUUID).
+		Updates(updateFields)
+	if result.Error != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(result.Error, &pgErr) && pgErr.Code == "23505" {
+			return Group{}, ErrNameDuplicated
+		}
+		return Group{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Group{}, ErrNotFound
+	}
+
+	return r.Read(ctx, group.TenantUUID, group.UUID)
+}
+
+func (r *repositoryImpl) Delete(ctx context.Context, tenantUUID, groupUUID uuid.UUID) error {
+	result := postgres.Conn(ctx, r.db).
+		Where("uuid = ? AND tenant_uuid = ?", groupUUID, tenantUUID).
+		Delete(&Group{})
+	if result.Error != nil {
+		return fmt.Errorf("falha ao deletar grupo: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *repositoryImpl) AddMember(ctx context.Context, member GroupMember) error {
+	result := postgres.Conn(ctx, r.db).Create(&member)
+	if result.Error == nil {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(result.Error, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return ErrMemberDuplicated
+		case "23503":
+			return ErrInvalidInput
+		}
+	}
+	return result.Error
+}
+
+func (r *repositoryImpl) RemoveMember(ctx context.Context, groupUUID, userUUID uuid.UUID) error {
+	result := postgres.Conn(ctx, r.db).
+		Where("group_uuid = ? AND user_uuid = ?", groupUUID, userUUID).
+		Delete(&GroupMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *repositoryImpl) ListMembers(ctx context.Context, groupUUID uuid.UUID, page, pageSize int) ([]Member, error) {
+	var members []Member
+	limit, offset := normalizePage(page, pageSize)
+	result := postgres.Conn(ctx, r.db).
+		Table("tenant_group_members AS gm").
+		Select("gm.user_uuid, u.name, u.email, gm.create_at AS joined_at").
+		Joins("INNER JOIN users AS u ON u.uuid = gm.user_uuid").
+		Where("gm.group_uuid = ?", groupUUID).
+		Order("u.name ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&members)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return members, nil
+}
+
+func (r *repositoryImpl) ListByUser(ctx context.Context, userUUID uuid.UUID) ([]Group, error) {
+	var groups []Group
+	result := postgres.Conn(ctx, r.db).
+		Joins("INNER JOIN tenant_group_members AS gm ON gm.group_uuid = tenant_groups.uuid").
+		Where("gm.user_uuid = ?", userUUID).
+		Order("tenant_groups.name ASC").
+		Find(&groups)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return groups, nil
+}
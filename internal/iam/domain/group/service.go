@@ -0,0 +1,115 @@
+package group
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/user"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	Create(ctx context.Context, group Group) (Group, error)
+	Read(ctx context.Context, tenantUUID, groupUUID uuid.UUID) (Group, error)
+	List(ctx context.Context, tenantUUID uuid.UUID, page, pageSize int) ([]Group, error)
+	Update(ctx context.Context, group Group) (Group, error)
+	Delete(ctx context.Context, tenantUUID, groupUUID uuid.UUID) error
+	AddMember(ctx context.Context, tenantUUID, groupUUID uuid.UUID, member user.User) error
+	RemoveMember(ctx context.Context, tenantUUID, groupUUID, userUUID uuid.UUID) error
+	ListMembers(ctx context.Context, tenantUUID, groupUUID uuid.UUID, page, pageSize int) ([]Member, error)
+	ListByUser(ctx context.Context, tenantUUID, userUUID uuid.UUID) ([]Group, error)
+}
+
+type serviceImpl struct {
+	Repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &serviceImpl{
+		Repository: repository,
+	}
+}
+
+func (s *serviceImpl) Create(ctx context.Context, group Group) (Group, error) {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.TenantUUID == uuid.Nil || group.Name == "" {
+		return Group{}, ErrInvalidInput
+	}
+	now := time.Now().UTC()
+	group.CreateAt = now
+	group.UpdateAt = now
+	return s.Repository.Create(ctx, group)
+}
+
+func (s *serviceImpl) Read(ctx context.Context, tenantUUID, groupUUID uuid.UUID) (Group, error) {
+	return s.Repository.Read(ctx, tenantUUID, groupUUID)
+}
+
+func (s *serviceImpl) List(ctx context.Context, tenantUUID uuid.UUID, page, pageSize int) ([]Group, error) {
+	if tenantUUID == uuid.Nil {
+		return nil, ErrInvalidInput
+	}
+	return s.Repository.List(ctx, tenantUUID, page, pageSize)
+}
+
+func (s *serviceImpl) Update(ctx context.Context, group Group) (Group, error) {
+	group.Name = strings.TrimSpace(group.Name)
+	return s.Repository.Update(ctx, group)
+}
+
+func (s *serviceImpl) Delete(ctx context.Context, tenantUUID, groupUUID uuid.UUID) error {
+	return s.Repository.Delete(ctx, tenantUUID, groupUUID)
+}
+
+// AddMember adiciona um usuário ao grupo. O usuário precisa pertencer ao mesmo tenant do grupo.
+func (s *serviceImpl) AddMember(ctx context.Context, tenantUUID, groupUUID uuid.UUID, member user.User) error {
+	if _, err := s.Repository.Read(ctx, tenantUUID, groupUUID); err != nil {
+		return err
+	}
+
+	target, err := user.MustUse().Service.Read(ctx, member)
+	if err != nil {
+		return err
+	}
+	if target.TenantUUID == nil || *target.TenantUUID != tenantUUID {
+		return ErrUserOutsideTenant
+	}
+
+	return s.Repository.AddMember(ctx, GroupMember{
+		GroupUUID: groupUUID,
+		UserUUID:  target.UUID,
+		CreateAt:  time.Now().UTC(),
+	})
+}
+
+func (s *serviceImpl) RemoveMember(ctx context.Context, tenantUUID, groupUUID, userUUID uuid.UUID) error {
+	if _, err := s.Repository.Read(ctx, tenantUUID, groupUUID); err != nil {
+		return err
+	}
+	return s.Repository.RemoveMember(ctx, groupUUID, userUUID)
+}
+
+func (s *serviceImpl) ListMembers(ctx context.Context, tenantUUID, groupUUID uuid.UUID, page, pageSize int) ([]Member, error) {
+	if _, err := s.Repository.Read(ctx, tenantUUID, groupUUID); err != nil {
+		return nil, err
+	}
+	return s.Repository.ListMembers(ctx, groupUUID, page, pageSize)
+}
+
+// ListByUser retorna os grupos do usuário, restritos ao tenant informado.
+func (s *serviceImpl) ListByUser(ctx context.Context, tenantUUID, userUUID uuid.UUID) ([]Group, error) {
+	groups, err := s.Repository.ListByUser(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	scoped := make([]Group, 0, len(groups))
+	for _, g := range groups {
+		if g.TenantUUID == tenantUUID {
+			scoped = append(scoped, g)
+		}
+	}
+	return scoped, nil
+}
@@ -0,0 +1,239 @@
+package metering
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/rest_err"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	Summary(c *gin.Context)
+	Series(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	meteringGroup := routes.Group("/metering")
+
+	{
+		meteringGroup.GET("/summary", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.Summary)
+		meteringGroup.GET("/series", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.Series)
+	}
+}
+
+// resolveTenant determina o tenant consultado. SYSTEM_ADMIN consulta todos os tenants (nil)
+// ou apenas o informado em 'tenant_identifier'; TENANT_ADMIN consulta sempre o próprio tenant.
+func (ctrl *controllerImpl) resolveTenant(c *gin.Context, login *middleware.Login, identifier string) (*uuid.UUID, *rest_err.RestErr) {
+	switch login.User.Role {
+	case model.RoleSystemAdmin:
+		if identifier == "" {
+			return nil, nil
+		}
+		t := tenant.Tenant{}
+		if err := uuid.Validate(identifier); err == nil {
+			t.UUID = uuid.MustParse(identifier)
+		} else {
+			t.Document = identifier
+		}
+		found, err := tenant.MustUse().Service.Read(c.Request.Context(), t)
+		if err != nil {
+			if errors.Is(err, tenant.ErrNotFound) {
+				return nil, rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "tenant not found")
+			}
+			return nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+		}
+		return &found.UUID, nil
+
+	case model.RoleTenantAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		return login.User.TenantUUID, nil
+
+	default:
+		return nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+}
+
+// parseRange converte as datas inclusivas da consulta no intervalo semiaberto [from, to).
+// Sem datas, considera os últimos 7 dias (incluindo hoje).
+func parseRange(fromParam, toParam string) (time.Time, time.Time, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	to := today
+	if toParam != "" {
+		parsed, err := time.Parse("2006-01-02", toParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidRange
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -6)
+	if fromParam != "" {
+		parsed, err := time.Parse("2006-01-02", fromParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidRange
+		}
+		from = parsed
+	}
+
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	switch {
+	case errors.Is(err, ErrInvalidRange):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "Intervalo de datas inválido. Use 'from' e 'to' no formato YYYY-MM-DD, com 'from' <= 'to' (até 31 dias por hora, 366 por dia).")
+	case errors.Is(err, ErrInvalidInput):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "Granularidade inválida. Use 'hour' ou 'day'.")
+	default:
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+}
+
+// @Summary      Resumo de Consumo por Tenant
+// @Description  Totais de chamadas, erros e latência por tenant no intervalo, ordenados pelo número de chamadas. SystemAdmin vê todos os tenants (ou o informado); TenantAdmin vê o próprio tenant. Dados consolidados periodicamente a partir do access_log.
+// @Tags         Metering
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (apenas SystemAdmin)"
+// @Param        from query string false "Data inicial YYYY-MM-DD (padrão: 6 dias antes de 'to')"
+// @Param        to query string false "Data final inclusiva YYYY-MM-DD (padrão: hoje, UTC)"
+// @Param        page query int false "Página" default(1)
+// @Param        size query int false "Itens por página" default(100)
+// @Success      200  {object}  SummaryResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/metering/summary [get]
+func (ctrl *controllerImpl) Summary(c *gin.Context) {
+	var req UsageQueryRequestDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid query parameters")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify, req.TenantIdentifier)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	from, to, err := parseRange(req.From, req.To)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	filter := Filter{TenantUUID: tenantUUID, From: from, To: to, Page: req.Page, PageSize: req.Size}
+	rows, err := ctrl.Service.Summary(c.Request.Context(), filter)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSummaryResponse(filter, rows))
+}
+
+// @Summary      Série de Consumo por Endpoint
+// @Description  Chamadas, erros e latência (p50/p95) por hora ou dia, método e endpoint. SystemAdmin vê todos os tenants (ou o informado); TenantAdmin vê o próprio tenant.
+// @Tags         Metering
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (apenas SystemAdmin)"
+// @Param        granularity query string false "hour ou day" default(day)
+// @Param        endpoint query string false "Rota exata, ex: /api/user/:uuid"
+// @Param        from query string false "Data inicial YYYY-MM-DD (padrão: 6 dias antes de 'to')"
+// @Param        to query string false "Data final inclusiva YYYY-MM-DD (padrão: hoje, UTC)"
+// @Param        page query int false "Página" default(1)
+// @Param        size query int false "Itens por página" default(100)
+// @Success      200  {object}  SeriesResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/metering/series [get]
+func (ctrl *controllerImpl) Series(c *gin.Context) {
+	var req SeriesQueryRequestDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid query parameters")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify, req.TenantIdentifier)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	from, to, err := parseRange(req.From, req.To)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	granularity := Granularity(strings.ToLower(strings.TrimSpace(req.Granularity)))
+	if granularity == "" {
+		granularity = GranularityDay
+	}
+
+	filter := Filter{
+		TenantUUID:  tenantUUID,
+		From:        from,
+		To:          to,
+		Granularity: granularity,
+		Endpoint:    strings.TrimSpace(req.Endpoint),
+		Page:        req.Page,
+		PageSize:    req.Size,
+	}
+	rows, err := ctrl.Service.Series(c.Request.Context(), filter)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSeriesResponse(filter, rows))
+}
@@ -0,0 +1,16 @@
+package metering
+
+// UsageQueryRequestDto filtra as consultas de consumo. Datas no formato YYYY-MM-DD (UTC), ambas inclusivas.
+type UsageQueryRequestDto struct {
+	TenantIdentifier string `form:"tenant_identifier"`
+	From             string `form:"from"`
+	To               string `form:"to"`
+	Page             int    `form:"page"`
+	Size             int    `form:"size"`
+}
+
+type SeriesQueryRequestDto struct {
+	UsageQueryRequestDto
+	Granularity string `form:"granularity"`
+	Endpoint    string `form:"endpoint"`
+}
@@ -0,0 +1,81 @@
+package metering
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TenantSummaryResponseDto struct {
+	TenantUUID   uuid.UUID `json:"tenant_uuid"`
+	TenantName   string    `json:"tenant_name"`
+	Calls        int64     `json:"calls"`
+	ClientErrors int64     `json:"client_errors"`
+	ServerErrors int64     `json:"server_errors"`
+	AvgP50Ms     float64   `json:"avg_p50_ms"`
+	MaxP95Ms     float64   `json:"max_p95_ms"`
+}
+
+type SummaryResponseDto struct {
+	From    time.Time                  `json:"from"`
+	To      time.Time                  `json:"to"`
+	Tenants []TenantSummaryResponseDto `json:"tenants"`
+}
+
+type UsageRowResponseDto struct {
+	TenantUUID   uuid.UUID `json:"tenant_uuid"`
+	Bucket       time.Time `json:"bucket"`
+	Method       string    `json:"method"`
+	Endpoint     string    `json:"endpoint"`
+	Calls        int64     `json:"calls"`
+	ClientErrors int64     `json:"client_errors"`
+	ServerErrors int64     `json:"server_errors"`
+	P50Ms        float64   `json:"p50_ms"`
+	P95Ms        float64   `json:"p95_ms"`
+}
+
+type SeriesResponseDto struct {
+	Granularity string                `json:"granularity"`
+	From        time.Time             `json:"from"`
+	To          time.Time             `json:"to"`
+	Rows        []UsageRowResponseDto `json:"rows"`
+}
+
+func toSummaryResponse(filter Filter, rows []TenantSummary) SummaryResponseDto {
+	out := SummaryResponseDto{From: filter.From, To: filter.To, Tenants: make([]TenantSummaryResponseDto, 0, len(rows))}
+	for _, r := range rows {
+		out.Tenants = append(out.Tenants, TenantSummaryResponseDto{
+			TenantUUID:   r.TenantUUID,
+			TenantName:   r.TenantName,
+			Calls:        r.Calls,
+			ClientErrors: r.ClientErrors,
+			ServerErrors: r.ServerErrors,
+			AvgP50Ms:     r.AvgP50Ms,
+			MaxP95Ms:     r.MaxP95Ms,
+		})
+	}
+	return out
+}
+
+func toSeriesResponse(filter Filter, rows []UsageRow) SeriesResponseDto {
+	out := SeriesResponseDto{
+		Granularity: string(filter.Granularity),
+		From:        filter.From,
+		To:          filter.To,
+		Rows:        make([]UsageRowResponseDto, 0, len(rows)),
+	}
+	for _, r := range rows {
+		out.Rows = append(out.Rows, UsageRowResponseDto{
+			TenantUUID:   r.TenantUUID,
+			Bucket:       r.Bucket,
+			Method:       r.Method,
+			Endpoint:     r.Endpoint,
+			Calls:        r.Calls,
+			ClientErrors: r.ClientErrors,
+			ServerErrors: r.ServerErrors,
+			P50Ms:        r.P50Ms,
+			P95Ms:        r.P95Ms,
+		})
+	}
+	return out
+}
@@ -0,0 +1,8 @@
+package metering
+
+import "errors"
+
+var (
+	ErrInvalidInput = errors.New("invalid input data")
+	ErrInvalidRange = errors.New("invalid date range")
+)
@@ -0,0 +1,51 @@
+package metering
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Granularity string
+
+const (
+	GranularityHour Granularity = "hour"
+	GranularityDay  Granularity = "day"
+)
+
+// UsageRow é o consumo de um endpoint de um tenant em uma hora ou dia.
+type UsageRow struct {
+	TenantUUID   uuid.UUID `gorm:"column:tenant_uuid"`
+	Bucket       time.Time `gorm:"column:bucket"`
+	Method       string    `gorm:"column:method"`
+	Endpoint     string    `gorm:"column:endpoint"`
+	Calls        int64     `gorm:"column:calls"`
+	ClientErrors int64     `gorm:"column:client_errors"`
+	ServerErrors int64     `gorm:"column:server_errors"`
+	P50Ms        float64   `gorm:"column:p50_ms"`
+	P95Ms        float64   `gorm:"column:p95_ms"`
+}
+
+// TenantSummary é o consumo total de um tenant no intervalo.
+// AvgP50Ms é a mediana diária ponderada pelas chamadas; MaxP95Ms é o pior p95 diário.
+type TenantSummary struct {
+	TenantUUID   uuid.UUID `gorm:"column:tenant_uuid"`
+	TenantName   string    `gorm:"column:tenant_name"`
+	Calls        int64     `gorm:"column:calls"`
+	ClientErrors int64     `gorm:"column:client_errors"`
+	ServerErrors int64     `gorm:"column:server_errors"`
+	AvgP50Ms     float64   `gorm:"column:avg_p50_ms"`
+	MaxP95Ms     float64   `gorm:"column:max_p95_ms"`
+}
+
+// Filter delimita as consultas de consumo. TenantUUID nil consulta todos os tenants.
+// O intervalo é semiaberto: [From, To).
+type Filter struct {
+	TenantUUID  *uuid.UUID
+	From        time.Time
+	To          time.Time
+	Granularity Granularity
+	Endpoint    string
+	Page        int
+	PageSize    int
+}
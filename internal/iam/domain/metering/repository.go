@@ -0,0 +1,182 @@
+package metering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	// AggregateHour recalcula os contadores da hora a partir do access_log.
+	AggregateHour(ctx context.Context, hour time.Time) error
+	// AggregateDay recalcula os contadores do dia a partir do access_log.
+	AggregateDay(ctx context.Context, day time.Time) error
+	LastBucket(ctx context.Context) (time.Time, bool, error)
+	SaveLastBucket(ctx context.Context, bucket time.Time) error
+	FirstLogTime(ctx context.Context) (time.Time, bool, error)
+	Series(ctx context.Context, filter Filter) ([]UsageRow, error)
+	Summary(ctx context.Context, filter Filter) ([]TenantSummary, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+const stateName = "access_log"
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	return pageSize, (page - 1) * pageSize
+}
+
+// aggregateQuery consolida o access_log de uma janela em uma tabela de consumo (%s = tabela, %s = truncagem).
+// Requisições sem tenant (SYSTEM_ADMIN) e de tenants já expurgados são ignoradas.
+const aggregateQuery = `
+INSERT INTO %s (tenant_uuid, bucket, method, endpoint, calls, client_errors, server_errors, p50_ms, p95_ms, update_at)
+SELECT
+        a.tenant_uuid,
+        date_trunc('%s', a.request_time),
+        a.method,
+        COALESCE(NULLIF(a.route, ''), a.path),
+        COUNT(*),
+        COUNT(*) FILTER (WHERE a.status_code BETWEEN 400 AND 499),
+        COUNT(*) FILTER (WHERE a.status_code >= 500),
+        percentile_cont(0.5) WITHIN GROUP (ORDER BY a.latency_ms),
+        percentile_cont(0.95) WITHIN GROUP (ORDER BY a.latency_ms),
+        NOW()
+FROM access_log AS a
+WHERE a.request_time >= ? AND a.request_time < ?
+  AND a.tenant_uuid IS NOT NULL
+  AND EXISTS (SELECT 1 FROM tenant AS t WHERE t.uuid = a.tenant_uuid)
+GROUP BY 1, 2, 3, 4
+ON CONFLICT (tenant_uuid, bucket, method, endpoint) DO UPDATE
+SET calls = EXCLUDED.calls,
+    client_errors = EXCLUDED.client_errors,
+    server_errors = EXCLUDED.server_errors,
+    p50_ms = EXCLUDED.p50_ms,
+    p95_ms = EXCLUDED.p95_ms,
+    update_at = EXCLUDED.update_at`
+
+func (r *repositoryImpl) AggregateHour(ctx context.Context, hour time.Time) error {
+	query := fmt.Sprintf(aggregateQuery, "usage_hourly", "hour")
+	if err := r.db.WithContext(ctx).Exec(query, hour, hour.Add(time.Hour)).Error; err != nil {
+		return fmt.Errorf("falha ao agregar consumo da hora %s: %w", hour.Format(time.RFC3339), err)
+	}
+	return nil
+}
+
+func (r *repositoryImpl) AggregateDay(ctx context.Context, day time.Time) error {
+	query := fmt.Sprintf(aggregateQuery, "usage_daily", "day")
+	if err := r.db.WithContext(ctx).Exec(query, day, day.AddDate(0, 0, 1)).Error; err != nil {
+		return fmt.Errorf("falha ao agregar consumo do dia %s: %w", day.Format("2006-01-02"), err)
+	}
+	return nil
+}
+
+func (r *repositoryImpl) LastBucket(ctx context.Context) (time.Time, bool, error) {
+	var state struct {
+		LastBucket time.Time `gorm:"column:last_bucket"`
+	}
+	result := r.db.WithContext(ctx).
+		Table("usage_aggregation_state").
+		Select("last_bucket").
+		Where("name = ?", stateName).
+		Take(&state)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, result.Error
+	}
+	return state.LastBucket, true, nil
+}
+
+func (r *repositoryImpl) SaveLastBucket(ctx context.Context, bucket time.Time) error {
+	return r.db.WithContext(ctx).Exec(`
+INSERT INTO usage_aggregation_state (name, last_bucket, update_at)
+VALUES (?, ?, NOW())
+ON CONFLICT (name) DO UPDATE SET last_bucket = EXCLUDED.last_bucket, update_at = EXCLUDED.update_at`,
+		stateName, bucket).Error
+}
+
+func (r *repositoryImpl) FirstLogTime(ctx context.Context) (time.Time, bool, error) {
+	var first *time.Time
+	if err := r.db.WithContext(ctx).Raw("SELECT MIN(request_time) FROM access_log").Scan(&first).Error; err != nil {
+		return time.Time{}, false, err
+	}
+	if first == nil {
+		return time.Time{}, false, nil
+	}
+	return *first, true, nil
+}
+
+func (r *repositoryImpl) Series(ctx context.Context, filter Filter) ([]UsageRow, error) {
+	table := "usage_daily"
+	if filter.Granularity == GranularityHour {
+		table = "usage_hourly"
+	}
+
+	query := r.db.WithContext(ctx).
+		Table(table).
+		Select("tenant_uuid, bucket, method, endpoint, calls, client_errors, server_errors, p50_ms, p95_ms").
+		Where("bucket >= ? AND bucket < ?", filter.From, filter.To)
+	if filter.TenantUUID != nil {
+		query = query.Where("tenant_uuid = ?", *filter.TenantUUID)
+	}
+	if filter.Endpoint != "" {
+		query = query.Where("endpoint = ?", filter.Endpoint)
+	}
+
+	var rows []UsageRow
+	limit, offset := normalizePage(filter.Page, filter.PageSize)
+	err := query.
+		Order("bucket ASC, tenant_uuid ASC, endpoint ASC, method ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *repositoryImpl) Summary(ctx context.Context, filter Filter) ([]TenantSummary, error) {
+	query := r.db.WithContext(ctx).
+		Table("usage_daily AS d").
+		Select(`d.tenant_uuid, t.name AS tenant_name,
+        SUM(d.calls) AS calls,
+        SUM(d.client_errors) AS client_errors,
+        SUM(d.server_errors) AS server_errors,
+        COALESCE(SUM(d.p50_ms * d.calls) / NULLIF(SUM(d.calls), 0), 0) AS avg_p50_ms,
+        MAX(d.p95_ms) AS max_p95_ms`).
+		Joins("INNER JOIN tenant AS t ON t.uuid = d.tenant_uuid").
+		Where("d.bucket >= ? AND d.bucket < ?", filter.From, filter.To)
+	if filter.TenantUUID != nil {
+		query = query.Where("d.tenant_uuid = ?", *filter.TenantUUID)
+	}
+
+	var rows []TenantSummary
+	limit, offset := normalizePage(filter.Page, filter.PageSize)
+	err := query.
+		Group("d.tenant_uuid, t.name").
+		Order("calls DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
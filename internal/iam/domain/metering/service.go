@@ -0,0 +1,126 @@
+package metering
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type Service interface {
+	// Aggregate consolida o access_log nas tabelas de consumo por hora e por dia.
+	// Executado periodicamente pelo agendador.
+	Aggregate(ctx context.Context) error
+	Summary(ctx context.Context, filter Filter) ([]TenantSummary, error)
+	Series(ctx context.Context, filter Filter) ([]UsageRow, error)
+}
+
+type serviceImpl struct {
+	Repository Repository
+	cfg        Config
+}
+
+func NewService(repository Repository, cfg Config) Service {
+	if cfg.MaxHoursPerRun <= 0 {
+		cfg.MaxHoursPerRun = 48
+	}
+	return &serviceImpl{
+		Repository: repository,
+		cfg:        cfg,
+	}
+}
+
+// Limites de intervalo por granularidade, para evitar varreduras longas nas tabelas de consumo.
+const (
+	maxHourRange = 31 * 24 * time.Hour
+	maxDayRange  = 366 * 24 * time.Hour
+)
+
+// Aggregate recalcula as horas a partir da última hora consolidada (inclusive, para absorver
+// registros gravados com atraso) até a hora corrente, limitado a MaxHoursPerRun por execução.
+// Os dias tocados são recalculados a partir do access_log, pois percentis não podem ser somados.
+func (s *serviceImpl) Aggregate(ctx context.Context) error {
+	current := time.Now().UTC().Truncate(time.Hour)
+
+	last, found, err := s.Repository.LastBucket(ctx)
+	if err != nil {
+		return err
+	}
+
+	var from time.Time
+	if found {
+		from = last.UTC().Add(-time.Hour)
+	} else {
+		first, ok, err := s.Repository.FirstLogTime(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		from = first.UTC().Truncate(time.Hour)
+	}
+
+	days := make(map[time.Time]struct{})
+	processed := 0
+	hour := from
+	for ; !hour.After(current) && processed < s.cfg.MaxHoursPerRun; hour = hour.Add(time.Hour) {
+		if err := s.Repository.AggregateHour(ctx, hour); err != nil {
+			return err
+		}
+		days[hour.Truncate(24*time.Hour)] = struct{}{}
+		processed++
+	}
+
+	if processed == 0 {
+		return nil
+	}
+
+	for day := range days {
+		if err := s.Repository.AggregateDay(ctx, day); err != nil {
+			return err
+		}
+	}
+
+	// A última hora processada será reprocessada na próxima execução
+	lastProcessed := hour.Add(-time.Hour)
+	if err := s.Repository.SaveLastBucket(ctx, lastProcessed); err != nil {
+		return err
+	}
+
+	if !hour.After(current) {
+		log.Printf("[METERING] Consolidação parcial até %s; restante na próxima execução", lastProcessed.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (s *serviceImpl) validate(filter Filter, maxRange time.Duration) error {
+	if !filter.From.Before(filter.To) {
+		return ErrInvalidRange
+	}
+	if filter.To.Sub(filter.From) > maxRange {
+		return ErrInvalidRange
+	}
+	return nil
+}
+
+func (s *serviceImpl) Summary(ctx context.Context, filter Filter) ([]TenantSummary, error) {
+	if err := s.validate(filter, maxDayRange); err != nil {
+		return nil, err
+	}
+	return s.Repository.Summary(ctx, filter)
+}
+
+func (s *serviceImpl) Series(ctx context.Context, filter Filter) ([]UsageRow, error) {
+	maxRange := maxDayRange
+	switch filter.Granularity {
+	case GranularityHour:
+		maxRange = maxHourRange
+	case GranularityDay:
+	default:
+		return nil, ErrInvalidInput
+	}
+	if err := s.validate(filter, maxRange); err != nil {
+		return nil, err
+	}
+	return s.Repository.Series(ctx, filter)
+}
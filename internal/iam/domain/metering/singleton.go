@@ -0,0 +1,70 @@
+package metering
+
+import (
+	"errors"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	repositoryInstance Repository
+	once               sync.Once
+	initErr            error
+	ErrNotInitialized  = errors.New("metering controller not initialized")
+)
+
+// UseMetering agrupa todas as camadas (Repository, Service, Controller)
+type UseMetering struct {
+	Repository Repository
+	Service    Service
+	Controller Controller
+}
+
+// Config usada somente no New()
+type Config struct {
+	// MaxHoursPerRun limita quantas horas são consolidadas por execução (padrão 48),
+	// para que a carga inicial de um access_log grande seja feita aos poucos.
+	MaxHoursPerRun int
+}
+
+// New inicializa o singleton do controller de consumo com todas as suas dependências
+func New(db *gorm.DB, cfg Config) (Controller, error) {
+	once.Do(func() {
+		if db == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+
+		// Inicializa as dependências em camadas
+		repositoryInstance = NewRepository(db)
+		serviceInstance = NewService(repositoryInstance, cfg)
+		controllerInstance = NewController(serviceInstance)
+	})
+
+	return controllerInstance, initErr
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Repository, Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UseMetering {
+	if controllerInstance == nil || serviceInstance == nil || repositoryInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UseMetering{
+		Repository: repositoryInstance,
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
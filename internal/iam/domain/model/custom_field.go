@@ -0,0 +1,114 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Metadata são os campos personalizados de um tenant ou usuário (coluna JSONB), validados
+// pelas definições de custom_fields.
+type Metadata map[string]any
+
+func (m Metadata) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+func (m *Metadata) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*m = Metadata{}
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("metadata: tipo %T não suportado", src)
+	}
+	out := Metadata{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return err
+	}
+	*m = out
+	return nil
+}
+
+// StringList é uma lista de textos gravada como array JSONB.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	raw, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(raw), nil
+}
+
+func (l *StringList) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("string list: tipo %T não suportado", src)
+	}
+	return json.Unmarshal(raw, (*[]string)(l))
+}
+
+type CustomFieldEntity string
+
+const (
+	CustomFieldEntityTenant CustomFieldEntity = "tenant"
+	CustomFieldEntityUser   CustomFieldEntity = "user"
+)
+
+type CustomFieldType string
+
+const (
+	CustomFieldString  CustomFieldType = "string"
+	CustomFieldNumber  CustomFieldType = "number"
+	CustomFieldBoolean CustomFieldType = "boolean"
+	CustomFieldDate    CustomFieldType = "date" // 2006-01-02
+	CustomFieldEnum    CustomFieldType = "enum"
+)
+
+// CustomField define um campo personalizado. Vale para os registros do tenant dono e de seus
+// descendentes; TenantUUID nil é uma definição global. Em chaves repetidas, vale a do dono
+// mais próximo.
+type CustomField struct {
+	UUID       uuid.UUID         `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TenantUUID *uuid.UUID        `gorm:"type:uuid;index"`
+	Entity     CustomFieldEntity `gorm:"type:varchar(10);not null"`
+	Key        string            `gorm:"type:varchar(63);not null"`
+	Label      string            `gorm:"type:varchar(255);not null"`
+	Type       CustomFieldType   `gorm:"type:varchar(10);not null"`
+	Required   bool              `gorm:"not null;default:false"`
+	EnumValues StringList        `gorm:"type:jsonb;not null;default:'[]'"` // Valores aceitos pelo tipo enum
+	Pattern    string            `gorm:"type:text;not null;default:''"`    // Expressão regular (tipo string)
+	CreateAt   time.Time         `gorm:"type:timestamp without time zone;not null"`
+	UpdateAt   time.Time         `gorm:"type:timestamp without time zone;not null"`
+}
+
+func (CustomField) TableName() string {
+	return "custom_fields"
+}
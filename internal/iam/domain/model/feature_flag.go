@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RolloutSubject é quem define o grupo da liberação gradual: todos os usuários de um tenant
+// caem no mesmo grupo (tenant) ou cada usuário é sorteado individualmente (user).
+type RolloutSubject string
+
+const (
+	RolloutByTenant RolloutSubject = "tenant"
+	RolloutByUser   RolloutSubject = "user"
+)
+
+// FeatureFlag é uma flag global. Enabled desligado desliga a flag para todos, exceto onde
+// houver override; ligado, vale para RolloutPercentage% dos tenants ou usuários.
+type FeatureFlag struct {
+	UUID              uuid.UUID      `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Key               string         `gorm:"type:varchar(63);not null;uniqueIndex"`
+	Description       string         `gorm:"type:text;not null;default:''"`
+	Enabled           bool           `gorm:"not null;default:false"`
+	RolloutPercentage int            `gorm:"not null;default:100"` // 0 a 100
+	RolloutBy         RolloutSubject `gorm:"type:varchar(10);not null;default:'tenant'"`
+	CreateAt          time.Time      `gorm:"type:timestamp without time zone;not null"`
+	UpdateAt          time.Time      `gorm:"type:timestamp without time zone;not null"`
+}
+
+func (FeatureFlag) TableName() string {
+	return "feature_flags"
+}
+
+// FeatureFlagOverride liga ou desliga a flag para um tenant (e seus descendentes) ou para um
+// usuário, independentemente da liberação gradual. Exatamente um dos dois UUIDs é preenchido.
+type FeatureFlagOverride struct {
+	UUID       uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	FlagUUID   uuid.UUID  `gorm:"type:uuid;not null"`
+	TenantUUID *uuid.UUID `gorm:"type:uuid"`
+	UserUUID   *uuid.UUID `gorm:"type:uuid"`
+	Enabled    bool       `gorm:"not null"`
+	CreateAt   time.Time  `gorm:"type:timestamp without time zone;not null"`
+}
+
+func (FeatureFlagOverride) TableName() string {
+	return "feature_flag_overrides"
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Group struct {
+	UUID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TenantUUID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	Name        string    `gorm:"type:varchar(255);not null"`
+	Description string    `gorm:"type:text"`
+	CreateAt    time.Time `gorm:"type:timestamp without time zone;not null"`
+	UpdateAt    time.Time `gorm:"type:timestamp without time zone;not null"`
+}
+
+func (Group) TableName() string {
+	return "tenant_groups"
+}
+
+type GroupMember struct {
+	GroupUUID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserUUID  uuid.UUID `gorm:"type:uuid;primaryKey"`
+	CreateAt  time.Time `gorm:"type:timestamp without time zone;not null"`
+}
+
+func (GroupMember) TableName() string {
+	return "tenant_group_members"
+}
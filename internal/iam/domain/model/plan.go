@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Plan é um item do catálogo de planos. Limites nil significam "ilimitado".
+type Plan struct {
+	UUID                uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Code                string    `gorm:"type:varchar(50);not null;unique"`
+	Name                string    `gorm:"type:varchar(255);not null"`
+	MaxUsers            *int64    `gorm:"type:integer"`
+	MaxAPIKeys          *int64    `gorm:"type:integer;column:max_api_keys"`
+	MonthlyRequestQuota *int64    `gorm:"type:bigint"`
+	CreateAt            time.Time `gorm:"type:timestamp without time zone;not null"`
+	UpdateAt            time.Time `gorm:"type:timestamp without time zone;not null"`
+}
+
+func (Plan) TableName() string {
+	return "plans"
+}
+
+type TenantPlan struct {
+	TenantUUID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	PlanUUID   uuid.UUID `gorm:"type:uuid;not null"`
+	AssignedAt time.Time `gorm:"type:timestamp without time zone;not null"`
+}
+
+func (TenantPlan) TableName() string {
+	return "tenant_plans"
+}
@@ -3,18 +3,90 @@ package model
 import (
 	"time"
 
+	"tenant-crud-simply/internal/pkg/document"
+
 	"github.com/google/uuid"
 )
 
+// TenantStatus é a etapa do ciclo de vida do tenant. As transições permitidas ficam no tenant.Service.
+type TenantStatus string
+
+const (
+	TenantStatusTrial     TenantStatus = "trial"     // Em avaliação até TrialEndsAt; acesso completo
+	TenantStatusActive    TenantStatus = "active"    // Acesso completo
+	TenantStatusPastDue   TenantStatus = "past_due"  // Pagamento pendente; acesso somente leitura
+	TenantStatusSuspended TenantStatus = "suspended" // Acesso bloqueado; pode ser reativado
+	TenantStatusCancelled TenantStatus = "cancelled" // Contrato encerrado; acesso bloqueado, sem volta
+)
+
+// Valid indica se o status existe no ciclo de vida.
+func (s TenantStatus) Valid() bool {
+	switch s {
+	case TenantStatusTrial, TenantStatusActive, TenantStatusPastDue, TenantStatusSuspended, TenantStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// AllowsAccess indica se os usuários do tenant podem autenticar e usar a API.
+func (s TenantStatus) AllowsAccess() bool {
+	switch s {
+	case TenantStatusTrial, TenantStatusActive, TenantStatusPastDue:
+		return true
+	}
+	return false
+}
+
+// ReadOnly indica que os usuários do tenant só podem fazer consultas.
+func (s TenantStatus) ReadOnly() bool {
+	return s == TenantStatusPastDue
+}
+
+// TenantIsolation define onde ficam os dados do tenant.
+type TenantIsolation string
+
+const (
+	TenantIsolationShared   TenantIsolation = "shared"   // Tabelas de public, filtradas por tenant_uuid
+	TenantIsolationSchema   TenantIsolation = "schema"   // Usuários e grupos em um schema próprio (SchemaName)
+	TenantIsolationDatabase TenantIsolation = "database" // Usuários e grupos em um banco de dados dedicado (tenant_database)
+)
+
+// Valid indica se o modo de isolamento existe.
+func (i TenantIsolation) Valid() bool {
+	return i == TenantIsolationShared || i == TenantIsolationSchema || i == TenantIsolationDatabase
+}
+
 type Tenant struct {
-	UUID     uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	Name     string    `gorm:"type:varchar(255);not null"`
-	Document string    `gorm:"type:varchar(100);not null;unique"`
-	Live     bool      `gorm:"type:boolean;not null;default:true"`
-	CreateAt time.Time `gorm:"type:timestamp without time zone;not null"`
-	UpdateAt time.Time `gorm:"type:timestamp without time zone;not null"`
+	UUID         uuid.UUID       `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	ParentUUID   *uuid.UUID      `gorm:"type:uuid;index"` // Tenant pai (revenda/organização). NULL = tenant raiz
+	Name         string          `gorm:"type:varchar(255);not null"`
+	Document     string          `gorm:"type:varchar(100);not null;unique"` // Forma canônica: sem pontuação, em maiúsculas
+	DocumentType document.Kind   `gorm:"type:varchar(10)"`                  // cpf, cnpj ou foreign. Vazio (NULL) = documento legado ainda não validado
+	Status       TenantStatus    `gorm:"type:varchar(20);not null;default:active"`
+	TrialEndsAt  *time.Time      `gorm:"type:timestamp without time zone"` // Fim da avaliação; só preenchido no status trial
+	Metadata     Metadata        `gorm:"type:jsonb;not null;default:'{}'"` // Campos personalizados (custom_fields)
+	Isolation    TenantIsolation `gorm:"type:varchar(16);not null;default:shared"`
+	SchemaName   *string         `gorm:"type:text;unique"` // Schema dedicado; só preenchido com isolamento por schema
+	CreateAt     time.Time       `gorm:"type:timestamp without time zone;not null"`
+	UpdateAt     time.Time       `gorm:"type:timestamp without time zone;not null"`
+	DeletedAt    *time.Time      `gorm:"type:timestamp without time zone"` // Exclusão lógica; expurgado após o período de carência
 }
 
 func (Tenant) TableName() string {
 	return "tenant"
 }
+
+// TenantStatusChange registra cada transição de status do tenant.
+type TenantStatusChange struct {
+	UUID       uuid.UUID     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TenantUUID uuid.UUID     `gorm:"type:uuid;not null;index"`
+	FromStatus *TenantStatus `gorm:"type:varchar(20)"` // NULL = status inicial
+	ToStatus   TenantStatus  `gorm:"type:varchar(20);not null"`
+	Reason     string        `gorm:"type:text;not null"`
+	ChangedBy  *uuid.UUID    `gorm:"type:uuid"` // NULL = alteração automática (ex.: fim da avaliação)
+	CreateAt   time.Time     `gorm:"type:timestamp without time zone;not null"`
+}
+
+func (TenantStatusChange) TableName() string {
+	return "tenant_status_history"
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DomainKind string
+
+const (
+	DomainKindSubdomain DomainKind = "subdomain" // Rótulo sob o domínio base da plataforma
+	DomainKindCustom    DomainKind = "custom"    // Domínio próprio do tenant, exige verificação DNS
+)
+
+// TenantDomain associa um host a um tenant. Domínios próprios só são resolvidos após a verificação.
+type TenantDomain struct {
+	UUID              uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	TenantUUID        uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Kind              DomainKind `gorm:"type:varchar(20);not null"`
+	Hostname          string     `gorm:"type:varchar(253);not null"`
+	VerificationToken string     `gorm:"type:varchar(64);not null"`
+	VerifiedAt        *time.Time `gorm:"type:timestamp without time zone"`
+	CreateAt          time.Time  `gorm:"type:timestamp without time zone;not null"`
+}
+
+func (TenantDomain) TableName() string {
+	return "tenant_domains"
+}
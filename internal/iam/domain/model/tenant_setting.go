@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TenantSetting é o valor de uma configuração sobrescrito por um tenant.
+// Value guarda o JSON já validado contra a definição da configuração.
+type TenantSetting struct {
+	TenantUUID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Key        string    `gorm:"type:varchar(100);primaryKey"`
+	Value      string    `gorm:"type:jsonb;not null"`
+	UpdateAt   time.Time `gorm:"type:timestamp without time zone;not null"`
+}
+
+func (TenantSetting) TableName() string {
+	return "tenant_settings"
+}
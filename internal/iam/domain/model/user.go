@@ -9,20 +9,27 @@ import (
 type UserRole string
 
 const (
-	RoleSystemAdmin UserRole = "SYSTEM_ADMIN"
-	RoleTenantAdmin UserRole = "TENANT_ADMIN"
-	RoleTenantUser  UserRole = "TENANT_USER"
+	RoleSystemAdmin  UserRole = "SYSTEM_ADMIN"
+	RolePartnerAdmin UserRole = "PARTNER_ADMIN" // Administra o próprio tenant e toda a sua subárvore
+	RoleTenantAdmin  UserRole = "TENANT_ADMIN"
+	RoleTenantUser   UserRole = "TENANT_USER"
 )
 
 type User struct {
-	UUID       uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	TenantUUID *uuid.UUID `gorm:"type:uuid;index"`
-	Name       string     `gorm:"type:varchar(255);not null"`
-	Email      string     `gorm:"type:varchar(255);not null;unique"`
-	Password   string     `gorm:"column:password_hash;type:varchar(255);not null"`
-	Role       UserRole   `gorm:"type:user_role;not null;default:'TENANT_USER'"`
-	Live       bool       `gorm:"not null;default:true"`
-	CreateAt   time.Time  `gorm:"column:create_at;not null;autoCreateTime"`
-	UpdateAt   time.Time  `gorm:"column:update_at;not null;autoUpdateTime"`
-	Tenant     Tenant     `gorm:"foreignKey:TenantUUID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	UUID        uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TenantUUID  *uuid.UUID `gorm:"type:uuid;index"`
+	Name        string     `gorm:"type:varchar(255);not null"`
+	Email       string     `gorm:"type:varchar(255);not null;unique"`
+	Password    string     `gorm:"column:password_hash;type:varchar(255);not null"`
+	Role        UserRole   `gorm:"type:user_role;not null;default:'TENANT_USER'"`
+	Live        bool       `gorm:"not null;default:true"`
+	Metadata    Metadata   `gorm:"type:jsonb;not null;default:'{}'"` // Campos personalizados (custom_fields)
+	CreateAt    time.Time  `gorm:"column:create_at;not null;autoCreateTime"`
+	UpdateAt    time.Time  `gorm:"column:update_at;not null;autoUpdateTime"`
+	LastLoginAt *time.Time `gorm:"column:last_login_at"`                 // Nil se o usuário nunca fez login
+	Phone       string     `gorm:"type:varchar(30);not null;default:''"` // Perfil alterado pelo próprio usuário em /api/me
+	AvatarURL   string     `gorm:"column:avatar_url;type:text;not null;default:''"`
+	Locale      string     `gorm:"type:varchar(35);not null;default:''"` // Vazio usa a configuração do tenant
+	Timezone    string     `gorm:"type:varchar(64);not null;default:''"` // Vazio usa a configuração do tenant
+	Tenant      Tenant     `gorm:"foreignKey:TenantUUID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
 }
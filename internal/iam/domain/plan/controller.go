@@ -0,0 +1,403 @@
+package plan
+
+import (
+	"errors"
+	"net/http"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	Create(c *gin.Context)
+	List(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	Assign(c *gin.Context)
+	Usage(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+
+		if login.User.Role == model.RolePartnerAdmin {
+			if target, ok := middleware.GetTargetTenant(c); ok {
+				actingTenantUUID = &target
+			}
+		}
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "plan",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	planGroup := routes.Group("/plan")
+
+	{
+		planGroup.POST("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Create)
+		planGroup.GET("/list", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.List)
+		planGroup.PUT("/assign", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Assign)
+		planGroup.PUT("/:code", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Update)
+		planGroup.DELETE("/:code", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Delete)
+	}
+
+	routes.GET("/tenant/usage", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Usage)
+}
+
+// resolveTenant determina o tenant cujo consumo será consultado ou cujo plano será alterado.
+// SYSTEM_ADMIN precisa informar 'tenant_identifier'; PARTNER_ADMIN pode informar um tenant
+// da sua hierarquia (padrão: o próprio); TENANT_ADMIN usa sempre o próprio tenant.
+func (ctrl *controllerImpl) resolveTenant(c *gin.Context, login *middleware.Login) (uuid.UUID, *rest_err.RestErr) {
+	var req TenantScopeRequestDto
+	_ = c.ShouldBindQuery(&req)
+
+	switch login.User.Role {
+	case model.RoleSystemAdmin:
+		if req.TenantIdentifier == "" {
+			return uuid.Nil, rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "É necessário informar o 'tenant_identifier' do tenant.")
+		}
+		return ctrl.findTenant(c, login, req.TenantIdentifier)
+
+	case model.RolePartnerAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		if req.TenantIdentifier == "" {
+			return *login.User.TenantUUID, nil
+		}
+		target, restError := ctrl.findTenant(c, login, req.TenantIdentifier)
+		if restError != nil {
+			return uuid.Nil, restError
+		}
+		inSubtree, err := tenant.MustUse().Service.InSubtree(c.Request.Context(), *login.User.TenantUUID, target)
+		if err != nil {
+			return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "Falha ao verificar hierarquia de tenants", nil)
+		}
+		if !inSubtree {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Tenant fora da sua hierarquia.")
+		}
+		middleware.SetTargetTenant(c, target)
+		return target, nil
+
+	case model.RoleTenantAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		return *login.User.TenantUUID, nil
+
+	default:
+		return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+}
+
+func (ctrl *controllerImpl) findTenant(c *gin.Context, login *middleware.Login, identifier string) (uuid.UUID, *rest_err.RestErr) {
+	t := tenant.Tenant{}
+	if err := uuid.Validate(identifier); err == nil {
+		t.UUID = uuid.MustParse(identifier)
+	} else {
+		t.Document = identifier
+	}
+	found, err := tenant.MustUse().Service.Read(c.Request.Context(), t)
+	if err != nil {
+		if errors.Is(err, tenant.ErrNotFound) {
+			return uuid.Nil, rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "tenant not found")
+		}
+		return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+	return found.UUID, nil
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrCodeDuplicated), errors.Is(err, ErrPlanInUse):
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, err.Error(), nil)
+	case errors.Is(err, ErrInvalidInput):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, err.Error())
+	default:
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+}
+
+// @Summary      Cria um Plano
+// @Description  Adiciona um plano ao catálogo. Limites omitidos são ilimitados.
+// @Tags         Plan
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body CreatePlanRequestDto true "Dados do plano"
+// @Success      201  {object}  PlanResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      409  {object}  rest_err.RestErr "Já existe um plano com este código."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/plan [post]
+func (ctrl *controllerImpl) Create(c *gin.Context) {
+	var req CreatePlanRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	created, err := ctrl.Service.Create(c.Request.Context(), Plan{
+		Code:                req.Code,
+		Name:                req.Name,
+		MaxUsers:            req.MaxUsers,
+		MaxAPIKeys:          req.MaxAPIKeys,
+		MonthlyRequestQuota: req.MonthlyRequestQuota,
+	})
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "create", "Create", false, req, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toPlanResponse(created)
+	ctrl.logAudit(c, ctxIdentify, "create", "Create", true, req, response)
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary      Lista os Planos
+// @Description  Lista o catálogo de planos com seus limites (null = ilimitado).
+// @Tags         Plan
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  PlansResponseDto
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/plan/list [get]
+func (ctrl *controllerImpl) List(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	plans, err := ctrl.Service.List(c.Request.Context())
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	resp := PlansResponseDto{Plans: make([]PlanResponseDto, 0, len(plans))}
+	for _, p := range plans {
+		resp.Plans = append(resp.Plans, toPlanResponse(p))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary      Atualiza um Plano
+// @Description  Substitui o nome e os limites do plano. Limites omitidos passam a ser ilimitados. Vale imediatamente para todos os tenants do plano.
+// @Tags         Plan
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        code path string true "Código do plano"
+// @Param        request body UpdatePlanRequestDto true "Nome e limites"
+// @Success      200  {object}  PlanResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Plano não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/plan/{code} [put]
+func (ctrl *controllerImpl) Update(c *gin.Context) {
+	var req UpdatePlanRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	code := c.Param("code")
+	updated, err := ctrl.Service.Update(c.Request.Context(), Plan{
+		Code:                code,
+		Name:                req.Name,
+		MaxUsers:            req.MaxUsers,
+		MaxAPIKeys:          req.MaxAPIKeys,
+		MonthlyRequestQuota: req.MonthlyRequestQuota,
+	})
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "update", "Update", false, gin.H{"code": code, "request": req}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toPlanResponse(updated)
+	ctrl.logAudit(c, ctxIdentify, "update", "Update", true, gin.H{"code": code, "request": req}, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Remove um Plano
+// @Description  Remove um plano do catálogo. Planos contratados por algum tenant, ou o plano padrão, não podem ser removidos.
+// @Tags         Plan
+// @Produce      json
+// @Security     BearerAuth
+// @Param        code path string true "Código do plano"
+// @Success      204  "Plano removido"
+// @Failure      404  {object}  rest_err.RestErr "Plano não encontrado."
+// @Failure      409  {object}  rest_err.RestErr "Plano em uso."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/plan/{code} [delete]
+func (ctrl *controllerImpl) Delete(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	code := c.Param("code")
+	if err := ctrl.Service.Delete(c.Request.Context(), code); err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "delete", "Delete", false, gin.H{"code": code}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctrl.logAudit(c, ctxIdentify, "delete", "Delete", true, gin.H{"code": code}, nil)
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Define o Plano de um Tenant
+// @Description  Associa o tenant a um plano do catálogo. Os novos limites valem imediatamente.
+// @Tags         Plan
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string true "UUID ou Documento do tenant"
+// @Param        request body AssignPlanRequestDto true "Código do plano"
+// @Success      200  {object}  PlanResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant ou plano não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/plan/assign [put]
+func (ctrl *controllerImpl) Assign(c *gin.Context) {
+	var req AssignPlanRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	assigned, err := ctrl.Service.Assign(c.Request.Context(), tenantUUID, req.PlanCode)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "assign", "Assign", false, gin.H{"tenant": tenantUUID, "request": req}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toPlanResponse(assigned)
+	ctrl.logAudit(c, ctxIdentify, "assign", "Assign", true, gin.H{"tenant": tenantUUID, "request": req}, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Consumo do Tenant
+// @Description  Retorna o plano do tenant e o consumo de cada limite no período corrente (mês UTC). Limites null são ilimitados.
+// @Tags         Tenant
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)"
+// @Success      200  {object}  UsageResponseDto
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/tenant/usage [get]
+func (ctrl *controllerImpl) Usage(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	usage, err := ctrl.Service.Usage(c.Request.Context(), tenantUUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	c.JSON(http.StatusOK, toUsageResponse(tenantUUID, usage))
+}
@@ -0,0 +1,26 @@
+package plan
+
+type TenantScopeRequestDto struct {
+	TenantIdentifier string `form:"tenant_identifier"`
+}
+
+// CreatePlanRequestDto cria um plano. Limites omitidos (null) são ilimitados.
+type CreatePlanRequestDto struct {
+	Code                string `json:"code" binding:"required"`
+	Name                string `json:"name" binding:"required"`
+	MaxUsers            *int64 `json:"max_users"`
+	MaxAPIKeys          *int64 `json:"max_api_keys"`
+	MonthlyRequestQuota *int64 `json:"monthly_request_quota"`
+}
+
+// UpdatePlanRequestDto substitui nome e limites do plano. Limites omitidos (null) passam a ser ilimitados.
+type UpdatePlanRequestDto struct {
+	Name                string `json:"name" binding:"required"`
+	MaxUsers            *int64 `json:"max_users"`
+	MaxAPIKeys          *int64 `json:"max_api_keys"`
+	MonthlyRequestQuota *int64 `json:"monthly_request_quota"`
+}
+
+type AssignPlanRequestDto struct {
+	PlanCode string `json:"plan_code" binding:"required"`
+}
@@ -0,0 +1,69 @@
+package plan
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PlanResponseDto struct {
+	UUID                uuid.UUID `json:"uuid"`
+	Code                string    `json:"code"`
+	Name                string    `json:"name"`
+	MaxUsers            *int64    `json:"max_users"`
+	MaxAPIKeys          *int64    `json:"max_api_keys"`
+	MonthlyRequestQuota *int64    `json:"monthly_request_quota"`
+	CreateAt            time.Time `json:"create_at"`
+	UpdateAt            time.Time `json:"update_at"`
+}
+
+type PlansResponseDto struct {
+	Plans []PlanResponseDto `json:"plans"`
+}
+
+type MetricUsageResponseDto struct {
+	Metric  string   `json:"metric"`
+	Used    int64    `json:"used"`
+	Limit   *int64   `json:"limit"`
+	Percent *float64 `json:"percent,omitempty"`
+}
+
+type UsageResponseDto struct {
+	TenantUUID  uuid.UUID                `json:"tenant_uuid"`
+	Plan        PlanResponseDto          `json:"plan"`
+	PeriodStart time.Time                `json:"period_start"`
+	PeriodEnd   time.Time                `json:"period_end"`
+	Metrics     []MetricUsageResponseDto `json:"metrics"`
+}
+
+func toPlanResponse(p Plan) PlanResponseDto {
+	return PlanResponseDto{
+		UUID:                p.UUID,
+		Code:                p.Code,
+		Name:                p.Name,
+		MaxUsers:            p.MaxUsers,
+		MaxAPIKeys:          p.MaxAPIKeys,
+		MonthlyRequestQuota: p.MonthlyRequestQuota,
+		CreateAt:            p.CreateAt,
+		UpdateAt:            p.UpdateAt,
+	}
+}
+
+func toUsageResponse(tenantUUID uuid.UUID, u Usage) UsageResponseDto {
+	resp := UsageResponseDto{
+		TenantUUID:  tenantUUID,
+		Plan:        toPlanResponse(u.Plan),
+		PeriodStart: u.PeriodStart,
+		PeriodEnd:   u.PeriodEnd,
+		Metrics:     make([]MetricUsageResponseDto, 0, len(u.Metrics)),
+	}
+	for _, m := range u.Metrics {
+		item := MetricUsageResponseDto{Metric: m.Metric, Used: m.Used, Limit: m.Limit}
+		if m.Limit != nil && *m.Limit > 0 {
+			percent := float64(m.Used) * 100 / float64(*m.Limit)
+			item.Percent = &percent
+		}
+		resp.Metrics = append(resp.Metrics, item)
+	}
+	return resp
+}
@@ -0,0 +1,29 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotFound       = errors.New("plan not found")
+	ErrInvalidInput   = errors.New("invalid input data")
+	ErrCodeDuplicated = errors.New("plan code already exists")
+	ErrPlanInUse      = errors.New("plan is assigned to tenants")
+	ErrQuotaExceeded  = errors.New("plan limit exceeded")
+)
+
+// QuotaError detalha o limite atingido. errors.Is(err, ErrQuotaExceeded) é verdadeiro.
+type QuotaError struct {
+	Metric string
+	Limit  int64
+	Used   int64
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("plan limit exceeded for %s (%d/%d)", e.Metric, e.Used, e.Limit)
+}
+
+func (e *QuotaError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
@@ -0,0 +1,57 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestQuotaHook contabiliza cada requisição autenticada na quota mensal do tenant
+// e bloqueia as requisições excedentes. Usuários sem tenant (SYSTEM_ADMIN) não são contabilizados.
+// Falhas ao contabilizar não bloqueiam a requisição.
+func requestQuotaHook(service Service) middleware.AfterAuthHook {
+	return func(c *gin.Context, login *middleware.Login) *rest_err.RestErr {
+		if login.User.TenantUUID == nil {
+			return nil
+		}
+
+		err := service.CountRequest(c.Request.Context(), *login.User.TenantUUID)
+		if err == nil {
+			return nil
+		}
+
+		var quotaErr *QuotaError
+		if errors.As(err, &quotaErr) {
+			return quotaRestError(&login.Metadata.RayTraceCode, quotaErr)
+		}
+		log.Printf("[PLAN] Falha ao contabilizar requisição do tenant %s: %v", *login.User.TenantUUID, err)
+		return nil
+	}
+}
+
+// QuotaRestError converte um erro de limite do plano no RestErr 'quota_exceeded' (HTTP 402).
+// Retorna nil se err não for um erro de limite.
+func QuotaRestError(traceID *string, err error) *rest_err.RestErr {
+	var quotaErr *QuotaError
+	if !errors.As(err, &quotaErr) {
+		return nil
+	}
+	return quotaRestError(traceID, quotaErr)
+}
+
+func quotaRestError(traceID *string, err *QuotaError) *rest_err.RestErr {
+	return rest_err.NewQuotaExceededError(traceID,
+		fmt.Sprintf("Limite de %s do plano atingido (%d/%d).", metricLabel(err.Metric), err.Used, err.Limit),
+		[]rest_err.Causes{
+			rest_err.NewCause("metric", err.Metric),
+			rest_err.NewCause("limit", strconv.FormatInt(err.Limit, 10)),
+			rest_err.NewCause("used", strconv.FormatInt(err.Used, 10)),
+		},
+	)
+}
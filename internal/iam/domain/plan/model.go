@@ -0,0 +1,55 @@
+package plan
+
+import (
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+)
+
+type Plan = model.Plan
+type TenantPlan = model.TenantPlan
+
+// Métricas controladas pelos planos.
+const (
+	MetricUsers    = "users"
+	MetricAPIKeys  = "api_keys"
+	MetricRequests = "requests"
+)
+
+// Percentuais de consumo que disparam email de aviso para os admins do tenant.
+var alertThresholds = []int64{80, 100}
+
+// MetricUsage é o consumo atual de uma métrica frente ao limite do plano (nil = ilimitado).
+type MetricUsage struct {
+	Metric string
+	Used   int64
+	Limit  *int64
+}
+
+// Usage é o consumo do tenant no período corrente.
+type Usage struct {
+	Plan        Plan
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Metrics     []MetricUsage
+}
+
+// limitOf retorna o limite do plano para a métrica.
+func limitOf(p Plan, metric string) *int64 {
+	switch metric {
+	case MetricUsers:
+		return p.MaxUsers
+	case MetricAPIKeys:
+		return p.MaxAPIKeys
+	case MetricRequests:
+		return p.MonthlyRequestQuota
+	}
+	return nil
+}
+
+// currentPeriod retorna o início (primeiro dia do mês, UTC) e o fim do período de cobrança.
+func currentPeriod(now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
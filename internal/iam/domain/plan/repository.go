@@ -0,0 +1,227 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/infra/database/postgres"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, plan Plan) (Plan, error)
+	List(ctx context.Context) ([]Plan, error)
+	ReadByCode(ctx context.Context, code string) (Plan, error)
+	Update(ctx context.Context, plan Plan) (Plan, error)
+	Delete(ctx context.Context, code string) error
+	Assign(ctx context.Context, assignment TenantPlan) error
+	ReadAssigned(ctx context.Context, tenantUUID uuid.UUID) (Plan, error)
+	CountUsers(ctx context.Context, tenantUUID uuid.UUID) (int64, error)
+	// CountAPIKeys conta as chaves de API ativas do tenant (tokens SCIM não revogados).
+	CountAPIKeys(ctx context.Context, tenantUUID uuid.UUID) (int64, error)
+	// IncrementRequests soma uma requisição ao período, desde que não ultrapasse a quota (nil = ilimitada).
+	// Retorna false quando a quota já foi atingida.
+	IncrementRequests(ctx context.Context, tenantUUID uuid.UUID, period time.Time, quota *int64) (int64, bool, error)
+	CountRequests(ctx context.Context, tenantUUID uuid.UUID, period time.Time) (int64, error)
+	// RecordAlert registra o aviso enviado; retorna false se ele já havia sido registrado no período.
+	RecordAlert(ctx context.Context, tenantUUID uuid.UUID, metric string, period time.Time, threshold int64) (bool, error)
+	ListAdminEmails(ctx context.Context, tenantUUID uuid.UUID) ([]string, error)
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, plan Plan) (Plan, error) {
+	result := r.db.WithContext(ctx).Create(&plan)
+	if result.Error != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(result.Error, &pgErr) && pgErr.Code == "23505" {
+			return Plan{}, ErrCodeDuplicated
+		}
+		return Plan{}, result.Error
+	}
+	return plan, nil
+}
+
+func (r *repositoryImpl) List(ctx context.Context) ([]Plan, error) {
+	var plans []Plan
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&plans).Error; err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+func (r *repositoryImpl) ReadByCode(ctx context.Context, code string) (Plan, error) {
+	var plan Plan
+	result := r.db.WithContext(ctx).Where("code = ?", code).First(&plan)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return Plan{}, ErrNotFound
+		}
+		return Plan{}, fmt.Errorf("erro ao ler plano: %w", result.Error)
+	}
+	return plan, nil
+}
+
+// Update grava nome e limites do plano. Limites nil passam a ser ilimitados.
+func (r *repositoryImpl) Update(ctx context.Context, plan Plan) (Plan, error) {
+	result := r.db.WithContext(ctx).
+		Model(&Plan{}).
+		Where("code = ?", plan.Code).
+		Updates(map[string]interface{}{
+			"name":                  plan.Name,
+			"max_users":             plan.MaxUsers,
+			"max_api_keys":          plan.MaxAPIKeys,
+			"monthly_request_quota": plan.MonthlyRequestQuota,
+			"update_at":             time.Now().UTC(),
+		})
+	if result.Error != nil {
+		return Plan{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return Plan{}, ErrNotFound
+	}
+	return r.ReadByCode(ctx, plan.Code)
+}
+
+func (r *repositoryImpl) Delete(ctx context.Context, code string) error {
+	result := r.db.WithContext(ctx).Where("code = ?", code).Delete(&Plan{})
+	if result.Error != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(result.Error, &pgErr) && pgErr.Code == "23503" {
+			return ErrPlanInUse
+		}
+		return fmt.Errorf("falha ao deletar plano: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *repositoryImpl) Assign(ctx context.Context, assignment TenantPlan) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tenant_uuid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"plan_uuid", "assigned_at"}),
+	}).Create(&assignment).Error
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrInvalidInput
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *repositoryImpl) ReadAssigned(ctx context.Context, tenantUUID uuid.UUID) (Plan, error) {
+	var plan Plan
+	result := r.db.WithContext(ctx).
+		Joins("INNER JOIN tenant_plans AS tp ON tp.plan_uuid = plans.uuid").
+		Where("tp.tenant_uuid = ?", tenantUUID).
+		First(&plan)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return Plan{}, ErrNotFound
+		}
+		return Plan{}, fmt.Errorf("erro ao ler plano do tenant: %w", result.Error)
+	}
+	return plan, nil
+}
+
+func (r *repositoryImpl) CountUsers(ctx context.Context, tenantUUID uuid.UUID) (int64, error) {
+	ctx, release, err := middleware.TenantScope(ctx, &tenantUUID)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	var total int64
+	err = postgres.Conn(ctx, r.db).
+		Model(&model.User{}).
+		Where("tenant_uuid = ?", tenantUUID).
+		Count(&total).Error
+	return total, err
+}
+
+func (r *repositoryImpl) CountAPIKeys(ctx context.Context, tenantUUID uuid.UUID) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).
+		Table("scim_tokens").
+		Where("tenant_uuid = ? AND revoked_at IS NULL", tenantUUID).
+		Count(&total).Error
+	return total, err
+}
+
+const incrementRequestsQuery = `
+INSERT INTO tenant_request_usage (tenant_uuid, period, requests)
+VALUES (?, ?, 1)
+ON CONFLICT (tenant_uuid, period) DO UPDATE
+SET requests = tenant_request_usage.requests + 1
+WHERE CAST(? AS BIGINT) IS NULL OR tenant_request_usage.requests < CAST(? AS BIGINT)
+RETURNING requests`
+
+func (r *repositoryImpl) IncrementRequests(ctx context.Context, tenantUUID uuid.UUID, period time.Time, quota *int64) (int64, bool, error) {
+	var requests []int64
+	err := r.db.WithContext(ctx).
+		Raw(incrementRequestsQuery, tenantUUID, period, quota, quota).
+		Scan(&requests).Error
+	if err != nil {
+		return 0, false, fmt.Errorf("falha ao contabilizar requisição: %w", err)
+	}
+	if len(requests) == 0 {
+		return 0, false, nil
+	}
+	return requests[0], true, nil
+}
+
+func (r *repositoryImpl) CountRequests(ctx context.Context, tenantUUID uuid.UUID, period time.Time) (int64, error) {
+	var requests int64
+	err := r.db.WithContext(ctx).
+		Table("tenant_request_usage").
+		Select("COALESCE(SUM(requests), 0)").
+		Where("tenant_uuid = ? AND period = ?", tenantUUID, period).
+		Scan(&requests).Error
+	return requests, err
+}
+
+const recordAlertQuery = `
+INSERT INTO plan_usage_alerts (tenant_uuid, metric, period, threshold, sent_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT DO NOTHING`
+
+func (r *repositoryImpl) RecordAlert(ctx context.Context, tenantUUID uuid.UUID, metric string, period time.Time, threshold int64) (bool, error) {
+	result := r.db.WithContext(ctx).Exec(recordAlertQuery, tenantUUID, metric, period, threshold, time.Now().UTC())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *repositoryImpl) ListAdminEmails(ctx context.Context, tenantUUID uuid.UUID) ([]string, error) {
+	ctx, release, err := middleware.TenantScope(ctx, &tenantUUID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var emails []string
+	err = postgres.Conn(ctx, r.db).
+		Model(&model.User{}).
+		Where("tenant_uuid = ? AND role = ? AND live = ?", tenantUUID, model.RoleTenantAdmin, true).
+		Pluck("email", &emails).Error
+	return emails, err
+}
@@ -0,0 +1,293 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"tenant-crud-simply/internal/pkg/mailer"
+
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+)
+
+type Service interface {
+	Create(ctx context.Context, plan Plan) (Plan, error)
+	List(ctx context.Context) ([]Plan, error)
+	Update(ctx context.Context, plan Plan) (Plan, error)
+	Delete(ctx context.Context, code string) error
+	Assign(ctx context.Context, tenantUUID uuid.UUID, code string) (Plan, error)
+	// PlanFor retorna o plano do tenant: o contratado ou, na falta dele, o plano padrão.
+	// Sem plano padrão configurado, retorna um plano sem limites.
+	PlanFor(ctx context.Context, tenantUUID uuid.UUID) (Plan, error)
+	Usage(ctx context.Context, tenantUUID uuid.UUID) (Usage, error)
+	// CheckLimit retorna *QuotaError se current já atingiu o limite da métrica no plano do tenant.
+	CheckLimit(ctx context.Context, tenantUUID uuid.UUID, metric string, current int64) error
+	// NotifyUsage envia os avisos de 80%/100% da métrica, se ainda não enviados no período.
+	NotifyUsage(ctx context.Context, tenantUUID uuid.UUID, metric string, used int64)
+	// CountRequest contabiliza uma requisição na quota mensal do tenant.
+	CountRequest(ctx context.Context, tenantUUID uuid.UUID) error
+}
+
+type serviceImpl struct {
+	Repository Repository
+	cfg        Config
+	// plans guarda o plano efetivo por tenant; alerts os avisos já registrados no período
+	plans  *cache.Cache
+	alerts *cache.Cache
+}
+
+func NewService(repository Repository, cfg Config) Service {
+	return &serviceImpl{
+		Repository: repository,
+		cfg:        cfg,
+		plans:      cache.New(time.Minute, 5*time.Minute),
+		alerts:     cache.New(time.Hour, time.Hour),
+	}
+}
+
+func validPlan(plan Plan) bool {
+	for _, limit := range []*int64{plan.MaxUsers, plan.MaxAPIKeys, plan.MonthlyRequestQuota} {
+		if limit != nil && *limit < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *serviceImpl) Create(ctx context.Context, plan Plan) (Plan, error) {
+	plan.Code = strings.ToLower(strings.TrimSpace(plan.Code))
+	plan.Name = strings.TrimSpace(plan.Name)
+	if plan.Code == "" || plan.Name == "" || !validPlan(plan) {
+		return Plan{}, ErrInvalidInput
+	}
+	now := time.Now().UTC()
+	plan.CreateAt = now
+	plan.UpdateAt = now
+	return s.Repository.Create(ctx, plan)
+}
+
+func (s *serviceImpl) List(ctx context.Context) ([]Plan, error) {
+	return s.Repository.List(ctx)
+}
+
+func (s *serviceImpl) Update(ctx context.Context, plan Plan) (Plan, error) {
+	plan.Name = strings.TrimSpace(plan.Name)
+	if plan.Code == "" || plan.Name == "" || !validPlan(plan) {
+		return Plan{}, ErrInvalidInput
+	}
+	updated, err := s.Repository.Update(ctx, plan)
+	if err != nil {
+		return Plan{}, err
+	}
+	// Os limites mudaram para todos os tenants do plano
+	s.plans.Flush()
+	return updated, nil
+}
+
+func (s *serviceImpl) Delete(ctx context.Context, code string) error {
+	if code == s.cfg.DefaultCode {
+		return ErrPlanInUse
+	}
+	return s.Repository.Delete(ctx, code)
+}
+
+func (s *serviceImpl) Assign(ctx context.Context, tenantUUID uuid.UUID, code string) (Plan, error) {
+	if tenantUUID == uuid.Nil || code == "" {
+		return Plan{}, ErrInvalidInput
+	}
+	plan, err := s.Repository.ReadByCode(ctx, code)
+	if err != nil {
+		return Plan{}, err
+	}
+	err = s.Repository.Assign(ctx, TenantPlan{
+		TenantUUID: tenantUUID,
+		PlanUUID:   plan.UUID,
+		AssignedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return Plan{}, err
+	}
+	s.plans.Delete(tenantUUID.String())
+	return plan, nil
+}
+
+func (s *serviceImpl) PlanFor(ctx context.Context, tenantUUID uuid.UUID) (Plan, error) {
+	if cached, ok := s.plans.Get(tenantUUID.String()); ok {
+		return cached.(Plan), nil
+	}
+
+	plan, err := s.Repository.ReadAssigned(ctx, tenantUUID)
+	if errors.Is(err, ErrNotFound) {
+		plan, err = s.defaultPlan(ctx)
+	}
+	if err != nil {
+		return Plan{}, err
+	}
+	s.plans.SetDefault(tenantUUID.String(), plan)
+	return plan, nil
+}
+
+func (s *serviceImpl) defaultPlan(ctx context.Context) (Plan, error) {
+	if s.cfg.DefaultCode == "" {
+		return Plan{Name: "Ilimitado"}, nil
+	}
+	plan, err := s.Repository.ReadByCode(ctx, s.cfg.DefaultCode)
+	if errors.Is(err, ErrNotFound) {
+		log.Printf("[PLAN] Plano padrão '%s' não existe no catálogo; aplicando plano sem limites.", s.cfg.DefaultCode)
+		return Plan{Name: "Ilimitado"}, nil
+	}
+	return plan, err
+}
+
+func (s *serviceImpl) Usage(ctx context.Context, tenantUUID uuid.UUID) (Usage, error) {
+	plan, err := s.PlanFor(ctx, tenantUUID)
+	if err != nil {
+		return Usage{}, err
+	}
+	start, end := currentPeriod(time.Now())
+
+	users, err := s.Repository.CountUsers(ctx, tenantUUID)
+	if err != nil {
+		return Usage{}, err
+	}
+	apiKeys, err := s.Repository.CountAPIKeys(ctx, tenantUUID)
+	if err != nil {
+		return Usage{}, err
+	}
+	requests, err := s.Repository.CountRequests(ctx, tenantUUID, start)
+	if err != nil {
+		return Usage{}, err
+	}
+
+	return Usage{
+		Plan:        plan,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Metrics: []MetricUsage{
+			{Metric: MetricUsers, Used: users, Limit: plan.MaxUsers},
+			{Metric: MetricAPIKeys, Used: apiKeys, Limit: plan.MaxAPIKeys},
+			{Metric: MetricRequests, Used: requests, Limit: plan.MonthlyRequestQuota},
+		},
+	}, nil
+}
+
+func (s *serviceImpl) CheckLimit(ctx context.Context, tenantUUID uuid.UUID, metric string, current int64) error {
+	plan, err := s.PlanFor(ctx, tenantUUID)
+	if err != nil {
+		return err
+	}
+	return CheckQuota(plan, metric, current)
+}
+
+// CheckQuota retorna *QuotaError se current já atingiu o limite da métrica no plano. Não consulta
+// o banco: serve para verificar o limite dentro da transação que faz a contagem.
+func CheckQuota(p Plan, metric string, current int64) error {
+	limit := limitOf(p, metric)
+	if limit != nil && current >= *limit {
+		return &QuotaError{Metric: metric, Limit: *limit, Used: current}
+	}
+	return nil
+}
+
+func (s *serviceImpl) CountRequest(ctx context.Context, tenantUUID uuid.UUID) error {
+	plan, err := s.PlanFor(ctx, tenantUUID)
+	if err != nil {
+		return err
+	}
+	quota := plan.MonthlyRequestQuota
+	if quota != nil && *quota == 0 {
+		return &QuotaError{Metric: MetricRequests, Limit: 0, Used: 0}
+	}
+
+	start, _ := currentPeriod(time.Now())
+	used, allowed, err := s.Repository.IncrementRequests(ctx, tenantUUID, start, quota)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return &QuotaError{Metric: MetricRequests, Limit: *quota, Used: *quota}
+	}
+	s.NotifyUsage(ctx, tenantUUID, MetricRequests, used)
+	return nil
+}
+
+func (s *serviceImpl) NotifyUsage(ctx context.Context, tenantUUID uuid.UUID, metric string, used int64) {
+	plan, err := s.PlanFor(ctx, tenantUUID)
+	if err != nil {
+		return
+	}
+	limit := limitOf(plan, metric)
+	if limit == nil || *limit == 0 {
+		return
+	}
+
+	start, _ := currentPeriod(time.Now())
+	for _, threshold := range alertThresholds {
+		if used*100 < *limit*threshold {
+			continue
+		}
+		key := fmt.Sprintf("%s:%s:%s:%d", tenantUUID, metric, start.Format("2006-01"), threshold)
+		if _, sent := s.alerts.Get(key); sent {
+			continue
+		}
+		s.alerts.SetDefault(key, true)
+
+		ctxDetached := context.WithoutCancel(ctx)
+		go s.sendAlert(ctxDetached, tenantUUID, plan, metric, threshold, used, *limit, start)
+	}
+}
+
+// sendAlert registra o aviso e, se for o primeiro do período, envia o email aos admins do tenant.
+func (s *serviceImpl) sendAlert(ctx context.Context, tenantUUID uuid.UUID, plan Plan, metric string, threshold, used, limit int64, period time.Time) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[PLAN] Recovered ao enviar aviso de consumo: %v", r)
+		}
+	}()
+
+	first, err := s.Repository.RecordAlert(ctx, tenantUUID, metric, period, threshold)
+	if err != nil {
+		log.Printf("[PLAN] Falha ao registrar aviso de consumo do tenant %s: %v", tenantUUID, err)
+		return
+	}
+	if !first {
+		return
+	}
+
+	mailService := mailer.Use()
+	if mailService == nil {
+		log.Printf("[PLAN] Aviso de consumo (%s %d%%) do tenant %s não enviado: mailer não inicializado.", metric, threshold, tenantUUID)
+		return
+	}
+	emails, err := s.Repository.ListAdminEmails(ctx, tenantUUID)
+	if err != nil {
+		log.Printf("[PLAN] Falha ao buscar admins do tenant %s: %v", tenantUUID, err)
+		return
+	}
+
+	subject := fmt.Sprintf("Seu plano %s atingiu %d%% do limite de %s", plan.Name, threshold, metricLabel(metric))
+	tpl := `<p>O consumo de <b>{{.Metric}}</b> do seu tenant está em <b>{{.Used}} de {{.Limit}}</b> ({{.Threshold}}% do limite do plano {{.Plan}}).</p>` +
+		`<p>Ao atingir 100%, novas operações serão bloqueadas até o próximo período ou a troca de plano.</p>`
+	data := map[string]any{"Metric": metricLabel(metric), "Used": used, "Limit": limit, "Threshold": threshold, "Plan": plan.Name}
+	for _, email := range emails {
+		if err := mailService.SendTemplate(email, subject, tpl, data); err != nil {
+			log.Printf("[PLAN] Falha ao enviar aviso de consumo para %s: %v", email, err)
+		}
+	}
+}
+
+func metricLabel(metric string) string {
+	switch metric {
+	case MetricUsers:
+		return "usuários"
+	case MetricAPIKeys:
+		return "chaves de API"
+	case MetricRequests:
+		return "requisições mensais"
+	}
+	return metric
+}
@@ -0,0 +1,76 @@
+package plan
+
+import (
+	"errors"
+	"sync"
+	"tenant-crud-simply/internal/iam/middleware"
+
+	"gorm.io/gorm"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	repositoryInstance Repository
+	once               sync.Once
+	initErr            error
+	ErrNotInitialized  = errors.New("plan controller not initialized")
+)
+
+// UsePlan agrupa todas as camadas (Repository, Service, Controller)
+type UsePlan struct {
+	Repository Repository
+	Service    Service
+	Controller Controller
+}
+
+// Config usada somente no New()
+type Config struct {
+	// DefaultCode é o código do plano aplicado a tenants sem plano contratado. Vazio = sem limites.
+	DefaultCode string
+	// EnforceRequests habilita a contagem e o bloqueio pela quota mensal de requisições.
+	EnforceRequests bool
+}
+
+// New inicializa o singleton do controller de planos com todas as suas dependências
+func New(db *gorm.DB, cfg Config) (Controller, error) {
+	once.Do(func() {
+		if db == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+
+		// Inicializa as dependências em camadas
+		repositoryInstance = NewRepository(db)
+		serviceInstance = NewService(repositoryInstance, cfg)
+		controllerInstance = NewController(serviceInstance)
+
+		if cfg.EnforceRequests {
+			middleware.MustUse().Middleware.AddAfterAuthHook(requestQuotaHook(serviceInstance))
+		}
+	})
+
+	return controllerInstance, initErr
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Repository, Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UsePlan {
+	if controllerInstance == nil || serviceInstance == nil || repositoryInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UsePlan{
+		Repository: repositoryInstance,
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
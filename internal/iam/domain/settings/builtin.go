@@ -0,0 +1,89 @@
+package settings
+
+import (
+	"fmt"
+	"strings"
+	"tenant-crud-simply/internal/iam/middleware"
+	"time"
+)
+
+// Configurações padrão da plataforma. Outros pacotes podem registrar as suas via Register.
+const (
+	KeyLocale              = "locale"
+	KeyTimezone            = "timezone"
+	KeySessionLifetimeMin  = "session_lifetime_min"
+	KeyAllowedEmailDomains = "allowed_email_domains"
+	KeyAllowedIPRanges     = "allowed_ip_ranges"
+)
+
+func init() {
+	Register(Definition{
+		Key:         KeyLocale,
+		Kind:        KindString,
+		Description: "Idioma padrão do tenant (BCP 47, ex.: pt-BR).",
+		Default:     "pt-BR",
+		Validate: func(value any) error {
+			v := value.(string)
+			if len(v) < 2 || len(v) > 35 || strings.ContainsAny(v, " _") {
+				return fmt.Errorf("locale '%s' inválido", v)
+			}
+			return nil
+		},
+	})
+
+	Register(Definition{
+		Key:         KeyTimezone,
+		Kind:        KindString,
+		Description: "Fuso horário do tenant (IANA, ex.: America/Sao_Paulo).",
+		Default:     "America/Sao_Paulo",
+		Validate: func(value any) error {
+			if _, err := time.LoadLocation(value.(string)); err != nil {
+				return fmt.Errorf("fuso horário '%s' desconhecido", value)
+			}
+			return nil
+		},
+	})
+
+	Register(Definition{
+		Key:         KeySessionLifetimeMin,
+		Kind:        KindInt,
+		Description: "Duração do access token, em minutos. 0 usa o padrão do servidor (security.jwt_access_expiry_min).",
+		Default:     0,
+		Validate: func(value any) error {
+			if v := value.(int); v < 0 || v > 7*24*60 {
+				return fmt.Errorf("a duração da sessão deve estar entre 0 e %d minutos", 7*24*60)
+			}
+			return nil
+		},
+	})
+
+	Register(Definition{
+		Key:         KeyAllowedEmailDomains,
+		Kind:        KindStringList,
+		Description: "Domínios de email permitidos para usuários do tenant. Vazio permite qualquer domínio.",
+		Default:     []string{},
+		Validate: func(value any) error {
+			for _, domain := range value.([]string) {
+				if domain == "" || strings.ContainsAny(domain, "@ ") || !strings.Contains(domain, ".") {
+					return fmt.Errorf("domínio '%s' inválido", domain)
+				}
+			}
+			return nil
+		},
+	})
+
+	Register(Definition{
+		Key:         KeyAllowedIPRanges,
+		Kind:        KindStringList,
+		Description: "Faixas de IP (CIDR ou IP isolado) de onde os usuários do tenant podem acessar. Vazio permite qualquer origem. Não se aplica a SYSTEM_ADMIN.",
+		Default:     []string{},
+		Validate: func(value any) error {
+			for _, r := range value.([]string) {
+				if _, err := middleware.ParseIPRange(r); err != nil {
+					return fmt.Errorf("faixa de IP '%s' inválida", r)
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,301 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	Read(c *gin.Context)
+	Patch(c *gin.Context)
+	Schema(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+
+		if login.User.Role == model.RolePartnerAdmin {
+			if target, ok := middleware.GetTargetTenant(c); ok {
+				actingTenantUUID = &target
+			}
+		}
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "settings",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	settingsGroup := routes.Group("/settings")
+
+	{
+		settingsGroup.GET("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Read)
+		settingsGroup.PATCH("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Patch)
+		settingsGroup.GET("/schema", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Schema)
+	}
+}
+
+// resolveTenant determina o tenant cujas configurações serão lidas/alteradas.
+// SYSTEM_ADMIN precisa informar 'tenant_identifier'; PARTNER_ADMIN pode informar um tenant
+// da sua hierarquia (padrão: o próprio); TENANT_ADMIN usa sempre o próprio tenant.
+func (ctrl *controllerImpl) resolveTenant(c *gin.Context, login *middleware.Login) (uuid.UUID, *rest_err.RestErr) {
+	var req TenantScopeRequestDto
+	_ = c.ShouldBindQuery(&req)
+
+	switch login.User.Role {
+	case model.RoleSystemAdmin:
+		if req.TenantIdentifier == "" {
+			return uuid.Nil, rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "É necessário informar o 'tenant_identifier' do tenant.")
+		}
+		return ctrl.findTenant(c, login, req.TenantIdentifier)
+
+	case model.RolePartnerAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		if req.TenantIdentifier == "" {
+			return *login.User.TenantUUID, nil
+		}
+		target, restError := ctrl.findTenant(c, login, req.TenantIdentifier)
+		if restError != nil {
+			return uuid.Nil, restError
+		}
+		inSubtree, err := tenant.MustUse().Service.InSubtree(c.Request.Context(), *login.User.TenantUUID, target)
+		if err != nil {
+			return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "Falha ao verificar hierarquia de tenants", nil)
+		}
+		if !inSubtree {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Tenant fora da sua hierarquia.")
+		}
+		middleware.SetTargetTenant(c, target)
+		return target, nil
+
+	case model.RoleTenantAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		return *login.User.TenantUUID, nil
+
+	default:
+		return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+}
+
+func (ctrl *controllerImpl) findTenant(c *gin.Context, login *middleware.Login, identifier string) (uuid.UUID, *rest_err.RestErr) {
+	t := tenant.Tenant{}
+	if err := uuid.Validate(identifier); err == nil {
+		t.UUID = uuid.MustParse(identifier)
+	} else {
+		t.Document = identifier
+	}
+	found, err := tenant.MustUse().Service.Read(c.Request.Context(), t)
+	if err != nil {
+		if errors.Is(err, tenant.ErrNotFound) {
+			return uuid.Nil, rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "tenant not found")
+		}
+		return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+	return found.UUID, nil
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	switch {
+	case errors.Is(err, ErrUnknownKey), errors.Is(err, ErrInvalidValue), errors.Is(err, ErrInvalidInput), errors.Is(err, ErrSelfLockout):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, err.Error())
+	default:
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+}
+
+// checkSelfLockout recusa, no tenant do próprio usuário, uma allowed_ip_ranges que deixaria de fora
+// o IP desta requisição: o admin perderia o acesso e não conseguiria desfazer a alteração.
+func (ctrl *controllerImpl) checkSelfLockout(ctx context.Context, login *middleware.Login, tenantUUID uuid.UUID, clientIP string, req PatchSettingsRequestDto) error {
+	raw, ok := req[KeyAllowedIPRanges]
+	if !ok || login.User.Role == model.RoleSystemAdmin || login.User.TenantUUID == nil || *login.User.TenantUUID != tenantUUID {
+		return nil
+	}
+
+	var ranges []string
+	if len(raw) == 0 || string(raw) == "null" {
+		// Sem a sobrescrita, vale o valor herdado do tenant pai (ou o global)
+		parent := uuid.Nil
+		if login.User.Tenant.ParentUUID != nil {
+			parent = *login.User.Tenant.ParentUUID
+		}
+		value, err := ctrl.Service.Resolve(ctx, parent, KeyAllowedIPRanges)
+		if err != nil {
+			return err
+		}
+		ranges, _ = value.Value.([]string)
+	} else if err := json.Unmarshal(raw, &ranges); err != nil {
+		// O valor inválido é recusado pela validação do Patch
+		return nil
+	}
+
+	if len(ranges) == 0 || middleware.IPInRanges(clientIP, ranges) {
+		return nil
+	}
+	return fmt.Errorf("%w (%s)", ErrSelfLockout, clientIP)
+}
+
+// @Summary      Lista as configurações do Tenant
+// @Description  Retorna o valor efetivo de todas as configurações do tenant e a origem de cada uma: 'tenant' (sobrescrito pelo tenant), 'parent' (herdado de um tenant ancestral), 'global' (configs.json) ou 'default' (padrão da definição).
+// @Tags         Settings
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)"
+// @Success      200  {object}  SettingsResponseDto
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/settings [get]
+func (ctrl *controllerImpl) Read(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	values, err := ctrl.Service.List(c.Request.Context(), tenantUUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	c.JSON(http.StatusOK, toSettingsResponse(tenantUUID, values))
+}
+
+// @Summary      Altera as configurações do Tenant
+// @Description  Sobrescreve configurações do tenant. O corpo é um objeto chave -> valor; cada valor é validado contra a definição da configuração (veja /api/settings/schema). Informe null para remover a sobrescrita e voltar a herdar o valor. A alteração é atômica: se algum valor for inválido, nada é gravado.
+// @Tags         Settings
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)"
+// @Param        request body object true "Configurações a alterar, ex.: {\"timezone\": \"America/Manaus\", \"locale\": null}"
+// @Success      200  {object}  SettingsResponseDto
+// @Failure      400  {object}  rest_err.RestErr "Configuração desconhecida, valor inválido ou allowed_ip_ranges sem o IP da requisição no próprio tenant."
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/settings [patch]
+func (ctrl *controllerImpl) Patch(c *gin.Context) {
+	var req PatchSettingsRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil || len(req) == 0 {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	if err := ctrl.checkSelfLockout(c.Request.Context(), ctxIdentify, tenantUUID, c.ClientIP(), req); err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "update", "Patch", false, gin.H{"tenant": tenantUUID, "request": req}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	values, err := ctrl.Service.Patch(c.Request.Context(), tenantUUID, req)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "update", "Patch", false, gin.H{"tenant": tenantUUID, "request": req}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toSettingsResponse(tenantUUID, values)
+	ctrl.logAudit(c, ctxIdentify, "update", "Patch", true, gin.H{"tenant": tenantUUID, "request": req}, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Lista as definições de configuração
+// @Description  Retorna todas as configurações disponíveis, com tipo, descrição e valor padrão.
+// @Tags         Settings
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  DefinitionsResponseDto
+// @Router       /api/settings/schema [get]
+func (ctrl *controllerImpl) Schema(c *gin.Context) {
+	defs := Definitions()
+	resp := DefinitionsResponseDto{Definitions: make([]DefinitionResponseDto, 0, len(defs))}
+	for _, def := range defs {
+		resp.Definitions = append(resp.Definitions, DefinitionResponseDto{
+			Key:         def.Key,
+			Kind:        def.Kind,
+			Description: def.Description,
+			Default:     def.Default,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
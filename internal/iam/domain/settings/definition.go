@@ -0,0 +1,125 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Kind é o tipo de dado aceito por uma configuração.
+type Kind string
+
+const (
+	KindString     Kind = "string"
+	KindInt        Kind = "int"
+	KindBool       Kind = "bool"
+	KindStringList Kind = "string_list"
+)
+
+// Definition descreve uma configuração de tenant: chave, tipo, valor padrão e validação.
+// O Default e o valor recebido por Validate já estão no tipo Go do Kind
+// (string, int, bool ou []string).
+type Definition struct {
+	Key         string
+	Kind        Kind
+	Description string
+	Default     any
+	Validate    func(value any) error
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Definition{}
+)
+
+// Register adiciona uma definição ao registro. Entra em pânico se a chave já existir
+// ou se o valor padrão não respeitar o tipo e a validação da própria definição.
+func Register(def Definition) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if def.Key == "" {
+		panic("settings: definição sem chave")
+	}
+	if _, exists := registry[def.Key]; exists {
+		panic(fmt.Sprintf("settings: definição '%s' registrada duas vezes", def.Key))
+	}
+	raw, err := json.Marshal(def.Default)
+	if err != nil {
+		panic(fmt.Sprintf("settings: padrão inválido para '%s': %v", def.Key, err))
+	}
+	if _, err := def.decode(raw); err != nil {
+		panic(fmt.Sprintf("settings: padrão inválido para '%s': %v", def.Key, err))
+	}
+	registry[def.Key] = def
+}
+
+// Lookup retorna a definição registrada para a chave.
+func Lookup(key string) (Definition, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	def, ok := registry[key]
+	return def, ok
+}
+
+// Definitions retorna todas as definições registradas, ordenadas pela chave.
+func Definitions() []Definition {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	defs := make([]Definition, 0, len(registry))
+	for _, def := range registry {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Key < defs[j].Key })
+	return defs
+}
+
+// decode converte o JSON recebido para o tipo Go da definição e aplica a validação.
+func (d Definition) decode(raw json.RawMessage) (any, error) {
+	var (
+		value any
+		err   error
+	)
+
+	switch d.Kind {
+	case KindString:
+		var v string
+		err = json.Unmarshal(raw, &v)
+		value = v
+	case KindInt:
+		var v int
+		err = json.Unmarshal(raw, &v)
+		value = v
+	case KindBool:
+		var v bool
+		err = json.Unmarshal(raw, &v)
+		value = v
+	case KindStringList:
+		var v []string
+		err = json.Unmarshal(raw, &v)
+		if v == nil {
+			v = []string{}
+		}
+		value = v
+	default:
+		return nil, fmt.Errorf("tipo '%s' não suportado", d.Kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("esperado valor do tipo %s", d.Kind)
+	}
+
+	if d.Validate != nil {
+		if err := d.Validate(value); err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
+
+// Check valida um valor JSON contra a definição, sem convertê-lo (ex.: importação de tenants).
+func (d Definition) Check(raw json.RawMessage) error {
+	_, err := d.decode(raw)
+	return err
+}
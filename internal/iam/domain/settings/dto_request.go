@@ -0,0 +1,10 @@
+package settings
+
+import "encoding/json"
+
+type TenantScopeRequestDto struct {
+	TenantIdentifier string `form:"tenant_identifier"`
+}
+
+// PatchSettingsRequestDto mapeia chave -> novo valor. Use null para voltar a herdar o valor.
+type PatchSettingsRequestDto map[string]json.RawMessage
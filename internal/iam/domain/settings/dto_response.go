@@ -0,0 +1,46 @@
+package settings
+
+import (
+	"github.com/google/uuid"
+)
+
+type SettingResponseDto struct {
+	Key           string     `json:"key"`
+	Kind          Kind       `json:"kind"`
+	Value         any        `json:"value"`
+	Source        string     `json:"source"`
+	InheritedFrom *uuid.UUID `json:"inherited_from,omitempty"`
+}
+
+type SettingsResponseDto struct {
+	TenantUUID uuid.UUID            `json:"tenant_uuid"`
+	Settings   []SettingResponseDto `json:"settings"`
+}
+
+type DefinitionResponseDto struct {
+	Key         string `json:"key"`
+	Kind        Kind   `json:"kind"`
+	Description string `json:"description"`
+	Default     any    `json:"default"`
+}
+
+type DefinitionsResponseDto struct {
+	Definitions []DefinitionResponseDto `json:"definitions"`
+}
+
+func toSettingsResponse(tenantUUID uuid.UUID, values []Value) SettingsResponseDto {
+	resp := SettingsResponseDto{
+		TenantUUID: tenantUUID,
+		Settings:   make([]SettingResponseDto, 0, len(values)),
+	}
+	for _, v := range values {
+		resp.Settings = append(resp.Settings, SettingResponseDto{
+			Key:           v.Key,
+			Kind:          v.Kind,
+			Value:         v.Value,
+			Source:        v.Source,
+			InheritedFrom: v.InheritedFrom,
+		})
+	}
+	return resp
+}
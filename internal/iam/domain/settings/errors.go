@@ -0,0 +1,12 @@
+package settings
+
+import "errors"
+
+var (
+	ErrInvalidInput = errors.New("invalid input data")
+	ErrUnknownKey   = errors.New("unknown setting")
+	ErrInvalidValue = errors.New("invalid setting value")
+	ErrTypeMismatch = errors.New("setting type mismatch")
+	// ErrSelfLockout indica uma lista de IPs permitidos que bloquearia quem a está alterando.
+	ErrSelfLockout = errors.New("allowed_ip_ranges does not include the current request IP")
+)
@@ -0,0 +1,26 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Get retorna o valor efetivo da configuração já no tipo esperado.
+// O tipo T deve corresponder ao Kind da definição: string, int, bool ou []string.
+//
+//	tz, err := settings.Get[string](ctx, tenantUUID, settings.KeyTimezone)
+func Get[T any](ctx context.Context, tenantUUID uuid.UUID, key string) (T, error) {
+	var zero T
+
+	value, err := MustUse().Service.Resolve(ctx, tenantUUID, key)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := value.Value.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s é do tipo %s, solicitado %T", ErrTypeMismatch, key, value.Kind, zero)
+	}
+	return typed, nil
+}
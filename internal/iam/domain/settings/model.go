@@ -0,0 +1,26 @@
+package settings
+
+import (
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type TenantSetting = model.TenantSetting
+
+// Origem do valor efetivo de uma configuração.
+const (
+	SourceDefault = "default" // Padrão da definição
+	SourceGlobal  = "global"  // Padrão global do configs.json
+	SourceTenant  = "tenant"  // Sobrescrito pelo próprio tenant
+	SourceParent  = "parent"  // Herdado de um tenant ancestral
+)
+
+// Value é o valor efetivo de uma configuração para um tenant, já decodificado no tipo da definição.
+type Value struct {
+	Key           string
+	Kind          Kind
+	Value         any
+	Source        string
+	InheritedFrom *uuid.UUID
+}
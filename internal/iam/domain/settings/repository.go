@@ -0,0 +1,89 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// ListEffective retorna, para cada chave, o valor sobrescrito mais próximo na cadeia
+	// tenant -> pai -> avô..., junto com a distância (0 = o próprio tenant).
+	ListEffective(ctx context.Context, tenantUUID uuid.UUID) ([]InheritedSetting, error)
+	Apply(ctx context.Context, upserts []TenantSetting, removals []string, tenantUUID uuid.UUID) error
+}
+
+// InheritedSetting é um valor sobrescrito encontrado na cadeia de ancestrais do tenant.
+type InheritedSetting struct {
+	TenantUUID uuid.UUID `gorm:"column:tenant_uuid"`
+	Key        string    `gorm:"column:key"`
+	Value      string    `gorm:"column:value"`
+	Depth      int       `gorm:"column:depth"`
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+// effectiveQuery percorre a cadeia de ancestrais do tenant e mantém, por chave,
+// o valor do ancestral mais próximo. O limite de profundidade protege contra ciclos.
+const effectiveQuery = `
+WITH RECURSIVE chain AS (
+        SELECT uuid, parent_uuid, 0 AS depth FROM tenant WHERE uuid = ?
+        UNION ALL
+        SELECT t.uuid, t.parent_uuid, c.depth + 1 FROM tenant AS t
+        INNER JOIN chain AS c ON t.uuid = c.parent_uuid
+        WHERE c.depth < 32
+)
+SELECT DISTINCT ON (s.key) s.tenant_uuid, s.key, s.value::text AS value, c.depth
+FROM tenant_settings AS s
+INNER JOIN chain AS c ON c.uuid = s.tenant_uuid
+ORDER BY s.key, c.depth ASC`
+
+func (r *repositoryImpl) ListEffective(ctx context.Context, tenantUUID uuid.UUID) ([]InheritedSetting, error) {
+	var rows []InheritedSetting
+	if err := r.db.WithContext(ctx).Raw(effectiveQuery, tenantUUID).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("falha ao consultar configurações do tenant: %w", err)
+	}
+	return rows, nil
+}
+
+// Apply grava e remove as sobrescritas do tenant em uma única transação.
+func (r *repositoryImpl) Apply(ctx context.Context, upserts []TenantSetting, removals []string, tenantUUID uuid.UUID) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(upserts) > 0 {
+			err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "tenant_uuid"}, {Name: "key"}},
+				DoUpdates: clause.AssignmentColumns([]string{"value", "update_at"}),
+			}).Create(&upserts).Error
+			if err != nil {
+				return err
+			}
+		}
+		if len(removals) > 0 {
+			err := tx.Where("tenant_uuid = ? AND key IN ?", tenantUUID, removals).
+				Delete(&TenantSetting{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrInvalidInput
+		}
+		return fmt.Errorf("falha ao gravar configurações do tenant: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package settings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	// Resolve retorna o valor efetivo de uma configuração para o tenant.
+	// Ordem de precedência: tenant, ancestrais (do mais próximo ao mais distante), configs.json, padrão.
+	Resolve(ctx context.Context, tenantUUID uuid.UUID, key string) (Value, error)
+	List(ctx context.Context, tenantUUID uuid.UUID) ([]Value, error)
+	// Patch aplica sobrescritas ao tenant. Um valor JSON null remove a sobrescrita,
+	// fazendo o tenant voltar a herdar o valor.
+	Patch(ctx context.Context, tenantUUID uuid.UUID, changes map[string]json.RawMessage) ([]Value, error)
+}
+
+type serviceImpl struct {
+	Repository Repository
+	globals    map[string]any
+}
+
+func NewService(repository Repository, cfg Config) Service {
+	return &serviceImpl{
+		Repository: repository,
+		globals:    decodeGlobals(cfg.Defaults),
+	}
+}
+
+// decodeGlobals valida os padrões globais do configs.json contra as definições.
+// Entradas desconhecidas ou inválidas são ignoradas para não impedir a subida da aplicação.
+func decodeGlobals(defaults map[string]any) map[string]any {
+	globals := make(map[string]any, len(defaults))
+	for key, raw := range defaults {
+		def, ok := Lookup(key)
+		if !ok {
+			log.Printf("[SETTINGS] Padrão global '%s' ignorado: configuração desconhecida.", key)
+			continue
+		}
+		encoded, err := json.Marshal(raw)
+		if err != nil {
+			log.Printf("[SETTINGS] Padrão global '%s' ignorado: %v", key, err)
+			continue
+		}
+		value, err := def.decode(encoded)
+		if err != nil {
+			log.Printf("[SETTINGS] Padrão global '%s' ignorado: %v", key, err)
+			continue
+		}
+		globals[key] = value
+	}
+	return globals
+}
+
+func (s *serviceImpl) Resolve(ctx context.Context, tenantUUID uuid.UUID, key string) (Value, error) {
+	if _, ok := Lookup(key); !ok {
+		return Value{}, fmt.Errorf("%w: %s", ErrUnknownKey, key)
+	}
+	values, err := s.List(ctx, tenantUUID)
+	if err != nil {
+		return Value{}, err
+	}
+	for _, v := range values {
+		if v.Key == key {
+			return v, nil
+		}
+	}
+	return Value{}, fmt.Errorf("%w: %s", ErrUnknownKey, key)
+}
+
+func (s *serviceImpl) List(ctx context.Context, tenantUUID uuid.UUID) ([]Value, error) {
+	overrides := map[string]InheritedSetting{}
+	if tenantUUID != uuid.Nil {
+		rows, err := s.Repository.ListEffective(ctx, tenantUUID)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			overrides[row.Key] = row
+		}
+	}
+
+	defs := Definitions()
+	values := make([]Value, 0, len(defs))
+	for _, def := range defs {
+		value := Value{Key: def.Key, Kind: def.Kind, Value: def.Default, Source: SourceDefault}
+		if global, ok := s.globals[def.Key]; ok {
+			value.Value = global
+			value.Source = SourceGlobal
+		}
+
+		if row, ok := overrides[def.Key]; ok {
+			// Valores gravados antes de uma mudança na definição podem ter ficado inválidos;
+			// nesse caso o tenant volta a usar o valor herdado.
+			decoded, err := def.decode(json.RawMessage(row.Value))
+			if err != nil {
+				log.Printf("[SETTINGS] Valor de '%s' no tenant %s ignorado: %v", def.Key, row.TenantUUID, err)
+			} else {
+				value.Value = decoded
+				value.Source = SourceTenant
+				if row.Depth > 0 {
+					from := row.TenantUUID
+					value.Source = SourceParent
+					value.InheritedFrom = &from
+				}
+			}
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (s *serviceImpl) Patch(ctx context.Context, tenantUUID uuid.UUID, changes map[string]json.RawMessage) ([]Value, error) {
+	if tenantUUID == uuid.Nil || len(changes) == 0 {
+		return nil, ErrInvalidInput
+	}
+
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	now := time.Now().UTC()
+	var (
+		upserts  []TenantSetting
+		removals []string
+	)
+	for _, key := range keys {
+		def, ok := Lookup(key)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownKey, key)
+		}
+
+		raw := changes[key]
+		if len(raw) == 0 || string(raw) == "null" {
+			removals = append(removals, key)
+			continue
+		}
+
+		value, err := def.decode(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidValue, key, err)
+		}
+		// Grava a forma canônica do valor, e não o JSON recebido.
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		upserts = append(upserts, TenantSetting{
+			TenantUUID: tenantUUID,
+			Key:        key,
+			Value:      string(encoded),
+			UpdateAt:   now,
+		})
+	}
+
+	if err := s.Repository.Apply(ctx, upserts, removals, tenantUUID); err != nil {
+		return nil, err
+	}
+	return s.List(ctx, tenantUUID)
+}
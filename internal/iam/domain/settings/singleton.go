@@ -0,0 +1,78 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"tenant-crud-simply/internal/iam/middleware"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	repositoryInstance Repository
+	once               sync.Once
+	initErr            error
+	ErrNotInitialized  = errors.New("settings controller not initialized")
+)
+
+// UseSettings agrupa todas as camadas (Repository, Service, Controller)
+type UseSettings struct {
+	Repository Repository
+	Service    Service
+	Controller Controller
+}
+
+// Config usada somente no New()
+type Config struct {
+	// Defaults são os padrões globais (configs.json: settings.defaults), que sobrescrevem
+	// o padrão da definição e podem ser sobrescritos por cada tenant.
+	Defaults map[string]any
+}
+
+// New inicializa o singleton do controller de configurações com todas as suas dependências
+func New(db *gorm.DB, cfg Config) (Controller, error) {
+	once.Do(func() {
+		if db == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+
+		// Inicializa as dependências em camadas
+		repositoryInstance = NewRepository(db)
+		serviceInstance = NewService(repositoryInstance, cfg)
+		controllerInstance = NewController(serviceInstance)
+
+		// A lista de IPs permitidos é aplicada pelo middleware (autenticação e login)
+		middleware.SetIPAllowlistResolver(func(ctx context.Context, tenantUUID uuid.UUID) ([]string, error) {
+			return Get[[]string](ctx, tenantUUID, KeyAllowedIPRanges)
+		})
+	})
+
+	return controllerInstance, initErr
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Repository, Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UseSettings {
+	if controllerInstance == nil || serviceInstance == nil || repositoryInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UseSettings{
+		Repository: repositoryInstance,
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
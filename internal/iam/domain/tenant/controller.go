@@ -1,9 +1,12 @@
 package tenant
 
 import (
+	"errors"
 	"net/http"
 	"tenant-crud-simply/internal/iam/domain/model"
 	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/document"
+	"tenant-crud-simply/internal/pkg/listing"
 	"tenant-crud-simply/internal/pkg/log/auditoria_log"
 	"tenant-crud-simply/internal/pkg/rest_err"
 	"time"
@@ -20,6 +23,13 @@ type Controller interface {
 	List(c *gin.Context)
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
+	Restore(c *gin.Context)
+	Subtree(c *gin.Context)
+	Activate(c *gin.Context)
+	Suspend(c *gin.Context)
+	MarkPastDue(c *gin.Context)
+	Cancel(c *gin.Context)
+	StatusHistory(c *gin.Context)
 }
 
 // controllerImpl implementa o Controller
@@ -40,10 +50,11 @@ func NewController(service Service) Controller {
 
 func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
 	var (
-		tenantUUID *uuid.UUID
-		userUUID   *uuid.UUID
-		identifier string
-		rayTrace   string
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
 	)
 
 	if login != nil {
@@ -53,19 +64,28 @@ func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, ac
 		}
 		identifier = login.User.Email
 		rayTrace = login.Metadata.RayTraceCode
+
+		// PARTNER_ADMIN atuando em um tenant descendente: o log pertence ao tenant alvo
+		// e registra o ancestral que executou a ação.
+		if target, ok := middleware.GetTargetTenant(c); ok && login.User.Role == model.RolePartnerAdmin &&
+			(tenantUUID == nil || *tenantUUID != target) {
+			actingTenantUUID = tenantUUID
+			tenantUUID = &target
+		}
 	}
 
 	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
-		TenantUUID:   tenantUUID,
-		UserUUID:     userUUID,
-		Identifier:   identifier,
-		RayTraceCode: rayTrace,
-		Domain:       "tenant",
-		Action:       action,
-		Function:     function,
-		Success:      success,
-		InputData:    auditoria_log.SerializeData(input),
-		OutputData:   auditoria_log.SerializeData(output),
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "tenant",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
 	})
 }
 
@@ -75,14 +95,39 @@ func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
 
 	{
 		// Rota protegida com autenticação e autorização de role
-		tenantGroup.POST("/create", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Create)
-		tenantGroup.GET("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.Read)
-		tenantGroup.GET("/list", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.List)
-		tenantGroup.PATCH("/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.Update)
-		tenantGroup.DELETE("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Delete)
+		tenantGroup.POST("/create", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin), ctrl.Create)
+		tenantGroup.GET("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Read)
+		tenantGroup.GET("/list", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin), ctrl.List)
+		tenantGroup.GET("/:uuid/subtree", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin), ctrl.Subtree)
+		tenantGroup.PATCH("/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Update)
+		tenantGroup.DELETE("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin), ctrl.Delete)
+		tenantGroup.POST("/:uuid/restore", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin), ctrl.Restore)
+
+		// Ciclo de vida
+		tenantGroup.POST("/:uuid/activate", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin), ctrl.Activate)
+		tenantGroup.POST("/:uuid/suspend", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin), ctrl.Suspend)
+		tenantGroup.POST("/:uuid/past-due", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin), ctrl.MarkPastDue)
+		tenantGroup.POST("/:uuid/cancel", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin), ctrl.Cancel)
+		tenantGroup.GET("/:uuid/status/history", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.StatusHistory)
 	}
 }
 
+// checkPartnerScope garante que o tenant alvo é o próprio tenant do PARTNER_ADMIN ou um de seus descendentes.
+func (ctrl *controllerImpl) checkPartnerScope(c *gin.Context, login *middleware.Login, target uuid.UUID) *rest_err.RestErr {
+	if login.User.TenantUUID == nil {
+		return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+	}
+	inSubtree, err := ctrl.service.InSubtree(c.Request.Context(), *login.User.TenantUUID, target)
+	if err != nil {
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "Falha ao verificar hierarquia de tenants", nil)
+	}
+	if !inSubtree {
+		return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Tenant fora da sua hierarquia.")
+	}
+	middleware.SetTargetTenant(c, target)
+	return nil
+}
+
 // Create cria um novo tenant
 // @Summary Cria um novo tenant
 // @Description Cria um novo tenant no sistema
@@ -93,6 +138,8 @@ func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
 // @Param request body CreateTenantRequestDto true "Dados do tenant"
 // @Success 201 {object} TenantResponseDto
 // @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} rest_err.RestErr "Isolamento por schema ou banco solicitado por quem não é SYSTEM_ADMIN"
+// @Failure 422 {object} map[string]interface{} "Modo de isolamento desabilitado ou banco dedicado inacessível"
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/tenant/create [post]
 func (ctrl *controllerImpl) Create(c *gin.Context) {
@@ -111,12 +158,30 @@ func (ctrl *controllerImpl) Create(c *gin.Context) {
 	}
 
 	// Cria o modelo Tenant
+	documentType, err := document.ParseKind(req.DocumentType)
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O 'document_type' deve ser cpf, cnpj ou foreign.")
+		c.JSON(restError.Code, restError)
+		return
+	}
 	tenant := model.Tenant{
-		Name:     req.Name,
-		Document: req.Document,
-		Live:     true,
-		CreateAt: time.Now().UTC(),
-		UpdateAt: time.Now().UTC(),
+		Name:         req.Name,
+		Document:     req.Document,
+		DocumentType: documentType,
+		Metadata:     model.Metadata(req.Metadata),
+		Isolation:    model.TenantIsolation(req.Isolation),
+		CreateAt:     time.Now().UTC(),
+		UpdateAt:     time.Now().UTC(),
+	}
+
+	if req.ParentUUID != "" {
+		parentUUID, err := uuid.Parse(req.ParentUUID)
+		if err != nil {
+			restError := rest_err.NewBadRequestError(nil, "O 'parent_uuid' fornecido não é um formato válido.")
+			c.JSON(restError.Code, restError)
+			return
+		}
+		tenant.ParentUUID = &parentUUID
 	}
 
 	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
@@ -125,8 +190,46 @@ func (ctrl *controllerImpl) Create(c *gin.Context) {
 		c.AbortWithStatusJSON(e.Code, e)
 		return
 	}
+
+	// O isolamento por schema ou banco é uma decisão de infraestrutura, restrita ao SYSTEM_ADMIN
+	dedicated := tenant.Isolation == model.TenantIsolationSchema || tenant.Isolation == model.TenantIsolationDatabase
+	if dedicated && ctxIdentify.User.Role != model.RoleSystemAdmin {
+		restError := rest_err.NewForbiddenError(nil, "Apenas SYSTEM_ADMIN pode criar tenants com isolamento por schema ou banco de dados.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	if tenant.Isolation == model.TenantIsolationDatabase && req.Database == nil {
+		restError := rest_err.NewBadRequestError(nil, "Informe em 'database' os dados de conexão do banco dedicado.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	// PARTNER_ADMIN cria tenants apenas dentro da sua subárvore (por padrão, como filho direto)
+	if ctxIdentify.User.Role == model.RolePartnerAdmin {
+		if tenant.ParentUUID == nil {
+			tenant.ParentUUID = ctxIdentify.User.TenantUUID
+		}
+		if tenant.ParentUUID == nil {
+			e := rest_err.NewForbiddenError(nil, "Usuário não associado a um tenant.")
+			c.AbortWithStatusJSON(e.Code, e)
+			return
+		}
+		if restError := ctrl.checkPartnerScope(c, ctxIdentify, *tenant.ParentUUID); restError != nil {
+			c.JSON(restError.Code, restError)
+			return
+		}
+	}
+
 	// Chama o serviço para criar
-	created, err := ctrl.service.Create(c.Request.Context(), tenant)
+	var created model.Tenant
+	if tenant.Isolation == model.TenantIsolationDatabase {
+		conn := req.Database.toConnection()
+		// A senha do banco não vai para a auditoria
+		req.Database.Password = ""
+		created, err = ctrl.service.CreateWithDatabase(c.Request.Context(), tenant, conn)
+	} else {
+		created, err = ctrl.service.Create(c.Request.Context(), tenant)
+	}
 	if err != nil {
 		switch err {
 		case ErrDocumentDuplicated:
@@ -134,7 +237,54 @@ func (ctrl *controllerImpl) Create(c *gin.Context) {
 				"error": "document already exists",
 			}
 			c.JSON(http.StatusConflict, response)
+		case ErrParentNotFound:
+			response := gin.H{
+				"error": "parent tenant not found",
+			}
+			c.JSON(http.StatusNotFound, response)
+		case ErrInvalidDocument:
+			response := gin.H{
+				"error":   "invalid document",
+				"details": "Documento inválido: informe um CPF ou CNPJ com dígitos verificadores válidos, ou document_type 'foreign' para identificadores estrangeiros.",
+			}
+			c.JSON(http.StatusBadRequest, response)
+		case ErrInvalidInput:
+			response := gin.H{
+				"error":   "invalid input",
+				"details": "O 'isolation' deve ser shared, schema ou database.",
+			}
+			c.JSON(http.StatusBadRequest, response)
+		case ErrIsolationDisabled:
+			response := gin.H{
+				"error":   "isolation disabled",
+				"details": "O modo de isolamento solicitado não está habilitado (tenant.isolation.schema_enabled ou tenant.isolation.database_enabled).",
+			}
+			c.JSON(http.StatusUnprocessableEntity, response)
 		default:
+			if errors.Is(err, ErrInvalidConnection) {
+				response := gin.H{
+					"error":   "invalid database connection",
+					"details": err.Error(),
+				}
+				c.JSON(http.StatusBadRequest, response)
+				break
+			}
+			if errors.Is(err, ErrDatabaseUnavailable) {
+				response := gin.H{
+					"error":   "tenant database unavailable",
+					"details": err.Error(),
+				}
+				c.JSON(http.StatusUnprocessableEntity, response)
+				break
+			}
+			if errors.Is(err, ErrInvalidMetadata) {
+				response := gin.H{
+					"error":   "invalid metadata",
+					"details": err.Error(),
+				}
+				c.JSON(http.StatusBadRequest, response)
+				break
+			}
 			response := gin.H{
 				"error":   "failed to create tenant",
 				"details": err.Error(),
@@ -145,13 +295,23 @@ func (ctrl *controllerImpl) Create(c *gin.Context) {
 		return
 	}
 
+	if ctxIdentify.User.Role == model.RolePartnerAdmin {
+		middleware.SetTargetTenant(c, created.UUID)
+	}
+
 	resp := &TenantResponseDto{
-		UUID:     created.UUID,
-		Name:     created.Name,
-		Document: created.Document,
-		Live:     created.Live,
-		CreateAt: created.CreateAt,
-		UpdateAt: created.UpdateAt,
+		UUID:              created.UUID,
+		ParentUUID:        created.ParentUUID,
+		Name:              created.Name,
+		Document:          created.Document,
+		DocumentType:      string(created.DocumentType),
+		DocumentFormatted: document.Format(created.DocumentType, created.Document),
+		Status:            string(created.Status),
+		TrialEndsAt:       created.TrialEndsAt,
+		Metadata:          created.Metadata,
+		Isolation:         string(created.Isolation),
+		CreateAt:          created.CreateAt,
+		UpdateAt:          created.UpdateAt,
 	}
 	c.JSON(http.StatusCreated, resp)
 	ctrl.logAudit(c, ctxIdentify, "create", "Create", true, req, resp)
@@ -213,8 +373,8 @@ func (ctrl *controllerImpl) Read(c *gin.Context) {
 
 	// analisar se o usuario tem permissao de ler outras empresas ou apenas a propria
 	switch ctxIdentify.User.Role {
-	case model.RoleSystemAdmin:
-		//
+	case model.RoleSystemAdmin, model.RolePartnerAdmin:
+		// PARTNER_ADMIN tem o escopo validado após a busca
 
 	case model.RoleTenantAdmin:
 		rTenant = model.Tenant{
@@ -248,77 +408,127 @@ func (ctrl *controllerImpl) Read(c *gin.Context) {
 		return
 	}
 
+	if ctxIdentify.User.Role == model.RolePartnerAdmin {
+		if restError := ctrl.checkPartnerScope(c, ctxIdentify, rTenant.UUID); restError != nil {
+			c.JSON(restError.Code, restError)
+			return
+		}
+	}
+
 	resp := &TenantResponseDto{
-		UUID:     rTenant.UUID,
-		Name:     rTenant.Name,
-		Document: rTenant.Document,
-		Live:     rTenant.Live,
-		CreateAt: rTenant.CreateAt,
-		UpdateAt: rTenant.UpdateAt,
+		UUID:              rTenant.UUID,
+		ParentUUID:        rTenant.ParentUUID,
+		Name:              rTenant.Name,
+		Document:          rTenant.Document,
+		DocumentType:      string(rTenant.DocumentType),
+		DocumentFormatted: document.Format(rTenant.DocumentType, rTenant.Document),
+		Status:            string(rTenant.Status),
+		TrialEndsAt:       rTenant.TrialEndsAt,
+		Metadata:          rTenant.Metadata,
+		Isolation:         string(rTenant.Isolation),
+		CreateAt:          rTenant.CreateAt,
+		UpdateAt:          rTenant.UpdateAt,
 	}
 	c.JSON(http.StatusOK, resp)
 	//ctrl.logAudit(c, ctxIdentify, "read", "Read", true, req, resp)
 }
 
 // @Summary      Lista Tenants
-// @Description  Retorna uma lista paginada de todos os tenants registrados no sistema.
+// @Description  Retorna os tenants com filtros, ordenação e paginação por cursor. SYSTEM_ADMIN vê todos os tenants; PARTNER_ADMIN, apenas a própria hierarquia. A resposta traz o total de registros e o cursor da próxima página, também informado no cabeçalho Link (rel="next").
 // @Tags         Tenant
 // @Produce      json
 // @Security     BearerAuth
 //
-// @Param        page query int true "O número da página a ser retornada (deve ser >= 1)." default(1)
-// @Param        pageSize query int true "O número de itens por página (máximo 100)." default(10)
+// @Param        name query string false "Filtra pelo nome (contém, sem diferenciar maiúsculas)."
+// @Param        status query string false "Filtra pelo status (trial, active, past_due, suspended, cancelled)."
+// @Param        created_from query string false "Criados a partir de (2006-01-02 ou RFC 3339, inclusivo)."
+// @Param        created_to query string false "Criados até (2006-01-02 inclui o dia inteiro)."
+// @Param        sort query string false "Campo de ordenação: name, document, create_at ou update_at. Prefixo '-' para ordem decrescente." default(name)
+// @Param        size query int false "O número de itens por página (máximo 100)." default(10)
+// @Param        cursor query string false "Cursor da próxima página (nextCursor da resposta anterior)."
+// @Param        metadata[chave] query string false "Filtra por campo personalizado (valor exato), ex.: metadata[contract_number]=123."
 //
-// @Success      200  {object}  TenantsResponseDto  "Lista de tenants retornada com sucesso."
-// @Failure      400  {object}  rest_err.RestErr    "Requisição inválida (parâmetros de paginação ausentes ou inválidos, ou pageSize > 100)."
+// @Success      200  {object}  TenantPageResponseDto  "Lista de tenants retornada com sucesso."
+// @Header       200  {string}  Link  "Links da primeira e da próxima página (RFC 8288)."
+// @Failure      400  {object}  rest_err.RestErr    "Filtro, ordenação, cursor ou tamanho de página inválidos."
 // @Failure      500  {object}  rest_err.RestErr    "Erro interno do servidor."
 //
 // @Router       /api/tenant/list [get]
 func (ctrl *controllerImpl) List(c *gin.Context) {
-	var req ListTenantRequestDto
+	var req ListTenantsRequestDto
 	if err := c.ShouldBindQuery(&req); err != nil {
-		restError := rest_err.NewBadRequestError(nil, "Parâmetros de busca inválidos. Verifique 'page' e 'pageSize'.")
+		restError := rest_err.NewBadRequestError(nil, "Parâmetros de busca inválidos.")
 		c.JSON(restError.Code, restError)
 		return
 	}
+	req.Metadata = c.QueryMap("metadata")
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
 
-	if req.PageSize > 100 {
-		restError := rest_err.NewBadRequestError(nil, "É permitido um máximo de 100 listagens por página.")
+	opts, err := req.options()
+	if err != nil {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
 		c.JSON(restError.Code, restError)
 		return
 	}
-	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
-	if !ok {
-		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+
+	var rootUUID *uuid.UUID
+	switch ctxIdentify.User.Role {
+	case model.RoleSystemAdmin:
+	case model.RolePartnerAdmin:
+		if ctxIdentify.User.TenantUUID == nil {
+			e := rest_err.NewForbiddenError(nil, "Usuário não associado a um tenant.")
+			c.AbortWithStatusJSON(e.Code, e)
+			return
+		}
+		rootUUID = ctxIdentify.User.TenantUUID
+	default:
+		e := rest_err.NewForbiddenError(nil, "Ação não permitida.")
 		c.AbortWithStatusJSON(e.Code, e)
 		return
 	}
 
-	lTenants, err := ctrl.service.List(c.Request.Context(), req.Page, req.PageSize)
+	page, err := ctrl.service.List(c.Request.Context(), rootUUID, opts)
 	if err != nil {
 		var restError *rest_err.RestErr
-		restError = rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao buscar tenants", nil)
+		if errors.Is(err, listing.ErrInvalidOptions) {
+			restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
+		} else {
+			restError = rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao buscar tenants", nil)
+		}
 		ctrl.logAudit(c, ctxIdentify, "list", "List", false, req, err.Error())
 		c.JSON(restError.Code, restError)
 		return
 	}
 
-	tenantResponses := make([]TenantResponseDto, len(lTenants))
-	for i, t := range lTenants {
+	tenantResponses := make([]TenantResponseDto, len(page.Items))
+	for i, t := range page.Items {
 		tenantResponses[i] = TenantResponseDto{
-			UUID:     t.UUID,
-			Name:     t.Name,
-			Document: t.Document,
-			Live:     t.Live,
-			CreateAt: t.CreateAt,
-			UpdateAt: t.UpdateAt,
+			UUID:              t.UUID,
+			ParentUUID:        t.ParentUUID,
+			Name:              t.Name,
+			Document:          t.Document,
+			DocumentType:      string(t.DocumentType),
+			DocumentFormatted: document.Format(t.DocumentType, t.Document),
+			Status:            string(t.Status),
+			TrialEndsAt:       t.TrialEndsAt,
+			Metadata:          t.Metadata,
+			Isolation:         string(t.Isolation),
+			CreateAt:          t.CreateAt,
+			UpdateAt:          t.UpdateAt,
 		}
 	}
-	resp := &TenantsResponseDto{
-		Tenants: tenantResponses,
-		Page:    req.Page,
-		Size:    req.PageSize,
+	resp := &TenantPageResponseDto{
+		Tenants:    tenantResponses,
+		Total:      page.Total,
+		Size:       page.Size,
+		NextCursor: page.NextCursor,
 	}
+	c.Header("Link", listing.LinkHeader(c.Request.URL, page.NextCursor))
 	c.JSON(http.StatusOK, resp)
 	//ctrl.logAudit(c, ctxIdentify, "list", "List", true, req, resp)
 }
@@ -356,12 +566,19 @@ func (ctrl *controllerImpl) Update(c *gin.Context) {
 		return
 	}
 
+	documentType, err := document.ParseKind(request.DocumentType)
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O 'document_type' deve ser cpf, cnpj ou foreign.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
 	uTenant := model.Tenant{
-		UUID:     tenantUUID,
-		Document: request.Document,
-		Live:     *request.Live,
-		Name:     request.Name,
-		UpdateAt: time.Now().UTC(),
+		UUID:         tenantUUID,
+		Document:     request.Document,
+		DocumentType: documentType,
+		Name:         request.Name,
+		UpdateAt:     time.Now().UTC(),
 	}
 
 	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
@@ -371,15 +588,59 @@ func (ctrl *controllerImpl) Update(c *gin.Context) {
 		return
 	}
 
+	var newParent *uuid.UUID
+	changeParent := false
+
 	// analisar se o usuario tem permissao de ler outras empresas ou apenas a propria
 	switch ctxIdentify.User.Role {
 	case model.RoleSystemAdmin:
-		//
+		if request.ParentUUID != nil {
+			changeParent = true
+			if *request.ParentUUID != "" {
+				parsed, err := uuid.Parse(*request.ParentUUID)
+				if err != nil {
+					restError := rest_err.NewBadRequestError(nil, "O 'parent_uuid' fornecido não é um formato válido.")
+					c.JSON(restError.Code, restError)
+					return
+				}
+				newParent = &parsed
+			}
+		}
+	case model.RolePartnerAdmin:
+		if restError := ctrl.checkPartnerScope(c, ctxIdentify, tenantUUID); restError != nil {
+			c.JSON(restError.Code, restError)
+			return
+		}
+		if tenantUUID == *ctxIdentify.User.TenantUUID {
+			// No próprio tenant o parceiro tem os mesmos poderes de um TENANT_ADMIN
+			uTenant = model.Tenant{
+				UUID:     ctxIdentify.User.Tenant.UUID,
+				Document: ctxIdentify.User.Tenant.Document,
+				Name:     request.Name,
+				UpdateAt: time.Now().UTC(),
+			}
+			break
+		}
+		if request.ParentUUID != nil {
+			parsed, err := uuid.Parse(*request.ParentUUID)
+			if err != nil {
+				restError := rest_err.NewBadRequestError(nil, "O 'parent_uuid' fornecido não é um formato válido.")
+				c.JSON(restError.Code, restError)
+				return
+			}
+			// O novo pai também precisa estar dentro da hierarquia do parceiro
+			if restError := ctrl.checkPartnerScope(c, ctxIdentify, parsed); restError != nil {
+				c.JSON(restError.Code, restError)
+				return
+			}
+			middleware.SetTargetTenant(c, tenantUUID)
+			newParent = &parsed
+			changeParent = true
+		}
 	case model.RoleTenantAdmin:
 		uTenant = model.Tenant{
 			UUID:     ctxIdentify.User.Tenant.UUID,
 			Document: ctxIdentify.User.Tenant.Document,
-			Live:     ctxIdentify.User.Tenant.Live,
 			Name:     request.Name,
 			UpdateAt: time.Now().UTC(),
 		}
@@ -390,7 +651,11 @@ func (ctrl *controllerImpl) Update(c *gin.Context) {
 		return
 	}
 
+	uTenant.Metadata = model.Metadata(request.Metadata)
 	tenantUpdated, err := ctrl.service.Update(c.Request.Context(), &uTenant)
+	if err == nil && changeParent {
+		tenantUpdated, err = ctrl.service.SetParent(c.Request.Context(), tenantUpdated.UUID, newParent)
+	}
 
 	if err != nil {
 		var restError *rest_err.RestErr
@@ -398,11 +663,21 @@ func (ctrl *controllerImpl) Update(c *gin.Context) {
 		switch err {
 		case ErrNotFound:
 			restError = rest_err.NewNotFoundError(&ctxIdentify.Metadata.RayTraceCode, ErrNotFound.Error())
+		case ErrParentNotFound:
+			restError = rest_err.NewNotFoundError(&ctxIdentify.Metadata.RayTraceCode, ErrParentNotFound.Error())
+		case ErrHierarchyCycle:
+			restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "O novo tenant pai não pode ser o próprio tenant nem um de seus descendentes.")
 		case ErrDocumentDuplicated:
 			restError = rest_err.NewConflictValidationError(&ctxIdentify.Metadata.RayTraceCode, "O novo documento fornecido já está em uso por outro ", nil)
 		case ErrInvalidInput:
 			restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, ErrInvalidInput.Error())
+		case ErrInvalidDocument:
+			restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "Documento inválido: informe um CPF ou CNPJ com dígitos verificadores válidos, ou document_type 'foreign' para identificadores estrangeiros.")
 		default:
+			if errors.Is(err, ErrInvalidMetadata) {
+				restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
+				break
+			}
 			restError = rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao atualizar tenant", nil)
 		}
 
@@ -412,19 +687,25 @@ func (ctrl *controllerImpl) Update(c *gin.Context) {
 	}
 
 	resp := &TenantResponseDto{
-		UUID:     tenantUpdated.UUID,
-		Name:     tenantUpdated.Name,
-		Document: tenantUpdated.Document,
-		Live:     tenantUpdated.Live,
-		CreateAt: tenantUpdated.CreateAt,
-		UpdateAt: tenantUpdated.UpdateAt,
+		UUID:              tenantUpdated.UUID,
+		ParentUUID:        tenantUpdated.ParentUUID,
+		Name:              tenantUpdated.Name,
+		Document:          tenantUpdated.Document,
+		DocumentType:      string(tenantUpdated.DocumentType),
+		DocumentFormatted: document.Format(tenantUpdated.DocumentType, tenantUpdated.Document),
+		Status:            string(tenantUpdated.Status),
+		TrialEndsAt:       tenantUpdated.TrialEndsAt,
+		Metadata:          tenantUpdated.Metadata,
+		Isolation:         string(tenantUpdated.Isolation),
+		CreateAt:          tenantUpdated.CreateAt,
+		UpdateAt:          tenantUpdated.UpdateAt,
 	}
 	c.JSON(http.StatusOK, resp)
 	ctrl.logAudit(c, ctxIdentify, "update", "Update", true, request, resp)
 }
 
 // @Summary      Deleta um model.Tenant
-// @Description  Exclui permanentemente um tenant no sistema usando o UUID ou o Documento (CNPJ/CPF). Pelo menos um dos dois campos deve ser fornecido.
+// @Description  Exclui logicamente um tenant usando o UUID ou o Documento (CNPJ/CPF). Pelo menos um dos dois campos deve ser fornecido. O tenant deixa de ser listado, seus usuários não conseguem mais autenticar e todas as sessões ativas são revogadas. Pode ser restaurado por um SYSTEM_ADMIN dentro do período de carência; depois disso é expurgado definitivamente.
 // @Tags         Tenant
 // @Produce      json
 // @Security     BearerAuth
@@ -469,6 +750,30 @@ func (ctrl *controllerImpl) Delete(c *gin.Context) {
 		return
 	}
 
+	if ctxIdentify.User.Role == model.RolePartnerAdmin {
+		// PARTNER_ADMIN só exclui descendentes, nunca o próprio tenant
+		target, err := ctrl.service.Read(c.Request.Context(), model.Tenant{UUID: tenantUUID, Document: req.Document})
+		if err != nil {
+			var restError *rest_err.RestErr
+			if err == ErrNotFound {
+				restError = rest_err.NewNotFoundError(&ctxIdentify.Metadata.RayTraceCode, ErrNotFound.Error())
+			} else {
+				restError = rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao excluir tenant", nil)
+			}
+			c.JSON(restError.Code, restError)
+			return
+		}
+		if ctxIdentify.User.TenantUUID != nil && target.UUID == *ctxIdentify.User.TenantUUID {
+			e := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Não é permitido excluir o próprio tenant.")
+			c.AbortWithStatusJSON(e.Code, e)
+			return
+		}
+		if restError := ctrl.checkPartnerScope(c, ctxIdentify, target.UUID); restError != nil {
+			c.JSON(restError.Code, restError)
+			return
+		}
+	}
+
 	err := ctrl.service.Delete(c.Request.Context(), model.Tenant{
 		UUID:     tenantUUID,
 		Document: req.Document,
@@ -492,3 +797,416 @@ func (ctrl *controllerImpl) Delete(c *gin.Context) {
 	ctrl.logAudit(c, ctxIdentify, "delete", "Delete", true, req, gin.H{"status": "deleted"})
 	c.Status(http.StatusNoContent)
 }
+
+// @Summary      Lista a subárvore de um Tenant
+// @Description  Retorna todos os tenants descendentes (filhos, netos...) do tenant informado, com os mesmos filtros, ordenação e paginação por cursor de /api/tenant/list. PARTNER_ADMIN só pode consultar tenants da própria hierarquia. O cursor da próxima página também é informado no cabeçalho Link (rel="next").
+// @Tags         Tenant
+// @Produce      json
+// @Security     BearerAuth
+//
+// @Param        uuid path string true "UUID do tenant raiz da subárvore."
+// @Param        name query string false "Filtra pelo nome (contém, sem diferenciar maiúsculas)."
+// @Param        status query string false "Filtra pelo status (trial, active, past_due, suspended, cancelled)."
+// @Param        created_from query string false "Criados a partir de (2006-01-02 ou RFC 3339, inclusivo)."
+// @Param        created_to query string false "Criados até (2006-01-02 inclui o dia inteiro)."
+// @Param        sort query string false "Campo de ordenação: name, document, create_at ou update_at. Prefixo '-' para ordem decrescente." default(name)
+// @Param        size query int false "O número de itens por página (máximo 100)." default(10)
+// @Param        cursor query string false "Cursor da próxima página (nextCursor da resposta anterior)."
+// @Param        metadata[chave] query string false "Filtra por campo personalizado (valor exato), ex.: metadata[contract_number]=123."
+//
+// @Success      200  {object}  TenantPageResponseDto  "Descendentes retornados com sucesso."
+// @Header       200  {string}  Link  "Links da primeira e da próxima página (RFC 8288)."
+// @Failure      400  {object}  rest_err.RestErr    "UUID, filtro, ordenação, cursor ou tamanho de página inválidos."
+// @Failure      403  {object}  rest_err.RestErr    "Tenant fora da hierarquia do usuário."
+// @Failure      500  {object}  rest_err.RestErr    "Erro interno do servidor."
+//
+// @Router       /api/tenant/{uuid}/subtree [get]
+func (ctrl *controllerImpl) Subtree(c *gin.Context) {
+	rootUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido na URL não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	var req ListTenantsRequestDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "Parâmetros de busca inválidos.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	req.Metadata = c.QueryMap("metadata")
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	opts, err := req.options()
+	if err != nil {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	if ctxIdentify.User.Role == model.RolePartnerAdmin {
+		if restError := ctrl.checkPartnerScope(c, ctxIdentify, rootUUID); restError != nil {
+			c.JSON(restError.Code, restError)
+			return
+		}
+	}
+
+	page, err := ctrl.service.List(c.Request.Context(), &rootUUID, opts)
+	if err != nil {
+		var restError *rest_err.RestErr
+		if errors.Is(err, listing.ErrInvalidOptions) {
+			restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
+		} else {
+			restError = rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao buscar tenants", nil)
+		}
+		ctrl.logAudit(c, ctxIdentify, "subtree", "Subtree", false, req, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	tenantResponses := make([]TenantResponseDto, len(page.Items))
+	for i, t := range page.Items {
+		tenantResponses[i] = TenantResponseDto{
+			UUID:              t.UUID,
+			ParentUUID:        t.ParentUUID,
+			Name:              t.Name,
+			Document:          t.Document,
+			DocumentType:      string(t.DocumentType),
+			DocumentFormatted: document.Format(t.DocumentType, t.Document),
+			Status:            string(t.Status),
+			TrialEndsAt:       t.TrialEndsAt,
+			Metadata:          t.Metadata,
+			Isolation:         string(t.Isolation),
+			CreateAt:          t.CreateAt,
+			UpdateAt:          t.UpdateAt,
+		}
+	}
+	c.Header("Link", listing.LinkHeader(c.Request.URL, page.NextCursor))
+	c.JSON(http.StatusOK, &TenantPageResponseDto{
+		Tenants:    tenantResponses,
+		Total:      page.Total,
+		Size:       page.Size,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// @Summary      Restaura um Tenant excluído
+// @Description  Desfaz a exclusão lógica de um tenant, desde que ainda esteja dentro do período de carência configurado (tenant.purge.grace_period_days). As sessões revogadas na exclusão não são restauradas.
+// @Tags         Tenant
+// @Produce      json
+// @Security     BearerAuth
+//
+// @Param        uuid path string true "UUID do tenant a ser restaurado."
+//
+// @Success      200  {object}  TenantResponseDto  "Tenant restaurado com sucesso."
+// @Failure      400  {object}  rest_err.RestErr    "UUID inválido."
+// @Failure      404  {object}  rest_err.RestErr    "Tenant não encontrado."
+// @Failure      409  {object}  rest_err.RestErr    "Tenant não está excluído ou o período de carência expirou."
+// @Failure      500  {object}  rest_err.RestErr    "Erro interno do servidor."
+//
+// @Router       /api/tenant/{uuid}/restore [post]
+func (ctrl *controllerImpl) Restore(c *gin.Context) {
+	tenantUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido na URL não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	restored, err := ctrl.service.Restore(c.Request.Context(), tenantUUID)
+	if err != nil {
+		var restError *rest_err.RestErr
+		switch err {
+		case ErrNotFound:
+			restError = rest_err.NewNotFoundError(&ctxIdentify.Metadata.RayTraceCode, ErrNotFound.Error())
+		case ErrNotDeleted:
+			restError = rest_err.NewConflictValidationError(&ctxIdentify.Metadata.RayTraceCode, "O tenant não está excluído.", nil)
+		case ErrRestoreExpired:
+			restError = rest_err.NewConflictValidationError(&ctxIdentify.Metadata.RayTraceCode, "O período de carência para restauração expirou.", nil)
+		default:
+			restError = rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao restaurar tenant", nil)
+		}
+
+		ctrl.logAudit(c, ctxIdentify, "restore", "Restore", false, gin.H{"uuid": tenantUUID}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	resp := &TenantResponseDto{
+		UUID:              restored.UUID,
+		ParentUUID:        restored.ParentUUID,
+		Name:              restored.Name,
+		Document:          restored.Document,
+		DocumentType:      string(restored.DocumentType),
+		DocumentFormatted: document.Format(restored.DocumentType, restored.Document),
+		Status:            string(restored.Status),
+		TrialEndsAt:       restored.TrialEndsAt,
+		Metadata:          restored.Metadata,
+		Isolation:         string(restored.Isolation),
+		CreateAt:          restored.CreateAt,
+		UpdateAt:          restored.UpdateAt,
+	}
+	ctrl.logAudit(c, ctxIdentify, "restore", "Restore", true, gin.H{"uuid": tenantUUID}, resp)
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary      Ativa um Tenant
+// @Description  Muda o status do tenant para active (a partir de trial, past_due ou suspended). O motivo é gravado no histórico de status. PARTNER_ADMIN só altera tenants descendentes, nunca o próprio.
+// @Tags         Tenant
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+//
+// @Param        uuid path string true "UUID do tenant."
+// @Param        request body TransitionTenantRequestDto true "Motivo da mudança"
+//
+// @Success      200  {object}  TenantResponseDto  "Status alterado com sucesso."
+// @Failure      400  {object}  rest_err.RestErr    "UUID inválido ou motivo ausente."
+// @Failure      403  {object}  rest_err.RestErr    "Tenant fora da hierarquia do usuário."
+// @Failure      404  {object}  rest_err.RestErr    "Tenant não encontrado."
+// @Failure      409  {object}  rest_err.RestErr    "Transição não permitida a partir do status atual."
+// @Failure      500  {object}  rest_err.RestErr    "Erro interno do servidor."
+//
+// @Router       /api/tenant/{uuid}/activate [post]
+func (ctrl *controllerImpl) Activate(c *gin.Context) {
+	ctrl.transition(c, model.TenantStatusActive, "activate", "Activate")
+}
+
+// @Summary      Suspende um Tenant
+// @Description  Muda o status do tenant para suspended: os usuários deixam de autenticar e as requisições com tokens já emitidos são bloqueadas. Pode ser revertido com /activate. PARTNER_ADMIN só altera tenants descendentes, nunca o próprio.
+// @Tags         Tenant
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+//
+// @Param        uuid path string true "UUID do tenant."
+// @Param        request body TransitionTenantRequestDto true "Motivo da mudança"
+//
+// @Success      200  {object}  TenantResponseDto  "Status alterado com sucesso."
+// @Failure      400  {object}  rest_err.RestErr    "UUID inválido ou motivo ausente."
+// @Failure      403  {object}  rest_err.RestErr    "Tenant fora da hierarquia do usuário."
+// @Failure      404  {object}  rest_err.RestErr    "Tenant não encontrado."
+// @Failure      409  {object}  rest_err.RestErr    "Transição não permitida a partir do status atual."
+// @Failure      500  {object}  rest_err.RestErr    "Erro interno do servidor."
+//
+// @Router       /api/tenant/{uuid}/suspend [post]
+func (ctrl *controllerImpl) Suspend(c *gin.Context) {
+	ctrl.transition(c, model.TenantStatusSuspended, "suspend", "Suspend")
+}
+
+// @Summary      Marca um Tenant com pagamento pendente
+// @Description  Muda o status do tenant para past_due: os usuários continuam autenticando, mas apenas requisições de leitura (GET) são aceitas. PARTNER_ADMIN só altera tenants descendentes, nunca o próprio.
+// @Tags         Tenant
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+//
+// @Param        uuid path string true "UUID do tenant."
+// @Param        request body TransitionTenantRequestDto true "Motivo da mudança"
+//
+// @Success      200  {object}  TenantResponseDto  "Status alterado com sucesso."
+// @Failure      400  {object}  rest_err.RestErr    "UUID inválido ou motivo ausente."
+// @Failure      403  {object}  rest_err.RestErr    "Tenant fora da hierarquia do usuário."
+// @Failure      404  {object}  rest_err.RestErr    "Tenant não encontrado."
+// @Failure      409  {object}  rest_err.RestErr    "Transição não permitida a partir do status atual."
+// @Failure      500  {object}  rest_err.RestErr    "Erro interno do servidor."
+//
+// @Router       /api/tenant/{uuid}/past-due [post]
+func (ctrl *controllerImpl) MarkPastDue(c *gin.Context) {
+	ctrl.transition(c, model.TenantStatusPastDue, "past_due", "MarkPastDue")
+}
+
+// @Summary      Cancela um Tenant
+// @Description  Muda o status do tenant para cancelled. O cancelamento é definitivo: o acesso fica bloqueado e nenhuma outra transição é permitida. Os dados são mantidos; para removê-los, exclua o tenant. PARTNER_ADMIN só altera tenants descendentes, nunca o próprio.
+// @Tags         Tenant
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+//
+// @Param        uuid path string true "UUID do tenant."
+// @Param        request body TransitionTenantRequestDto true "Motivo da mudança"
+//
+// @Success      200  {object}  TenantResponseDto  "Status alterado com sucesso."
+// @Failure      400  {object}  rest_err.RestErr    "UUID inválido ou motivo ausente."
+// @Failure      403  {object}  rest_err.RestErr    "Tenant fora da hierarquia do usuário."
+// @Failure      404  {object}  rest_err.RestErr    "Tenant não encontrado."
+// @Failure      409  {object}  rest_err.RestErr    "Transição não permitida a partir do status atual."
+// @Failure      500  {object}  rest_err.RestErr    "Erro interno do servidor."
+//
+// @Router       /api/tenant/{uuid}/cancel [post]
+func (ctrl *controllerImpl) Cancel(c *gin.Context) {
+	ctrl.transition(c, model.TenantStatusCancelled, "cancel", "Cancel")
+}
+
+// transition concentra as rotas de mudança de status; cada rota define apenas o status de destino.
+func (ctrl *controllerImpl) transition(c *gin.Context, to model.TenantStatus, action, function string) {
+	tenantUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido na URL não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	var req TransitionTenantRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, "É necessário informar o 'reason' da mudança de status.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+	input := gin.H{"uuid": tenantUUID, "status": to, "reason": req.Reason}
+
+	if ctxIdentify.User.Role == model.RolePartnerAdmin {
+		// PARTNER_ADMIN não altera o status do próprio tenant
+		if ctxIdentify.User.TenantUUID != nil && tenantUUID == *ctxIdentify.User.TenantUUID {
+			e := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Não é permitido alterar o status do próprio tenant.")
+			c.AbortWithStatusJSON(e.Code, e)
+			return
+		}
+		if restError := ctrl.checkPartnerScope(c, ctxIdentify, tenantUUID); restError != nil {
+			c.JSON(restError.Code, restError)
+			return
+		}
+	}
+
+	updated, err := ctrl.service.Transition(c.Request.Context(), tenantUUID, to, req.Reason, &ctxIdentify.User.UUID)
+	if err != nil {
+		var restError *rest_err.RestErr
+		switch {
+		case errors.Is(err, ErrNotFound):
+			restError = rest_err.NewNotFoundError(&ctxIdentify.Metadata.RayTraceCode, ErrNotFound.Error())
+		case errors.Is(err, ErrInvalidTransition):
+			restError = rest_err.NewConflictValidationError(&ctxIdentify.Metadata.RayTraceCode, "Transição de status não permitida: "+err.Error(), nil)
+		case errors.Is(err, ErrReasonRequired), errors.Is(err, ErrInvalidInput):
+			restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
+		default:
+			restError = rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao alterar status do tenant", nil)
+		}
+
+		ctrl.logAudit(c, ctxIdentify, action, function, false, input, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	resp := &TenantResponseDto{
+		UUID:              updated.UUID,
+		ParentUUID:        updated.ParentUUID,
+		Name:              updated.Name,
+		Document:          updated.Document,
+		DocumentType:      string(updated.DocumentType),
+		DocumentFormatted: document.Format(updated.DocumentType, updated.Document),
+		Status:            string(updated.Status),
+		TrialEndsAt:       updated.TrialEndsAt,
+		Metadata:          updated.Metadata,
+		Isolation:         string(updated.Isolation),
+		CreateAt:          updated.CreateAt,
+		UpdateAt:          updated.UpdateAt,
+	}
+	ctrl.logAudit(c, ctxIdentify, action, function, true, input, resp)
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary      Histórico de status de um Tenant
+// @Description  Retorna o status atual e todas as mudanças de status do tenant, da mais recente para a mais antiga. TENANT_ADMIN consulta apenas o próprio tenant; PARTNER_ADMIN, tenants da própria hierarquia.
+// @Tags         Tenant
+// @Produce      json
+// @Security     BearerAuth
+//
+// @Param        uuid path string true "UUID do tenant."
+//
+// @Success      200  {object}  TenantStatusHistoryResponseDto  "Histórico retornado com sucesso."
+// @Failure      400  {object}  rest_err.RestErr    "UUID inválido."
+// @Failure      403  {object}  rest_err.RestErr    "Tenant fora do escopo do usuário."
+// @Failure      404  {object}  rest_err.RestErr    "Tenant não encontrado."
+// @Failure      500  {object}  rest_err.RestErr    "Erro interno do servidor."
+//
+// @Router       /api/tenant/{uuid}/status/history [get]
+func (ctrl *controllerImpl) StatusHistory(c *gin.Context) {
+	tenantUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido na URL não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	switch ctxIdentify.User.Role {
+	case model.RolePartnerAdmin:
+		if restError := ctrl.checkPartnerScope(c, ctxIdentify, tenantUUID); restError != nil {
+			c.JSON(restError.Code, restError)
+			return
+		}
+	case model.RoleTenantAdmin:
+		if ctxIdentify.User.TenantUUID == nil || *ctxIdentify.User.TenantUUID != tenantUUID {
+			e := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Você só pode consultar o histórico do próprio tenant.")
+			c.AbortWithStatusJSON(e.Code, e)
+			return
+		}
+	}
+
+	current, err := ctrl.service.Read(c.Request.Context(), model.Tenant{UUID: tenantUUID})
+	if err != nil {
+		var restError *rest_err.RestErr
+		if errors.Is(err, ErrNotFound) {
+			restError = rest_err.NewNotFoundError(&ctxIdentify.Metadata.RayTraceCode, ErrNotFound.Error())
+		} else {
+			restError = rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao buscar tenant", nil)
+		}
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	history, err := ctrl.service.StatusHistory(c.Request.Context(), tenantUUID)
+	if err != nil {
+		restError := rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "Falha ao buscar histórico de status", nil)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	resp := TenantStatusHistoryResponseDto{
+		TenantUUID: current.UUID,
+		Status:     string(current.Status),
+		History:    make([]TenantStatusChangeResponseDto, len(history)),
+	}
+	for i, h := range history {
+		var from *string
+		if h.FromStatus != nil {
+			s := string(*h.FromStatus)
+			from = &s
+		}
+		resp.History[i] = TenantStatusChangeResponseDto{
+			UUID:       h.UUID,
+			FromStatus: from,
+			ToStatus:   string(h.ToStatus),
+			Reason:     h.Reason,
+			ChangedBy:  h.ChangedBy,
+			CreateAt:   h.CreateAt,
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
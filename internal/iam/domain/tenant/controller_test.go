@@ -0,0 +1,88 @@
+package tenant
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func seedTenant(t *testing.T, db *gorm.DB, name string, parentUUID *uuid.UUID) uuid.UUID {
+	t.Helper()
+	tenantUUID := uuid.New()
+	now := time.Now().UTC()
+	err := db.Exec("INSERT INTO tenant (uuid, parent_uuid, name, document, create_at, update_at) VALUES (?, ?, ?, ?, ?, ?)",
+		tenantUUID, parentUUID, name, tenantUUID.String(), now, now).Error
+	if err != nil {
+		t.Fatalf("falha ao criar o tenant: %v", err)
+	}
+	return tenantUUID
+}
+
+func TestSubtreePagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := newTestDB(t)
+	ctrl := &controllerImpl{service: NewService(NewRepository(db), Config{})}
+	router := gin.New()
+	router.GET("/api/tenant/:uuid/subtree", func(c *gin.Context) {
+		middleware.SetAuthenticatedUser(c, &middleware.Login{User: model.User{UUID: uuid.New(), Role: model.RoleSystemAdmin}})
+	}, ctrl.Subtree)
+
+	root := seedTenant(t, db, "Raiz", nil)
+	child := seedTenant(t, db, "Bravo", &root)
+	seedTenant(t, db, "Alfa", &root)
+	seedTenant(t, db, "Charlie", &child)
+	seedTenant(t, db, "Delta", &child)
+	seedTenant(t, db, "Fora da subárvore", nil)
+
+	get := func(query string) (TenantPageResponseDto, http.Header) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/tenant/"+root.String()+"/subtree"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: status = %d: %s", query, w.Code, w.Body.String())
+		}
+		var page TenantPageResponseDto
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatal(err)
+		}
+		return page, w.Header()
+	}
+	names := func(page TenantPageResponseDto) string {
+		var names []string
+		for _, tenant := range page.Tenants {
+			names = append(names, tenant.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	first, header := get("?size=3")
+	if names(first) != "Alfa,Bravo,Charlie" || first.Total != 4 || first.NextCursor == "" {
+		t.Fatalf("primeira página = %s (total %d, cursor %q)", names(first), first.Total, first.NextCursor)
+	}
+	if link := header.Get("Link"); !strings.Contains(link, `rel="next"`) || !strings.Contains(link, "cursor=") {
+		t.Fatalf("Link = %q, esperado o rel=\"next\" com o cursor", link)
+	}
+
+	second, header := get("?size=3&cursor=" + first.NextCursor)
+	if names(second) != "Delta" || second.NextCursor != "" {
+		t.Fatalf("segunda página = %s (cursor %q)", names(second), second.NextCursor)
+	}
+	if link := header.Get("Link"); strings.Contains(link, `rel="next"`) {
+		t.Fatalf("Link = %q na última página", link)
+	}
+
+	sorted, _ := get("?sort=-name&size=2")
+	if names(sorted) != "Delta,Charlie" {
+		t.Fatalf("ordenação decrescente = %s", names(sorted))
+	}
+}
@@ -1,9 +1,49 @@
 package tenant
 
+import (
+	"strings"
+
+	"tenant-crud-simply/internal/infra/database/tenantdb"
+	"tenant-crud-simply/internal/pkg/listing"
+)
+
 // CreateTenantRequest representa a requisição para criar um novo tenant
 type CreateTenantRequestDto struct {
 	Name     string `json:"name" binding:"required"`
 	Document string `json:"document" binding:"required"`
+	// DocumentType: cpf, cnpj ou foreign. Vazio = detecta CPF/CNPJ pelo formato
+	DocumentType string `json:"document_type"`
+	ParentUUID   string `json:"parent_uuid"`
+	// Metadata são os campos personalizados, validados pelas definições de /api/custom-fields
+	Metadata map[string]any `json:"metadata"`
+	// Isolation: shared (padrão), schema (schema próprio; com tenant.isolation.schema_enabled) ou
+	// database (banco dedicado; com tenant.isolation.database_enabled). Os dois últimos apenas para SYSTEM_ADMIN
+	Isolation string `json:"isolation"`
+	// Database são os dados de conexão do banco dedicado, obrigatórios com isolation = database
+	Database *TenantDatabaseRequestDto `json:"database"`
+}
+
+// TenantDatabaseRequestDto são os dados de conexão do banco dedicado de um tenant. São gravados
+// cifrados e nunca retornados pela API.
+type TenantDatabaseRequestDto struct {
+	Host     string `json:"host" binding:"required"`
+	Port     string `json:"port"`
+	User     string `json:"user" binding:"required"`
+	Password string `json:"password"`
+	DBName   string `json:"db_name" binding:"required"`
+	// SSLMode: disable, require (padrão), verify-ca ou verify-full
+	SSLMode string `json:"ssl_mode"`
+}
+
+func (d TenantDatabaseRequestDto) toConnection() tenantdb.Connection {
+	return tenantdb.Connection{
+		Host:     d.Host,
+		Port:     d.Port,
+		User:     d.User,
+		Password: d.Password,
+		Database: d.DBName,
+		SSLMode:  d.SSLMode,
+	}
 }
 
 type ReadTenantRequestDto struct {
@@ -11,13 +51,54 @@ type ReadTenantRequestDto struct {
 	Document string `form:"document"`
 }
 
-type ListTenantRequestDto struct {
-	Page     int `form:"page"`
-	PageSize int `form:"size"`
+// ListTenantsRequestDto são os filtros, a ordenação e a paginação por cursor de /tenant/list.
+type ListTenantsRequestDto struct {
+	Name        string `form:"name"`
+	Status      string `form:"status"`
+	CreatedFrom string `form:"created_from"`
+	CreatedTo   string `form:"created_to"`
+	Sort        string `form:"sort"`
+	Size        int    `form:"size"`
+	Cursor      string `form:"cursor"`
+	// Metadata filtra por campos personalizados (metadata[chave]=valor); preenchido pelo controller
+	Metadata map[string]string `form:"-"`
 }
 
 type UpdateTenantRequestDto struct {
 	Name     string `json:"name"`
 	Document string `json:"document"`
-	Live     *bool  `json:"live"`
+	// DocumentType: cpf, cnpj ou foreign. Vazio = detecta CPF/CNPJ pelo formato
+	DocumentType string `json:"document_type"`
+	// ParentUUID move o tenant na hierarquia. String vazia torna o tenant raiz (apenas SystemAdmin).
+	ParentUUID *string `json:"parent_uuid"`
+	// Metadata altera apenas os campos informados; null remove o campo
+	Metadata map[string]any `json:"metadata"`
+}
+
+// TransitionTenantRequestDto acompanha as mudanças de status; o motivo fica no histórico.
+type TransitionTenantRequestDto struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+func (r ListTenantsRequestDto) options() (listing.Options, error) {
+	from, err := listing.ParseTime(r.CreatedFrom, false)
+	if err != nil {
+		return listing.Options{}, err
+	}
+	to, err := listing.ParseTime(r.CreatedTo, true)
+	if err != nil {
+		return listing.Options{}, err
+	}
+	return listing.Options{
+		Filters: listing.Filters{
+			Name:        strings.TrimSpace(r.Name),
+			Status:      r.Status,
+			CreatedFrom: from,
+			CreatedTo:   to,
+			Metadata:    r.Metadata,
+		},
+		Sort:   r.Sort,
+		Size:   r.Size,
+		Cursor: r.Cursor,
+	}, nil
 }
@@ -8,16 +8,43 @@ import (
 
 // TenantResponse representa a resposta com os dados de um tenant
 type TenantResponseDto struct {
-	UUID     uuid.UUID `json:"uuid"`
-	Name     string    `json:"name"`
-	Document string    `json:"document"`
-	Live     bool      `json:"live"`
-	CreateAt time.Time `json:"createAt"`
-	UpdateAt time.Time `json:"updateAt"`
+	UUID       uuid.UUID  `json:"uuid"`
+	ParentUUID *uuid.UUID `json:"parentUuid,omitempty"`
+	Name       string     `json:"name"`
+	Document   string     `json:"document"`
+	// Tipo do documento (cpf, cnpj ou foreign). Vazio = documento legado ainda não validado
+	DocumentType      string `json:"documentType,omitempty"`
+	DocumentFormatted string `json:"documentFormatted"` // Documento formatado para exibição
+	// Status do ciclo de vida: trial, active, past_due, suspended ou cancelled
+	Status      string     `json:"status"`
+	TrialEndsAt *time.Time `json:"trialEndsAt,omitempty"`
+	// Campos personalizados (veja /api/custom-fields)
+	Metadata map[string]any `json:"metadata"`
+	// Isolamento dos dados: shared ou schema
+	Isolation string    `json:"isolation"`
+	CreateAt  time.Time `json:"createAt"`
+	UpdateAt  time.Time `json:"updateAt"`
 }
 
-type TenantsResponseDto struct {
-	Tenants []TenantResponseDto `json:"tenants"`
-	Page    int                 `json:"page"`
-	Size    int                 `json:"size"`
+// TenantPageResponseDto é uma página de /tenant/list. nextCursor ausente indica a última página.
+type TenantPageResponseDto struct {
+	Tenants    []TenantResponseDto `json:"tenants"`
+	Total      int64               `json:"total"`
+	Size       int                 `json:"size"`
+	NextCursor string              `json:"nextCursor,omitempty"`
+}
+
+type TenantStatusChangeResponseDto struct {
+	UUID       uuid.UUID  `json:"uuid"`
+	FromStatus *string    `json:"fromStatus,omitempty"`
+	ToStatus   string     `json:"toStatus"`
+	Reason     string     `json:"reason"`
+	ChangedBy  *uuid.UUID `json:"changedBy,omitempty"`
+	CreateAt   time.Time  `json:"createAt"`
+}
+
+type TenantStatusHistoryResponseDto struct {
+	TenantUUID uuid.UUID                       `json:"tenantUuid"`
+	Status     string                          `json:"status"`
+	History    []TenantStatusChangeResponseDto `json:"history"`
 }
@@ -6,4 +6,19 @@ var (
 	ErrDocumentDuplicated = errors.New("document already exists")
 	ErrNotFound           = errors.New("tenant not found")
 	ErrInvalidInput       = errors.New("invalid input data")
+	ErrParentNotFound     = errors.New("parent tenant not found")
+	ErrHierarchyCycle     = errors.New("tenant hierarchy cycle detected")
+	ErrNotDeleted         = errors.New("tenant is not deleted")
+	ErrRestoreExpired     = errors.New("tenant restore grace period expired")
+	ErrInvalidDocument    = errors.New("invalid tenant document")
+	ErrInvalidTransition  = errors.New("tenant status transition not allowed")
+	ErrReasonRequired     = errors.New("status transition reason is required")
+	ErrInvalidMetadata    = errors.New("invalid metadata")
+	ErrIsolationDisabled  = errors.New("isolation mode is disabled")
+	// ErrInvalidConnection indica dados de conexão do banco dedicado incompletos ou inválidos.
+	ErrInvalidConnection = errors.New("invalid tenant database connection")
+	// ErrDatabaseUnavailable indica que o banco dedicado não pôde ser acessado ou preparado.
+	ErrDatabaseUnavailable = errors.New("tenant database unavailable")
+	// ErrLegalHold indica retenção legal ativa sobre o tenant ou um de seus usuários.
+	ErrLegalHold = errors.New("tenant under legal hold")
 )
@@ -0,0 +1,28 @@
+package tenant
+
+import "tenant-crud-simply/internal/iam/domain/model"
+
+// transitions é a tabela de transições permitidas do ciclo de vida do tenant.
+// trial só existe como status inicial; cancelled é definitivo.
+var transitions = map[model.TenantStatus][]model.TenantStatus{
+	model.TenantStatusTrial:     {model.TenantStatusActive, model.TenantStatusPastDue, model.TenantStatusSuspended, model.TenantStatusCancelled},
+	model.TenantStatusActive:    {model.TenantStatusPastDue, model.TenantStatusSuspended, model.TenantStatusCancelled},
+	model.TenantStatusPastDue:   {model.TenantStatusActive, model.TenantStatusSuspended, model.TenantStatusCancelled},
+	model.TenantStatusSuspended: {model.TenantStatusActive, model.TenantStatusCancelled},
+	model.TenantStatusCancelled: {},
+}
+
+// CanTransition indica se o tenant pode passar do status from para o status to.
+func CanTransition(from, to model.TenantStatus) bool {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidStatus indica se o status existe no ciclo de vida.
+func ValidStatus(status model.TenantStatus) bool {
+	return status.Valid()
+}
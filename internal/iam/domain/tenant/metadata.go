@@ -0,0 +1,54 @@
+package tenant
+
+import (
+	"context"
+	"sync"
+
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// MetadataValidator aplica o patch sobre o metadata atual de um tenant e valida o resultado
+// contra as definições de campos personalizados que valem para ownerUUID (nil = apenas as
+// globais). Os erros de validação devem embrulhar ErrInvalidMetadata.
+type MetadataValidator func(ctx context.Context, ownerUUID *uuid.UUID, current, patch model.Metadata) (model.Metadata, error)
+
+var (
+	metadataMu        sync.RWMutex
+	metadataValidator MetadataValidator
+)
+
+// SetMetadataValidator registra a validação do metadata. O domínio custom_field depende do
+// tenant e faz o registro; sem validador, o metadata é gravado sem validação.
+func SetMetadataValidator(v MetadataValidator) {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+	metadataValidator = v
+}
+
+// validateMetadata retorna o metadata a gravar. patch nil mantém o atual sem revalidá-lo.
+func validateMetadata(ctx context.Context, ownerUUID *uuid.UUID, current, patch model.Metadata) (model.Metadata, error) {
+	if patch == nil {
+		return current, nil
+	}
+	metadataMu.RLock()
+	v := metadataValidator
+	metadataMu.RUnlock()
+
+	if v != nil {
+		return v(ctx, ownerUUID, current, patch)
+	}
+	merged := model.Metadata{}
+	for k, value := range current {
+		merged[k] = value
+	}
+	for k, value := range patch {
+		if value == nil {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = value
+	}
+	return merged, nil
+}
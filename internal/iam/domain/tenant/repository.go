@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
 	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/infra/database/migrations"
+	"tenant-crud-simply/internal/infra/database/postgres"
+	"tenant-crud-simply/internal/infra/database/tenantdb"
+	"tenant-crud-simply/internal/pkg/listing"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -15,10 +20,22 @@ import (
 
 type Repository interface {
 	Create(ctx context.Context, tenant model.Tenant) (model.Tenant, error)
+	// CreateWithDatabase grava o tenant e, na mesma transação, a conexão cifrada do seu banco dedicado.
+	CreateWithDatabase(ctx context.Context, tenant model.Tenant, conn tenantdb.Connection) (model.Tenant, error)
 	Read(ctx context.Context, m model.Tenant) (model.Tenant, error)
-	List(ctx context.Context, page, pageSize int) ([]model.Tenant, error)
+	List(ctx context.Context, rootUUID *uuid.UUID, opts listing.Options) (listing.Page[model.Tenant], error)
 	Update(ctx context.Context, m *model.Tenant) (model.Tenant, error)
 	Delete(ctx context.Context, m model.Tenant) error
+	Restore(ctx context.Context, tenantUUID uuid.UUID, deletedAfter time.Time) error
+	ListPurgeable(ctx context.Context, deletedBefore time.Time) ([]model.Tenant, error)
+	Purge(ctx context.Context, tenantUUID uuid.UUID, anonymizeLogs bool) error
+	SetParent(ctx context.Context, tenantUUID uuid.UUID, parentUUID *uuid.UUID) error
+	InSubtree(ctx context.Context, rootUUID, tenantUUID uuid.UUID) (bool, error)
+	// Transition aplica a mudança de status, desde que o tenant ainda esteja no status from,
+	// e grava o histórico na mesma transação.
+	Transition(ctx context.Context, from model.TenantStatus, change model.TenantStatusChange) error
+	History(ctx context.Context, tenantUUID uuid.UUID) ([]model.TenantStatusChange, error)
+	ListExpiredTrials(ctx context.Context, now time.Time) ([]model.Tenant, error)
 }
 
 type implRepository struct {
@@ -29,23 +46,72 @@ func NewRepository(db *gorm.DB) Repository {
 	return &implRepository{db: db}
 }
 
+// Create grava o tenant e o registro inicial do histórico de status. Com isolamento por schema,
+// o schema do tenant é criado e migrado na mesma transação.
 func (r *implRepository) Create(ctx context.Context, m model.Tenant) (model.Tenant, error) {
-	result := r.db.WithContext(ctx).Create(&m)
-	if result.Error != nil {
-		if strings.Contains(result.Error.Error(), "duplicate key value violates unique constraint") {
-			return model.Tenant{}, ErrDocumentDuplicated
+	return r.create(ctx, m, func(tx *gorm.DB, m *model.Tenant) error {
+		if m.Isolation != model.TenantIsolationSchema {
+			return nil
 		}
-		return model.Tenant{}, result.Error
+		schema := postgres.TenantSchemaName(m.UUID)
+		if err := tx.Model(&model.Tenant{}).Where("uuid = ?", m.UUID).Update("schema_name", schema).Error; err != nil {
+			return err
+		}
+		if err := migrations.NewManager(tx).ApplyTenant(schema); err != nil {
+			return fmt.Errorf("falha ao provisionar o schema do tenant: %w", err)
+		}
+		m.SchemaName = &schema
+		return nil
+	})
+}
+
+func (r *implRepository) CreateWithDatabase(ctx context.Context, m model.Tenant, conn tenantdb.Connection) (model.Tenant, error) {
+	registry, err := tenantdb.Use()
+	if err != nil {
+		return model.Tenant{}, err
 	}
+	return r.create(ctx, m, func(tx *gorm.DB, m *model.Tenant) error {
+		return registry.Save(tx, m.UUID, conn)
+	})
+}
 
-	if result.RowsAffected == 0 {
-		return model.Tenant{}, fmt.Errorf("no rows affected")
+// create grava o tenant e o histórico inicial; provision completa a criação dentro da mesma transação.
+func (r *implRepository) create(ctx context.Context, m model.Tenant, provision func(tx *gorm.DB, m *model.Tenant) error) (model.Tenant, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Create(&m)
+		if result.Error != nil {
+			if strings.Contains(result.Error.Error(), "duplicate key value violates unique constraint") {
+				return ErrDocumentDuplicated
+			}
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("no rows affected")
+		}
+		if err := tx.Create(&model.TenantStatusChange{
+			TenantUUID: m.UUID,
+			ToStatus:   m.Status,
+			Reason:     "Criação do tenant",
+			CreateAt:   m.CreateAt,
+		}).Error; err != nil {
+			return err
+		}
+		return provision(tx, &m)
+	})
+	if err != nil {
+		return model.Tenant{}, err
 	}
 	return m, nil
 }
 
 func (r *implRepository) Read(ctx context.Context, m model.Tenant) (model.Tenant, error) {
-	query := r.db.WithContext(ctx).Model(&model.Tenant{})
+	return r.read(r.db.WithContext(ctx).Where("deleted_at IS NULL"), m)
+}
+
+// read busca o tenant por UUID ou Documento usando a query base informada
+// (com ou sem o filtro de exclusão lógica).
+func (r *implRepository) read(base *gorm.DB, m model.Tenant) (model.Tenant, error) {
+	query := base.Model(&model.Tenant{})
 	if m.UUID != uuid.Nil {
 		query = query.First(&m, "uuid = ?", m.UUID)
 	} else if m.Document != "" {
@@ -62,21 +128,42 @@ func (r *implRepository) Read(ctx context.Context, m model.Tenant) (model.Tenant
 	return m, nil
 }
 
-func (r *implRepository) List(ctx context.Context, page, pageSize int) ([]model.Tenant, error) {
-	var listTenant []model.Tenant
-	query := r.db.WithContext(ctx).Model(&model.Tenant{})
-	if page <= 0 {
-		page = 1
+var listSpec = listing.Spec[model.Tenant]{
+	Sortable: map[string]listing.Column[model.Tenant]{
+		"name":      {Name: "name", Value: func(t model.Tenant) any { return t.Name }},
+		"document":  {Name: "document", Value: func(t model.Tenant) any { return t.Document }},
+		"create_at": {Name: "create_at", Value: func(t model.Tenant) any { return t.CreateAt }},
+		"update_at": {Name: "update_at", Value: func(t model.Tenant) any { return t.UpdateAt }},
+	},
+	DefaultSort: "name",
+	IDColumn:    "uuid",
+	ID:          func(t model.Tenant) uuid.UUID { return t.UUID },
+}
+
+// List lista os tenants não excluídos; com rootUUID, apenas os descendentes dele.
+func (r *implRepository) List(ctx context.Context, rootUUID *uuid.UUID, opts listing.Options) (listing.Page[model.Tenant], error) {
+	query := r.db.WithContext(ctx).Model(&model.Tenant{}).Where("deleted_at IS NULL")
+	if rootUUID != nil {
+		query = query.Where("uuid IN (?)", gorm.Expr(subtreeQuery, *rootUUID))
 	}
-	if pageSize <= 0 {
-		pageSize = 10
+
+	f := opts.Filters
+	if f.Name != "" {
+		query = query.Where("name ILIKE ?", listing.Contains(f.Name))
 	}
-	offset := (page - 1) * pageSize
-	result := query.Limit(pageSize).Offset(offset).Find(&listTenant)
-	if result.Error != nil {
-		return nil, result.Error
+	if f.Status != "" {
+		query = query.Where("status = ?", f.Status)
 	}
-	return listTenant, nil
+	if f.CreatedFrom != nil {
+		query = query.Where("create_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		query = query.Where("create_at < ?", *f.CreatedTo)
+	}
+	for _, cond := range listing.MetadataConditions("metadata", f.Metadata) {
+		query = query.Where(cond.SQL, cond.Args...)
+	}
+	return listing.Find(query, opts, listSpec)
 }
 
 func (r *implRepository) Update(ctx context.Context, m *model.Tenant) (model.Tenant, error) {
@@ -85,14 +172,15 @@ func (r *implRepository) Update(ctx context.Context, m *model.Tenant) (model.Ten
 	}
 
 	updateModel := model.Tenant{
-		Name:     m.Name,
-		Document: m.Document,
-		Live:     m.Live,
-		UpdateAt: time.Now().UTC(),
+		Name:         m.Name,
+		Document:     m.Document,
+		DocumentType: m.DocumentType,
+		Metadata:     m.Metadata,
+		UpdateAt:     time.Now().UTC(),
 	}
 	result := r.db.WithContext(ctx).
-		Where("uuid = ?", m.UUID).
-		Select("Name", "Document", "Live", "UpdateAt").
+		Where("uuid = ? AND deleted_at IS NULL", m.UUID).
+		Select("Name", "Document", "DocumentType", "Metadata", "UpdateAt").
 		Updates(updateModel)
 
 	if result.Error != nil {
@@ -121,22 +209,323 @@ func (r *implRepository) Update(ctx context.Context, m *model.Tenant) (model.Ten
 	return updatedTenant, nil
 }
 
+// Delete marca o tenant como excluído (exclusão lógica) e revoga, na mesma transação,
+// todas as sessões ativas dos seus usuários. O expurgo definitivo é feito por Purge.
 func (r *implRepository) Delete(ctx context.Context, m model.Tenant) error {
 	if m.UUID == uuid.Nil && m.Document == "" {
 		return ErrInvalidInput
 	}
-	query := r.db.WithContext(ctx)
-	if m.UUID != uuid.Nil {
-		query = query.Where("uuid = ?", m.UUID)
-	} else {
-		query = query.Where("document = ?", m.Document)
+	now := time.Now().UTC()
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		target, err := r.read(tx.Where("deleted_at IS NULL"), m)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&model.Tenant{}).
+			Where("uuid = ? AND deleted_at IS NULL", target.UUID).
+			Updates(map[string]interface{}{
+				"deleted_at": now,
+				"update_at":  now,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("falha ao deletar tenant: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		if err := tx.Exec(revokeTenantTokensQuery, now, now, target.UUID).Error; err != nil {
+			return fmt.Errorf("falha ao revogar sessões do tenant: %w", err)
+		}
+		return nil
+	})
+}
+
+const revokeTenantTokensQuery = `
+UPDATE users_acess_tokens
+SET expire_date = ?
+WHERE expire_date > ?
+  AND user_uuid IN (SELECT uuid FROM user_directory WHERE tenant_uuid = ?)`
+
+// Restore desfaz a exclusão lógica, desde que ela tenha ocorrido depois de deletedAfter.
+func (r *implRepository) Restore(ctx context.Context, tenantUUID uuid.UUID, deletedAfter time.Time) error {
+	if tenantUUID == uuid.Nil {
+		return ErrInvalidInput
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&model.Tenant{}).
+		Where("uuid = ? AND deleted_at IS NOT NULL AND deleted_at >= ?", tenantUUID, deletedAfter).
+		Updates(map[string]interface{}{
+			"deleted_at": nil,
+			"update_at":  time.Now().UTC(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("falha ao restaurar tenant: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	// Nada foi restaurado: descobre o motivo para retornar o erro adequado
+	existing, err := r.read(r.db.WithContext(ctx), model.Tenant{UUID: tenantUUID})
+	if err != nil {
+		return err
+	}
+	if existing.DeletedAt == nil {
+		return ErrNotDeleted
+	}
+	return ErrRestoreExpired
+}
+
+// heldTenants seleciona os tenants com retenção legal ativa sobre o próprio tenant ou sobre um
+// de seus usuários. Esses tenants não são expurgados até a liberação da retenção.
+const heldTenants = `SELECT h.tenant_uuid FROM legal_holds h
+	WHERE h.released_at IS NULL AND h.tenant_uuid IS NOT NULL
+	UNION
+	SELECT d.tenant_uuid FROM legal_holds h
+	JOIN user_directory d ON d.uuid = h.user_uuid
+	WHERE h.released_at IS NULL AND d.tenant_uuid IS NOT NULL`
+
+func (r *implRepository) ListPurgeable(ctx context.Context, deletedBefore time.Time) ([]model.Tenant, error) {
+	var listTenant []model.Tenant
+	result := r.db.WithContext(ctx).
+		Model(&model.Tenant{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Where("uuid NOT IN (" + heldTenants + ")").
+		Order("deleted_at ASC").
+		Find(&listTenant)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return listTenant, nil
+}
+
+type purgeStep struct {
+	name  string
+	query string
+}
+
+// Purge remove definitivamente um tenant excluído logicamente, junto com seus usuários,
+// tokens e logs, em uma única transação. Tenants com retenção legal ativa (sobre o tenant ou
+// um de seus usuários) não são expurgados: retorna ErrLegalHold. Com anonymizeLogs os logs
+// são mantidos para fins estatísticos, mas sem qualquer dado que identifique os usuários. Do
+// banco dedicado de um tenant isolado por banco de dados são apagados os usuários e os grupos
+// antes do desregistro; o banco em si fica para o operador remover.
+func (r *implRepository) Purge(ctx context.Context, tenantUUID uuid.UUID, anonymizeLogs bool) error {
+	purgedDatabase := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var target model.Tenant
+		if err := tx.Where("uuid = ? AND deleted_at IS NOT NULL", tenantUUID).First(&target).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrNotFound
+			}
+			return err
+		}
+		// Verificada na transação do expurgo: a retenção pode ter sido criada após a listagem
+		var held int64
+		if err := tx.Raw("SELECT COUNT(*) FROM ("+heldTenants+") held WHERE held.tenant_uuid = ?", tenantUUID).Scan(&held).Error; err != nil {
+			return fmt.Errorf("falha ao verificar retenções legais do tenant %s: %w", tenantUUID, err)
+		}
+		if held > 0 {
+			return ErrLegalHold
+		}
+
+		steps := []purgeStep{
+			{"tokens", `DELETE FROM users_acess_tokens WHERE user_uuid IN (SELECT uuid FROM user_directory WHERE tenant_uuid = ?)`},
+		}
+		if target.SchemaName != nil && postgres.IsTenantSchema(*target.SchemaName) {
+			// Os usuários somem com o schema; como DROP SCHEMA não dispara os triggers de linha,
+			// o índice global é limpo à parte
+			if err := tx.Exec(fmt.Sprintf(`DROP SCHEMA IF EXISTS "%s" CASCADE`, *target.SchemaName)).Error; err != nil {
+				return fmt.Errorf("falha ao expurgar schema do tenant %s: %w", tenantUUID, err)
+			}
+			steps = append(steps, purgeStep{"user_directory", `DELETE FROM user_directory WHERE tenant_uuid = ?`})
+		} else if target.Isolation == model.TenantIsolationDatabase {
+			// Sem o banco dedicado acessível o expurgo falha e é tentado de novo na próxima execução:
+			// o registro da conexão é o único caminho até os dados que ficaram lá
+			if err := purgeTenantDatabase(ctx, tenantUUID); err != nil {
+				return err
+			}
+			// Aqui saem o índice global e, em cascata com o tenant, o registro da conexão
+			steps = append(steps, purgeStep{"user_directory", `DELETE FROM user_directory WHERE tenant_uuid = ?`})
+			purgedDatabase = true
+		} else {
+			steps = append(steps, purgeStep{"users", `DELETE FROM users WHERE tenant_uuid = ?`})
+		}
+		if anonymizeLogs {
+			steps = append(steps,
+				purgeStep{"access_log", `UPDATE access_log SET user_uuid = NULL, identifier = NULL, ip = '0.0.0.0', user_agent = NULL, referer = NULL WHERE tenant_uuid = ?`},
+				purgeStep{"audit_log", `UPDATE audit_log SET user_uuid = NULL, identifier = NULL, input_data = NULL, output_data = NULL WHERE tenant_uuid = ?`},
+			)
+		} else {
+			steps = append(steps,
+				purgeStep{"access_log", `DELETE FROM access_log WHERE tenant_uuid = ?`},
+				purgeStep{"audit_log", `DELETE FROM audit_log WHERE tenant_uuid = ?`},
+			)
+		}
+		steps = append(steps, purgeStep{"tenant", `DELETE FROM tenant WHERE uuid = ?`})
+
+		for _, step := range steps {
+			if err := tx.Exec(step.query, tenantUUID).Error; err != nil {
+				return fmt.Errorf("falha ao expurgar %s do tenant %s: %w", step.name, tenantUUID, err)
+			}
+		}
+		return nil
+	})
+	if err == nil && purgedDatabase {
+		if registry, regErr := tenantdb.Use(); regErr == nil {
+			registry.Evict(tenantUUID)
+		}
 	}
-	result := query.Delete(&model.Tenant{})
+	return err
+}
+
+// purgeTenantDatabase apaga os usuários e os grupos do tenant no seu banco dedicado. O banco não
+// é removido (o operador pode precisar de um backup antes), então o host e o nome do banco são
+// registrados no log, já que os dados de conexão saem junto com o tenant.
+func purgeTenantDatabase(ctx context.Context, tenantUUID uuid.UUID) error {
+	registry, err := tenantdb.Use()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseUnavailable, err)
+	}
+	conn, err := registry.Connection(ctx, tenantUUID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseUnavailable, err)
+	}
+	db, err := registry.DB(ctx, tenantUUID)
+	if err != nil {
+		log.Printf("[TENANT-PURGE] Banco dedicado do tenant %s (%s/%s) inacessível; o expurgo fica pendente.", tenantUUID, conn.Host, conn.Database)
+		return fmt.Errorf("%w: %v", ErrDatabaseUnavailable, err)
+	}
+
+	steps := []purgeStep{
+		{"tenant_group_members", `DELETE FROM tenant_group_members WHERE group_uuid IN (SELECT uuid FROM tenant_groups WHERE tenant_uuid = ?)`},
+		{"tenant_groups", `DELETE FROM tenant_groups WHERE tenant_uuid = ?`},
+		{"users", `DELETE FROM users WHERE tenant_uuid = ?`},
+	}
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, step := range steps {
+			if err := tx.Exec(step.query, tenantUUID).Error; err != nil {
+				return fmt.Errorf("falha ao expurgar %s do banco dedicado do tenant %s: %w", step.name, tenantUUID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Printf("[TENANT-PURGE] Usuários e grupos do tenant %s apagados do banco dedicado %s/%s; o banco pode ser removido pelo operador.", tenantUUID, conn.Host, conn.Database)
+	return nil
+}
+
+func (r *implRepository) SetParent(ctx context.Context, tenantUUID uuid.UUID, parentUUID *uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.Tenant{}).
+		Where("uuid = ?", tenantUUID).
+		Updates(map[string]interface{}{
+			"parent_uuid": parentUUID,
+			"update_at":   time.Now().UTC(),
+		})
 	if result.Error != nil {
-		return fmt.Errorf("falha ao deletar tenant: %w", result.Error)
+		return fmt.Errorf("falha ao atualizar tenant pai: %w", result.Error)
 	}
 	if result.RowsAffected == 0 {
 		return ErrNotFound
 	}
 	return nil
 }
+
+// subtreeQuery percorre recursivamente todos os descendentes de um tenant.
+// UNION (e não UNION ALL) garante a parada mesmo se existir um ciclo legado no banco.
+const subtreeQuery = `
+WITH RECURSIVE subtree AS (
+        SELECT uuid FROM tenant WHERE parent_uuid = ?
+        UNION
+        SELECT t.uuid FROM tenant AS t
+        INNER JOIN subtree AS s ON t.parent_uuid = s.uuid
+)
+SELECT uuid FROM subtree`
+
+// ancestorsQuery percorre recursivamente a cadeia de ancestrais de um tenant (incluindo ele mesmo).
+const ancestorsQuery = `
+WITH RECURSIVE chain AS (
+        SELECT uuid, parent_uuid FROM tenant WHERE uuid = ?
+        UNION
+        SELECT t.uuid, t.parent_uuid FROM tenant AS t
+        INNER JOIN chain AS c ON t.uuid = c.parent_uuid
+)
+SELECT EXISTS (SELECT 1 FROM chain WHERE uuid = ?)`
+
+// InSubtree indica se tenantUUID é o próprio rootUUID ou um de seus descendentes.
+func (r *implRepository) InSubtree(ctx context.Context, rootUUID, tenantUUID uuid.UUID) (bool, error) {
+	if rootUUID == uuid.Nil || tenantUUID == uuid.Nil {
+		return false, ErrInvalidInput
+	}
+	var exists bool
+	if err := r.db.WithContext(ctx).Raw(ancestorsQuery, tenantUUID, rootUUID).Scan(&exists).Error; err != nil {
+		return false, fmt.Errorf("falha ao consultar hierarquia de tenants: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *implRepository) Transition(ctx context.Context, from model.TenantStatus, change model.TenantStatusChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"status":    change.ToStatus,
+			"update_at": change.CreateAt,
+		}
+		// A data de fim da avaliação só faz sentido enquanto o tenant está em trial
+		if change.ToStatus != model.TenantStatusTrial {
+			updates["trial_ends_at"] = nil
+		}
+		result := tx.Model(&model.Tenant{}).
+			Where("uuid = ? AND status = ? AND deleted_at IS NULL", change.TenantUUID, from).
+			Updates(updates)
+		if result.Error != nil {
+			return fmt.Errorf("falha ao alterar status do tenant: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			// Excluído ou alterado por outra requisição desde a leitura
+			existing, err := r.read(tx.Where("deleted_at IS NULL"), model.Tenant{UUID: change.TenantUUID})
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("%w: status atual é %s", ErrInvalidTransition, existing.Status)
+		}
+
+		fromStatus := from
+		change.FromStatus = &fromStatus
+		if err := tx.Create(&change).Error; err != nil {
+			return fmt.Errorf("falha ao registrar histórico de status: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *implRepository) History(ctx context.Context, tenantUUID uuid.UUID) ([]model.TenantStatusChange, error) {
+	var history []model.TenantStatusChange
+	err := r.db.WithContext(ctx).
+		Where("tenant_uuid = ?", tenantUUID).
+		Order("create_at DESC, uuid").
+		Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (r *implRepository) ListExpiredTrials(ctx context.Context, now time.Time) ([]model.Tenant, error) {
+	var listTenant []model.Tenant
+	result := r.db.WithContext(ctx).
+		Model(&model.Tenant{}).
+		Where("status = ? AND trial_ends_at <= ? AND deleted_at IS NULL", model.TenantStatusTrial, now).
+		Order("trial_ends_at ASC").
+		Find(&listTenant)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return listTenant, nil
+}
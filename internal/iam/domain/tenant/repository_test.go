@@ -0,0 +1,176 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// O expurgo é testado contra um SQLite em memória com as tabelas que ele consulta, no lugar do
+// PostgreSQL.
+const testSchema = `
+CREATE TABLE tenant (
+    uuid TEXT PRIMARY KEY,
+    parent_uuid TEXT,
+    name TEXT NOT NULL,
+    document TEXT NOT NULL UNIQUE,
+    document_type TEXT,
+    status TEXT NOT NULL DEFAULT 'active',
+    trial_ends_at DATETIME,
+    metadata TEXT NOT NULL DEFAULT '{}',
+    isolation TEXT NOT NULL DEFAULT 'shared',
+    schema_name TEXT,
+    create_at DATETIME NOT NULL,
+    update_at DATETIME NOT NULL,
+    deleted_at DATETIME
+);
+CREATE TABLE users (uuid TEXT PRIMARY KEY, tenant_uuid TEXT, email TEXT NOT NULL);
+CREATE TABLE user_directory (uuid TEXT PRIMARY KEY, tenant_uuid TEXT, email TEXT NOT NULL);
+CREATE TABLE users_acess_tokens (token TEXT PRIMARY KEY, user_uuid TEXT NOT NULL);
+CREATE TABLE access_log (tenant_uuid TEXT, user_uuid TEXT, identifier TEXT, ip TEXT, user_agent TEXT, referer TEXT);
+CREATE TABLE audit_log (tenant_uuid TEXT, user_uuid TEXT, identifier TEXT, input_data TEXT, output_data TEXT);
+CREATE TABLE legal_holds (
+    uuid TEXT PRIMARY KEY,
+    tenant_uuid TEXT,
+    user_uuid TEXT,
+    reason TEXT NOT NULL,
+    released_at DATETIME
+);`
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("falha ao abrir o SQLite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cada conexão teria o seu próprio banco em memória
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.Exec(testSchema).Error; err != nil {
+		t.Fatalf("falha ao criar as tabelas: %v", err)
+	}
+	return db
+}
+
+// seedDeletedTenant cria um tenant excluído logicamente em deletedAt, com um usuário.
+func seedDeletedTenant(t *testing.T, db *gorm.DB, deletedAt time.Time) (tenantUUID, userUUID uuid.UUID) {
+	t.Helper()
+	tenantUUID, userUUID = uuid.New(), uuid.New()
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{"INSERT INTO tenant (uuid, name, document, create_at, update_at, deleted_at) VALUES (?, ?, ?, ?, ?, ?)",
+			[]any{tenantUUID, "Tenant " + tenantUUID.String()[:8], tenantUUID.String(), deletedAt, deletedAt, deletedAt}},
+		{"INSERT INTO users (uuid, tenant_uuid, email) VALUES (?, ?, ?)", []any{userUUID, tenantUUID, userUUID.String() + "@example.com"}},
+		{"INSERT INTO user_directory (uuid, tenant_uuid, email) VALUES (?, ?, ?)", []any{userUUID, tenantUUID, userUUID.String() + "@example.com"}},
+	}
+	for _, st := range statements {
+		if err := db.Exec(st.query, st.args...).Error; err != nil {
+			t.Fatalf("falha ao criar o tenant: %v", err)
+		}
+	}
+	return tenantUUID, userUUID
+}
+
+func seedHold(t *testing.T, db *gorm.DB, tenantUUID, userUUID *uuid.UUID, released bool) {
+	t.Helper()
+	var releasedAt *time.Time
+	if released {
+		now := time.Now().UTC()
+		releasedAt = &now
+	}
+	err := db.Exec("INSERT INTO legal_holds (uuid, tenant_uuid, user_uuid, reason, released_at) VALUES (?, ?, ?, ?, ?)",
+		uuid.New(), tenantUUID, userUUID, "processo judicial", releasedAt).Error
+	if err != nil {
+		t.Fatalf("falha ao criar a retenção: %v", err)
+	}
+}
+
+func TestPurgeSkipsTenantsUnderLegalHold(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+	deletedAt := time.Now().UTC().Add(-48 * time.Hour)
+
+	tenantHeld, _ := seedDeletedTenant(t, db, deletedAt)
+	seedHold(t, db, &tenantHeld, nil, false)
+	userHeldTenant, heldUser := seedDeletedTenant(t, db, deletedAt)
+	seedHold(t, db, nil, &heldUser, false)
+	releasedTenant, _ := seedDeletedTenant(t, db, deletedAt)
+	seedHold(t, db, &releasedTenant, nil, true)
+	freeTenant, _ := seedDeletedTenant(t, db, deletedAt)
+
+	purgeable, err := repo.ListPurgeable(ctx, time.Now().UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	listed := map[uuid.UUID]bool{}
+	for _, p := range purgeable {
+		listed[p.UUID] = true
+	}
+	if len(purgeable) != 2 || !listed[releasedTenant] || !listed[freeTenant] {
+		t.Fatalf("ListPurgeable = %v, esperados apenas %s e %s", purgeable, releasedTenant, freeTenant)
+	}
+
+	// Purge recusa o tenant mesmo fora da listagem (retenção criada depois dela)
+	for _, held := range []uuid.UUID{tenantHeld, userHeldTenant} {
+		if err := repo.Purge(ctx, held, false); !errors.Is(err, ErrLegalHold) {
+			t.Fatalf("Purge(%s) = %v, esperado ErrLegalHold", held, err)
+		}
+	}
+
+	if err := NewService(repo, Config{GracePeriod: time.Hour}).PurgeExpired(ctx); err != nil {
+		t.Fatalf("PurgeExpired: %v", err)
+	}
+
+	var remaining []uuid.UUID
+	if err := db.Raw("SELECT uuid FROM tenant ORDER BY uuid").Scan(&remaining).Error; err != nil {
+		t.Fatal(err)
+	}
+	want := map[uuid.UUID]bool{tenantHeld: true, userHeldTenant: true}
+	if len(remaining) != len(want) || !want[remaining[0]] || !want[remaining[1]] {
+		t.Fatalf("tenants restantes = %v, esperados %s e %s", remaining, tenantHeld, userHeldTenant)
+	}
+	var holds int64
+	if err := db.Raw("SELECT COUNT(*) FROM legal_holds").Scan(&holds).Error; err != nil {
+		t.Fatal(err)
+	}
+	if holds != 3 {
+		t.Fatalf("retenções restantes = %d, esperadas 3 (inclusive a do tenant expurgado)", holds)
+	}
+}
+
+func TestPurgeKeepsTenantWithUnreachableDatabase(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	// Sem o registro de bancos dedicados (tenantdb) os usuários do banco do tenant não podem ser
+	// apagados, então o tenant e o registro da conexão ficam para a próxima execução
+	tenantUUID, _ := seedDeletedTenant(t, db, time.Now().UTC().Add(-48*time.Hour))
+	if err := db.Exec("UPDATE tenant SET isolation = 'database' WHERE uuid = ?", tenantUUID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Purge(ctx, tenantUUID, false); !errors.Is(err, ErrDatabaseUnavailable) {
+		t.Fatalf("Purge = %v, esperado ErrDatabaseUnavailable", err)
+	}
+	var count int64
+	if err := db.Raw("SELECT COUNT(*) FROM tenant WHERE uuid = ?", tenantUUID).Scan(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatal("tenant expurgado sem limpar o banco dedicado")
+	}
+}
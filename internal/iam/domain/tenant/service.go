@@ -2,42 +2,327 @@ package tenant
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/infra/database/tenantdb"
+	"tenant-crud-simply/internal/pkg/document"
+	"tenant-crud-simply/internal/pkg/listing"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type Service interface {
 	Create(ctx context.Context, tenant model.Tenant) (model.Tenant, error)
+	// CreateWithDatabase cria um tenant com banco de dados dedicado: a conexão é testada, as tabelas
+	// do tenant são criadas no banco e os dados de conexão são gravados cifrados.
+	CreateWithDatabase(ctx context.Context, tenant model.Tenant, conn tenantdb.Connection) (model.Tenant, error)
 	Read(ctx context.Context, tenant model.Tenant) (model.Tenant, error)
-	List(ctx context.Context, page, pageSize int) ([]model.Tenant, error)
+	// List lista os tenants com filtros, ordenação e paginação por cursor. Com rootUUID,
+	// apenas os descendentes dele.
+	List(ctx context.Context, rootUUID *uuid.UUID, opts listing.Options) (listing.Page[model.Tenant], error)
 	Update(ctx context.Context, m *model.Tenant) (model.Tenant, error)
 	Delete(ctx context.Context, m model.Tenant) error
+	Restore(ctx context.Context, tenantUUID uuid.UUID) (model.Tenant, error)
+	PurgeExpired(ctx context.Context) error
+	SetParent(ctx context.Context, tenantUUID uuid.UUID, parentUUID *uuid.UUID) (model.Tenant, error)
+	InSubtree(ctx context.Context, rootUUID, tenantUUID uuid.UUID) (bool, error)
+	// Transition muda o status do ciclo de vida do tenant, conforme a tabela de transições.
+	// changedBy nil indica uma alteração automática.
+	Transition(ctx context.Context, tenantUUID uuid.UUID, to model.TenantStatus, reason string, changedBy *uuid.UUID) (model.Tenant, error)
+	StatusHistory(ctx context.Context, tenantUUID uuid.UUID) ([]model.TenantStatusChange, error)
+	// InitialStatus retorna o status (e o fim da avaliação) de um tenant criado em now.
+	InitialStatus(now time.Time) (model.TenantStatus, *time.Time)
+	// ExpireTrials aplica o status configurado aos tenants cuja avaliação terminou.
+	ExpireTrials(ctx context.Context) error
 }
 
 type implService struct {
 	Repository Repository
+	cfg        Config
 }
 
-func NewService(repository Repository) Service {
+func NewService(repository Repository, cfg Config) Service {
 	return &implService{
 		Repository: repository,
+		cfg:        cfg,
 	}
 }
 
+// Create valida o documento (DocumentType vazio = detecção automática entre CPF e CNPJ)
+// e grava sua forma canônica. Tenants com isolamento por schema têm o schema criado junto.
+// O isolamento por banco de dados exige os dados de conexão (CreateWithDatabase).
 func (s *implService) Create(ctx context.Context, tenant model.Tenant) (model.Tenant, error) {
+	if tenant.Isolation == model.TenantIsolationDatabase {
+		return model.Tenant{}, ErrInvalidConnection
+	}
+	if err := s.prepare(ctx, &tenant); err != nil {
+		return model.Tenant{}, err
+	}
 	return s.Repository.Create(ctx, tenant)
 }
 
+func (s *implService) CreateWithDatabase(ctx context.Context, tenant model.Tenant, conn tenantdb.Connection) (model.Tenant, error) {
+	tenant.Isolation = model.TenantIsolationDatabase
+	if err := s.prepare(ctx, &tenant); err != nil {
+		return model.Tenant{}, err
+	}
+	if err := conn.Validate(); err != nil {
+		return model.Tenant{}, fmt.Errorf("%w: %v", ErrInvalidConnection, err)
+	}
+
+	registry, err := tenantdb.Use()
+	if err != nil {
+		return model.Tenant{}, err
+	}
+	// As migrations são idempotentes: se a gravação do tenant falhar, o banco pode ser reaproveitado
+	if err := registry.Provision(ctx, conn); err != nil {
+		return model.Tenant{}, fmt.Errorf("%w: %v", ErrDatabaseUnavailable, err)
+	}
+	return s.Repository.CreateWithDatabase(ctx, tenant, conn)
+}
+
+// prepare normaliza e valida o tenant a ser criado.
+func (s *implService) prepare(ctx context.Context, tenant *model.Tenant) error {
+	doc, err := document.Parse(tenant.Document, tenant.DocumentType)
+	if err != nil {
+		return ErrInvalidDocument
+	}
+	tenant.Document, tenant.DocumentType = doc.Value, doc.Kind
+
+	if tenant.Status == "" {
+		tenant.Status, tenant.TrialEndsAt = s.InitialStatus(tenant.CreateAt)
+	}
+
+	// O isolamento é escolhido na criação e não muda depois
+	if tenant.Isolation == "" {
+		tenant.Isolation = model.TenantIsolationShared
+	}
+	if !tenant.Isolation.Valid() {
+		return ErrInvalidInput
+	}
+	if tenant.Isolation == model.TenantIsolationSchema && !s.cfg.SchemaIsolation ||
+		tenant.Isolation == model.TenantIsolationDatabase && !s.cfg.DatabaseIsolation {
+		return ErrIsolationDisabled
+	}
+
+	if tenant.ParentUUID != nil {
+		if err := s.ensureParentExists(ctx, *tenant.ParentUUID); err != nil {
+			return err
+		}
+	}
+
+	// Na criação valem as definições dos ancestrais; os obrigatórios são exigidos mesmo sem metadata
+	patch := tenant.Metadata
+	if patch == nil {
+		patch = model.Metadata{}
+	}
+	tenant.Metadata, err = validateMetadata(ctx, tenant.ParentUUID, nil, patch)
+	return err
+}
+
+// Read aceita o documento em qualquer formatação; documentos legados (não normalizados
+// pela migração) ainda são encontrados pelo valor exato informado.
 func (s *implService) Read(ctx context.Context, tenant model.Tenant) (model.Tenant, error) {
-	return s.Repository.Read(ctx, tenant)
+	if tenant.UUID != uuid.Nil || tenant.Document == "" {
+		return s.Repository.Read(ctx, tenant)
+	}
+	raw := tenant.Document
+	tenant.Document = document.Normalize(raw)
+	found, err := s.Repository.Read(ctx, tenant)
+	if errors.Is(err, ErrNotFound) && tenant.Document != raw {
+		tenant.Document = raw
+		return s.Repository.Read(ctx, tenant)
+	}
+	return found, err
 }
 
-func (s *implService) List(ctx context.Context, page, pageSize int) ([]model.Tenant, error) {
-	return s.Repository.List(ctx, page, pageSize)
+func (s *implService) List(ctx context.Context, rootUUID *uuid.UUID, opts listing.Options) (listing.Page[model.Tenant], error) {
+	if opts.Filters.Status != "" && !model.TenantStatus(opts.Filters.Status).Valid() {
+		return listing.Page[model.Tenant]{}, fmt.Errorf("%w: status '%s' desconhecido", listing.ErrInvalidOptions, opts.Filters.Status)
+	}
+	return s.Repository.List(ctx, rootUUID, opts)
 }
 
+// Update valida o documento apenas quando ele muda: documento vazio ou igual ao atual
+// mantém o valor gravado, inclusive documentos legados ainda não validados. O metadata
+// informado é um patch sobre o atual (null remove o campo); nil mantém o atual.
 func (s *implService) Update(ctx context.Context, m *model.Tenant) (model.Tenant, error) {
+	if m.UUID == uuid.Nil {
+		return model.Tenant{}, ErrInvalidInput
+	}
+	current, err := s.Repository.Read(ctx, model.Tenant{UUID: m.UUID})
+	if err != nil {
+		return model.Tenant{}, err
+	}
+
+	if m.Document == "" || (m.Document == current.Document && m.DocumentType == "") {
+		m.Document, m.DocumentType = current.Document, current.DocumentType
+	} else {
+		doc, err := document.Parse(m.Document, m.DocumentType)
+		if err != nil {
+			return model.Tenant{}, ErrInvalidDocument
+		}
+		m.Document, m.DocumentType = doc.Value, doc.Kind
+	}
+
+	if m.Metadata, err = validateMetadata(ctx, &m.UUID, current.Metadata, m.Metadata); err != nil {
+		return model.Tenant{}, err
+	}
 	return s.Repository.Update(ctx, m)
 }
 func (s *implService) Delete(ctx context.Context, m model.Tenant) error {
 	return s.Repository.Delete(ctx, m)
 }
+
+// Restore reativa um tenant excluído logicamente, se ainda estiver dentro do período de carência.
+func (s *implService) Restore(ctx context.Context, tenantUUID uuid.UUID) (model.Tenant, error) {
+	deletedAfter := time.Now().UTC().Add(-s.cfg.GracePeriod)
+	if err := s.Repository.Restore(ctx, tenantUUID, deletedAfter); err != nil {
+		return model.Tenant{}, err
+	}
+	return s.Repository.Read(ctx, model.Tenant{UUID: tenantUUID})
+}
+
+// PurgeExpired expurga todos os tenants cuja exclusão lógica ultrapassou o período de carência.
+// Cada tenant é expurgado em sua própria transação; uma falha não impede os demais. Tenants sob
+// retenção legal ficam para depois da liberação.
+func (s *implService) PurgeExpired(ctx context.Context) error {
+	deletedBefore := time.Now().UTC().Add(-s.cfg.GracePeriod)
+	expired, err := s.Repository.ListPurgeable(ctx, deletedBefore)
+	if err != nil {
+		return fmt.Errorf("falha ao buscar tenants para expurgo: %w", err)
+	}
+
+	var failed int
+	for _, t := range expired {
+		if err := s.Repository.Purge(ctx, t.UUID, s.cfg.AnonymizeLogs); err != nil {
+			if errors.Is(err, ErrLegalHold) {
+				log.Printf("[TENANT-PURGE] Tenant %s (%s) sob retenção legal; expurgo adiado.", t.UUID, t.Name)
+				continue
+			}
+			log.Printf("[TENANT-PURGE] Falha ao expurgar tenant %s: %v", t.UUID, err)
+			failed++
+			continue
+		}
+		log.Printf("[TENANT-PURGE] Tenant %s (%s) expurgado.", t.UUID, t.Name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d de %d tenants não puderam ser expurgados", failed, len(expired))
+	}
+	return nil
+}
+
+// SetParent move o tenant para baixo de um novo pai (ou o torna raiz quando parentUUID é nil).
+// Impede ciclos: o novo pai não pode ser o próprio tenant nem um de seus descendentes.
+func (s *implService) SetParent(ctx context.Context, tenantUUID uuid.UUID, parentUUID *uuid.UUID) (model.Tenant, error) {
+	if tenantUUID == uuid.Nil {
+		return model.Tenant{}, ErrInvalidInput
+	}
+	if parentUUID != nil {
+		if *parentUUID == tenantUUID {
+			return model.Tenant{}, ErrHierarchyCycle
+		}
+		if err := s.ensureParentExists(ctx, *parentUUID); err != nil {
+			return model.Tenant{}, err
+		}
+		cycle, err := s.Repository.InSubtree(ctx, tenantUUID, *parentUUID)
+		if err != nil {
+			return model.Tenant{}, err
+		}
+		if cycle {
+			return model.Tenant{}, ErrHierarchyCycle
+		}
+	}
+
+	if err := s.Repository.SetParent(ctx, tenantUUID, parentUUID); err != nil {
+		return model.Tenant{}, err
+	}
+	return s.Repository.Read(ctx, model.Tenant{UUID: tenantUUID})
+}
+
+func (s *implService) InSubtree(ctx context.Context, rootUUID, tenantUUID uuid.UUID) (bool, error) {
+	return s.Repository.InSubtree(ctx, rootUUID, tenantUUID)
+}
+
+func (s *implService) ensureParentExists(ctx context.Context, parentUUID uuid.UUID) error {
+	if _, err := s.Repository.Read(ctx, model.Tenant{UUID: parentUUID}); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return ErrParentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *implService) InitialStatus(now time.Time) (model.TenantStatus, *time.Time) {
+	if s.cfg.TrialPeriod <= 0 {
+		return model.TenantStatusActive, nil
+	}
+	ends := now.Add(s.cfg.TrialPeriod)
+	return model.TenantStatusTrial, &ends
+}
+
+func (s *implService) Transition(ctx context.Context, tenantUUID uuid.UUID, to model.TenantStatus, reason string, changedBy *uuid.UUID) (model.Tenant, error) {
+	if tenantUUID == uuid.Nil || !ValidStatus(to) {
+		return model.Tenant{}, ErrInvalidInput
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return model.Tenant{}, ErrReasonRequired
+	}
+
+	current, err := s.Repository.Read(ctx, model.Tenant{UUID: tenantUUID})
+	if err != nil {
+		return model.Tenant{}, err
+	}
+	if !CanTransition(current.Status, to) {
+		return model.Tenant{}, fmt.Errorf("%w: %s → %s", ErrInvalidTransition, current.Status, to)
+	}
+
+	err = s.Repository.Transition(ctx, current.Status, model.TenantStatusChange{
+		TenantUUID: tenantUUID,
+		ToStatus:   to,
+		Reason:     reason,
+		ChangedBy:  changedBy,
+		CreateAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return model.Tenant{}, err
+	}
+	return s.Repository.Read(ctx, model.Tenant{UUID: tenantUUID})
+}
+
+func (s *implService) StatusHistory(ctx context.Context, tenantUUID uuid.UUID) ([]model.TenantStatusChange, error) {
+	if tenantUUID == uuid.Nil {
+		return nil, ErrInvalidInput
+	}
+	return s.Repository.History(ctx, tenantUUID)
+}
+
+// ExpireTrials encerra as avaliações vencidas. Cada tenant é alterado em sua própria transação;
+// uma falha não impede os demais.
+func (s *implService) ExpireTrials(ctx context.Context) error {
+	expired, err := s.Repository.ListExpiredTrials(ctx, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("falha ao buscar avaliações vencidas: %w", err)
+	}
+
+	var failed int
+	for _, t := range expired {
+		_, err := s.Transition(ctx, t.UUID, s.cfg.TrialExpiredStatus, "Período de avaliação encerrado", nil)
+		if err != nil {
+			log.Printf("[TENANT-TRIAL] Falha ao encerrar avaliação do tenant %s: %v", t.UUID, err)
+			failed++
+			continue
+		}
+		log.Printf("[TENANT-TRIAL] Avaliação do tenant %s (%s) encerrada: status %s.", t.UUID, t.Name, s.cfg.TrialExpiredStatus)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d de %d avaliações não puderam ser encerradas", failed, len(expired))
+	}
+	return nil
+}
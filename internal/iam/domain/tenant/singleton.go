@@ -3,6 +3,9 @@ package tenant
 import (
 	"errors"
 	"sync"
+	"time"
+
+	"tenant-crud-simply/internal/iam/domain/model"
 
 	"gorm.io/gorm"
 )
@@ -23,17 +26,44 @@ type UseTenant struct {
 	Controller Controller
 }
 
+// DefaultGracePeriod é o período de carência padrão para restaurar um tenant excluído.
+const DefaultGracePeriod = 30 * 24 * time.Hour
+
+// Config usada somente no New()
+type Config struct {
+	// GracePeriod é o tempo em que um tenant excluído ainda pode ser restaurado antes do expurgo.
+	GracePeriod time.Duration
+	// AnonymizeLogs mantém os logs de acesso/auditoria do tenant expurgado, removendo os dados pessoais.
+	// Quando falso, os logs são apagados junto com o tenant.
+	AnonymizeLogs bool
+	// TrialPeriod é a duração da avaliação dos novos tenants. Zero cria os tenants já ativos.
+	TrialPeriod time.Duration
+	// TrialExpiredStatus é o status aplicado quando a avaliação termina (padrão: past_due).
+	TrialExpiredStatus model.TenantStatus
+	// SchemaIsolation permite criar tenants com isolamento por schema (tenant.isolation.schema_enabled).
+	SchemaIsolation bool
+	// DatabaseIsolation permite criar tenants com banco de dados dedicado (tenant.isolation.database_enabled).
+	DatabaseIsolation bool
+}
+
 // New inicializa o singleton do controller de tenant com todas as suas dependências
-func New(db *gorm.DB) (Controller, error) {
+func New(db *gorm.DB, cfg Config) (Controller, error) {
 	once.Do(func() {
 		if db == nil {
 			initErr = errors.New("database connection cannot be nil")
 			return
 		}
 
+		if cfg.GracePeriod <= 0 {
+			cfg.GracePeriod = DefaultGracePeriod
+		}
+		if !CanTransition(model.TenantStatusTrial, cfg.TrialExpiredStatus) {
+			cfg.TrialExpiredStatus = model.TenantStatusPastDue
+		}
+
 		// Inicializa as dependências em camadas
 		repositoryInstance = NewRepository(db)
-		serviceInstance = NewService(repositoryInstance)
+		serviceInstance = NewService(repositoryInstance, cfg)
 		controllerInstance = NewController(serviceInstance)
 	})
 
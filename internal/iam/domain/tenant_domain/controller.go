@@ -0,0 +1,389 @@
+package tenant_domain
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/log/auditoria_log"
+	"tenant-crud-simply/internal/pkg/rest_err"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type Controller interface {
+	Routes(routes gin.IRouter)
+	List(c *gin.Context)
+	AddCustom(c *gin.Context)
+	SetSubdomain(c *gin.Context)
+	Verify(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type controllerImpl struct {
+	Service Service
+	mw      middleware.Middleware
+}
+
+func NewController(service Service) Controller {
+	mw := middleware.MustUse().Middleware
+	return &controllerImpl{
+		Service: service,
+		mw:      mw,
+	}
+}
+
+func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
+	var (
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
+	)
+
+	if login != nil {
+		tenantUUID = login.User.TenantUUID
+		if login.User.UUID != uuid.Nil {
+			userUUID = &login.User.UUID
+		}
+		identifier = login.User.Email
+		rayTrace = login.Metadata.RayTraceCode
+
+		if login.User.Role == model.RolePartnerAdmin {
+			if target, ok := middleware.GetTargetTenant(c); ok {
+				actingTenantUUID = &target
+			}
+		}
+	}
+
+	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "tenant_domain",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
+	})
+}
+
+func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
+	domainGroup := routes.Group("/domain")
+
+	{
+		domainGroup.GET("/list", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.List)
+		domainGroup.POST("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.AddCustom)
+		domainGroup.PUT("/subdomain", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.SetSubdomain)
+		domainGroup.POST("/:uuid/verify", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Verify)
+		domainGroup.DELETE("/:uuid", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Delete)
+	}
+}
+
+// resolveTenant determina o tenant cujos domínios serão lidos/alterados.
+// SYSTEM_ADMIN precisa informar 'tenant_identifier'; PARTNER_ADMIN pode informar um tenant
+// da sua hierarquia (padrão: o próprio); TENANT_ADMIN usa sempre o próprio tenant.
+func (ctrl *controllerImpl) resolveTenant(c *gin.Context, login *middleware.Login) (uuid.UUID, *rest_err.RestErr) {
+	var req TenantScopeRequestDto
+	_ = c.ShouldBindQuery(&req)
+
+	switch login.User.Role {
+	case model.RoleSystemAdmin:
+		if req.TenantIdentifier == "" {
+			return uuid.Nil, rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, "É necessário informar o 'tenant_identifier' do tenant.")
+		}
+		return ctrl.findTenant(c, login, req.TenantIdentifier)
+
+	case model.RolePartnerAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		if req.TenantIdentifier == "" {
+			return *login.User.TenantUUID, nil
+		}
+		target, restError := ctrl.findTenant(c, login, req.TenantIdentifier)
+		if restError != nil {
+			return uuid.Nil, restError
+		}
+		inSubtree, err := tenant.MustUse().Service.InSubtree(c.Request.Context(), *login.User.TenantUUID, target)
+		if err != nil {
+			return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "Falha ao verificar hierarquia de tenants", nil)
+		}
+		if !inSubtree {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Tenant fora da sua hierarquia.")
+		}
+		middleware.SetTargetTenant(c, target)
+		return target, nil
+
+	case model.RoleTenantAdmin:
+		if login.User.TenantUUID == nil || *login.User.TenantUUID == uuid.Nil {
+			return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Usuário não associado a um tenant.")
+		}
+		return *login.User.TenantUUID, nil
+
+	default:
+		return uuid.Nil, rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Ação não permitida.")
+	}
+}
+
+func (ctrl *controllerImpl) findTenant(c *gin.Context, login *middleware.Login, identifier string) (uuid.UUID, *rest_err.RestErr) {
+	t := tenant.Tenant{}
+	if err := uuid.Validate(identifier); err == nil {
+		t.UUID = uuid.MustParse(identifier)
+	} else {
+		t.Document = identifier
+	}
+	found, err := tenant.MustUse().Service.Read(c.Request.Context(), t)
+	if err != nil {
+		if errors.Is(err, tenant.ErrNotFound) {
+			return uuid.Nil, rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, "tenant not found")
+		}
+		return uuid.Nil, rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+	return found.UUID, nil
+}
+
+func (ctrl *controllerImpl) restError(login *middleware.Login, err error) *rest_err.RestErr {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return rest_err.NewNotFoundError(&login.Metadata.RayTraceCode, err.Error())
+	case errors.Is(err, ErrHostnameDuplicated), errors.Is(err, ErrAlreadyVerified), errors.Is(err, ErrVerificationFailed):
+		return rest_err.NewConflictValidationError(&login.Metadata.RayTraceCode, err.Error(), nil)
+	case errors.As(err, new(*net.DNSError)):
+		return rest_err.NewExternalProviderError(&login.Metadata.RayTraceCode, "Falha ao consultar o DNS do domínio.", nil)
+	case errors.Is(err, ErrInvalidHostname), errors.Is(err, ErrReservedSubdomain), errors.Is(err, ErrInvalidInput):
+		return rest_err.NewBadRequestError(&login.Metadata.RayTraceCode, err.Error())
+	default:
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
+	}
+}
+
+// @Summary      Lista os domínios do Tenant
+// @Description  Lista o subdomínio e os domínios próprios do tenant. Domínios próprios pendentes trazem o registro TXT que deve ser publicado para a verificação.
+// @Tags         Domain
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)"
+// @Success      200  {object}  DomainsResponseDto
+// @Failure      403  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr "Tenant não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/domain/list [get]
+func (ctrl *controllerImpl) List(c *gin.Context) {
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	domains, err := ctrl.Service.List(c.Request.Context(), tenantUUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	resp := DomainsResponseDto{Domains: make([]DomainResponseDto, 0, len(domains))}
+	for _, d := range domains {
+		resp.Domains = append(resp.Domains, toDomainResponse(d, ctrl.Service.BaseDomain()))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary      Cadastra um domínio próprio
+// @Description  Cadastra um domínio próprio para o tenant. O domínio só passa a resolver o tenant após a verificação: publique o registro TXT retornado em 'verification' e chame /api/domain/{uuid}/verify.
+// @Tags         Domain
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)"
+// @Param        request body AddCustomDomainRequestDto true "Domínio"
+// @Success      201  {object}  DomainResponseDto
+// @Failure      400  {object}  rest_err.RestErr "Domínio inválido."
+// @Failure      409  {object}  rest_err.RestErr "Domínio já cadastrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/domain [post]
+func (ctrl *controllerImpl) AddCustom(c *gin.Context) {
+	var req AddCustomDomainRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	created, err := ctrl.Service.AddCustom(c.Request.Context(), tenantUUID, req.Hostname)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "create", "AddCustom", false, req, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toDomainResponse(created, ctrl.Service.BaseDomain())
+	ctrl.logAudit(c, ctxIdentify, "create", "AddCustom", true, req, response)
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Summary      Define o subdomínio do Tenant
+// @Description  Define ou troca o subdomínio do tenant sob o domínio base da plataforma (ex.: 'acme' -> acme.<base_domain>). Não exige verificação.
+// @Tags         Domain
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)"
+// @Param        request body SetSubdomainRequestDto true "Subdomínio"
+// @Success      200  {object}  DomainResponseDto
+// @Failure      400  {object}  rest_err.RestErr "Subdomínio inválido ou reservado."
+// @Failure      409  {object}  rest_err.RestErr "Subdomínio em uso por outro tenant."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/domain/subdomain [put]
+func (ctrl *controllerImpl) SetSubdomain(c *gin.Context) {
+	var req SetSubdomainRequestDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		restError := rest_err.NewBadRequestError(nil, "invalid json body")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	domain, err := ctrl.Service.SetSubdomain(c.Request.Context(), tenantUUID, req.Subdomain)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "update", "SetSubdomain", false, req, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toDomainResponse(domain, ctrl.Service.BaseDomain())
+	ctrl.logAudit(c, ctxIdentify, "update", "SetSubdomain", true, req, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Verifica um domínio próprio
+// @Description  Consulta o registro TXT '_tenant-verification.<domínio>' e, se o valor conferir com o token do domínio, marca-o como verificado. A partir daí o domínio resolve o tenant.
+// @Tags         Domain
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do domínio"
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)"
+// @Success      200  {object}  DomainResponseDto
+// @Failure      404  {object}  rest_err.RestErr "Domínio não encontrado."
+// @Failure      409  {object}  rest_err.RestErr "Domínio já verificado ou registro TXT não encontrado."
+// @Failure      502  {object}  rest_err.RestErr "Falha ao consultar o DNS."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/domain/{uuid}/verify [post]
+func (ctrl *controllerImpl) Verify(c *gin.Context) {
+	domainUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido na URL não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	domain, err := ctrl.Service.Verify(c.Request.Context(), tenantUUID, domainUUID)
+	if err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "verify", "Verify", false, gin.H{"uuid": domainUUID}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	response := toDomainResponse(domain, ctrl.Service.BaseDomain())
+	ctrl.logAudit(c, ctxIdentify, "verify", "Verify", true, gin.H{"uuid": domainUUID}, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Remove um domínio do Tenant
+// @Description  Remove o subdomínio ou domínio próprio. O host deixa de resolver o tenant imediatamente.
+// @Tags         Domain
+// @Produce      json
+// @Security     BearerAuth
+// @Param        uuid path string true "UUID do domínio"
+// @Param        tenant_identifier query string false "UUID ou Documento do tenant (obrigatório para SystemAdmin, opcional para PartnerAdmin)"
+// @Success      204  "Domínio removido"
+// @Failure      404  {object}  rest_err.RestErr "Domínio não encontrado."
+// @Failure      500  {object}  rest_err.RestErr
+// @Router       /api/domain/{uuid} [delete]
+func (ctrl *controllerImpl) Delete(c *gin.Context) {
+	domainUUID, err := uuid.Parse(c.Param("uuid"))
+	if err != nil {
+		restError := rest_err.NewBadRequestError(nil, "O UUID fornecido na URL não é um formato válido.")
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
+	if !ok {
+		e := rest_err.NewForbiddenError(nil, "Usuário não autenticado.")
+		c.AbortWithStatusJSON(e.Code, e)
+		return
+	}
+
+	tenantUUID, restError := ctrl.resolveTenant(c, ctxIdentify)
+	if restError != nil {
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	if err := ctrl.Service.Delete(c.Request.Context(), tenantUUID, domainUUID); err != nil {
+		restError := ctrl.restError(ctxIdentify, err)
+		ctrl.logAudit(c, ctxIdentify, "delete", "Delete", false, gin.H{"uuid": domainUUID}, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	ctrl.logAudit(c, ctxIdentify, "delete", "Delete", true, gin.H{"uuid": domainUUID}, nil)
+	c.Status(http.StatusNoContent)
+}
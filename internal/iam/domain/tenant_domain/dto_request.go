@@ -0,0 +1,13 @@
+package tenant_domain
+
+type TenantScopeRequestDto struct {
+	TenantIdentifier string `form:"tenant_identifier"`
+}
+
+type AddCustomDomainRequestDto struct {
+	Hostname string `json:"hostname" binding:"required"`
+}
+
+type SetSubdomainRequestDto struct {
+	Subdomain string `json:"subdomain" binding:"required"`
+}
@@ -0,0 +1,54 @@
+package tenant_domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type VerificationResponseDto struct {
+	RecordType  string `json:"record_type"`
+	RecordName  string `json:"record_name"`
+	RecordValue string `json:"record_value"`
+}
+
+type DomainResponseDto struct {
+	UUID         uuid.UUID                `json:"uuid"`
+	TenantUUID   uuid.UUID                `json:"tenant_uuid"`
+	Kind         DomainKind               `json:"kind"`
+	Host         string                   `json:"host"`
+	Verified     bool                     `json:"verified"`
+	VerifiedAt   *time.Time               `json:"verified_at,omitempty"`
+	Verification *VerificationResponseDto `json:"verification,omitempty"`
+	CreateAt     time.Time                `json:"create_at"`
+}
+
+type DomainsResponseDto struct {
+	Domains []DomainResponseDto `json:"domains"`
+}
+
+// toDomainResponse monta a resposta com o host completo e, para domínios próprios pendentes,
+// as instruções do registro TXT de verificação.
+func toDomainResponse(d TenantDomain, baseDomain string) DomainResponseDto {
+	resp := DomainResponseDto{
+		UUID:       d.UUID,
+		TenantUUID: d.TenantUUID,
+		Kind:       d.Kind,
+		Host:       d.Hostname,
+		Verified:   d.VerifiedAt != nil,
+		VerifiedAt: d.VerifiedAt,
+		CreateAt:   d.CreateAt,
+	}
+	if d.Kind == KindSubdomain && baseDomain != "" {
+		resp.Host = d.Hostname + "." + baseDomain
+	}
+	if d.Kind == KindCustom && d.VerifiedAt == nil {
+		name, value := VerificationRecord(d)
+		resp.Verification = &VerificationResponseDto{
+			RecordType:  "TXT",
+			RecordName:  name,
+			RecordValue: value,
+		}
+	}
+	return resp
+}
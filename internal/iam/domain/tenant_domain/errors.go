@@ -0,0 +1,13 @@
+package tenant_domain
+
+import "errors"
+
+var (
+	ErrNotFound           = errors.New("domain not found")
+	ErrInvalidInput       = errors.New("invalid input data")
+	ErrInvalidHostname    = errors.New("invalid hostname")
+	ErrReservedSubdomain  = errors.New("subdomain is reserved")
+	ErrHostnameDuplicated = errors.New("hostname already in use")
+	ErrAlreadyVerified    = errors.New("domain already verified")
+	ErrVerificationFailed = errors.New("verification record not found")
+)
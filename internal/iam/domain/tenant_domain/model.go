@@ -0,0 +1,24 @@
+package tenant_domain
+
+import "tenant-crud-simply/internal/iam/domain/model"
+
+type TenantDomain = model.TenantDomain
+type DomainKind = model.DomainKind
+
+const (
+	KindSubdomain = model.DomainKindSubdomain
+	KindCustom    = model.DomainKindCustom
+)
+
+// Registro TXT esperado para comprovar a posse de um domínio próprio:
+//
+//	_tenant-verification.<hostname>  TXT  "tenant-verification=<token>"
+const (
+	VerificationRecordPrefix = "_tenant-verification"
+	VerificationValuePrefix  = "tenant-verification="
+)
+
+// VerificationRecord retorna o nome e o valor do registro TXT que o tenant deve publicar.
+func VerificationRecord(d TenantDomain) (name, value string) {
+	return VerificationRecordPrefix + "." + d.Hostname, VerificationValuePrefix + d.VerificationToken
+}
@@ -0,0 +1,122 @@
+package tenant_domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	Create(ctx context.Context, domain TenantDomain) (TenantDomain, error)
+	Read(ctx context.Context, tenantUUID, domainUUID uuid.UUID) (TenantDomain, error)
+	List(ctx context.Context, tenantUUID uuid.UUID) ([]TenantDomain, error)
+	Delete(ctx context.Context, tenantUUID, domainUUID uuid.UUID) error
+	// ReplaceSubdomain substitui o subdomínio atual do tenant (se houver) pelo informado.
+	ReplaceSubdomain(ctx context.Context, domain TenantDomain) (TenantDomain, error)
+	MarkVerified(ctx context.Context, domainUUID uuid.UUID, verifiedAt time.Time) error
+}
+
+type repositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repositoryImpl{db: db}
+}
+
+func createError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return ErrHostnameDuplicated
+		case "23503":
+			return ErrInvalidInput
+		}
+	}
+	return err
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, domain TenantDomain) (TenantDomain, error) {
+	if err := r.db.WithContext(ctx).Create(&domain).Error; err != nil {
+		return TenantDomain{}, createError(err)
+	}
+	return domain, nil
+}
+
+func (r *repositoryImpl) Read(ctx context.Context, tenantUUID, domainUUID uuid.UUID) (TenantDomain, error) {
+	if tenantUUID == uuid.Nil || domainUUID == uuid.Nil {
+		return TenantDomain{}, ErrInvalidInput
+	}
+
+	var domain TenantDomain
+	result := r.db.WithContext(ctx).
+		Where("uuid = ? AND tenant_uuid = ?", domainUUID, tenantUUID).
+		First(&domain)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return TenantDomain{}, ErrNotFound
+		}
+		return TenantDomain{}, fmt.Errorf("erro ao ler domínio: %w", result.Error)
+	}
+	return domain, nil
+}
+
+func (r *repositoryImpl) List(ctx context.Context, tenantUUID uuid.UUID) ([]TenantDomain, error) {
+	var domains []TenantDomain
+	result := r.db.WithContext(ctx).
+		Where("tenant_uuid = ?", tenantUUID).
+		Order("kind DESC, hostname ASC").
+		Find(&domains)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return domains, nil
+}
+
+func (r *repositoryImpl) Delete(ctx context.Context, tenantUUID, domainUUID uuid.UUID) error {
+	result := r.db.WithContext(ctx).
+		Where("uuid = ? AND tenant_uuid = ?", domainUUID, tenantUUID).
+		Delete(&TenantDomain{})
+	if result.Error != nil {
+		return fmt.Errorf("falha ao deletar domínio: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *repositoryImpl) ReplaceSubdomain(ctx context.Context, domain TenantDomain) (TenantDomain, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("tenant_uuid = ? AND kind = ?", domain.TenantUUID, KindSubdomain).
+			Delete(&TenantDomain{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&domain).Error
+	})
+	if err != nil {
+		return TenantDomain{}, createError(err)
+	}
+	return domain, nil
+}
+
+func (r *repositoryImpl) MarkVerified(ctx context.Context, domainUUID uuid.UUID, verifiedAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&TenantDomain{}).
+		Where("uuid = ?", domainUUID).
+		Update("verified_at", verifiedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
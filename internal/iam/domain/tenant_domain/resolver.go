@@ -0,0 +1,24 @@
+package tenant_domain
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// TXTResolver consulta registros DNS TXT. *net.Resolver satisfaz a interface;
+// em ambiente local use StaticResolver.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// StaticResolver responde consultas TXT a partir de um mapa nome -> registros,
+// permitindo testar a verificação sem DNS real.
+type StaticResolver map[string][]string
+
+func (r StaticResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if records, ok := r[strings.ToLower(strings.TrimSuffix(name, "."))]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
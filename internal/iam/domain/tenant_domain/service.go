@@ -0,0 +1,170 @@
+package tenant_domain
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"tenant-crud-simply/internal/iam/middleware"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	List(ctx context.Context, tenantUUID uuid.UUID) ([]TenantDomain, error)
+	// AddCustom cadastra um domínio próprio pendente de verificação.
+	AddCustom(ctx context.Context, tenantUUID uuid.UUID, hostname string) (TenantDomain, error)
+	// SetSubdomain define (ou troca) o subdomínio do tenant sob o domínio base da plataforma.
+	SetSubdomain(ctx context.Context, tenantUUID uuid.UUID, label string) (TenantDomain, error)
+	// Verify consulta o registro TXT do domínio e, se o token conferir, marca o domínio como verificado.
+	Verify(ctx context.Context, tenantUUID, domainUUID uuid.UUID) (TenantDomain, error)
+	Delete(ctx context.Context, tenantUUID, domainUUID uuid.UUID) error
+	BaseDomain() string
+}
+
+type serviceImpl struct {
+	Repository Repository
+	cfg        Config
+}
+
+func NewService(repository Repository, cfg Config) Service {
+	return &serviceImpl{
+		Repository: repository,
+		cfg:        cfg,
+	}
+}
+
+var (
+	labelRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+	// Subdomínios reservados para a própria plataforma
+	reservedSubdomains = map[string]bool{
+		"www": true, "api": true, "app": true, "admin": true, "mail": true, "static": true, "status": true,
+	}
+)
+
+func (s *serviceImpl) BaseDomain() string {
+	return s.cfg.BaseDomain
+}
+
+func (s *serviceImpl) List(ctx context.Context, tenantUUID uuid.UUID) ([]TenantDomain, error) {
+	if tenantUUID == uuid.Nil {
+		return nil, ErrInvalidInput
+	}
+	return s.Repository.List(ctx, tenantUUID)
+}
+
+func (s *serviceImpl) AddCustom(ctx context.Context, tenantUUID uuid.UUID, hostname string) (TenantDomain, error) {
+	if tenantUUID == uuid.Nil {
+		return TenantDomain{}, ErrInvalidInput
+	}
+	hostname = middleware.NormalizeHost(hostname)
+	if !validHostname(hostname) {
+		return TenantDomain{}, ErrInvalidHostname
+	}
+	// Hosts sob o domínio base são da plataforma; tenants usam SetSubdomain
+	if s.cfg.BaseDomain != "" && (hostname == s.cfg.BaseDomain || strings.HasSuffix(hostname, "."+s.cfg.BaseDomain)) {
+		return TenantDomain{}, ErrInvalidHostname
+	}
+
+	token, err := newVerificationToken()
+	if err != nil {
+		return TenantDomain{}, err
+	}
+	return s.Repository.Create(ctx, TenantDomain{
+		TenantUUID:        tenantUUID,
+		Kind:              KindCustom,
+		Hostname:          hostname,
+		VerificationToken: token,
+		CreateAt:          time.Now().UTC(),
+	})
+}
+
+func (s *serviceImpl) SetSubdomain(ctx context.Context, tenantUUID uuid.UUID, label string) (TenantDomain, error) {
+	if tenantUUID == uuid.Nil {
+		return TenantDomain{}, ErrInvalidInput
+	}
+	label = strings.ToLower(strings.TrimSpace(label))
+	if !labelRegex.MatchString(label) {
+		return TenantDomain{}, ErrInvalidHostname
+	}
+	if reservedSubdomains[label] {
+		return TenantDomain{}, ErrReservedSubdomain
+	}
+
+	token, err := newVerificationToken()
+	if err != nil {
+		return TenantDomain{}, err
+	}
+	// O domínio base pertence à plataforma: o subdomínio já nasce verificado
+	now := time.Now().UTC()
+	return s.Repository.ReplaceSubdomain(ctx, TenantDomain{
+		TenantUUID:        tenantUUID,
+		Kind:              KindSubdomain,
+		Hostname:          label,
+		VerificationToken: token,
+		VerifiedAt:        &now,
+		CreateAt:          now,
+	})
+}
+
+func (s *serviceImpl) Verify(ctx context.Context, tenantUUID, domainUUID uuid.UUID) (TenantDomain, error) {
+	domain, err := s.Repository.Read(ctx, tenantUUID, domainUUID)
+	if err != nil {
+		return TenantDomain{}, err
+	}
+	if domain.VerifiedAt != nil {
+		return domain, ErrAlreadyVerified
+	}
+
+	name, expected := VerificationRecord(domain)
+	records, err := s.cfg.Resolver.LookupTXT(ctx, name)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return domain, ErrVerificationFailed
+		}
+		return domain, err
+	}
+
+	for _, record := range records {
+		if strings.TrimSpace(record) == expected {
+			now := time.Now().UTC()
+			if err := s.Repository.MarkVerified(ctx, domain.UUID, now); err != nil {
+				return domain, err
+			}
+			domain.VerifiedAt = &now
+			return domain, nil
+		}
+	}
+	return domain, ErrVerificationFailed
+}
+
+func (s *serviceImpl) Delete(ctx context.Context, tenantUUID, domainUUID uuid.UUID) error {
+	return s.Repository.Delete(ctx, tenantUUID, domainUUID)
+}
+
+func validHostname(hostname string) bool {
+	if len(hostname) > 253 || !strings.Contains(hostname, ".") {
+		return false
+	}
+	for _, label := range strings.Split(hostname, ".") {
+		if !labelRegex.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+func newVerificationToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,78 @@
+package tenant_domain
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"tenant-crud-simply/internal/iam/middleware"
+
+	"gorm.io/gorm"
+)
+
+var (
+	controllerInstance Controller
+	serviceInstance    Service
+	repositoryInstance Repository
+	once               sync.Once
+	initErr            error
+	ErrNotInitialized  = errors.New("tenant domain controller not initialized")
+)
+
+// UseTenantDomain agrupa todas as camadas (Repository, Service, Controller)
+type UseTenantDomain struct {
+	Repository Repository
+	Service    Service
+	Controller Controller
+}
+
+// Config usada somente no New()
+type Config struct {
+	// BaseDomain é o domínio da plataforma sob o qual ficam os subdomínios dos tenants.
+	BaseDomain string
+	// Resolver consulta os registros TXT de verificação. Padrão: DNS do sistema (net.DefaultResolver).
+	Resolver TXTResolver
+}
+
+// New inicializa o singleton do controller de domínios de tenant com todas as suas dependências
+func New(db *gorm.DB, cfg Config) (Controller, error) {
+	once.Do(func() {
+		if db == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+
+		if cfg.Resolver == nil {
+			cfg.Resolver = net.DefaultResolver
+		}
+		cfg.BaseDomain = middleware.NormalizeHost(cfg.BaseDomain)
+
+		// Inicializa as dependências em camadas
+		repositoryInstance = NewRepository(db)
+		serviceInstance = NewService(repositoryInstance, cfg)
+		controllerInstance = NewController(serviceInstance)
+	})
+
+	return controllerInstance, initErr
+}
+
+// Use retorna a instância singleton do controller
+// Retorna erro se o controller não foi inicializado
+func Use() (Controller, error) {
+	if controllerInstance == nil {
+		return nil, ErrNotInitialized
+	}
+	return controllerInstance, nil
+}
+
+// MustUse retorna todas as camadas (Repository, Service, Controller)
+// Entra em pânico se o singleton não foi inicializado
+func MustUse() *UseTenantDomain {
+	if controllerInstance == nil || serviceInstance == nil || repositoryInstance == nil {
+		panic(ErrNotInitialized)
+	}
+	return &UseTenantDomain{
+		Repository: repositoryInstance,
+		Service:    serviceInstance,
+		Controller: controllerInstance,
+	}
+}
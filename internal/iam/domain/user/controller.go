@@ -1,15 +1,22 @@
 package user
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"tenant-crud-simply/internal/iam/domain/custom_field"
 	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/plan"
 	"tenant-crud-simply/internal/iam/domain/tenant"
 	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/pkg/listing"
 	"tenant-crud-simply/internal/pkg/log/auditoria_log"
 	"tenant-crud-simply/internal/pkg/rest_err"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -39,10 +46,11 @@ func NewController(service Service) Controller {
 
 func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, action, function string, success bool, input, output interface{}) {
 	var (
-		tenantUUID *uuid.UUID
-		userUUID   *uuid.UUID
-		identifier string
-		rayTrace   string
+		tenantUUID       *uuid.UUID
+		actingTenantUUID *uuid.UUID
+		userUUID         *uuid.UUID
+		identifier       string
+		rayTrace         string
 	)
 
 	if login != nil {
@@ -52,19 +60,28 @@ func (ctrl *controllerImpl) logAudit(c *gin.Context, login *middleware.Login, ac
 		}
 		identifier = login.User.Email
 		rayTrace = login.Metadata.RayTraceCode
+
+		// PARTNER_ADMIN atuando em um tenant descendente: o log pertence ao tenant alvo
+		// e registra o ancestral que executou a ação.
+		if target, ok := middleware.GetTargetTenant(c); ok && login.User.Role == model.RolePartnerAdmin &&
+			(tenantUUID == nil || *tenantUUID != target) {
+			actingTenantUUID = tenantUUID
+			tenantUUID = &target
+		}
 	}
 
 	auditoria_log.LogAsync(c.Request.Context(), auditoria_log.AuditLog{
-		TenantUUID:   tenantUUID,
-		UserUUID:     userUUID,
-		Identifier:   identifier,
-		RayTraceCode: rayTrace,
-		Domain:       "user",
-		Action:       action,
-		Function:     function,
-		Success:      success,
-		InputData:    auditoria_log.SerializeData(input),
-		OutputData:   auditoria_log.SerializeData(output),
+		TenantUUID:       tenantUUID,
+		ActingTenantUUID: actingTenantUUID,
+		UserUUID:         userUUID,
+		Identifier:       identifier,
+		RayTraceCode:     rayTrace,
+		Domain:           "user",
+		Action:           action,
+		Function:         function,
+		Success:          success,
+		InputData:        auditoria_log.SerializeData(input),
+		OutputData:       auditoria_log.SerializeData(output),
 	})
 }
 
@@ -72,13 +89,29 @@ func (ctrl *controllerImpl) Routes(routes gin.IRouter) {
 	userGroup := routes.Group("/user")
 
 	{
-		userGroup.POST("/:identifier", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.Create)
-		userGroup.GET("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin, model.RoleTenantUser), ctrl.Read)
-		userGroup.GET("/:identifier", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin, model.RoleTenantUser), ctrl.Read)
-		userGroup.GET("/list", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.List)
-		userGroup.PATCH("/:identifier", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin, model.RoleTenantUser), ctrl.Update)
-		userGroup.DELETE("/:identifier", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RoleTenantAdmin), ctrl.Delete)
+		userGroup.POST("/:identifier", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Create)
+		userGroup.GET("", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin, model.RoleTenantUser), ctrl.Read)
+		userGroup.GET("/:identifier", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin, model.RoleTenantUser), ctrl.Read)
+		userGroup.GET("/list", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.List)
+		userGroup.PATCH("/:identifier", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin, model.RoleTenantUser), ctrl.Update)
+		userGroup.DELETE("/:identifier", ctrl.mw.SetContextAutorization(), ctrl.mw.AuthorizeRole(model.RoleSystemAdmin, model.RolePartnerAdmin, model.RoleTenantAdmin), ctrl.Delete)
+	}
+}
+
+// checkPartnerScope garante que o tenant alvo pertence à hierarquia do PARTNER_ADMIN autenticado.
+func (ctrl *controllerImpl) checkPartnerScope(c *gin.Context, login *middleware.Login, target *uuid.UUID) *rest_err.RestErr {
+	if login.User.TenantUUID == nil || target == nil {
+		return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Você não tem permissão para acessar usuários fora da sua hierarquia.")
+	}
+	inSubtree, err := tenant.MustUse().Service.InSubtree(c.Request.Context(), *login.User.TenantUUID, *target)
+	if err != nil {
+		return rest_err.NewInternalServerError(&login.Metadata.RayTraceCode, "internal server error", nil)
 	}
+	if !inSubtree {
+		return rest_err.NewForbiddenError(&login.Metadata.RayTraceCode, "Você não tem permissão para acessar usuários fora da sua hierarquia.")
+	}
+	middleware.SetTargetTenant(c, *target)
+	return nil
 }
 
 // @Summary      Cria um novo Usuário
@@ -146,6 +179,46 @@ func (ctrl *controllerImpl) Create(c *gin.Context) {
 			}
 		}
 
+	case RolePartnerAdmin:
+		t := tenant.Tenant{}
+		if err := uuid.Validate(tenantIdentifier); err == nil {
+			t.UUID = uuid.MustParse(tenantIdentifier)
+		} else {
+			t.Document = tenantIdentifier
+		}
+		targetTenant, err := tenant.MustUse().Service.Read(c.Request.Context(), t)
+		if err != nil {
+			var restError *rest_err.RestErr
+			if errors.Is(err, tenant.ErrNotFound) {
+				restError = rest_err.NewNotFoundError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
+			} else {
+				restError = rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "internal server error", nil)
+			}
+			c.JSON(restError.Code, restError)
+			return
+		}
+		if restError := ctrl.checkPartnerScope(c, ctxIdentify, &targetTenant.UUID); restError != nil {
+			c.AbortWithStatusJSON(restError.Code, restError)
+			return
+		}
+		newUser = User{
+			Tenant: tenant.Tenant{
+				UUID: targetTenant.UUID,
+			},
+			Name:     req.Name,
+			Email:    req.Email,
+			Password: req.Password,
+			Role:     req.Role,
+			Live:     true,
+		}
+		if newUser.Role == RoleSystemAdmin {
+			restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode,
+				fmt.Sprintf("invalid user role. Valid roles are: %s, %s, %s", RolePartnerAdmin, RoleTenantAdmin, RoleTenantUser),
+			)
+			c.JSON(restError.Code, restError)
+			return
+		}
+
 	case RoleTenantAdmin:
 		newUser = User{
 			Tenant: tenant.Tenant{
@@ -179,6 +252,7 @@ func (ctrl *controllerImpl) Create(c *gin.Context) {
 		return
 	}
 
+	newUser.Metadata = model.Metadata(req.Metadata)
 	userCreated, err := ctrl.Service.Create(c.Request.Context(), newUser)
 
 	if err != nil {
@@ -187,8 +261,13 @@ func (ctrl *controllerImpl) Create(c *gin.Context) {
 		case errors.Is(err, tenant.ErrNotFound):
 			restError = rest_err.NewNotFoundError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
 
+		case errors.Is(err, plan.ErrQuotaExceeded):
+			restError = plan.QuotaRestError(&ctxIdentify.Metadata.RayTraceCode, err)
+
 		case errors.Is(err, ErrEmailDuplicated):
 			restError = rest_err.NewConflictValidationError(&ctxIdentify.Metadata.RayTraceCode, err.Error(), nil)
+		case errors.Is(err, ErrEmailDomain), errors.Is(err, custom_field.ErrInvalidMetadata):
+			restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
 
 		case errors.Is(err, ErrInvalidInput):
 			restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
@@ -209,6 +288,7 @@ func (ctrl *controllerImpl) Create(c *gin.Context) {
 		Email:      userCreated.Email,
 		Role:       userCreated.Role,
 		Live:       userCreated.Live,
+		Metadata:   userCreated.Metadata,
 		CreateAt:   userCreated.CreateAt,
 		UpdateAt:   userCreated.UpdateAt,
 	}
@@ -272,6 +352,13 @@ func (ctrl *controllerImpl) Read(c *gin.Context) {
 	case model.RoleSystemAdmin:
 		// SystemAdmin vê tudo. Permissão concedida.
 
+	case model.RolePartnerAdmin:
+		// PartnerAdmin vê usuários do próprio tenant e dos descendentes.
+		if restError := ctrl.checkPartnerScope(c, ctxIdentify, userFound.TenantUUID); restError != nil {
+			c.AbortWithStatusJSON(restError.Code, restError)
+			return
+		}
+
 	case model.RoleTenantAdmin:
 		// TenantAdmin só vê usuários do MESMO tenant.
 		if userFound.Tenant.UUID.String() != ctxIdentify.User.Tenant.UUID.String() {
@@ -296,28 +383,51 @@ func (ctrl *controllerImpl) Read(c *gin.Context) {
 	}
 
 	response := UserResponseDto{
-		UUID:       userFound.UUID,
-		TenantUUID: userFound.TenantUUID,
-		Name:       userFound.Name,
-		Email:      userFound.Email,
-		Role:       userFound.Role,
-		Live:       userFound.Live,
-		CreateAt:   userFound.CreateAt,
-		UpdateAt:   userFound.UpdateAt,
+		UUID:        userFound.UUID,
+		TenantUUID:  userFound.TenantUUID,
+		Name:        userFound.Name,
+		Email:       userFound.Email,
+		Role:        userFound.Role,
+		Live:        userFound.Live,
+		Metadata:    userFound.Metadata,
+		CreateAt:    userFound.CreateAt,
+		UpdateAt:    userFound.UpdateAt,
+		LastLoginAt: userFound.LastLoginAt,
+		Phone:       userFound.Phone,
+		AvatarURL:   userFound.AvatarURL,
+		Locale:      userFound.Locale,
+		Timezone:    userFound.Timezone,
 	}
 	//ctrl.logAudit(c, ctxIdentify, "read", "Read", true, map[string]interface{}{"identifier": identificador}, response)
 	c.JSON(http.StatusOK, response)
 }
 
 // @Summary      Lista Usuários
-// @Description  Retorna uma lista paginada de usuários.
+// @Description  Retorna os usuários com filtros, ordenação e paginação por cursor. A resposta traz o total de registros e o cursor da próxima página, também informado no cabeçalho Link (rel="next"). Com "Accept: text/csv", todos os usuários filtrados são enviados em CSV (sem paginação), na ordenação pedida.
 // @Tags         User
 // @Produce      json
+// @Produce      text/csv
 // @Security     BearerAuth
-// @Param        page              query     int     false  "Número da página (padrão 1)"
-// @Param        size              query     int     false  "Tamanho da página (padrão 10)"
-// @Param        tenant_identifier query     string  false  "Filtro opcional: UUID ou Documento do Tenant (Apenas para SystemAdmin)"
-// @Success      200  {array}   UserResponseDto
+// @Param        tenant_identifier query     string  false  "Filtro opcional: UUID ou Documento do Tenant (SystemAdmin e PartnerAdmin)"
+// @Param        name              query     string  false  "Filtra pelo nome (contém, sem diferenciar maiúsculas)"
+// @Param        email             query     string  false  "Filtra pelo email (contém, sem diferenciar maiúsculas)"
+// @Param        email_domain      query     string  false  "Filtra pelo domínio do email, ex.: empresa.com.br"
+// @Param        role              query     string  false  "Filtra pela role (SYSTEM_ADMIN, PARTNER_ADMIN, TENANT_ADMIN, TENANT_USER)"
+// @Param        live              query     bool    false  "Filtra usuários ativos (true) ou inativos (false)"
+// @Param        created_from      query     string  false  "Criados a partir de (2006-01-02 ou RFC 3339, inclusivo)"
+// @Param        created_to        query     string  false  "Criados até (2006-01-02 inclui o dia inteiro)"
+// @Param        updated_from      query     string  false  "Atualizados a partir de (2006-01-02 ou RFC 3339, inclusivo)"
+// @Param        updated_to        query     string  false  "Atualizados até (2006-01-02 inclui o dia inteiro)"
+// @Param        last_login_from   query     string  false  "Último login a partir de (usuários sem login ficam de fora)"
+// @Param        last_login_to     query     string  false  "Último login até (usuários sem login ficam de fora)"
+// @Param        sort              query     string  false  "Campo de ordenação: name, email, create_at, update_at ou last_login_at. Prefixo '-' para ordem decrescente" default(name)
+// @Param        size              query     int     false  "Tamanho da página (padrão 10, máximo 100)"
+// @Param        cursor            query     string  false  "Cursor da próxima página (next_cursor da resposta anterior)"
+// @Param        metadata[chave]   query     string  false  "Filtra por campo personalizado (valor exato), ex.: metadata[cost_center]=CC-10"
+// @Success      200  {object}  UserListResponseDto
+// @Header       200  {string}  Link  "Links da primeira e da próxima página (RFC 8288)"
+// @Failure      400  {object}  rest_err.RestErr
+// @Failure      404  {object}  rest_err.RestErr
 // @Failure      500  {object}  rest_err.RestErr
 // @Router       /api/user/list [get]
 func (ctrl *controllerImpl) List(c *gin.Context) {
@@ -327,6 +437,7 @@ func (ctrl *controllerImpl) List(c *gin.Context) {
 		c.JSON(restError.Code, restError)
 		return
 	}
+	req.Metadata = c.QueryMap("metadata")
 
 	ctxIdentify, ok := middleware.GetAuthenticatedUser(c)
 	if !ok {
@@ -335,8 +446,15 @@ func (ctrl *controllerImpl) List(c *gin.Context) {
 		return
 	}
 
-	var users []User
-	var err error
+	opts, err := req.options()
+	if err != nil {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+
+	// target nil lista os usuários de todos os tenants (SYSTEM_ADMIN sem filtro)
+	var target *tenant.Tenant
 
 	switch ctxIdentify.User.Role {
 	case model.RoleSystemAdmin:
@@ -347,13 +465,30 @@ func (ctrl *controllerImpl) List(c *gin.Context) {
 			} else {
 				t.Document = req.TenantIdentifier
 			}
-			users, err = ctrl.Service.ListByTenant(c, t, req.Page, req.PageSize)
-		} else {
-			users, err = ctrl.Service.List(c, req.Page, req.PageSize)
+			target = &t
+		}
+
+	case model.RolePartnerAdmin:
+		t := ctxIdentify.User.Tenant
+		if req.TenantIdentifier != "" {
+			t = tenant.Tenant{}
+			if errUuid := uuid.Validate(req.TenantIdentifier); errUuid == nil {
+				t.UUID = uuid.MustParse(req.TenantIdentifier)
+			} else {
+				t.Document = req.TenantIdentifier
+			}
+			t, err = tenant.MustUse().Service.Read(c.Request.Context(), t)
+			if err == nil {
+				if restError := ctrl.checkPartnerScope(c, ctxIdentify, &t.UUID); restError != nil {
+					c.AbortWithStatusJSON(restError.Code, restError)
+					return
+				}
+			}
 		}
+		target = &t
 
 	case model.RoleTenantAdmin:
-		users, err = ctrl.Service.ListByTenant(c, ctxIdentify.User.Tenant, req.Page, req.PageSize)
+		target = &ctxIdentify.User.Tenant
 
 	default:
 		e := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Ação não permitida.")
@@ -361,40 +496,159 @@ func (ctrl *controllerImpl) List(c *gin.Context) {
 		return
 	}
 
-	if err != nil {
-		if errors.Is(err, tenant.ErrNotFound) {
-			restError := rest_err.NewNotFoundError(&ctxIdentify.Metadata.RayTraceCode, "tenant not found")
-			ctrl.logAudit(c, ctxIdentify, "list", "List", false, req, err.Error())
-			c.JSON(restError.Code, restError)
-			return
+	if err == nil && c.NegotiateFormat(gin.MIMEJSON, mimeCSV) == mimeCSV {
+		ctrl.exportCSV(c, ctxIdentify, req, target, opts)
+		return
+	}
+
+	var page listing.Page[User]
+	if err == nil {
+		if target != nil {
+			page, err = ctrl.Service.ListByTenant(c, *target, opts)
+		} else {
+			page, err = ctrl.Service.List(c, opts)
 		}
+	}
+	if err != nil {
+		ctrl.listError(c, ctxIdentify, req, err)
+		return
+	}
 
-		restError := rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "internal server error", nil)
-		ctrl.logAudit(c, ctxIdentify, "list", "List", false, req, err.Error())
+	response := UserListResponseDto{
+		Users:      make([]UserResponseDto, 0, len(page.Items)),
+		Total:      page.Total,
+		Size:       page.Size,
+		NextCursor: page.NextCursor,
+	}
+	for _, u := range page.Items {
+		response.Users = append(response.Users, UserResponseDto{
+			UUID:        u.UUID,
+			TenantUUID:  u.TenantUUID,
+			Name:        u.Name,
+			Email:       u.Email,
+			Role:        u.Role,
+			Live:        u.Live,
+			Metadata:    u.Metadata,
+			CreateAt:    u.CreateAt,
+			UpdateAt:    u.UpdateAt,
+			LastLoginAt: u.LastLoginAt,
+			Phone:       u.Phone,
+			AvatarURL:   u.AvatarURL,
+			Locale:      u.Locale,
+			Timezone:    u.Timezone,
+		})
+	}
+
+	c.Header("Link", listing.LinkHeader(c.Request.URL, page.NextCursor))
+	//ctrl.logAudit(c, ctxIdentify, "list", "List", true, req, response)
+	c.JSON(http.StatusOK, response)
+}
+
+// listError responde aos erros da listagem (JSON ou CSV, antes do envio da primeira linha).
+func (ctrl *controllerImpl) listError(c *gin.Context, ctxIdentify *middleware.Login, req ListUserRequestDto, err error) {
+	ctrl.logAudit(c, ctxIdentify, "list", "List", false, req, err.Error())
+	if errors.Is(err, listing.ErrInvalidOptions) {
+		restError := rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
+		c.JSON(restError.Code, restError)
+		return
+	}
+	if errors.Is(err, tenant.ErrNotFound) {
+		restError := rest_err.NewNotFoundError(&ctxIdentify.Metadata.RayTraceCode, "tenant not found")
 		c.JSON(restError.Code, restError)
 		return
 	}
 
-	var response []UserResponseDto
-	for _, u := range users {
-		response = append(response, UserResponseDto{
-			UUID:       u.UUID,
-			TenantUUID: u.TenantUUID,
-			Name:       u.Name,
-			Email:      u.Email,
-			Role:       u.Role,
-			Live:       u.Live,
-			CreateAt:   u.CreateAt,
-			UpdateAt:   u.UpdateAt,
-		})
+	restError := rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "internal server error", nil)
+	c.JSON(restError.Code, restError)
+}
+
+const (
+	mimeCSV = "text/csv"
+	// csvFlushEvery é a quantidade de linhas enviadas ao cliente de cada vez.
+	csvFlushEvery = 500
+)
+
+var csvHeader = []string{"uuid", "tenant_uuid", "name", "email", "role", "live", "metadata", "create_at", "update_at", "last_login_at"}
+
+// exportCSV envia todos os usuários filtrados em CSV, linha a linha. O cabeçalho HTTP só é escrito
+// com a primeira linha: erros anteriores (filtro inválido, tenant inexistente) ainda viram JSON.
+// Depois disso, um erro apenas interrompe o envio.
+func (ctrl *controllerImpl) exportCSV(c *gin.Context, ctxIdentify *middleware.Login, req ListUserRequestDto, target *tenant.Tenant, opts listing.Options) {
+	w := csv.NewWriter(c.Writer)
+	rows := 0
+	started := false
+	start := func() error {
+		started = true
+		c.Header("Content-Type", mimeCSV+"; charset=utf-8")
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="users-%s.csv"`, time.Now().UTC().Format("20060102-150405")))
+		c.Status(http.StatusOK)
+		return w.Write(csvHeader)
+	}
+
+	write := func(u User) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := w.Write(csvRecord(u)); err != nil {
+			return err
+		}
+		rows++
+		if rows%csvFlushEvery == 0 {
+			w.Flush()
+			c.Writer.Flush()
+		}
+		return w.Error()
 	}
 
-	if response == nil {
-		response = []UserResponseDto{}
+	var err error
+	if target != nil {
+		err = ctrl.Service.EachByTenant(c, *target, opts, write)
+	} else {
+		err = ctrl.Service.Each(c, opts, write)
+	}
+	if err != nil && !started {
+		ctrl.listError(c, ctxIdentify, req, err)
+		return
 	}
+	if err == nil && !started {
+		err = start()
+	}
+	w.Flush()
+	if err == nil {
+		err = w.Error()
+	}
+	if err != nil {
+		// A resposta já começou: não há como devolver um status de erro
+		ctrl.logAudit(c, ctxIdentify, "export", "List", false, req, err.Error())
+		_ = c.Error(err)
+		return
+	}
+	ctrl.logAudit(c, ctxIdentify, "export", "List", true, req, map[string]int{"rows": rows})
+}
 
-	//ctrl.logAudit(c, ctxIdentify, "list", "List", true, req, response)
-	c.JSON(http.StatusOK, response)
+func csvRecord(u User) []string {
+	tenantUUID, lastLogin := "", ""
+	if u.TenantUUID != nil {
+		tenantUUID = u.TenantUUID.String()
+	}
+	if u.LastLoginAt != nil {
+		lastLogin = u.LastLoginAt.UTC().Format(time.RFC3339)
+	}
+	metadata, _ := json.Marshal(u.Metadata)
+	return []string{
+		u.UUID.String(),
+		tenantUUID,
+		u.Name,
+		u.Email,
+		string(u.Role),
+		strconv.FormatBool(u.Live),
+		string(metadata),
+		u.CreateAt.UTC().Format(time.RFC3339),
+		u.UpdateAt.UTC().Format(time.RFC3339),
+		lastLogin,
+	}
 }
 
 // @Summary      Atualiza um Usuário
@@ -459,6 +713,18 @@ func (ctrl *controllerImpl) Update(c *gin.Context) {
 	case model.RoleSystemAdmin:
 		userToUpdate.Role = req.Role
 
+	case model.RolePartnerAdmin:
+		if restError := ctrl.checkPartnerScope(c, ctxIdentify, targetUser.TenantUUID); restError != nil {
+			c.AbortWithStatusJSON(restError.Code, restError)
+			return
+		}
+		if req.Role == model.RoleSystemAdmin {
+			e := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Partner Admin não pode atribuir permissão de System Admin.")
+			c.AbortWithStatusJSON(e.Code, e)
+			return
+		}
+		userToUpdate.Role = req.Role
+
 	case model.RoleTenantAdmin:
 		if targetUser.TenantUUID.String() != ctxIdentify.User.Tenant.UUID.String() {
 			e := rest_err.NewForbiddenError(&ctxIdentify.Metadata.RayTraceCode, "Você não tem permissão para alterar usuários de outro tenant.")
@@ -496,6 +762,7 @@ func (ctrl *controllerImpl) Update(c *gin.Context) {
 	userToUpdate.Name = req.Name
 	userToUpdate.Email = req.Email
 	userToUpdate.Password = req.Password
+	userToUpdate.Metadata = model.Metadata(req.Metadata)
 
 	if userToUpdate.Role != "" {
 		if !IsValidUserRole(userToUpdate.Role) {
@@ -516,6 +783,8 @@ func (ctrl *controllerImpl) Update(c *gin.Context) {
 			restError = rest_err.NewNotFoundError(&ctxIdentify.Metadata.RayTraceCode, "user not found")
 		case errors.Is(err, ErrEmailDuplicated):
 			restError = rest_err.NewConflictValidationError(&ctxIdentify.Metadata.RayTraceCode, err.Error(), nil)
+		case errors.Is(err, ErrEmailDomain), errors.Is(err, custom_field.ErrInvalidMetadata):
+			restError = rest_err.NewBadRequestError(&ctxIdentify.Metadata.RayTraceCode, err.Error())
 		default:
 			restError = rest_err.NewInternalServerError(&ctxIdentify.Metadata.RayTraceCode, "internal server error", nil)
 		}
@@ -525,14 +794,20 @@ func (ctrl *controllerImpl) Update(c *gin.Context) {
 	}
 
 	response := UserResponseDto{
-		UUID:       updatedUser.UUID,
-		TenantUUID: updatedUser.TenantUUID,
-		Name:       updatedUser.Name,
-		Email:      updatedUser.Email,
-		Role:       updatedUser.Role,
-		Live:       updatedUser.Live,
-		CreateAt:   updatedUser.CreateAt,
-		UpdateAt:   updatedUser.UpdateAt,
+		UUID:        updatedUser.UUID,
+		TenantUUID:  updatedUser.TenantUUID,
+		Name:        updatedUser.Name,
+		Email:       updatedUser.Email,
+		Role:        updatedUser.Role,
+		Live:        updatedUser.Live,
+		Metadata:    updatedUser.Metadata,
+		CreateAt:    updatedUser.CreateAt,
+		UpdateAt:    updatedUser.UpdateAt,
+		LastLoginAt: updatedUser.LastLoginAt,
+		Phone:       updatedUser.Phone,
+		AvatarURL:   updatedUser.AvatarURL,
+		Locale:      updatedUser.Locale,
+		Timezone:    updatedUser.Timezone,
 	}
 	ctrl.logAudit(c, ctxIdentify, "update", "Update", true, map[string]interface{}{"identifier": identificador, "request": req}, response)
 	c.JSON(http.StatusOK, response)
@@ -591,6 +866,13 @@ func (ctrl *controllerImpl) Delete(c *gin.Context) {
 	case model.RoleSystemAdmin:
 		// SystemAdmin deleta qualquer um.
 
+	case model.RolePartnerAdmin:
+		// PartnerAdmin deleta usuários do próprio tenant e dos descendentes
+		if restError := ctrl.checkPartnerScope(c, ctxIdentify, targetUser.TenantUUID); restError != nil {
+			c.AbortWithStatusJSON(restError.Code, restError)
+			return
+		}
+
 	case model.RoleTenantAdmin:
 		// TenantAdmin só deleta do MESMO tenant
 		if targetUser.Tenant.UUID.String() != ctxIdentify.User.Tenant.UUID.String() {
@@ -1,10 +1,19 @@
 package user
 
+import (
+	"strings"
+	"time"
+
+	"tenant-crud-simply/internal/pkg/listing"
+)
+
 type CreateUserRequestDto struct {
 	Name     string   `json:"name" binding:"required"`
 	Email    string   `json:"email" binding:"required,email"`
 	Password string   `json:"password" binding:"required,min=8"`
 	Role     UserRole `json:"role" binding:"required"`
+	// Metadata são os campos personalizados, validados pelas definições de /api/custom-fields
+	Metadata map[string]any `json:"metadata"`
 }
 
 type UpdateUserRequestDto struct {
@@ -12,10 +21,63 @@ type UpdateUserRequestDto struct {
 	Email    string   `json:"email"`
 	Password string   `json:"password"`
 	Role     UserRole `json:"role"`
+	// Metadata altera apenas os campos informados; null remove o campo
+	Metadata map[string]any `json:"metadata"`
 }
 
 type ListUserRequestDto struct {
-	Page             int    `form:"page"`
-	PageSize         int    `form:"size"`
 	TenantIdentifier string `form:"tenant_identifier"`
+	Name             string `form:"name"`
+	Email            string `form:"email"`
+	EmailDomain      string `form:"email_domain"`
+	Role             string `form:"role"`
+	Live             *bool  `form:"live"`
+	CreatedFrom      string `form:"created_from"`
+	CreatedTo        string `form:"created_to"`
+	UpdatedFrom      string `form:"updated_from"`
+	UpdatedTo        string `form:"updated_to"`
+	LastLoginFrom    string `form:"last_login_from"`
+	LastLoginTo      string `form:"last_login_to"`
+	Sort             string `form:"sort"`
+	Size             int    `form:"size"`
+	Cursor           string `form:"cursor"`
+	// Metadata filtra por campos personalizados (metadata[chave]=valor); preenchido pelo controller
+	Metadata map[string]string `form:"-"`
+}
+
+func (r ListUserRequestDto) options() (listing.Options, error) {
+	filters := listing.Filters{
+		Name:        strings.TrimSpace(r.Name),
+		Email:       strings.TrimSpace(r.Email),
+		EmailDomain: strings.ToLower(strings.TrimPrefix(strings.TrimSpace(r.EmailDomain), "@")),
+		Role:        strings.ToUpper(r.Role),
+		Live:        r.Live,
+		Metadata:    r.Metadata,
+	}
+	// Os limites "até" sem hora incluem o dia inteiro
+	ranges := []struct {
+		value    string
+		endOfDay bool
+		target   **time.Time
+	}{
+		{r.CreatedFrom, false, &filters.CreatedFrom},
+		{r.CreatedTo, true, &filters.CreatedTo},
+		{r.UpdatedFrom, false, &filters.UpdatedFrom},
+		{r.UpdatedTo, true, &filters.UpdatedTo},
+		{r.LastLoginFrom, false, &filters.LastLoginFrom},
+		{r.LastLoginTo, true, &filters.LastLoginTo},
+	}
+	for _, rg := range ranges {
+		t, err := listing.ParseTime(rg.value, rg.endOfDay)
+		if err != nil {
+			return listing.Options{}, err
+		}
+		*rg.target = t
+	}
+	return listing.Options{
+		Filters: filters,
+		Sort:    r.Sort,
+		Size:    r.Size,
+		Cursor:  r.Cursor,
+	}, nil
 }
@@ -13,10 +13,23 @@ type UserResponseDto struct {
 	Email      string     `json:"email"`
 	Role       UserRole   `json:"role"`
 	Live       bool       `json:"live"`
-	CreateAt   time.Time  `json:"create_at"`
-	UpdateAt   time.Time  `json:"update_at"`
+	// Campos personalizados (veja /api/custom-fields)
+	Metadata map[string]any `json:"metadata"`
+	CreateAt time.Time      `json:"create_at"`
+	UpdateAt time.Time      `json:"update_at"`
+	// LastLoginAt é a data do último login; ausente se o usuário nunca fez login
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	// Dados de perfil, alterados pelo próprio usuário em /api/me
+	Phone     string `json:"phone,omitempty"`
+	AvatarURL string `json:"avatar_url,omitempty"`
+	Locale    string `json:"locale,omitempty"`
+	Timezone  string `json:"timezone,omitempty"`
 }
 
+// UserListResponseDto é uma página de /user/list. next_cursor ausente indica a última página.
 type UserListResponseDto struct {
-	Users []UserResponseDto `json:"users"`
+	Users      []UserResponseDto `json:"users"`
+	Total      int64             `json:"total"`
+	Size       int               `json:"size"`
+	NextCursor string            `json:"next_cursor,omitempty"`
 }
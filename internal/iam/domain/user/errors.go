@@ -7,4 +7,5 @@ var (
 	ErrNotFound           = errors.New("user not found")
 	ErrInvalidInput       = errors.New("invalid input data")
 	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrEmailDomain        = errors.New("email domain not allowed for tenant")
 )
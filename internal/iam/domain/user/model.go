@@ -9,19 +9,22 @@ type User = model.User
 type UserRole = model.UserRole
 
 const (
-	RoleSystemAdmin = model.RoleSystemAdmin
-	RoleTenantAdmin = model.RoleTenantAdmin
-	RoleTenantUser  = model.RoleTenantUser
+	RoleSystemAdmin  = model.RoleSystemAdmin
+	RolePartnerAdmin = model.RolePartnerAdmin
+	RoleTenantAdmin  = model.RoleTenantAdmin
+	RoleTenantUser   = model.RoleTenantUser
 )
 
 var validRolesMap = map[UserRole]bool{
-	RoleSystemAdmin: true,
-	RoleTenantAdmin: true,
-	RoleTenantUser:  true,
+	RoleSystemAdmin:  true,
+	RolePartnerAdmin: true,
+	RoleTenantAdmin:  true,
+	RoleTenantUser:   true,
 }
 
 var AllValidRoles = []string{
 	"SYSTEM_ADMIN",
+	"PARTNER_ADMIN",
 	"TENANT_ADMIN",
 	"TENANT_USER",
 }
@@ -30,3 +33,13 @@ func IsValidUserRole(r UserRole) bool {
 	_, ok := validRolesMap[r]
 	return ok
 }
+
+// Profile são os dados que o próprio usuário altera (/api/me). Campos nil não são alterados;
+// "" limpa o campo (Name não pode ficar vazio).
+type Profile struct {
+	Name      *string
+	Phone     *string
+	AvatarURL *string
+	Locale    *string
+	Timezone  *string
+}
@@ -4,20 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/iam/middleware"
+	"tenant-crud-simply/internal/infra/database/postgres"
+	"tenant-crud-simply/internal/pkg/listing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
-	Create(ctx context.Context, user User) (User, error)
+	// Create grava o usuário. Com limit, a contagem dos usuários do tenant e a inserção ocorrem em uma
+	// transação com a linha do tenant bloqueada (SELECT ... FOR UPDATE): criações simultâneas no
+	// mesmo tenant esperam a anterior, e limit recebe o total antes da inserção.
+	Create(ctx context.Context, user User, limit func(users int64) error) (User, error)
 	Read(ctx context.Context, user User) (User, error)
-	List(ctx context.Context, page, pageSize int) ([]User, error)
-	ListByTenant(ctx context.Context, tenant tenant.Tenant, page, pageSize int) ([]User, error)
+	List(ctx context.Context, opts listing.Options) (listing.Page[User], error)
+	ListByTenant(ctx context.Context, tenant tenant.Tenant, opts listing.Options) (listing.Page[User], error)
+	Each(ctx context.Context, opts listing.Options, fn func(User) error) error
+	EachByTenant(ctx context.Context, tenant tenant.Tenant, opts listing.Options, fn func(User) error) error
 	Update(ctx context.Context, user User) (User, error)
+	UpdateProfile(ctx context.Context, userUUID uuid.UUID, profile Profile) (User, error)
 	Delete(ctx context.Context, user User) error
+	SetLive(ctx context.Context, userUUID uuid.UUID, live bool) (User, error)
+	RecordLogin(ctx context.Context, userUUID uuid.UUID, at time.Time) error
 }
 
 type repositoryImpl struct {
@@ -30,32 +44,136 @@ func NewRepository(db *gorm.DB) Repository {
 	}
 }
 
-func (r *repositoryImpl) Create(ctx context.Context, user User) (User, error) {
-	result := r.db.WithContext(ctx).Create(&user)
-	if result.Error == nil {
-		return user, nil
+// scope direciona o ctx para o schema ou o banco do tenant do usuário (middleware.TenantScope). Sem o tenant
+// informado, ele é resolvido pelo índice global (user_directory) a partir do UUID ou do email.
+func (r *repositoryImpl) scope(ctx context.Context, user User) (context.Context, func(), error) {
+	if !middleware.IsolationEnabled() {
+		return ctx, func() {}, nil
 	}
+
+	tenantUUID := user.TenantUUID
+	if tenantUUID == nil && user.Tenant.UUID != uuid.Nil {
+		tenantUUID = &user.Tenant.UUID
+	}
+	if tenantUUID == nil {
+		query := r.db.WithContext(ctx).Table("user_directory").Select("tenant_uuid")
+		if user.UUID != uuid.Nil {
+			query = query.Where("uuid = ?", user.UUID)
+		} else {
+			query = query.Where("email = ?", user.Email)
+		}
+		if err := query.Limit(1).Scan(&tenantUUID).Error; err != nil {
+			return ctx, func() {}, err
+		}
+	}
+	return middleware.TenantScope(ctx, tenantUUID)
+}
+
+func (r *repositoryImpl) Create(ctx context.Context, user User, limit func(users int64) error) (User, error) {
+	ctx, release, err := r.scope(ctx, user)
+	if err != nil {
+		return User{}, err
+	}
+	defer release()
+
+	dedicated := postgres.HasTenantDatabase(ctx)
+	if dedicated && user.UUID == uuid.Nil {
+		user.UUID = uuid.New()
+	}
+	inserted := false
+	// A transação é a do banco de controle, onde está a linha do tenant. Com banco dedicado, a
+	// contagem e a inserção são feitas no banco do tenant enquanto o bloqueio é mantido
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		users := tx
+		if dedicated {
+			users = postgres.Conn(ctx, r.db)
+		}
+		if limit != nil && user.TenantUUID != nil {
+			if err := tx.Exec("SELECT 1 FROM tenant WHERE uuid = ? FOR UPDATE", *user.TenantUUID).Error; err != nil {
+				return err
+			}
+			var total int64
+			if err := users.Model(&User{}).Where("tenant_uuid = ?", *user.TenantUUID).Count(&total).Error; err != nil {
+				return err
+			}
+			if err := limit(total); err != nil {
+				return err
+			}
+		}
+		if !dedicated {
+			return mapCreateError(tx.Create(&user).Error)
+		}
+
+		// Com banco dedicado não há trigger entre bancos: o índice global é gravado na transação,
+		// o que também garante a unicidade do email entre todos os tenants
+		if err := tx.Exec(
+			"INSERT INTO user_directory (uuid, tenant_uuid, email) VALUES (?, ?, ?)",
+			user.UUID, user.TenantUUID, user.Email,
+		).Error; err != nil {
+			return mapCreateError(err)
+		}
+		// O banco dedicado não possui a tabela tenant
+		if err := users.Omit(clause.Associations).Create(&user).Error; err != nil {
+			return mapCreateError(err)
+		}
+		inserted = true
+		return nil
+	})
+	if err != nil {
+		if inserted {
+			// O commit do índice global falhou depois da inserção no banco dedicado
+			r.removeFromTenantDatabase(ctx, user.UUID)
+		}
+		return User{}, err
+	}
+	return user, nil
+}
+
+// removeFromTenantDatabase apaga o usuário do banco dedicado quando a sua entrada no índice global não foi gravada.
+func (r *repositoryImpl) removeFromTenantDatabase(ctx context.Context, userUUID uuid.UUID) {
+	if err := postgres.Conn(ctx, r.db).Delete(&User{}, "uuid = ?", userUUID).Error; err != nil {
+		log.Printf("[USER] falha ao remover o usuário %s do banco dedicado: %v", userUUID, err)
+	}
+}
+
+// mapCreateError converte os erros do PostgreSQL na criação de usuários.
+func mapCreateError(err error) error {
 	var pgErr *pgconn.PgError
 
-	if errors.As(result.Error, &pgErr) {
+	if errors.As(err, &pgErr) {
 		if pgErr.Code == "23505" {
 			switch pgErr.ConstraintName {
-			case "users_email_key":
-				return User{}, ErrEmailDuplicated
+			case "users_email_key", "user_directory_email_key":
+				return ErrEmailDuplicated
 			default:
-				return User{}, result.Error
+				return err
 			}
 		}
 		if pgErr.Code == "23503" {
-			return User{}, tenant.ErrNotFound
+			return tenant.ErrNotFound
 		}
 	}
-	return User{}, result.Error
+	return err
+}
 
+// removeFromDirectory apaga a entrada do usuário no índice global (bancos dedicados).
+func (r *repositoryImpl) removeFromDirectory(ctx context.Context, userUUID uuid.UUID) {
+	if err := r.db.WithContext(ctx).Exec("DELETE FROM user_directory WHERE uuid = ?", userUUID).Error; err != nil {
+		log.Printf("[USER] falha ao remover o usuário %s do índice global: %v", userUUID, err)
+	}
 }
 
 func (r *repositoryImpl) Read(ctx context.Context, user User) (User, error) {
-	query := r.db.WithContext(ctx).Last(&User{})
+	if user.UUID == uuid.Nil && user.Email == "" {
+		return User{}, ErrInvalidInput
+	}
+	ctx, release, err := r.scope(ctx, User{UUID: user.UUID, Email: user.Email})
+	if err != nil {
+		return User{}, err
+	}
+	defer release()
+
+	query := postgres.Conn(ctx, r.db).Last(&User{})
 	if user.UUID != uuid.Nil {
 		query = query.Where("uuid = ?", user.UUID).First(&user)
 	} else if user.Email != "" {
@@ -72,52 +190,123 @@ func (r *repositoryImpl) Read(ctx context.Context, user User) (User, error) {
 	return user, nil
 }
 
-func (r *repositoryImpl) List(ctx context.Context, page, pageSize int) ([]User, error) {
-	var users []User
-	query := r.db.WithContext(ctx).Model(&users)
-	if page <= 0 {
-		page = 1
-	}
-	if pageSize <= 0 {
-		pageSize = 10
-	}
-	offset := (page - 1) * pageSize
-	result := query.Limit(pageSize).Offset(offset).Find(&users)
-	if result.Error != nil {
-		return users, result.Error
-	}
-	return users, nil
+// neverLoggedIn ordena os usuários sem login antes dos demais (ou depois, em ordem decrescente).
+// Corresponde ao time.Time zero, usado como valor do cursor.
+const neverLoggedIn = "TIMESTAMP '0001-01-01 00:00:00'"
+
+var listSpec = listing.Spec[User]{
+	Sortable: map[string]listing.Column[User]{
+		"name":      {Name: "users.name", Value: func(u User) any { return u.Name }},
+		"email":     {Name: "users.email", Value: func(u User) any { return u.Email }},
+		"create_at": {Name: "users.create_at", Value: func(u User) any { return u.CreateAt }},
+		"update_at": {Name: "users.update_at", Value: func(u User) any { return u.UpdateAt }},
+		"last_login_at": {
+			Name: "COALESCE(users.last_login_at, " + neverLoggedIn + ")",
+			Value: func(u User) any {
+				if u.LastLoginAt == nil {
+					return time.Time{}
+				}
+				return *u.LastLoginAt
+			},
+		},
+	},
+	DefaultSort: "name",
+	IDColumn:    "users.uuid",
+	ID:          func(u User) uuid.UUID { return u.UUID },
 }
 
-func (r *repositoryImpl) ListByTenant(ctx context.Context, t tenant.Tenant, page, pageSize int) ([]User, error) {
-	var users []User
+func (r *repositoryImpl) List(ctx context.Context, opts listing.Options) (listing.Page[User], error) {
+	query := filter(r.db.WithContext(ctx).Model(&User{}), opts.Filters)
+	return listing.Find(query, opts, listSpec)
+}
 
-	if page <= 0 {
-		page = 1
+func (r *repositoryImpl) ListByTenant(ctx context.Context, t tenant.Tenant, opts listing.Options) (listing.Page[User], error) {
+	ctx, release, err := middleware.TenantScope(ctx, &t.UUID)
+	if err != nil {
+		return listing.Page[User]{}, err
 	}
-	if pageSize <= 0 {
-		pageSize = 10
+	defer release()
+
+	query, err := r.tenantQuery(ctx, t)
+	if err != nil {
+		return listing.Page[User]{}, err
 	}
-	offset := (page - 1) * pageSize
+	return listing.Find(filter(query, opts.Filters), opts, listSpec)
+}
 
-	query := r.db.WithContext(ctx).Model(&User{})
-	if t.UUID != uuid.Nil {
-		query = query.Where("tenant_uuid = ?", t.UUID)
+func (r *repositoryImpl) Each(ctx context.Context, opts listing.Options, fn func(User) error) error {
+	query := filter(r.db.WithContext(ctx).Model(&User{}), opts.Filters)
+	return listing.Stream(query, opts, listSpec, fn)
+}
 
-	} else if t.Document != "" {
-		query = query.Joins("INNER JOIN tenant ON tenant.uuid = users.tenant_uuid").
-			Where("tenant.document = ?", t.Document)
+func (r *repositoryImpl) EachByTenant(ctx context.Context, t tenant.Tenant, opts listing.Options, fn func(User) error) error {
+	ctx, release, err := middleware.TenantScope(ctx, &t.UUID)
+	if err != nil {
+		return err
+	}
+	defer release()
 
-	} else {
-		return nil, errors.New("é necessário informar o UUID ou o Documento do Tenant")
+	query, err := r.tenantQuery(ctx, t)
+	if err != nil {
+		return err
 	}
-	result := query.Limit(pageSize).Offset(offset).Find(&users)
+	return listing.Stream(filter(query, opts.Filters), opts, listSpec, fn)
+}
 
-	if result.Error != nil {
-		return users, result.Error
+// tenantQuery retorna a consulta dos usuários do tenant (pelo UUID ou pelo documento).
+func (r *repositoryImpl) tenantQuery(ctx context.Context, t tenant.Tenant) (*gorm.DB, error) {
+	query := postgres.Conn(ctx, r.db).Model(&User{})
+	if t.UUID != uuid.Nil {
+		return query.Where("users.tenant_uuid = ?", t.UUID), nil
+	}
+	if t.Document != "" {
+		return query.Joins("INNER JOIN tenant ON tenant.uuid = users.tenant_uuid").
+			Where("tenant.document = ?", t.Document), nil
 	}
+	return nil, errors.New("é necessário informar o UUID ou o Documento do Tenant")
+}
 
-	return users, nil
+// filter aplica os filtros de usuário. As colunas são qualificadas por causa do JOIN
+// com tenant em tenantQuery.
+func filter(query *gorm.DB, f listing.Filters) *gorm.DB {
+	if f.Name != "" {
+		query = query.Where("users.name ILIKE ?", listing.Contains(f.Name))
+	}
+	if f.Email != "" {
+		query = query.Where("users.email ILIKE ?", listing.Contains(f.Email))
+	}
+	if f.EmailDomain != "" {
+		query = query.Where("users.email ILIKE ?", listing.EndsWith("@"+f.EmailDomain))
+	}
+	if f.Role != "" {
+		query = query.Where("users.role = ?", f.Role)
+	}
+	if f.Live != nil {
+		query = query.Where("users.live = ?", *f.Live)
+	}
+	if f.CreatedFrom != nil {
+		query = query.Where("users.create_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		query = query.Where("users.create_at < ?", *f.CreatedTo)
+	}
+	if f.UpdatedFrom != nil {
+		query = query.Where("users.update_at >= ?", *f.UpdatedFrom)
+	}
+	if f.UpdatedTo != nil {
+		query = query.Where("users.update_at < ?", *f.UpdatedTo)
+	}
+	// Usuários que nunca fizeram login (last_login_at nulo) ficam fora dos filtros de último login
+	if f.LastLoginFrom != nil {
+		query = query.Where("users.last_login_at >= ?", *f.LastLoginFrom)
+	}
+	if f.LastLoginTo != nil {
+		query = query.Where("users.last_login_at < ?", *f.LastLoginTo)
+	}
+	for _, cond := range listing.MetadataConditions("users.metadata", f.Metadata) {
+		query = query.Where(cond.SQL, cond.Args...)
+	}
+	return query.Select("users.*")
 }
 
 func (r *repositoryImpl) Update(ctx context.Context, user User) (User, error) {
@@ -140,6 +329,9 @@ func (r *repositoryImpl) Update(ctx context.Context, user User) (User, error) {
 	if user.Live {
 		updateFields["live"] = user.Live
 	}
+	if user.Metadata != nil {
+		updateFields["metadata"] = user.Metadata
+	}
 	if !user.UpdateAt.IsZero() {
 		updateFields["update_at"] = user.UpdateAt
 	}
@@ -148,19 +340,44 @@ func (r *repositoryImpl) Update(ctx context.Context, user User) (User, error) {
 		return User{}, errors.New("nenhum campo válido para atualização")
 	}
 
+	ctx, release, err := r.scope(ctx, User{UUID: user.UUID})
+	if err != nil {
+		return User{}, err
+	}
+	defer release()
+
+	// Com banco dedicado, o novo email é gravado antes no índice global (unicidade entre tenants)
+	previousEmail := ""
+	dedicated := postgres.HasTenantDatabase(ctx) && user.Email != ""
+	if dedicated {
+		current, err := r.Read(ctx, User{UUID: user.UUID})
+		if err != nil {
+			return User{}, err
+		}
+		previousEmail = current.Email
+		if err := r.setDirectoryEmail(ctx, user.UUID, user.Email); err != nil {
+			return User{}, err
+		}
+	}
+
 	// Executa o update
-	query := r.db.WithContext(ctx).
+	query := postgres.Conn(ctx, r.db).
 		Model(&User{}).
 		Where("uuid = ?", user.UUID).
 		Updates(updateFields)
 
 	// --- Tratamento de erros do PostgreSQL ---
+	if query.Error != nil && dedicated {
+		if err := r.setDirectoryEmail(ctx, user.UUID, previousEmail); err != nil {
+			log.Printf("[USER] falha ao restaurar o email do usuário %s no índice global: %v", user.UUID, err)
+		}
+	}
 	if query.Error != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(query.Error, &pgErr) {
 			switch pgErr.Code {
 			case "23505": // Unique violation
-				if pgErr.ConstraintName == "users_email_key" {
+				if pgErr.ConstraintName == "users_email_key" || pgErr.ConstraintName == "user_directory_email_key" {
 					return User{}, ErrEmailDuplicated
 				}
 				return User{}, fmt.Errorf("violação de unicidade (%s): %w", pgErr.ConstraintName, query.Error)
@@ -198,13 +415,99 @@ func (r *repositoryImpl) Update(ctx context.Context, user User) (User, error) {
 	return updatedUser, nil
 }
 
+func (r *repositoryImpl) UpdateProfile(ctx context.Context, userUUID uuid.UUID, profile Profile) (User, error) {
+	updateFields := map[string]interface{}{"update_at": time.Now().UTC()}
+	for column, value := range map[string]*string{
+		"name":       profile.Name,
+		"phone":      profile.Phone,
+		"avatar_url": profile.AvatarURL,
+		"locale":     profile.Locale,
+		"timezone":   profile.Timezone,
+	} {
+		if value != nil {
+			updateFields[column] = *value
+		}
+	}
+
+	ctx, release, err := r.scope(ctx, User{UUID: userUUID})
+	if err != nil {
+		return User{}, err
+	}
+	defer release()
+
+	query := postgres.Conn(ctx, r.db).
+		Model(&User{}).
+		Where("uuid = ?", userUUID).
+		Updates(updateFields)
+	if query.Error != nil {
+		return User{}, query.Error
+	}
+	if query.RowsAffected == 0 {
+		return User{}, ErrNotFound
+	}
+	return r.Read(ctx, User{UUID: userUUID})
+}
+
+func (r *repositoryImpl) SetLive(ctx context.Context, userUUID uuid.UUID, live bool) (User, error) {
+	ctx, release, err := r.scope(ctx, User{UUID: userUUID})
+	if err != nil {
+		return User{}, err
+	}
+	defer release()
+
+	query := postgres.Conn(ctx, r.db).
+		Model(&User{}).
+		Where("uuid = ?", userUUID).
+		Updates(map[string]interface{}{"live": live, "update_at": time.Now().UTC()})
+	if query.Error != nil {
+		return User{}, query.Error
+	}
+	if query.RowsAffected == 0 {
+		return User{}, ErrNotFound
+	}
+	return r.Read(ctx, User{UUID: userUUID})
+}
+
+// RecordLogin grava a data do último login. Não altera update_at, que registra mudanças no cadastro.
+func (r *repositoryImpl) RecordLogin(ctx context.Context, userUUID uuid.UUID, at time.Time) error {
+	ctx, release, err := r.scope(ctx, User{UUID: userUUID})
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return postgres.Conn(ctx, r.db).
+		Model(&User{}).
+		Where("uuid = ?", userUUID).
+		UpdateColumn("last_login_at", at).Error
+}
+
+// setDirectoryEmail atualiza o email do usuário no índice global (bancos dedicados).
+func (r *repositoryImpl) setDirectoryEmail(ctx context.Context, userUUID uuid.UUID, email string) error {
+	err := r.db.WithContext(ctx).Exec("UPDATE user_directory SET email = ? WHERE uuid = ?", email, userUUID).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrEmailDuplicated
+	}
+	return err
+}
+
 func (r *repositoryImpl) Delete(ctx context.Context, user User) error {
-	query := r.db.WithContext(ctx).Where("uuid = ?", user.UUID).Delete(&User{})
+	ctx, release, err := r.scope(ctx, User{UUID: user.UUID})
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	query := postgres.Conn(ctx, r.db).Where("uuid = ?", user.UUID).Delete(&User{})
 	if query.Error != nil {
 		return query.Error
 	}
 	if query.RowsAffected == 0 {
 		return ErrNotFound
 	}
+	if postgres.HasTenantDatabase(ctx) {
+		r.removeFromDirectory(ctx, user.UUID)
+	}
 	return nil
 }
@@ -2,18 +2,38 @@ package user
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"tenant-crud-simply/internal/iam/domain/custom_field"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/iam/domain/plan"
+	"tenant-crud-simply/internal/iam/domain/settings"
 	"tenant-crud-simply/internal/iam/domain/tenant"
+	"tenant-crud-simply/internal/pkg/listing"
 	"tenant-crud-simply/internal/pkg/util"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type Service interface {
 	Create(ctx context.Context, user User) (User, error)
 	Read(ctx context.Context, user User) (User, error)
-	List(ctx context.Context, page, pageSize int) ([]User, error)
-	ListByTenant(ctx context.Context, tenant tenant.Tenant, page, pageSize int) ([]User, error)
+	// List e ListByTenant aplicam filtros, ordenação e paginação por cursor (listing.Options).
+	List(ctx context.Context, opts listing.Options) (listing.Page[User], error)
+	ListByTenant(ctx context.Context, tenant tenant.Tenant, opts listing.Options) (listing.Page[User], error)
+	// Each e EachByTenant percorrem todos os usuários filtrados na ordenação pedida, sem paginar
+	// (exportação em CSV). Size e Cursor são ignorados.
+	Each(ctx context.Context, opts listing.Options, fn func(User) error) error
+	EachByTenant(ctx context.Context, tenant tenant.Tenant, opts listing.Options, fn func(User) error) error
 	Update(ctx context.Context, user User) (User, error)
+	// UpdateProfile altera os dados de perfil do usuário (já validados pelo chamador).
+	UpdateProfile(ctx context.Context, userUUID uuid.UUID, profile Profile) (User, error)
 	Delete(ctx context.Context, user User) error
+	// SetLive ativa ou desativa o usuário (Update ignora live = false).
+	SetLive(ctx context.Context, userUUID uuid.UUID, live bool) (User, error)
+	// RecordLogin grava a data do último login do usuário.
+	RecordLogin(ctx context.Context, userUUID uuid.UUID, at time.Time) error
 }
 
 type serviceImpl struct {
@@ -31,6 +51,23 @@ func (s *serviceImpl) Create(ctx context.Context, user User) (User, error) {
 	if err != nil {
 		return User{}, err
 	}
+	if err := checkEmailDomain(ctx, t.UUID, user.Email); err != nil {
+		return User{}, err
+	}
+	// O plano é lido antes: o limite é verificado na transação da inserção, com o tenant bloqueado
+	tenantPlan, err := plan.MustUse().Service.PlanFor(ctx, t.UUID)
+	if err != nil {
+		return User{}, err
+	}
+	// Os campos obrigatórios são exigidos mesmo sem metadata
+	patch := user.Metadata
+	if patch == nil {
+		patch = model.Metadata{}
+	}
+	metadata, err := custom_field.MustUse().Service.Validate(ctx, model.CustomFieldEntityUser, &t.UUID, nil, patch)
+	if err != nil {
+		return User{}, err
+	}
 	hashPwd, err := util.UsePassword().Hash(user.Password)
 	if err != nil {
 		return User{}, err
@@ -42,32 +79,91 @@ func (s *serviceImpl) Create(ctx context.Context, user User) (User, error) {
 		Password:   hashPwd,
 		Role:       user.Role,
 		Live:       user.Live,
+		Metadata:   metadata,
 		CreateAt:   time.Now().UTC(),
 		UpdateAt:   time.Now().UTC(),
 		Tenant:     t,
 	}
 
-	return s.Repository.Create(ctx, newUser)
+	created, err := s.Repository.Create(ctx, newUser, func(users int64) error {
+		return plan.CheckQuota(tenantPlan, plan.MetricUsers, users)
+	})
+	if err != nil {
+		return User{}, err
+	}
+	s.notifyUserUsage(ctx, t.UUID)
+	return created, nil
+}
+
+// notifyUserUsage dispara os avisos de consumo do limite de usuários do plano.
+func (s *serviceImpl) notifyUserUsage(ctx context.Context, tenantUUID uuid.UUID) {
+	plans := plan.MustUse()
+	total, err := plans.Repository.CountUsers(ctx, tenantUUID)
+	if err != nil {
+		return
+	}
+	plans.Service.NotifyUsage(ctx, tenantUUID, plan.MetricUsers, total)
 }
 
 func (s *serviceImpl) Read(ctx context.Context, user User) (User, error) {
 	return s.Repository.Read(ctx, user)
 }
 
-func (s *serviceImpl) List(ctx context.Context, page, pageSize int) ([]User, error) {
-	return s.Repository.List(ctx, page, pageSize)
+func (s *serviceImpl) List(ctx context.Context, opts listing.Options) (listing.Page[User], error) {
+	if err := checkListFilters(opts.Filters); err != nil {
+		return listing.Page[User]{}, err
+	}
+	return s.Repository.List(ctx, opts)
 }
 
-func (s *serviceImpl) ListByTenant(ctx context.Context, inputTenant tenant.Tenant, page, pageSize int) ([]User, error) {
+func (s *serviceImpl) ListByTenant(ctx context.Context, inputTenant tenant.Tenant, opts listing.Options) (listing.Page[User], error) {
+	if err := checkListFilters(opts.Filters); err != nil {
+		return listing.Page[User]{}, err
+	}
 	// Isso garante validação (se o tenant existe) e obtém o UUID se for passado apenas o Documento.
 	t, err := tenant.MustUse().Service.Read(ctx, inputTenant)
 	if err != nil {
-		return nil, err
+		return listing.Page[User]{}, err
+	}
+	return s.Repository.ListByTenant(ctx, t, opts)
+}
+
+func (s *serviceImpl) Each(ctx context.Context, opts listing.Options, fn func(User) error) error {
+	if err := checkListFilters(opts.Filters); err != nil {
+		return err
+	}
+	return s.Repository.Each(ctx, opts, fn)
+}
+
+func (s *serviceImpl) EachByTenant(ctx context.Context, inputTenant tenant.Tenant, opts listing.Options, fn func(User) error) error {
+	if err := checkListFilters(opts.Filters); err != nil {
+		return err
+	}
+	t, err := tenant.MustUse().Service.Read(ctx, inputTenant)
+	if err != nil {
+		return err
+	}
+	return s.Repository.EachByTenant(ctx, t, opts, fn)
+}
+
+func (s *serviceImpl) RecordLogin(ctx context.Context, userUUID uuid.UUID, at time.Time) error {
+	return s.Repository.RecordLogin(ctx, userUUID, at)
+}
+
+func checkListFilters(f listing.Filters) error {
+	if f.Role != "" && !IsValidUserRole(UserRole(f.Role)) {
+		return fmt.Errorf("%w: role '%s' desconhecida", listing.ErrInvalidOptions, f.Role)
 	}
-	return s.Repository.ListByTenant(ctx, t, page, pageSize)
+	return nil
 }
 
 func (s *serviceImpl) Update(ctx context.Context, user User) (User, error) {
+	if user.Email != "" && user.Tenant.UUID != uuid.Nil {
+		if err := checkEmailDomain(ctx, user.Tenant.UUID, user.Email); err != nil {
+			return User{}, err
+		}
+	}
+
 	if user.Password != "" {
 		hashPwd, err := util.UsePassword().Hash(user.Password)
 		if err != nil {
@@ -76,11 +172,54 @@ func (s *serviceImpl) Update(ctx context.Context, user User) (User, error) {
 		user.Password = hashPwd
 	}
 
+	// O metadata informado é um patch sobre o atual: null remove o campo
+	if user.Metadata != nil {
+		current, err := s.Repository.Read(ctx, User{UUID: user.UUID})
+		if err != nil {
+			return User{}, err
+		}
+		user.Metadata, err = custom_field.MustUse().Service.Validate(ctx, model.CustomFieldEntityUser, current.TenantUUID, current.Metadata, user.Metadata)
+		if err != nil {
+			return User{}, err
+		}
+	}
+
 	user.UpdateAt = time.Now().UTC()
 
 	return s.Repository.Update(ctx, user)
 }
 
+func (s *serviceImpl) UpdateProfile(ctx context.Context, userUUID uuid.UUID, profile Profile) (User, error) {
+	return s.Repository.UpdateProfile(ctx, userUUID, profile)
+}
+
+func (s *serviceImpl) SetLive(ctx context.Context, userUUID uuid.UUID, live bool) (User, error) {
+	return s.Repository.SetLive(ctx, userUUID, live)
+}
+
 func (s *serviceImpl) Delete(ctx context.Context, user User) error {
 	return s.Repository.Delete(ctx, user)
 }
+
+// checkEmailDomain aplica a configuração 'allowed_email_domains' do tenant (lista vazia permite qualquer domínio).
+func checkEmailDomain(ctx context.Context, tenantUUID uuid.UUID, email string) error {
+	allowed, err := settings.Get[[]string](ctx, tenantUUID, settings.KeyAllowedEmailDomains)
+	if err != nil {
+		return err
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ErrInvalidInput
+	}
+	domain := email[at+1:]
+	for _, d := range allowed {
+		if strings.EqualFold(d, domain) {
+			return nil
+		}
+	}
+	return ErrEmailDomain
+}
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/infra/database/postgres"
 	"tenant-crud-simply/internal/pkg/log/acess_log"
 	"time"
 
@@ -20,15 +22,26 @@ import (
 type Middleware interface {
 	SetContextAutorization() gin.HandlerFunc
 	AuthorizeRole(requiredRoles ...model.UserRole) gin.HandlerFunc
+	ResolveTenantHost() gin.HandlerFunc
+	// AddAfterAuthHook registra uma verificação executada por SetContextAutorization
+	// logo após autenticar a requisição. Deve ser chamado apenas na inicialização.
+	AddAfterAuthHook(hook AfterAuthHook)
 }
 
+// AfterAuthHook recebe a requisição já autenticada. Um RestErr não nulo interrompe a requisição
+// com esse erro (o acesso continua sendo registrado no access_log).
+type AfterAuthHook func(c *gin.Context, login *Login) *rest_err.RestErr
+
 type impl struct {
 	repository Repository
+	cfg        Config
+	hooks      []AfterAuthHook
 }
 
-func NewMiddleware(repository Repository) Middleware {
+func NewMiddleware(repository Repository, cfg Config) Middleware {
 	return &impl{
 		repository: repository,
+		cfg:        cfg,
 	}
 }
 
@@ -52,6 +65,20 @@ func (mw *impl) SetContextAutorization() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
+		// Com isolamento por schema ou banco, a requisição inteira usa o schema (ou o banco) do tenant do usuário autenticado
+		if mw.cfg.SchemaIsolation || mw.cfg.DatabaseIsolation {
+			scoped, release, err := mw.tokenScope(ctx, token)
+			if err != nil {
+				e := rest_err.NewInternalServerError(nil, "Falha ao resolver o schema ou o banco de dados do tenant.", nil)
+				c.Header("X-Request-ID", traceID)
+				c.AbortWithStatusJSON(e.Code, e)
+				return
+			}
+			defer release()
+			ctx = scoped
+			c.Request = c.Request.WithContext(ctx)
+		}
+
 		login, err := mw.repository.GetLogin(ctx, token)
 		if err != nil {
 			var e *rest_err.RestErr
@@ -80,6 +107,37 @@ func (mw *impl) SetContextAutorization() gin.HandlerFunc {
 			return
 		}
 
+		if login.User.Tenant.DeletedAt != nil {
+			e := rest_err.NewForbiddenError(nil, "Tenant excluído. Acesso bloqueado.")
+			c.Header("X-Request-ID", traceID)
+			c.AbortWithStatusJSON(e.Code, e)
+			return
+		}
+
+		// Status do ciclo de vida do tenant. SYSTEM_ADMIN não é afetado pelo status do próprio tenant.
+		if status := login.User.Tenant.Status; login.User.TenantUUID != nil && login.User.Role != model.RoleSystemAdmin {
+			if !status.AllowsAccess() {
+				e := rest_err.NewForbiddenError(nil, fmt.Sprintf("Tenant com status '%s'. Acesso bloqueado.", status))
+				c.Header("X-Request-ID", traceID)
+				c.AbortWithStatusJSON(e.Code, e)
+				return
+			}
+			if status.ReadOnly() && !isReadMethod(c.Request.Method) && !readOnlyWritable[c.Request.Method+" "+c.FullPath()] {
+				e := rest_err.NewForbiddenError(nil, "Tenant com pagamento pendente: acesso somente leitura.")
+				c.Header("X-Request-ID", traceID)
+				c.AbortWithStatusJSON(e.Code, e)
+				return
+			}
+		}
+
+		// Em hosts de tenant, apenas tokens do próprio tenant (ou de SYSTEM_ADMIN) são aceitos
+		if hostTenant, ok := GetHostTenant(c); ok && !AllowedOnHost(login.User, hostTenant) {
+			e := rest_err.NewForbiddenError(nil, "Token não pertence ao tenant deste host.")
+			c.Header("X-Request-ID", traceID)
+			c.AbortWithStatusJSON(e.Code, e)
+			return
+		}
+
 		// 2. Preenche metadata
 		login.Metadata = Metadata{
 			RayTraceCode: traceID,
@@ -87,6 +145,7 @@ func (mw *impl) SetContextAutorization() gin.HandlerFunc {
 			Agent:        c.Request.UserAgent(),
 			Method:       c.Request.Method,
 			Path:         c.Request.URL.Path,
+			Route:        c.FullPath(),
 			Host:         c.Request.Host,
 			Referer:      c.Request.Referer(),
 			ContentType:  c.ContentType(),
@@ -100,8 +159,23 @@ func (mw *impl) SetContextAutorization() gin.HandlerFunc {
 
 		SetAuthenticatedUser(c, login)
 
-		// processa handler
-		c.Next()
+		// Lista de IPs permitidos do tenant. A tentativa negada é registrada no access_log com status próprio
+		accessStatus := acess_log.StatusAllowed
+		if err := CheckClientIP(ctx, login.User, login.Metadata.IP); err != nil {
+			var e *rest_err.RestErr
+			if errors.Is(err, ErrIPNotAllowed) {
+				accessStatus = acess_log.StatusIPDenied
+				e = rest_err.NewForbiddenError(nil, "Acesso não permitido a partir deste endereço IP.")
+			} else {
+				e = rest_err.NewInternalServerError(nil, "Falha ao validar o endereço IP de origem.", nil)
+			}
+			c.AbortWithStatusJSON(e.Code, e)
+		} else if e := mw.runAfterAuthHooks(c, login); e != nil {
+			// processa handler (se nenhum hook barrar a requisição)
+			c.AbortWithStatusJSON(e.Code, e)
+		} else {
+			c.Next()
+		}
 
 		// 4. calcular latência
 		login.Metadata.RequestLatency = time.Since(start)
@@ -116,15 +190,17 @@ func (mw *impl) SetContextAutorization() gin.HandlerFunc {
 
 		identifier := login.User.Email
 
-		accessLog := acess_log.AccessLog{
+		LogAccess(ctx, acess_log.AccessLog{
 			TenantUUID:   login.User.TenantUUID,
 			UserUUID:     userUUID,
 			Identifier:   identifier,
 			RayTraceCode: login.Metadata.RayTraceCode,
 			Method:       login.Metadata.Method,
 			Path:         login.Metadata.Path,
+			Route:        login.Metadata.Route,
 			Host:         login.Metadata.Host,
 			StatusCode:   statusCode,
+			Status:       accessStatus,
 			IP:           login.Metadata.IP,
 			UserAgent:    login.Metadata.Agent,
 			Referer:      login.Metadata.Referer,
@@ -132,19 +208,26 @@ func (mw *impl) SetContextAutorization() gin.HandlerFunc {
 			UserLanguage: login.Metadata.UserLanguage,
 			RequestTime:  login.Metadata.TimeRequest,
 			LatencyMs:    float64(login.Metadata.RequestLatency.Microseconds()) / 1000.0,
-		}
-		ctxDetached := context.WithoutCancel(ctx)
-		go func() {
-			defer func() {
-				if r := recover(); r != nil {
-					log.Printf("Recovered in access log: %v", r)
-				}
-			}()
-			if err := acess_log.MustUse().Log(ctxDetached, accessLog); err != nil {
-				log.Printf("Erro log: %v", err)
+		})
+	}
+}
+
+// LogAccess grava o acesso no access_log em segundo plano, sem depender do ciclo de vida da requisição.
+func LogAccess(ctx context.Context, entry acess_log.AccessLog) {
+	if entry.Status == "" {
+		entry.Status = acess_log.StatusAllowed
+	}
+	ctxDetached := context.WithoutCancel(ctx)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Recovered in access log: %v", r)
 			}
 		}()
-	}
+		if err := acess_log.MustUse().Log(ctxDetached, entry); err != nil {
+			log.Printf("Erro log: %v", err)
+		}
+	}()
 }
 
 func (mw *impl) AuthorizeRole(requiredRoles ...model.UserRole) gin.HandlerFunc {
@@ -185,6 +268,23 @@ func isRoleAuthorized(userRole model.UserRole, requiredRoles []model.UserRole) b
 	return false
 }
 
+// readOnlyWritable são as rotas autenticadas (método e c.FullPath()) que continuam aceitando escrita
+// com o tenant somente leitura (pagamento pendente): senha e dados da própria conta do usuário. Login,
+// logout, OTP e redefinição de senha são públicos e não passam por esta verificação.
+var readOnlyWritable = map[string]bool{
+	"PATCH /api/me":         true,
+	"DELETE /api/me":        true,
+	"POST /api/me/password": true,
+}
+
+func isReadMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
 func extractBearerToken(header string) string {
 	const prefix = "Bearer "
 	if !strings.HasPrefix(header, prefix) {
@@ -192,3 +292,74 @@ func extractBearerToken(header string) string {
 	}
 	return strings.TrimSpace(header[len(prefix):])
 }
+
+// ResolveTenantHost identifica o tenant pelo header Host e o registra no contexto (GetHostTenant).
+// Hosts sob o domínio base são resolvidos pelo subdomínio; os demais, pelos domínios próprios verificados.
+// Hosts não cadastrados seguem como host compartilhado, exceto subdomínios desconhecidos do domínio base.
+func (mw *impl) ResolveTenantHost() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !mw.cfg.HostResolution {
+			c.Next()
+			return
+		}
+
+		host := NormalizeHost(c.Request.Host)
+		if host == "" || host == mw.cfg.BaseDomain {
+			c.Next()
+			return
+		}
+
+		kind, hostname := model.DomainKindCustom, host
+		if label, ok := SubdomainLabel(host, mw.cfg.BaseDomain); ok {
+			kind, hostname = model.DomainKindSubdomain, label
+		}
+
+		tenantUUID, err := mw.repository.GetTenantByHost(c.Request.Context(), kind, hostname)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				e := rest_err.NewInternalServerError(nil, "Falha ao resolver o tenant do host.", nil)
+				c.AbortWithStatusJSON(e.Code, e)
+				return
+			}
+			if kind == model.DomainKindSubdomain {
+				e := rest_err.NewNotFoundError(nil, "Nenhum tenant associado a este host.")
+				c.AbortWithStatusJSON(e.Code, e)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		SetHostTenant(c, tenantUUID)
+		c.Next()
+	}
+}
+
+// tokenScope cria o escopo de schema da requisição, já apontando para o schema (ou o banco) do tenant
+// do dono do token. Token desconhecido mantém public (GetLogin responde com o erro adequado).
+func (mw *impl) tokenScope(ctx context.Context, token string) (context.Context, func(), error) {
+	scoped, release := postgres.WithSchemaScope(ctx)
+	tenantUUID, err := mw.repository.GetTokenTenant(scoped, token)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		release()
+		return ctx, nil, err
+	}
+	if _, err := useTenant(scoped, tenantUUID); err != nil {
+		release()
+		return ctx, nil, err
+	}
+	return scoped, release, nil
+}
+
+func (mw *impl) AddAfterAuthHook(hook AfterAuthHook) {
+	mw.hooks = append(mw.hooks, hook)
+}
+
+func (mw *impl) runAfterAuthHooks(c *gin.Context, login *Login) *rest_err.RestErr {
+	for _, hook := range mw.hooks {
+		if e := hook(c, login); e != nil {
+			return e
+		}
+	}
+	return nil
+}
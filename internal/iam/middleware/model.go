@@ -17,6 +17,17 @@ type Login struct {
 	User       model.User
 	AcessToken AcessToken
 	Metadata   Metadata
+	Groups     []model.Group
+}
+
+// InGroup indica se o usuário autenticado pertence ao grupo informado (UUID ou nome).
+func (l *Login) InGroup(identifier string) bool {
+	for _, g := range l.Groups {
+		if g.UUID.String() == identifier || g.Name == identifier {
+			return true
+		}
+	}
+	return false
 }
 
 type Metadata struct {
@@ -25,6 +36,7 @@ type Metadata struct {
 	Agent          string
 	Method         string
 	Path           string
+	Route          string
 	Host           string
 	Referer        string
 	ContentType    string
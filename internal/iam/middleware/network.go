@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"tenant-crud-simply/internal/iam/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// ErrIPNotAllowed indica que o IP de origem não está nas faixas permitidas do tenant.
+var ErrIPNotAllowed = errors.New("ip not allowed for tenant")
+
+// IPAllowlistResolver retorna as faixas (CIDR ou IP) de onde os usuários do tenant podem acessar.
+// Lista vazia libera qualquer origem.
+type IPAllowlistResolver func(ctx context.Context, tenantUUID uuid.UUID) ([]string, error)
+
+var (
+	ipAllowlistMu       sync.RWMutex
+	ipAllowlistResolver IPAllowlistResolver
+)
+
+// SetIPAllowlistResolver registra a origem das faixas permitidas por tenant. Sem resolver,
+// nenhuma restrição de rede é aplicada. O registro é feito pelo pacote settings, que importa
+// este pacote.
+func SetIPAllowlistResolver(resolver IPAllowlistResolver) {
+	ipAllowlistMu.Lock()
+	defer ipAllowlistMu.Unlock()
+	ipAllowlistResolver = resolver
+}
+
+// CheckClientIP aplica a lista de IPs permitidos do tenant do usuário ao IP de origem.
+// SYSTEM_ADMIN e usuários sem tenant não são restringidos.
+func CheckClientIP(ctx context.Context, user model.User, clientIP string) error {
+	if user.Role == model.RoleSystemAdmin || user.TenantUUID == nil {
+		return nil
+	}
+
+	ipAllowlistMu.RLock()
+	resolver := ipAllowlistResolver
+	ipAllowlistMu.RUnlock()
+	if resolver == nil {
+		return nil
+	}
+
+	ranges, err := resolver(ctx, *user.TenantUUID)
+	if err != nil {
+		return err
+	}
+	if len(ranges) == 0 || IPInRanges(clientIP, ranges) {
+		return nil
+	}
+	return ErrIPNotAllowed
+}
+
+// IPInRanges indica se o IP pertence a alguma das faixas. Entradas sem máscara são tratadas
+// como um único endereço; entradas inválidas são ignoradas.
+func IPInRanges(ip string, ranges []string) bool {
+	addr := net.ParseIP(strings.TrimSpace(ip))
+	if addr == nil {
+		return false
+	}
+	for _, r := range ranges {
+		if network, err := ParseIPRange(r); err == nil && network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseIPRange converte uma faixa CIDR (10.0.0.0/8, 2001:db8::/32) ou um IP isolado em uma rede.
+func ParseIPRange(value string) (*net.IPNet, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "/") {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, &net.ParseError{Type: "IP address", Text: value}
+		}
+		bits := 128
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 32
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, network, err := net.ParseCIDR(value)
+	return network, err
+}
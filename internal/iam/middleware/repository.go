@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/infra/database/postgres"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +14,11 @@ import (
 
 type Repository interface {
 	GetLogin(ctx context.Context, token string) (*Login, error)
+	GetTenantByHost(ctx context.Context, kind model.DomainKind, hostname string) (uuid.UUID, error)
+	// GetTenantPlacement retorna onde ficam os dados do tenant (isolamento e schema dedicado).
+	GetTenantPlacement(ctx context.Context, tenantUUID uuid.UUID) (TenantPlacement, error)
+	// GetTokenTenant resolve, pelo índice global de usuários, o tenant do dono do token.
+	GetTokenTenant(ctx context.Context, token string) (*uuid.UUID, error)
 }
 
 type repositoryImpl struct {
@@ -33,13 +39,16 @@ type loginQueryResult struct {
 	UserPasswordHash string         `gorm:"column:password_hash"`
 	UserRole         model.UserRole `gorm:"column:role"`
 	UserLive         bool           `gorm:"column:live"`
+	UserMetadata     model.Metadata `gorm:"column:metadata"`
 	UserCreateAt     time.Time      `gorm:"column:create_at"`
 	UserUpdateAt     time.Time      `gorm:"column:update_at"`
+	TenantParentUUID *uuid.UUID     `gorm:"column:tenant_parent_uuid"`
 	TenantName       sql.NullString `gorm:"column:tenant_name"`
 	TenantDocument   sql.NullString `gorm:"column:tenant_document"`
-	TenantLive       sql.NullBool   `gorm:"column:tenant_live"`
+	TenantStatus     sql.NullString `gorm:"column:tenant_status"`
 	TenantCreateAt   sql.NullTime   `gorm:"column:tenant_create_at"`
 	TenantUpdateAt   sql.NullTime   `gorm:"column:tenant_update_at"`
+	TenantDeletedAt  sql.NullTime   `gorm:"column:tenant_deleted_at"`
 }
 
 const loginQuery = `
@@ -53,35 +62,91 @@ SELECT
         u.password_hash,
         u.role,
         u.live,
+        u.metadata,
         u.create_at,
         u.update_at,
+        t.parent_uuid AS tenant_parent_uuid,
         t.name AS tenant_name,
         t.document AS tenant_document,
-        t.live AS tenant_live,
+        t.status AS tenant_status,
         t.create_at AS tenant_create_at,
-        t.update_at AS tenant_update_at
+        t.update_at AS tenant_update_at,
+        t.deleted_at AS tenant_deleted_at
 FROM users_acess_tokens AS at
 INNER JOIN users AS u ON u.uuid = at.user_uuid
 LEFT JOIN tenant AS t ON t.uuid = u.tenant_uuid
 WHERE at.token = ?
 LIMIT 1`
 
+// Com banco dedicado, o usuário não está no banco de controle: o token e o tenant são lidos
+// pelo índice global (dedicatedTokenQuery) e o usuário, no banco do tenant (dedicatedUserQuery).
+const dedicatedTokenQuery = `
+SELECT
+        at.token,
+        at.expire_date,
+        at.user_uuid,
+        d.tenant_uuid,
+        t.parent_uuid AS tenant_parent_uuid,
+        t.name AS tenant_name,
+        t.document AS tenant_document,
+        t.status AS tenant_status,
+        t.create_at AS tenant_create_at,
+        t.update_at AS tenant_update_at,
+        t.deleted_at AS tenant_deleted_at
+FROM users_acess_tokens AS at
+INNER JOIN user_directory AS d ON d.uuid = at.user_uuid
+LEFT JOIN tenant AS t ON t.uuid = d.tenant_uuid
+WHERE at.token = ?
+LIMIT 1`
+
+const dedicatedUserQuery = `
+SELECT
+        u.name AS user_name,
+        u.email AS user_email,
+        u.password_hash,
+        u.role,
+        u.live,
+        u.metadata,
+        u.create_at,
+        u.update_at
+FROM users AS u
+WHERE u.uuid = ?
+LIMIT 1`
+
+const groupsQuery = `
+SELECT
+        g.uuid,
+        g.tenant_uuid,
+        g.name,
+        g.description,
+        g.create_at,
+        g.update_at
+FROM tenant_group_members AS gm
+INNER JOIN tenant_groups AS g ON g.uuid = gm.group_uuid
+WHERE gm.user_uuid = ? AND g.tenant_uuid = ?
+ORDER BY g.name`
+
 func (r *repositoryImpl) GetLogin(ctx context.Context, token string) (*Login, error) {
 	if token == "" {
 		return nil, errors.New("token cannot be empty")
 	}
 
 	var result loginQueryResult
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		query := tx.Raw(loginQuery, token).Scan(&result)
-		if query.Error != nil {
-			return query.Error
-		}
-		if query.RowsAffected == 0 {
-			return gorm.ErrRecordNotFound
-		}
-		return nil
-	})
+	var err error
+	if postgres.HasTenantDatabase(ctx) {
+		result, err = r.getDedicatedLogin(ctx, token)
+	} else {
+		err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			query := tx.Raw(loginQuery, token).Scan(&result)
+			if query.Error != nil {
+				return query.Error
+			}
+			if query.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+			return nil
+		})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -95,6 +160,7 @@ func (r *repositoryImpl) GetLogin(ctx context.Context, token string) (*Login, er
 			Password:   result.UserPasswordHash,
 			Role:       result.UserRole,
 			Live:       result.UserLive,
+			Metadata:   result.UserMetadata,
 			CreateAt:   result.UserCreateAt,
 			UpdateAt:   result.UserUpdateAt,
 		},
@@ -107,12 +173,13 @@ func (r *repositoryImpl) GetLogin(ctx context.Context, token string) (*Login, er
 
 	if result.UserTenantUUID != nil {
 		tenant := model.Tenant{
-			UUID:     *result.UserTenantUUID,
-			Name:     result.TenantName.String,
-			Document: result.TenantDocument.String,
+			UUID:       *result.UserTenantUUID,
+			ParentUUID: result.TenantParentUUID,
+			Name:       result.TenantName.String,
+			Document:   result.TenantDocument.String,
 		}
-		if result.TenantLive.Valid {
-			tenant.Live = result.TenantLive.Bool
+		if result.TenantStatus.Valid {
+			tenant.Status = model.TenantStatus(result.TenantStatus.String)
 		}
 		if result.TenantCreateAt.Valid {
 			tenant.CreateAt = result.TenantCreateAt.Time
@@ -120,8 +187,109 @@ func (r *repositoryImpl) GetLogin(ctx context.Context, token string) (*Login, er
 		if result.TenantUpdateAt.Valid {
 			tenant.UpdateAt = result.TenantUpdateAt.Time
 		}
+		if result.TenantDeletedAt.Valid {
+			deletedAt := result.TenantDeletedAt.Time
+			tenant.DeletedAt = &deletedAt
+		}
 		login.User.Tenant = tenant
+
+		groups, err := r.getGroups(ctx, result.UserUUID, *result.UserTenantUUID)
+		if err != nil {
+			return nil, err
+		}
+		login.Groups = groups
 	}
 
 	return login, nil
 }
+
+// getDedicatedLogin monta o resultado do login de um usuário de tenant com banco dedicado.
+func (r *repositoryImpl) getDedicatedLogin(ctx context.Context, token string) (loginQueryResult, error) {
+	var result loginQueryResult
+	query := r.db.WithContext(ctx).Raw(dedicatedTokenQuery, token).Scan(&result)
+	if query.Error != nil {
+		return result, query.Error
+	}
+	if query.RowsAffected == 0 {
+		return result, gorm.ErrRecordNotFound
+	}
+
+	var user loginQueryResult
+	query = postgres.Conn(ctx, r.db).Raw(dedicatedUserQuery, result.UserUUID).Scan(&user)
+	if query.Error != nil {
+		return result, query.Error
+	}
+	if query.RowsAffected == 0 {
+		return result, gorm.ErrRecordNotFound
+	}
+	result.UserName, result.UserEmail, result.UserPasswordHash = user.UserName, user.UserEmail, user.UserPasswordHash
+	result.UserRole, result.UserLive, result.UserMetadata = user.UserRole, user.UserLive, user.UserMetadata
+	result.UserCreateAt, result.UserUpdateAt = user.UserCreateAt, user.UserUpdateAt
+	return result, nil
+}
+
+// getGroups carrega os grupos do usuário dentro do seu tenant.
+func (r *repositoryImpl) getGroups(ctx context.Context, userUUID, tenantUUID uuid.UUID) ([]model.Group, error) {
+	var groups []model.Group
+	if err := postgres.Conn(ctx, r.db).Raw(groupsQuery, userUUID, tenantUUID).Scan(&groups).Error; err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// tenantByHostQuery resolve o tenant ativo de um host. Domínios próprios precisam estar verificados.
+const tenantByHostQuery = `
+SELECT d.tenant_uuid
+FROM tenant_domains AS d
+INNER JOIN tenant AS t ON t.uuid = d.tenant_uuid
+WHERE d.kind = ? AND d.hostname = ?
+  AND d.verified_at IS NOT NULL
+  AND t.deleted_at IS NULL
+LIMIT 1`
+
+func (r *repositoryImpl) GetTenantByHost(ctx context.Context, kind model.DomainKind, hostname string) (uuid.UUID, error) {
+	var tenantUUID uuid.UUID
+	query := r.db.WithContext(ctx).Raw(tenantByHostQuery, kind, hostname).Scan(&tenantUUID)
+	if query.Error != nil {
+		return uuid.Nil, query.Error
+	}
+	if query.RowsAffected == 0 {
+		return uuid.Nil, gorm.ErrRecordNotFound
+	}
+	return tenantUUID, nil
+}
+
+func (r *repositoryImpl) GetTenantPlacement(ctx context.Context, tenantUUID uuid.UUID) (TenantPlacement, error) {
+	var result struct {
+		Isolation  model.TenantIsolation `gorm:"column:isolation"`
+		SchemaName sql.NullString        `gorm:"column:schema_name"`
+	}
+	query := r.db.WithContext(ctx).Raw("SELECT isolation, schema_name FROM tenant WHERE uuid = ?", tenantUUID).Scan(&result)
+	if query.Error != nil {
+		return TenantPlacement{}, query.Error
+	}
+	if query.RowsAffected == 0 {
+		return TenantPlacement{}, gorm.ErrRecordNotFound
+	}
+	return TenantPlacement{Isolation: result.Isolation, Schema: result.SchemaName.String}, nil
+}
+
+// tokenTenantQuery usa user_directory (public) porque o usuário pode estar no schema de um tenant isolado.
+const tokenTenantQuery = `
+SELECT d.tenant_uuid
+FROM users_acess_tokens AS at
+INNER JOIN user_directory AS d ON d.uuid = at.user_uuid
+WHERE at.token = ?
+LIMIT 1`
+
+func (r *repositoryImpl) GetTokenTenant(ctx context.Context, token string) (*uuid.UUID, error) {
+	var tenantUUID *uuid.UUID
+	query := r.db.WithContext(ctx).Raw(tokenTenantQuery, token).Scan(&tenantUUID)
+	if query.Error != nil {
+		return nil, query.Error
+	}
+	if query.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return tenantUUID, nil
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"tenant-crud-simply/internal/iam/domain/model"
+	"tenant-crud-simply/internal/infra/database/postgres"
+	"tenant-crud-simply/internal/infra/database/tenantdb"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TenantPlacement indica onde ficam os dados de um tenant.
+type TenantPlacement struct {
+	Isolation model.TenantIsolation
+	Schema    string // Schema dedicado; vazio fora do isolamento por schema
+}
+
+var (
+	schemaIsolation   bool
+	databaseIsolation bool
+	// placementCache guarda onde ficam os dados de cada tenant; o isolamento não muda após a criação
+	placementCache sync.Map
+)
+
+// IsolationEnabled indica se algum isolamento (tenant.isolation.schema_enabled ou
+// tenant.isolation.database_enabled) está habilitado.
+func IsolationEnabled() bool {
+	return schemaIsolation || databaseIsolation
+}
+
+// TenantScope direciona as consultas feitas com o ctx retornado para o schema ou o banco dedicado
+// do tenant (public para tenants compartilhados ou tenantUUID nil). Se o ctx já tiver um escopo
+// (requisição autenticada), ele é reaproveitado e release volta ao tenant anterior; caso contrário,
+// release devolve a conexão ao pool. Sem isolamento habilitado, nada muda.
+func TenantScope(ctx context.Context, tenantUUID *uuid.UUID) (context.Context, func(), error) {
+	noop := func() {}
+	if !IsolationEnabled() {
+		return ctx, noop, nil
+	}
+
+	scoped, release := postgres.WithSchemaScope(ctx)
+	restore, err := useTenant(scoped, tenantUUID)
+	if err != nil {
+		release()
+		return ctx, noop, err
+	}
+	return scoped, func() {
+		restore()
+		release()
+	}, nil
+}
+
+// switchSchema troca o schema (ou o banco) do escopo da requisição para o do tenant, sem criar um novo escopo.
+func switchSchema(ctx context.Context, tenantUUID uuid.UUID) {
+	if !IsolationEnabled() || !postgres.HasSchemaScope(ctx) {
+		return
+	}
+	if _, err := useTenant(ctx, &tenantUUID); err != nil {
+		log.Printf("[SCHEMA] falha ao usar o schema do tenant %s: %v", tenantUUID, err)
+	}
+}
+
+// useTenant aponta o escopo do ctx para o schema ou o banco dedicado do tenant. restore volta ao anterior.
+func useTenant(ctx context.Context, tenantUUID *uuid.UUID) (func(), error) {
+	placement, err := tenantPlacement(ctx, tenantUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var database *gorm.DB
+	if placement.Isolation == model.TenantIsolationDatabase {
+		registry, err := tenantdb.Use()
+		if err != nil {
+			return nil, err
+		}
+		if database, err = registry.DB(ctx, *tenantUUID); err != nil {
+			return nil, err
+		}
+	}
+
+	restoreSchema, err := postgres.UseSchema(ctx, placement.Schema)
+	if err != nil {
+		return nil, err
+	}
+	restoreDatabase, err := postgres.UseDatabase(ctx, database)
+	if err != nil {
+		restoreSchema()
+		return nil, err
+	}
+	return func() {
+		restoreDatabase()
+		restoreSchema()
+	}, nil
+}
+
+// tenantPlacement retorna onde ficam os dados do tenant (compartilhado para tenants inexistentes ou nil).
+func tenantPlacement(ctx context.Context, tenantUUID *uuid.UUID) (TenantPlacement, error) {
+	shared := TenantPlacement{Isolation: model.TenantIsolationShared}
+	if tenantUUID == nil || *tenantUUID == uuid.Nil {
+		return shared, nil
+	}
+	if cached, ok := placementCache.Load(*tenantUUID); ok {
+		return cached.(TenantPlacement), nil
+	}
+	if repositoryInstance == nil {
+		return shared, ErrNotInitialized
+	}
+
+	placement, err := repositoryInstance.GetTenantPlacement(ctx, *tenantUUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return shared, nil
+		}
+		return shared, err
+	}
+	placementCache.Store(*tenantUUID, placement)
+	return placement, nil
+}
@@ -21,8 +21,22 @@ type UseMiddleware struct {
 	Middleware Middleware
 }
 
+// Config usada somente no New()
+type Config struct {
+	// HostResolution habilita a resolução do tenant pelo header Host (subdomínio ou domínio próprio).
+	HostResolution bool
+	// BaseDomain é o domínio da plataforma sob o qual ficam os subdomínios dos tenants (ex.: app.exemplo.com.br).
+	BaseDomain string
+	// SchemaIsolation habilita o roteamento das consultas para o schema dedicado dos tenants
+	// isolados (search_path por requisição).
+	SchemaIsolation bool
+	// DatabaseIsolation habilita o roteamento das consultas para o banco dedicado dos tenants
+	// isolados (tenantdb.Registry).
+	DatabaseIsolation bool
+}
+
 // New inicializa o singleton do middleware com todas as suas dependências
-func New(db *gorm.DB) (Middleware, error) {
+func New(db *gorm.DB, cfg Config) (Middleware, error) {
 	once.Do(func() {
 		if db == nil {
 			initErr = errors.New("database connection cannot be nil")
@@ -31,7 +45,9 @@ func New(db *gorm.DB) (Middleware, error) {
 
 		// Inicializa as dependências em camadas
 		repositoryInstance = NewRepository(db)
-		middlewareInstance = NewMiddleware(repositoryInstance)
+		cfg.BaseDomain = NormalizeHost(cfg.BaseDomain)
+		schemaIsolation, databaseIsolation = cfg.SchemaIsolation, cfg.DatabaseIsolation
+		middlewareInstance = NewMiddleware(repositoryInstance, cfg)
 	})
 
 	return middlewareInstance, initErr
@@ -1,8 +1,19 @@
 package middleware
 
-import "github.com/gin-gonic/gin"
+import (
+	"net"
+	"strings"
+	"tenant-crud-simply/internal/iam/domain/model"
 
-const UserContextKey = "AuthenticatedUserKey"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	UserContextKey         = "AuthenticatedUserKey"
+	TargetTenantContextKey = "TargetTenantKey"
+	HostTenantContextKey   = "HostTenantKey"
+)
 
 func SetAuthenticatedUser(c *gin.Context, userLogin *Login) {
 	if userLogin != nil {
@@ -23,3 +34,71 @@ func GetAuthenticatedUser(c *gin.Context) (*Login, bool) {
 
 	return userLogin, true
 }
+
+// SetTargetTenant registra o tenant sobre o qual a requisição está atuando.
+// Usado pela auditoria para distinguir o tenant alvo do tenant que executou a ação.
+// Com isolamento por schema ou banco, as consultas seguintes da requisição passam a usar o schema (ou o banco) do tenant alvo.
+func SetTargetTenant(c *gin.Context, tenantUUID uuid.UUID) {
+	if tenantUUID != uuid.Nil {
+		c.Set(TargetTenantContextKey, tenantUUID)
+		switchSchema(c.Request.Context(), tenantUUID)
+	}
+}
+
+func GetTargetTenant(c *gin.Context) (uuid.UUID, bool) {
+	value, exists := c.Get(TargetTenantContextKey)
+	if !exists {
+		return uuid.Nil, false
+	}
+	tenantUUID, ok := value.(uuid.UUID)
+	return tenantUUID, ok
+}
+
+// SetHostTenant registra o tenant resolvido a partir do header Host da requisição.
+func SetHostTenant(c *gin.Context, tenantUUID uuid.UUID) {
+	if tenantUUID != uuid.Nil {
+		c.Set(HostTenantContextKey, tenantUUID)
+	}
+}
+
+// GetHostTenant retorna o tenant do host, quando a requisição chegou por um subdomínio
+// ou domínio próprio de tenant.
+func GetHostTenant(c *gin.Context) (uuid.UUID, bool) {
+	value, exists := c.Get(HostTenantContextKey)
+	if !exists {
+		return uuid.Nil, false
+	}
+	tenantUUID, ok := value.(uuid.UUID)
+	return tenantUUID, ok
+}
+
+// NormalizeHost remove a porta e o ponto final e converte o host para minúsculas.
+func NormalizeHost(host string) string {
+	host = strings.TrimSpace(host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// SubdomainLabel retorna o rótulo do subdomínio quando host é um filho direto de baseDomain
+// (ex.: "acme" para acme.app.exemplo.com.br).
+func SubdomainLabel(host, baseDomain string) (string, bool) {
+	if baseDomain == "" || !strings.HasSuffix(host, "."+baseDomain) {
+		return "", false
+	}
+	label := strings.TrimSuffix(host, "."+baseDomain)
+	if label == "" || strings.Contains(label, ".") {
+		return "", false
+	}
+	return label, true
+}
+
+// AllowedOnHost indica se o usuário pode atuar no host do tenant informado.
+// SYSTEM_ADMIN não pertence a tenant e é aceito em qualquer host.
+func AllowedOnHost(user model.User, hostTenant uuid.UUID) bool {
+	if user.Role == model.RoleSystemAdmin {
+		return true
+	}
+	return user.TenantUUID != nil && *user.TenantUUID == hostTenant
+}
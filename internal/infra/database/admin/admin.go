@@ -63,6 +63,9 @@ WHERE schemaname = current_schema();
 
 type BackupOptions struct {
 	Destination string
+	// Connection aponta o backup para outro banco (ex.: o banco dedicado de um tenant).
+	// Nil usa o banco configurado em databases.postgres.
+	Connection *ConnectionInfo
 }
 
 func Backup(opts BackupOptions) error {
@@ -71,6 +74,9 @@ func Backup(opts BackupOptions) error {
 	}
 
 	info := connectionInfoFromConfig()
+	if opts.Connection != nil {
+		info = *opts.Connection
+	}
 	if info.Database == "" {
 		return errors.New("nome do banco de dados não configurado")
 	}
@@ -92,6 +98,9 @@ func Backup(opts BackupOptions) error {
 		"-f", destination,
 	)
 	cmd.Env = append(os.Environ(), fmt.Sprintf("PGPASSWORD=%s", info.Password))
+	if info.SSLMode != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("PGSSLMODE=%s", info.SSLMode))
+	}
 
 	if output, err := cmd.CombinedOutput(); err != nil {
 		if len(output) > 0 {
@@ -103,16 +112,18 @@ func Backup(opts BackupOptions) error {
 	return nil
 }
 
-type connectionInfo struct {
+// ConnectionInfo são os dados de acesso usados pelo pg_dump.
+type ConnectionInfo struct {
 	Host     string
 	Port     string
 	User     string
 	Password string
 	Database string
+	SSLMode  string // Vazio mantém o padrão do pg_dump
 }
 
-func connectionInfoFromConfig() connectionInfo {
-	return connectionInfo{
+func connectionInfoFromConfig() ConnectionInfo {
+	return ConnectionInfo{
 		Host:     viper.GetString("databases.postgres.host"),
 		Port:     viper.GetString("databases.postgres.port"),
 		User:     viper.GetString("databases.postgres.user"),
@@ -8,16 +8,24 @@ import (
 	"strings"
 	"time"
 
+	"tenant-crud-simply/internal/infra/database/postgres"
+
 	"gorm.io/gorm"
 )
 
 const (
 	seedCategory   = "seed"
 	updateCategory = "update"
+	// tenantCategory reúne as tabelas do tenant, aplicadas dentro de cada schema dedicado
+	// (tenants com isolamento por schema).
+	tenantCategory = "tenant"
+	// databaseCategory cria as tabelas do tenant em um banco de dados dedicado
+	// (tenants com isolamento por banco de dados).
+	databaseCategory = "database"
 )
 
 var (
-	//go:embed sql/seed/*.sql sql/update/*.sql
+	//go:embed sql/seed/*.sql sql/update/*.sql sql/tenant/*.sql sql/database/*.sql
 	embeddedMigrations embed.FS
 )
 
@@ -44,6 +52,80 @@ func (m *Manager) ApplyUpdate() error {
 	return m.applyCategory(updateCategory)
 }
 
+// ApplyDatabase aplica as migrations de banco dedicado. O Manager deve ter sido criado com a
+// conexão do banco do tenant, que mantém a sua própria schema_migrations.
+func (m *Manager) ApplyDatabase() error {
+	return m.applyCategory(databaseCategory)
+}
+
+// ApplyTenant cria o schema do tenant, se necessário, e aplica nele as migrations de tenant.
+// O controle de migrations aplicadas fica na schema_migrations do próprio schema.
+func (m *Manager) ApplyTenant(schema string) error {
+	if !postgres.IsTenantSchema(schema) {
+		return fmt.Errorf("schema de tenant inválido: %s", schema)
+	}
+	files, err := loadFiles(tenantCategory)
+	if err != nil {
+		return err
+	}
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS "%s"`, schema)).Error; err != nil {
+			return fmt.Errorf("falha ao criar schema %s: %w", schema, err)
+		}
+		// SET LOCAL vale só até o fim desta transação
+		if err := tx.Exec("SET LOCAL search_path TO " + postgres.SearchPath(schema)).Error; err != nil {
+			return fmt.Errorf("falha ao definir search_path do schema %s: %w", schema, err)
+		}
+		if err := ensureSchemaMigrationsTable(tx); err != nil {
+			return err
+		}
+
+		applied, err := fetchApplied(tx, tenantCategory)
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			if applied[file.Name] {
+				continue
+			}
+
+			if err := executeMigration(tx, file); err != nil {
+				return fmt.Errorf("schema %s: %w", schema, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// TenantSchemas lista os schemas dedicados dos tenants com isolamento por schema.
+func (m *Manager) TenantSchemas() ([]string, error) {
+	var schemas []string
+	if err := m.db.Raw(
+		"SELECT schema_name FROM tenant WHERE schema_name IS NOT NULL ORDER BY schema_name",
+	).Scan(&schemas).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar schemas de tenant: %w", err)
+	}
+	return schemas, nil
+}
+
+// ApplyTenantUpdates aplica as migrations de tenant em todos os schemas dedicados e retorna
+// quantos schemas foram processados.
+func (m *Manager) ApplyTenantUpdates() (int, error) {
+	schemas, err := m.TenantSchemas()
+	if err != nil {
+		return 0, err
+	}
+	for i, schema := range schemas {
+		if err := m.ApplyTenant(schema); err != nil {
+			return i, err
+		}
+	}
+	return len(schemas), nil
+}
+
 func (m *Manager) applyCategory(category string) error {
 	files, err := loadFiles(category)
 	if err != nil {
@@ -84,6 +166,10 @@ func loadFiles(category string) ([]migrationFile, error) {
 		dir = "sql/seed"
 	case updateCategory:
 		dir = "sql/update"
+	case tenantCategory:
+		dir = "sql/tenant"
+	case databaseCategory:
+		dir = "sql/database"
 	default:
 		return nil, fmt.Errorf("categoria de migration desconhecida: %s", category)
 	}
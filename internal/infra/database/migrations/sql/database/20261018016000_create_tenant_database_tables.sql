@@ -0,0 +1,62 @@
+-- Tabelas do tenant em seu banco de dados dedicado. As tabelas compartilhadas (tenant, user_directory,
+-- tokens, logs...) ficam apenas no banco de controle, então não há chaves estrangeiras para elas.
+-- Alterações nas tabelas users, tenant_groups e tenant_group_members precisam ser repetidas aqui.
+CREATE EXTENSION IF NOT EXISTS "pgcrypto";
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+DO $$
+BEGIN
+    CREATE TYPE user_role AS ENUM ('SYSTEM_ADMIN', 'TENANT_ADMIN', 'TENANT_USER', 'PARTNER_ADMIN');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$;
+
+CREATE TABLE IF NOT EXISTS users (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_uuid UUID,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL,
+    role user_role NOT NULL DEFAULT 'TENANT_USER',
+    live BOOLEAN NOT NULL DEFAULT TRUE,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    update_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_users_tenant_uuid ON users (tenant_uuid);
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING gin (email gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_name_tsv ON users USING gin (to_tsvector('simple', name));
+CREATE INDEX IF NOT EXISTS idx_users_metadata ON users USING gin (metadata jsonb_path_ops);
+
+CREATE TABLE IF NOT EXISTS tenant_groups (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_uuid UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    update_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT tenant_groups_tenant_name_key UNIQUE (tenant_uuid, name)
+);
+
+CREATE TABLE IF NOT EXISTS tenant_group_members (
+    group_uuid UUID NOT NULL,
+    user_uuid UUID NOT NULL,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (group_uuid, user_uuid),
+
+    CONSTRAINT fk_tenant_group_members_group
+        FOREIGN KEY(group_uuid)
+            REFERENCES tenant_groups(uuid)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_tenant_group_members_user
+        FOREIGN KEY(user_uuid)
+            REFERENCES users(uuid)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_group_members_user
+    ON tenant_group_members (user_uuid);
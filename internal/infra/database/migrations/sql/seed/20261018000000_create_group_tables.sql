@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS tenant_groups (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_uuid UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    update_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_tenant_groups_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE,
+
+    -- O nome do grupo é único dentro do tenant
+    CONSTRAINT tenant_groups_tenant_name_key UNIQUE (tenant_uuid, name)
+);
+
+CREATE TABLE IF NOT EXISTS tenant_group_members (
+    group_uuid UUID NOT NULL,
+    user_uuid UUID NOT NULL,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (group_uuid, user_uuid),
+
+    CONSTRAINT fk_tenant_group_members_group
+        FOREIGN KEY(group_uuid)
+            REFERENCES tenant_groups(uuid)
+            ON DELETE CASCADE,
+    CONSTRAINT fk_tenant_group_members_user
+        FOREIGN KEY(user_uuid)
+            REFERENCES users(uuid)
+            ON DELETE CASCADE
+);
+
+-- Busca dos grupos de um usuário (middleware.Login)
+CREATE INDEX IF NOT EXISTS idx_tenant_group_members_user
+    ON tenant_group_members (user_uuid);
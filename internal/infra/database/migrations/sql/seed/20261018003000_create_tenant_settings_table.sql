@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS tenant_settings (
+    tenant_uuid UUID NOT NULL,
+    key VARCHAR(100) NOT NULL,
+    value JSONB NOT NULL,
+    update_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Cada tenant sobrescreve uma configuração no máximo uma vez
+    PRIMARY KEY (tenant_uuid, key),
+
+    CONSTRAINT fk_tenant_settings_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE
+);
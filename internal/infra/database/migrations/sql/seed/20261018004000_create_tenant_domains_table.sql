@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS tenant_domains (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_uuid UUID NOT NULL,
+    -- 'subdomain': hostname guarda apenas o rótulo (ex.: 'acme' em acme.<base_domain>)
+    -- 'custom': hostname guarda o domínio completo (ex.: 'login.acme.com.br')
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('subdomain', 'custom')),
+    hostname VARCHAR(253) NOT NULL,
+    verification_token VARCHAR(64) NOT NULL,
+    verified_at TIMESTAMP WITHOUT TIME ZONE,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_tenant_domains_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE,
+
+    CONSTRAINT tenant_domains_kind_hostname_key UNIQUE (kind, hostname)
+);
+
+-- Cada tenant possui no máximo um subdomínio
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenant_domains_subdomain
+    ON tenant_domains (tenant_uuid)
+    WHERE kind = 'subdomain';
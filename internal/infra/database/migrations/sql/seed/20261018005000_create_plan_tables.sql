@@ -0,0 +1,65 @@
+-- Catálogo de planos. Limites NULL significam "ilimitado".
+CREATE TABLE IF NOT EXISTS plans (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    max_users INTEGER CHECK (max_users >= 0),
+    max_api_keys INTEGER CHECK (max_api_keys >= 0),
+    monthly_request_quota BIGINT CHECK (monthly_request_quota >= 0),
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    update_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Plano contratado por tenant. Tenants sem registro usam o plano padrão (plans.default_code).
+CREATE TABLE IF NOT EXISTS tenant_plans (
+    tenant_uuid UUID PRIMARY KEY,
+    plan_uuid UUID NOT NULL,
+    assigned_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_tenant_plans_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE,
+    -- Um plano em uso não pode ser removido do catálogo
+    CONSTRAINT fk_tenant_plans_plan
+        FOREIGN KEY(plan_uuid)
+            REFERENCES plans(uuid)
+            ON DELETE RESTRICT
+);
+
+-- Contador mensal de requisições autenticadas por tenant
+CREATE TABLE IF NOT EXISTS tenant_request_usage (
+    tenant_uuid UUID NOT NULL,
+    period DATE NOT NULL, -- Primeiro dia do mês
+    requests BIGINT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (tenant_uuid, period),
+
+    CONSTRAINT fk_tenant_request_usage_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE
+);
+
+-- Avisos de consumo já enviados (80% / 100%), para não repetir o email no mesmo período
+CREATE TABLE IF NOT EXISTS plan_usage_alerts (
+    tenant_uuid UUID NOT NULL,
+    metric VARCHAR(50) NOT NULL,
+    period DATE NOT NULL,
+    threshold INTEGER NOT NULL,
+    sent_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_uuid, metric, period, threshold),
+
+    CONSTRAINT fk_plan_usage_alerts_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE
+);
+
+INSERT INTO plans (code, name, max_users, max_api_keys, monthly_request_quota)
+VALUES
+    ('free', 'Free', 5, 1, 10000),
+    ('pro', 'Pro', 50, 10, 1000000),
+    ('enterprise', 'Enterprise', NULL, NULL, NULL)
+ON CONFLICT (code) DO NOTHING;
@@ -0,0 +1,53 @@
+-- Consumo agregado por tenant e endpoint, alimentado a partir do access_log.
+CREATE TABLE IF NOT EXISTS usage_hourly (
+    tenant_uuid UUID NOT NULL,
+    bucket TIMESTAMP WITHOUT TIME ZONE NOT NULL, -- Início da hora (UTC)
+    method VARCHAR(10) NOT NULL,
+    endpoint TEXT NOT NULL,
+    calls BIGINT NOT NULL,
+    client_errors BIGINT NOT NULL, -- HTTP 4xx
+    server_errors BIGINT NOT NULL, -- HTTP 5xx
+    p50_ms NUMERIC(10,3) NOT NULL,
+    p95_ms NUMERIC(10,3) NOT NULL,
+    update_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_uuid, bucket, method, endpoint),
+
+    CONSTRAINT fk_usage_hourly_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_hourly_bucket
+    ON usage_hourly (bucket);
+
+CREATE TABLE IF NOT EXISTS usage_daily (
+    tenant_uuid UUID NOT NULL,
+    bucket DATE NOT NULL, -- Dia (UTC)
+    method VARCHAR(10) NOT NULL,
+    endpoint TEXT NOT NULL,
+    calls BIGINT NOT NULL,
+    client_errors BIGINT NOT NULL,
+    server_errors BIGINT NOT NULL,
+    p50_ms NUMERIC(10,3) NOT NULL,
+    p95_ms NUMERIC(10,3) NOT NULL,
+    update_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_uuid, bucket, method, endpoint),
+
+    CONSTRAINT fk_usage_daily_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_daily_bucket
+    ON usage_daily (bucket);
+
+-- Marca d'água do agregador: última hora já consolidada
+CREATE TABLE IF NOT EXISTS usage_aggregation_state (
+    name VARCHAR(50) PRIMARY KEY,
+    last_bucket TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+    update_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
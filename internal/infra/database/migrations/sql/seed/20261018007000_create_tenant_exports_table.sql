@@ -0,0 +1,32 @@
+-- Exportações de dados de tenant (portabilidade / LGPD). O arquivo fica no diretório
+-- configurado em tenant.export.directory até ser baixado uma única vez ou expirar.
+CREATE TABLE IF NOT EXISTS tenant_exports (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_uuid UUID NOT NULL,
+    requested_by UUID, -- NULL quando gerada pela linha de comando
+    format VARCHAR(10) NOT NULL CHECK (format IN ('json', 'ndjson')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'running', 'ready', 'failed', 'downloaded', 'expired')),
+    file_name TEXT,
+    size_bytes BIGINT,
+    sha256 VARCHAR(64),
+    error TEXT,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITHOUT TIME ZONE,
+    downloaded_at TIMESTAMP WITHOUT TIME ZONE,
+
+    CONSTRAINT fk_tenant_exports_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_tenant_exports_user
+        FOREIGN KEY(requested_by)
+            REFERENCES users(uuid)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_exports_tenant
+    ON tenant_exports (tenant_uuid, create_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_exports_status
+    ON tenant_exports (status);
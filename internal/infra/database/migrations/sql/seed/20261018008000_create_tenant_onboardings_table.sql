@@ -0,0 +1,26 @@
+-- Registro dos onboardings (tenant + primeiro admin). Garante a idempotência pelo documento:
+-- repetir a mesma requisição retorna o onboarding já feito.
+CREATE TABLE IF NOT EXISTS tenant_onboardings (
+    tenant_uuid UUID PRIMARY KEY,
+    document VARCHAR(100) NOT NULL UNIQUE, -- Documento informado no onboarding
+    admin_user_uuid UUID,
+    request_hash VARCHAR(64) NOT NULL, -- SHA-256 da requisição (sem a senha)
+    invited BOOLEAN NOT NULL,
+    requested_by UUID,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_tenant_onboardings_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_tenant_onboardings_admin
+        FOREIGN KEY(admin_user_uuid)
+            REFERENCES users(uuid)
+            ON DELETE SET NULL,
+
+    CONSTRAINT fk_tenant_onboardings_requested_by
+        FOREIGN KEY(requested_by)
+            REFERENCES users(uuid)
+            ON DELETE SET NULL
+);
@@ -0,0 +1,23 @@
+-- Histórico das transições de status (ciclo de vida) dos tenants
+CREATE TABLE IF NOT EXISTS tenant_status_history (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_uuid UUID NOT NULL,
+    from_status VARCHAR(20), -- NULL = status inicial
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL,
+    changed_by UUID, -- NULL = alteração automática (ex.: fim da avaliação)
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_tenant_status_history_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_tenant_status_history_changed_by
+        FOREIGN KEY(changed_by)
+            REFERENCES users(uuid)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_status_history_tenant
+    ON tenant_status_history (tenant_uuid, create_at DESC);
@@ -0,0 +1,28 @@
+-- Definições dos campos personalizados (metadata) de tenants e usuários.
+-- tenant_uuid NULL = definição global; as demais valem para o tenant dono e seus descendentes.
+CREATE TABLE IF NOT EXISTS custom_fields (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_uuid UUID,
+    entity VARCHAR(10) NOT NULL,
+    key VARCHAR(63) NOT NULL,
+    label VARCHAR(255) NOT NULL,
+    type VARCHAR(10) NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    enum_values JSONB NOT NULL DEFAULT '[]', -- Valores aceitos pelo tipo enum
+    pattern TEXT NOT NULL DEFAULT '', -- Expressão regular (tipo string)
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    update_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_custom_fields_entity CHECK (entity IN ('tenant', 'user')),
+    CONSTRAINT chk_custom_fields_type CHECK (type IN ('string', 'number', 'boolean', 'date', 'enum')),
+    CONSTRAINT chk_custom_fields_key CHECK (key ~ '^[a-z][a-z0-9_]{0,62}$'),
+
+    CONSTRAINT fk_custom_fields_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE
+);
+
+-- Uma chave por dono e entidade; as globais usam o UUID nulo na comparação
+CREATE UNIQUE INDEX IF NOT EXISTS idx_custom_fields_owner_key
+    ON custom_fields (COALESCE(tenant_uuid, '00000000-0000-0000-0000-000000000000'::uuid), entity, key);
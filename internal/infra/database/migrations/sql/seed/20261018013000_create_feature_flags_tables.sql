@@ -0,0 +1,51 @@
+-- Feature flags globais e overrides por tenant ou usuário
+CREATE TABLE IF NOT EXISTS feature_flags (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    key VARCHAR(63) NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    rollout_percentage INTEGER NOT NULL DEFAULT 100, -- Percentual liberado quando ligada
+    rollout_by VARCHAR(10) NOT NULL DEFAULT 'tenant', -- tenant ou user
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    update_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT chk_feature_flags_rollout_percentage CHECK (rollout_percentage BETWEEN 0 AND 100),
+    CONSTRAINT chk_feature_flags_rollout_by CHECK (rollout_by IN ('tenant', 'user'))
+);
+
+CREATE TABLE IF NOT EXISTS feature_flag_overrides (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    flag_uuid UUID NOT NULL,
+    tenant_uuid UUID,
+    user_uuid UUID,
+    enabled BOOLEAN NOT NULL,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- Exatamente um alvo: o tenant (e seus descendentes) ou o usuário
+    CONSTRAINT chk_feature_flag_overrides_target CHECK ((tenant_uuid IS NULL) <> (user_uuid IS NULL)),
+
+    CONSTRAINT fk_feature_flag_overrides_flag
+        FOREIGN KEY(flag_uuid)
+            REFERENCES feature_flags(uuid)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_feature_flag_overrides_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_feature_flag_overrides_user
+        FOREIGN KEY(user_uuid)
+            REFERENCES users(uuid)
+            ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feature_flag_overrides_tenant
+    ON feature_flag_overrides (flag_uuid, tenant_uuid) WHERE tenant_uuid IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feature_flag_overrides_user
+    ON feature_flag_overrides (flag_uuid, user_uuid) WHERE user_uuid IS NOT NULL;
+-- Avaliação: overrides do usuário autenticado e da cadeia de tenants
+CREATE INDEX IF NOT EXISTS idx_feature_flag_overrides_user_lookup
+    ON feature_flag_overrides (user_uuid) WHERE user_uuid IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_feature_flag_overrides_tenant_lookup
+    ON feature_flag_overrides (tenant_uuid) WHERE tenant_uuid IS NOT NULL;
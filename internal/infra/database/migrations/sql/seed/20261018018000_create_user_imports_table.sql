@@ -0,0 +1,30 @@
+-- Importações de usuários em lote (CSV/XLSX). As linhas válidas são criadas em segundo plano;
+-- o resultado de cada linha fica em um CSV no diretório users.import.directory até expirar.
+CREATE TABLE IF NOT EXISTS user_imports (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_uuid UUID NOT NULL,
+    requested_by UUID,
+    file_name TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'running', 'completed', 'failed', 'expired')),
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    created INTEGER NOT NULL DEFAULT 0,
+    invited INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    result_file TEXT,
+    error TEXT,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP WITHOUT TIME ZONE,
+
+    CONSTRAINT fk_user_imports_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_imports_tenant
+    ON user_imports (tenant_uuid, create_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_user_imports_status
+    ON user_imports (status);
@@ -0,0 +1,15 @@
+-- Tentativas de login dos usuários (sucesso e falha), exibidas em /api/me/logins.
+-- Fica em public mesmo para tenants isolados, como o access_log.
+CREATE TABLE IF NOT EXISTS user_login_history (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_uuid UUID NOT NULL,
+    tenant_uuid UUID,
+    success BOOLEAN NOT NULL,
+    reason VARCHAR(32) NOT NULL DEFAULT '',
+    ip INET NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_login_history_user
+    ON user_login_history (user_uuid, created_at DESC);
@@ -0,0 +1,42 @@
+-- Retenções legais: enquanto ativas (released_at nulo), impedem a anonimização do usuário
+-- ou de todos os usuários do tenant e o expurgo do tenant. tenant_uuid e user_uuid não têm chave
+-- estrangeira: o registro da retenção sobrevive ao tenant e ao usuário.
+CREATE TABLE IF NOT EXISTS legal_holds (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_uuid UUID,
+    user_uuid UUID,
+    reason TEXT NOT NULL,
+    created_by UUID,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    released_at TIMESTAMP WITHOUT TIME ZONE,
+    released_by UUID,
+
+    -- Exatamente um alvo: o usuário ou o tenant
+    CONSTRAINT chk_legal_holds_target
+        CHECK ((tenant_uuid IS NULL) <> (user_uuid IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_legal_holds_active_user
+    ON legal_holds (user_uuid) WHERE released_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_legal_holds_active_tenant
+    ON legal_holds (tenant_uuid) WHERE released_at IS NULL;
+
+-- Certificados de eliminação (LGPD). Não têm chave estrangeira e não contêm dados pessoais:
+-- devem sobreviver ao usuário e ao tenant como prova da anonimização.
+CREATE TABLE IF NOT EXISTS erasure_certificates (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_uuid UUID NOT NULL,
+    tenant_uuid UUID,
+    pseudonym VARCHAR(64) NOT NULL,
+    requested_by UUID,
+    source VARCHAR(16) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL,
+    affected JSONB NOT NULL DEFAULT '{}',
+    sha256 VARCHAR(64) NOT NULL DEFAULT '',
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_erasure_certificates_user
+    ON erasure_certificates (user_uuid, create_at DESC);
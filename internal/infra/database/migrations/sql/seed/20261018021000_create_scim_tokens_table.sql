@@ -0,0 +1,20 @@
+-- Tokens SCIM dos tenants (provisionamento pelo provedor de identidade). Apenas o hash SHA-256
+-- do token é gravado. Um novo token revoga os anteriores (revoked_at).
+CREATE TABLE IF NOT EXISTS scim_tokens (
+    uuid UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_uuid UUID NOT NULL,
+    token_hash VARCHAR(64) NOT NULL,
+    created_by UUID,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP WITHOUT TIME ZONE,
+    revoked_at TIMESTAMP WITHOUT TIME ZONE,
+
+    CONSTRAINT uq_scim_tokens_hash UNIQUE (token_hash),
+    CONSTRAINT fk_scim_tokens_tenant
+        FOREIGN KEY (tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_scim_tokens_active_tenant
+    ON scim_tokens (tenant_uuid) WHERE revoked_at IS NULL;
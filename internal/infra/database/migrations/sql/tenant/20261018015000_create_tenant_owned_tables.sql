@@ -0,0 +1,35 @@
+-- Tabelas do tenant em seu schema dedicado (executado com search_path = "<schema>", public).
+-- As definições são copiadas de public (colunas, padrões, checks e índices); as chaves estrangeiras
+-- são recriadas aqui. Tabelas compartilhadas, como tenant e user_directory, continuam em public.
+CREATE TABLE IF NOT EXISTS users (LIKE public.users INCLUDING ALL);
+
+ALTER TABLE users
+    ADD CONSTRAINT fk_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES public.tenant(uuid)
+            ON DELETE SET NULL;
+
+-- Mantém o índice global de usuários (login por email e unicidade do email entre schemas)
+CREATE TRIGGER trg_users_directory
+    AFTER INSERT OR UPDATE OF email, tenant_uuid OR DELETE ON users
+    FOR EACH ROW EXECUTE FUNCTION public.sync_user_directory();
+
+CREATE TABLE IF NOT EXISTS tenant_groups (LIKE public.tenant_groups INCLUDING ALL);
+
+ALTER TABLE tenant_groups
+    ADD CONSTRAINT fk_tenant_groups_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES public.tenant(uuid)
+            ON DELETE CASCADE;
+
+CREATE TABLE IF NOT EXISTS tenant_group_members (LIKE public.tenant_group_members INCLUDING ALL);
+
+ALTER TABLE tenant_group_members
+    ADD CONSTRAINT fk_tenant_group_members_group
+        FOREIGN KEY(group_uuid)
+            REFERENCES tenant_groups(uuid)
+            ON DELETE CASCADE,
+    ADD CONSTRAINT fk_tenant_group_members_user
+        FOREIGN KEY(user_uuid)
+            REFERENCES users(uuid)
+            ON DELETE CASCADE;
@@ -0,0 +1,6 @@
+-- Data do último login do usuário (mesma alteração de update/20261018017000 para os schemas dedicados).
+-- Schemas criados depois já recebem a coluna ao copiar public.users.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_last_login_at ON users (last_login_at);
@@ -0,0 +1,7 @@
+-- Dados de perfil do usuário (mesma alteração de update/20261018019000 para os schemas dedicados).
+-- Schemas criados depois já recebem as colunas ao copiar public.users.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS phone VARCHAR(30) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS avatar_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(35) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT '';
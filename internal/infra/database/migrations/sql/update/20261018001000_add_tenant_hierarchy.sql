@@ -0,0 +1,31 @@
+-- Hierarquia de tenants (revendas e sub-organizações)
+ALTER TABLE tenant
+    ADD COLUMN IF NOT EXISTS parent_uuid UUID;
+
+ALTER TABLE tenant
+    DROP CONSTRAINT IF EXISTS fk_tenant_parent;
+
+ALTER TABLE tenant
+    ADD CONSTRAINT fk_tenant_parent
+        FOREIGN KEY(parent_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE SET NULL;
+
+-- Um tenant nunca pode ser pai de si mesmo (ciclos maiores são barrados no tenant.Service)
+ALTER TABLE tenant
+    DROP CONSTRAINT IF EXISTS chk_tenant_parent_self;
+
+ALTER TABLE tenant
+    ADD CONSTRAINT chk_tenant_parent_self CHECK (parent_uuid IS NULL OR parent_uuid <> uuid);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_parent_uuid ON tenant (parent_uuid);
+
+-- Papel de administrador de parceiro (gerencia o próprio tenant e seus descendentes)
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'PARTNER_ADMIN';
+
+-- Tenant ancestral que executou a ação quando um PARTNER_ADMIN atua em um tenant filho
+ALTER TABLE audit_log
+    ADD COLUMN IF NOT EXISTS acting_tenant_uuid UUID;
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_acting_tenant_uuid
+    ON audit_log (acting_tenant_uuid);
@@ -0,0 +1,8 @@
+-- Exclusão lógica de tenants (restaurável dentro do período de carência)
+ALTER TABLE tenant
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITHOUT TIME ZONE;
+
+-- Usado pelo job de expurgo para localizar tenants vencidos
+CREATE INDEX IF NOT EXISTS idx_tenant_deleted_at
+    ON tenant (deleted_at)
+    WHERE deleted_at IS NOT NULL;
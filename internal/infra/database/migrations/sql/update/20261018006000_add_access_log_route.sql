@@ -0,0 +1,8 @@
+-- Rota do Gin (ex.: /api/user/:identifier), usada para agregar o consumo por endpoint
+-- sem explodir a cardinalidade com UUIDs e identificadores da URL.
+ALTER TABLE access_log
+    ADD COLUMN IF NOT EXISTS route TEXT;
+
+-- O agregador de consumo varre o log por janela de tempo
+CREATE INDEX IF NOT EXISTS idx_access_log_request_time
+    ON access_log (request_time);
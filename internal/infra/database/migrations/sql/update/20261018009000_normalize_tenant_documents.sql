@@ -0,0 +1,125 @@
+-- Tipo do documento do tenant (cpf, cnpj ou foreign). NULL = documento legado ainda não validado,
+-- mantido como estava até ser corrigido por um administrador.
+ALTER TABLE tenant
+    ADD COLUMN IF NOT EXISTS document_type VARCHAR(10)
+        CHECK (document_type IN ('cpf', 'cnpj', 'foreign'));
+
+-- Relatório dos documentos que a normalização não pôde aplicar:
+--   invalid   = formato ou dígito verificador inválido (o documento fica como estava, sem tipo)
+--   duplicate = a forma canônica pertence a um tenant mais antigo (conflicting_tenant_uuid). Se o
+--               tenant já usava a forma canônica, o documento recebe o sufixo '#<uuid>' para que
+--               o mais antigo fique com ela; o original continua em document
+CREATE TABLE IF NOT EXISTS tenant_document_issues (
+    tenant_uuid UUID NOT NULL REFERENCES tenant(uuid) ON DELETE CASCADE,
+    document VARCHAR(100) NOT NULL,
+    normalized VARCHAR(100) NOT NULL,
+    issue VARCHAR(20) NOT NULL CHECK (issue IN ('invalid', 'duplicate')),
+    conflicting_tenant_uuid UUID,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+    PRIMARY KEY (tenant_uuid, issue)
+);
+
+-- Normaliza os documentos existentes com as mesmas regras do pacote internal/pkg/document:
+-- remove pontuação, converte para maiúsculas e confere os dígitos verificadores.
+-- Em caso de duplicidade, a forma canônica fica com um tenant já validado que a tenha ou, sem
+-- ele, com o tenant mais antigo, mesmo que um mais novo já a use.
+DO $$
+DECLARE
+    r            RECORD;
+    v            TEXT;
+    kind         TEXT;
+    w            INT[] := ARRAY[6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2];
+    s            INT;
+    d            INT;
+    i            INT;
+    n_normalized INT := 0;
+    n_invalid    INT := 0;
+    n_duplicated INT := 0;
+BEGIN
+    CREATE TEMP TABLE tenant_document_candidates (
+        uuid       UUID PRIMARY KEY,
+        document   TEXT NOT NULL,
+        normalized TEXT NOT NULL,
+        kind       TEXT NOT NULL,
+        create_at  TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+        owner      UUID
+    ) ON COMMIT DROP;
+
+    -- 1. Normaliza todos os documentos legados antes de decidir os conflitos
+    FOR r IN SELECT uuid, document, create_at FROM tenant WHERE document_type IS NULL LOOP
+        v := upper(regexp_replace(r.document, '[[:space:]./-]', '', 'g'));
+        kind := NULL;
+
+        IF v ~ '^[0-9]{11}$' AND v !~ '^(.)\1*$' THEN
+            s := 0;
+            FOR i IN 1..9 LOOP s := s + substr(v, i, 1)::INT * (11 - i); END LOOP;
+            d := CASE WHEN s % 11 < 2 THEN 0 ELSE 11 - s % 11 END;
+            IF d = substr(v, 10, 1)::INT THEN
+                s := 0;
+                FOR i IN 1..10 LOOP s := s + substr(v, i, 1)::INT * (12 - i); END LOOP;
+                d := CASE WHEN s % 11 < 2 THEN 0 ELSE 11 - s % 11 END;
+                IF d = substr(v, 11, 1)::INT THEN
+                    kind := 'cpf';
+                END IF;
+            END IF;
+        ELSIF v ~ '^[0-9A-Z]{12}[0-9]{2}$' AND v !~ '^(.)\1*$' THEN
+            -- CNPJ alfanumérico: cada caractere vale seu código ASCII menos 48
+            s := 0;
+            FOR i IN 1..12 LOOP s := s + (ascii(substr(v, i, 1)) - 48) * w[i + 1]; END LOOP;
+            d := CASE WHEN s % 11 < 2 THEN 0 ELSE 11 - s % 11 END;
+            IF d = substr(v, 13, 1)::INT THEN
+                s := 0;
+                FOR i IN 1..13 LOOP s := s + (ascii(substr(v, i, 1)) - 48) * w[i]; END LOOP;
+                d := CASE WHEN s % 11 < 2 THEN 0 ELSE 11 - s % 11 END;
+                IF d = substr(v, 14, 1)::INT THEN
+                    kind := 'cnpj';
+                END IF;
+            END IF;
+        END IF;
+
+        IF kind IS NULL THEN
+            INSERT INTO tenant_document_issues (tenant_uuid, document, normalized, issue)
+            VALUES (r.uuid, r.document, v, 'invalid')
+            ON CONFLICT DO NOTHING;
+            n_invalid := n_invalid + 1;
+            CONTINUE;
+        END IF;
+
+        INSERT INTO tenant_document_candidates (uuid, document, normalized, kind, create_at)
+        VALUES (r.uuid, r.document, v, kind, r.create_at);
+    END LOOP;
+
+    -- 2. Dono de cada forma canônica: o tenant já validado que a tenha ou o candidato mais antigo
+    UPDATE tenant_document_candidates c
+    SET owner = COALESCE(
+        (SELECT t.uuid FROM tenant t WHERE t.document = c.normalized AND t.document_type IS NOT NULL),
+        (SELECT o.uuid FROM tenant_document_candidates o
+          WHERE o.normalized = c.normalized
+          ORDER BY o.create_at, o.uuid
+          LIMIT 1)
+    );
+
+    FOR r IN SELECT * FROM tenant_document_candidates WHERE owner <> uuid ORDER BY create_at, uuid LOOP
+        INSERT INTO tenant_document_issues (tenant_uuid, document, normalized, issue, conflicting_tenant_uuid)
+        VALUES (r.uuid, r.document, r.normalized, 'duplicate', r.owner)
+        ON CONFLICT DO NOTHING;
+        RAISE WARNING 'Documento duplicado após normalização: tenant % (%) conflita com tenant % (%)',
+            r.uuid, r.document, r.owner, r.normalized;
+        n_duplicated := n_duplicated + 1;
+    END LOOP;
+
+    -- 3. Os tenants mais novos que já usavam a forma canônica a liberam para o dono
+    UPDATE tenant t
+    SET document = c.document || '#' || c.uuid::TEXT
+    FROM tenant_document_candidates c
+    WHERE t.uuid = c.uuid AND c.owner <> c.uuid AND t.document = c.normalized;
+
+    UPDATE tenant t
+    SET document = c.normalized, document_type = c.kind
+    FROM tenant_document_candidates c
+    WHERE t.uuid = c.uuid AND c.owner = c.uuid;
+    GET DIAGNOSTICS n_normalized = ROW_COUNT;
+
+    RAISE NOTICE 'Documentos de tenant: % normalizados, % inválidos, % duplicados (detalhes em tenant_document_issues)',
+        n_normalized, n_invalid, n_duplicated;
+END $$;
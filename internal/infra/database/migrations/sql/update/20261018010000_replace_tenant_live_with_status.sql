@@ -0,0 +1,28 @@
+-- Substitui o booleano live pelo status do ciclo de vida do tenant
+ALTER TABLE tenant
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('trial', 'active', 'past_due', 'suspended', 'cancelled')),
+    ADD COLUMN IF NOT EXISTS trial_ends_at TIMESTAMP WITHOUT TIME ZONE;
+
+-- live = false passa a ser suspended, com o registro inicial no histórico
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'tenant' AND column_name = 'live') THEN
+        UPDATE tenant SET status = CASE WHEN live THEN 'active' ELSE 'suspended' END;
+
+        INSERT INTO tenant_status_history (tenant_uuid, from_status, to_status, reason, create_at)
+        SELECT uuid, NULL, status, 'Migração do campo live', NOW() AT TIME ZONE 'utc'
+        FROM tenant;
+
+        DROP INDEX IF EXISTS idx_tenant_live;
+        ALTER TABLE tenant DROP COLUMN live;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_tenant_status
+    ON tenant (status);
+
+-- Usado pelo job que encerra as avaliações vencidas
+CREATE INDEX IF NOT EXISTS idx_tenant_trial_ends_at
+    ON tenant (trial_ends_at)
+    WHERE status = 'trial';
@@ -0,0 +1,19 @@
+-- Busca aproximada (pg_trgm) e por palavras (tsvector) em tenants e usuários.
+-- A criação da extensão exige um usuário com permissão para CREATE EXTENSION no banco.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Trigramas: ILIKE '%...%', similarity (%) e word_similarity (<%)
+CREATE INDEX IF NOT EXISTS idx_tenant_name_trgm
+    ON tenant USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_tenant_document_trgm
+    ON tenant USING gin (document gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm
+    ON users USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm
+    ON users USING gin (email gin_trgm_ops);
+
+-- Palavras inteiras, em qualquer ordem. Configuração 'simple': nomes próprios não passam por stemming
+CREATE INDEX IF NOT EXISTS idx_tenant_name_tsv
+    ON tenant USING gin (to_tsvector('simple', name));
+CREATE INDEX IF NOT EXISTS idx_users_name_tsv
+    ON users USING gin (to_tsvector('simple', name));
@@ -0,0 +1,9 @@
+-- Campos personalizados de tenants e usuários, validados pelas definições de custom_fields.
+ALTER TABLE tenant ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
+
+-- Filtros das listagens por contenção (metadata @> '{"chave": "valor"}')
+CREATE INDEX IF NOT EXISTS idx_tenant_metadata
+    ON tenant USING gin (metadata jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_users_metadata
+    ON users USING gin (metadata jsonb_path_ops);
@@ -0,0 +1,8 @@
+-- Status do acesso, separado do status HTTP: 'allowed' ou 'ip_denied' (origem fora da lista de IPs do tenant)
+ALTER TABLE access_log
+    ADD COLUMN IF NOT EXISTS status VARCHAR(32) NOT NULL DEFAULT 'allowed';
+
+-- Consulta das tentativas negadas (status <> 'allowed') por tenant
+CREATE INDEX IF NOT EXISTS idx_access_log_denied
+    ON access_log (tenant_uuid, created_at DESC)
+    WHERE status <> 'allowed';
@@ -0,0 +1,67 @@
+-- Isolamento do tenant: 'shared' (tabelas de public, filtradas por tenant_uuid) ou 'schema'
+-- (usuários e grupos em um schema próprio, tenant_<uuid>)
+ALTER TABLE tenant
+    ADD COLUMN IF NOT EXISTS isolation VARCHAR(16) NOT NULL DEFAULT 'shared'
+        CHECK (isolation IN ('shared', 'schema')),
+    ADD COLUMN IF NOT EXISTS schema_name TEXT UNIQUE;
+
+-- Índice global de usuários, em public: resolve o tenant (e o schema) de um usuário pelo UUID ou email
+-- e garante a unicidade do email entre todos os schemas
+CREATE TABLE IF NOT EXISTS user_directory (
+    uuid UUID PRIMARY KEY,
+    tenant_uuid UUID,
+    email VARCHAR(255) NOT NULL,
+
+    CONSTRAINT user_directory_email_key UNIQUE (email),
+    CONSTRAINT fk_user_directory_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_directory_tenant
+    ON user_directory (tenant_uuid);
+
+INSERT INTO user_directory (uuid, tenant_uuid, email)
+SELECT uuid, tenant_uuid, email FROM users
+ON CONFLICT (uuid) DO NOTHING;
+
+-- Mantido por trigger nas tabelas users de public e de cada schema de tenant
+CREATE OR REPLACE FUNCTION public.sync_user_directory() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        DELETE FROM public.user_directory WHERE uuid = OLD.uuid;
+        RETURN OLD;
+    ELSIF TG_OP = 'UPDATE' THEN
+        UPDATE public.user_directory
+        SET email = NEW.email, tenant_uuid = NEW.tenant_uuid
+        WHERE uuid = NEW.uuid;
+        RETURN NEW;
+    END IF;
+    INSERT INTO public.user_directory (uuid, tenant_uuid, email)
+    VALUES (NEW.uuid, NEW.tenant_uuid, NEW.email);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_users_directory ON users;
+CREATE TRIGGER trg_users_directory
+    AFTER INSERT OR UPDATE OF email, tenant_uuid OR DELETE ON users
+    FOR EACH ROW EXECUTE FUNCTION public.sync_user_directory();
+
+-- Tabelas compartilhadas passam a referenciar o índice global, pois usuários de tenants isolados
+-- não existem em public.users. As regras de ON DELETE são preservadas.
+DO $$
+DECLARE
+    fk RECORD;
+BEGIN
+    FOR fk IN
+        SELECT c.conname, c.conrelid::regclass AS tbl, pg_get_constraintdef(c.oid) AS def
+        FROM pg_constraint AS c
+        WHERE c.contype = 'f' AND c.confrelid = 'public.users'::regclass
+    LOOP
+        EXECUTE format('ALTER TABLE %s DROP CONSTRAINT %I', fk.tbl, fk.conname);
+        EXECUTE format('ALTER TABLE %s ADD CONSTRAINT %I %s', fk.tbl, fk.conname,
+            regexp_replace(fk.def, 'REFERENCES (public\.)?users\(', 'REFERENCES public.user_directory('));
+    END LOOP;
+END $$;
@@ -0,0 +1,19 @@
+-- Isolamento 'database': usuários e grupos do tenant em um banco de dados dedicado
+ALTER TABLE tenant DROP CONSTRAINT IF EXISTS tenant_isolation_check;
+ALTER TABLE tenant
+    ADD CONSTRAINT tenant_isolation_check
+        CHECK (isolation IN ('shared', 'schema', 'database'));
+
+-- Registro de conexões dos bancos dedicados. Os dados de conexão (host, usuário, senha...)
+-- ficam cifrados (AES-256-GCM) com a chave tenant.isolation.database_key; nada é gravado em claro.
+CREATE TABLE IF NOT EXISTS tenant_database (
+    tenant_uuid UUID PRIMARY KEY,
+    connection TEXT NOT NULL,
+    create_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+    update_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_tenant_database_tenant
+        FOREIGN KEY(tenant_uuid)
+            REFERENCES tenant(uuid)
+            ON DELETE CASCADE
+);
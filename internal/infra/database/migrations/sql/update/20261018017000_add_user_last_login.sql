@@ -0,0 +1,5 @@
+-- Data do último login do usuário, usada nos filtros e na ordenação de /user/list
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMP NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_last_login_at ON users (last_login_at);
@@ -0,0 +1,7 @@
+-- Dados de perfil que o próprio usuário altera em /api/me. Vazio em locale/timezone usa a
+-- configuração do tenant.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS phone VARCHAR(30) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS avatar_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(35) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+-- O expurgo do tenant apagava em cascata as suas retenções legais. O registro da retenção
+-- deve sobreviver ao tenant, então a chave estrangeira é removida nos bancos já criados
+ALTER TABLE legal_holds
+    DROP CONSTRAINT IF EXISTS fk_legal_holds_tenant;
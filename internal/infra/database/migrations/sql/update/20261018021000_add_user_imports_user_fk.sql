@@ -0,0 +1,14 @@
+-- Autor da importação: referencia o índice global de usuários, que inclui os tenants isolados.
+-- A importação continua no histórico do tenant depois que o autor é removido.
+UPDATE user_imports AS i
+SET requested_by = NULL
+WHERE requested_by IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM user_directory AS d WHERE d.uuid = i.requested_by);
+
+ALTER TABLE user_imports
+    DROP CONSTRAINT IF EXISTS fk_user_imports_requested_by;
+ALTER TABLE user_imports
+    ADD CONSTRAINT fk_user_imports_requested_by
+        FOREIGN KEY (requested_by)
+            REFERENCES user_directory(uuid)
+            ON DELETE SET NULL;
@@ -0,0 +1,12 @@
+-- O histórico de logins pertence ao usuário: referencia o índice global (inclui os usuários de
+-- tenants isolados) e sai junto com ele.
+DELETE FROM user_login_history AS h
+WHERE NOT EXISTS (SELECT 1 FROM user_directory AS d WHERE d.uuid = h.user_uuid);
+
+ALTER TABLE user_login_history
+    DROP CONSTRAINT IF EXISTS fk_user_login_history_user;
+ALTER TABLE user_login_history
+    ADD CONSTRAINT fk_user_login_history_user
+        FOREIGN KEY (user_uuid)
+            REFERENCES user_directory(uuid)
+            ON DELETE CASCADE;
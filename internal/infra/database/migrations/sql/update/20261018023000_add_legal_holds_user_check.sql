@@ -0,0 +1,18 @@
+-- O usuário da retenção deve existir no índice global (inclui os tenants isolados) quando ela é
+-- criada. Uma chave estrangeira impediria a remoção do usuário ou apagaria a retenção com ele, e o
+-- registro deve sobreviver ao usuário como sobrevive ao tenant: a verificação fica em um trigger.
+CREATE OR REPLACE FUNCTION public.check_legal_holds_user() RETURNS trigger AS $$
+BEGIN
+    IF NEW.user_uuid IS NOT NULL
+        AND NOT EXISTS (SELECT 1 FROM public.user_directory WHERE uuid = NEW.user_uuid) THEN
+        RAISE EXCEPTION 'user % not found in user_directory', NEW.user_uuid
+            USING ERRCODE = 'foreign_key_violation', CONSTRAINT = 'fk_legal_holds_user';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_legal_holds_user ON legal_holds;
+CREATE TRIGGER trg_legal_holds_user
+    BEFORE INSERT OR UPDATE OF user_uuid ON legal_holds
+    FOR EACH ROW EXECUTE FUNCTION public.check_legal_holds_user();
@@ -0,0 +1,14 @@
+-- Autor do token SCIM: referencia o índice global de usuários, que inclui os tenants isolados.
+-- O token continua válido depois que o autor é removido.
+UPDATE scim_tokens AS t
+SET created_by = NULL
+WHERE created_by IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM user_directory AS d WHERE d.uuid = t.created_by);
+
+ALTER TABLE scim_tokens
+    DROP CONSTRAINT IF EXISTS fk_scim_tokens_created_by;
+ALTER TABLE scim_tokens
+    ADD CONSTRAINT fk_scim_tokens_created_by
+        FOREIGN KEY (created_by)
+            REFERENCES user_directory(uuid)
+            ON DELETE SET NULL;
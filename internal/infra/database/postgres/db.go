@@ -50,6 +50,9 @@ func InitPostgres() *gorm.DB {
 			log.Fatalf("[DATABASE] erro ao testar conexão com o banco de dados: %v", err)
 		}
 
+		// Consultas com schema de tenant no contexto (WithSchemaScope/UseSchema) usam uma conexão própria
+		installSchemaRouter(db, sqlDB)
+
 		log.Println("[DATABASE] Conexão GORM com PostgreSQL estabelecida com sucesso.")
 	})
 
@@ -96,7 +99,7 @@ func buildDSN() string {
 		name = "appdb"
 	}
 	ssl := viper.GetString("databases.postgres.ssl_mode")
-	if !isValidSSLMode(ssl) {
+	if !IsValidSSLMode(ssl) {
 		log.Printf("[DATABASE] Modo SSL '%s' inválido. Usando o padrão '%s'.", ssl, SSLDisable)
 		ssl = SSLDisable
 	}
@@ -107,8 +110,8 @@ func buildDSN() string {
 	)
 }
 
-// isValidSSLMode verifica se a string de modo SSL fornecida é um valor válido.
-func isValidSSLMode(mode string) bool {
+// IsValidSSLMode verifica se a string de modo SSL fornecida é um valor válido.
+func IsValidSSLMode(mode string) bool {
 	switch mode {
 	case SSLDisable, SSLRequire, SSLVerifyFull, SSLVerifyCA:
 		return true
@@ -0,0 +1,286 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrNoSchemaScope indica que o contexto não possui um escopo de schema (ver WithSchemaScope).
+var ErrNoSchemaScope = errors.New("context without schema scope")
+
+var tenantSchemaPattern = regexp.MustCompile(`^tenant_[0-9a-f]{32}$`)
+
+// TenantSchemaName retorna o nome do schema dedicado do tenant (tenant_<uuid sem hífens>).
+func TenantSchemaName(tenantUUID uuid.UUID) string {
+	return "tenant_" + strings.ReplaceAll(tenantUUID.String(), "-", "")
+}
+
+// IsTenantSchema indica se o nome segue o padrão de TenantSchemaName. Apenas esses nomes são
+// aceitos em search_path, o que também impede injeção de SQL pelo nome do schema.
+func IsTenantSchema(name string) bool {
+	return tenantSchemaPattern.MatchString(name)
+}
+
+// SearchPath monta o search_path do schema do tenant, com fallback para as tabelas compartilhadas em public.
+func SearchPath(schema string) string {
+	return fmt.Sprintf(`"%s", public`, schema)
+}
+
+type schemaScopeKey struct{}
+
+// schemaScope prende uma conexão do pool enquanto houver um schema de tenant ativo no contexto,
+// pois o search_path é um estado da conexão. Para tenants com banco dedicado, guarda o *gorm.DB
+// desse banco (ver Conn).
+type schemaScope struct {
+	mu       sync.Mutex
+	schema   string
+	database *gorm.DB
+	conn     *sql.Conn
+	applied  string
+	closed   bool
+}
+
+// WithSchemaScope cria um escopo de schema no contexto. Enquanto nenhum schema for escolhido
+// (UseSchema), as consultas seguem no pool comum, em public. release devolve a conexão presa ao pool
+// e deve ser chamado ao fim da requisição; se o contexto já possuir escopo, nada é criado.
+func WithSchemaScope(ctx context.Context) (context.Context, func()) {
+	if _, ok := ctx.Value(schemaScopeKey{}).(*schemaScope); ok {
+		return ctx, func() {}
+	}
+	scope := &schemaScope{}
+	return context.WithValue(ctx, schemaScopeKey{}, scope), scope.close
+}
+
+// HasSchemaScope indica se o contexto possui um escopo de schema.
+func HasSchemaScope(ctx context.Context) bool {
+	_, ok := ctx.Value(schemaScopeKey{}).(*schemaScope)
+	return ok
+}
+
+// UseSchema direciona as consultas do escopo para o schema informado ("" volta para public).
+// restore volta ao schema anterior.
+func UseSchema(ctx context.Context, schema string) (func(), error) {
+	scope, ok := ctx.Value(schemaScopeKey{}).(*schemaScope)
+	if !ok {
+		return nil, ErrNoSchemaScope
+	}
+	if schema != "" && !IsTenantSchema(schema) {
+		return nil, fmt.Errorf("schema de tenant inválido: %s", schema)
+	}
+
+	scope.mu.Lock()
+	previous := scope.schema
+	scope.schema = schema
+	scope.mu.Unlock()
+
+	return func() {
+		scope.mu.Lock()
+		scope.schema = previous
+		scope.mu.Unlock()
+	}, nil
+}
+
+// CurrentSchema retorna o schema de tenant ativo no contexto ("" para public).
+func CurrentSchema(ctx context.Context) string {
+	scope, ok := ctx.Value(schemaScopeKey{}).(*schemaScope)
+	if !ok {
+		return ""
+	}
+	scope.mu.Lock()
+	defer scope.mu.Unlock()
+	if scope.closed {
+		return ""
+	}
+	return scope.schema
+}
+
+// UseDatabase direciona as consultas das tabelas do tenant (ver Conn) para o banco dedicado
+// informado (nil volta para o banco de controle). restore volta ao banco anterior.
+func UseDatabase(ctx context.Context, database *gorm.DB) (func(), error) {
+	scope, ok := ctx.Value(schemaScopeKey{}).(*schemaScope)
+	if !ok {
+		return nil, ErrNoSchemaScope
+	}
+
+	scope.mu.Lock()
+	previous := scope.database
+	scope.database = database
+	scope.mu.Unlock()
+
+	return func() {
+		scope.mu.Lock()
+		scope.database = previous
+		scope.mu.Unlock()
+	}, nil
+}
+
+// HasTenantDatabase indica se o contexto aponta para o banco dedicado de um tenant.
+func HasTenantDatabase(ctx context.Context) bool {
+	return tenantDatabase(ctx) != nil
+}
+
+// Conn retorna a conexão das tabelas do tenant (users, tenant_groups, tenant_group_members):
+// o banco dedicado do escopo, quando houver, ou fallback (banco de controle). As tabelas
+// compartilhadas devem sempre usar o banco de controle.
+func Conn(ctx context.Context, fallback *gorm.DB) *gorm.DB {
+	if database := tenantDatabase(ctx); database != nil {
+		return database.WithContext(ctx)
+	}
+	return fallback.WithContext(ctx)
+}
+
+func tenantDatabase(ctx context.Context) *gorm.DB {
+	scope, ok := ctx.Value(schemaScopeKey{}).(*schemaScope)
+	if !ok {
+		return nil
+	}
+	scope.mu.Lock()
+	defer scope.mu.Unlock()
+	if scope.closed {
+		return nil
+	}
+	return scope.database
+}
+
+// executor é o que *sql.DB e *sql.Conn têm em comum.
+type executor interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// acquire retorna a conexão presa com o search_path do schema atual, ou nil quando as consultas
+// devem seguir no pool (sem schema ou escopo já encerrado).
+func (s *schemaScope) acquire(ctx context.Context, pool *sql.DB) (*sql.Conn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed || (s.schema == "" && s.conn == nil) {
+		return nil, nil
+	}
+	if s.conn == nil {
+		conn, err := pool.Conn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.conn = conn
+	}
+	if s.applied != s.schema {
+		stmt := "SET search_path TO DEFAULT"
+		if s.schema != "" {
+			stmt = "SET search_path TO " + SearchPath(s.schema)
+		}
+		if _, err := s.conn.ExecContext(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("falha ao definir search_path: %w", err)
+		}
+		s.applied = s.schema
+	}
+	return s.conn, nil
+}
+
+// close devolve a conexão ao pool com o search_path padrão. Se não for possível restaurá-lo,
+// a conexão é descartada para não vazar o schema para outra requisição.
+func (s *schemaScope) close() {
+	s.mu.Lock()
+	conn := s.conn
+	s.closed, s.conn, s.database = true, nil, nil
+	s.mu.Unlock()
+
+	if conn == nil {
+		return
+	}
+	if _, err := conn.ExecContext(context.Background(), "RESET search_path"); err != nil {
+		log.Printf("[DATABASE] descartando conexão com search_path de tenant: %v", err)
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+	_ = conn.Close()
+}
+
+// schemaRouter substitui o ConnPool do GORM: consultas feitas com um contexto que possui schema
+// de tenant ativo vão para a conexão presa do escopo; as demais seguem no pool.
+type schemaRouter struct {
+	pool *sql.DB
+}
+
+func (r *schemaRouter) executor(ctx context.Context) (executor, error) {
+	scope, ok := ctx.Value(schemaScopeKey{}).(*schemaScope)
+	if !ok {
+		return r.pool, nil
+	}
+	conn, err := scope.acquire(ctx, r.pool)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return r.pool, nil
+	}
+	return conn, nil
+}
+
+func (r *schemaRouter) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	exec, err := r.executor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return exec.PrepareContext(ctx, query)
+}
+
+func (r *schemaRouter) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	exec, err := r.executor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return exec.ExecContext(ctx, query, args...)
+}
+
+func (r *schemaRouter) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	exec, err := r.executor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return exec.QueryContext(ctx, query, args...)
+}
+
+func (r *schemaRouter) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	exec, err := r.executor(ctx)
+	if err != nil {
+		// *sql.Row não aceita um erro pronto: a consulta é feita com o contexto já cancelado
+		log.Printf("[DATABASE] %v", err)
+		failed, cancel := context.WithCancelCause(ctx)
+		cancel(err)
+		return r.pool.QueryRowContext(failed, query, args...)
+	}
+	return exec.QueryRowContext(ctx, query, args...)
+}
+
+func (r *schemaRouter) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	exec, err := r.executor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return exec.BeginTx(ctx, opts)
+}
+
+// GetDBConn mantém db.DB() funcionando com o ConnPool substituído.
+func (r *schemaRouter) GetDBConn() (*sql.DB, error) {
+	return r.pool, nil
+}
+
+// installSchemaRouter faz o GORM passar pelo schemaRouter.
+func installSchemaRouter(gormDB *gorm.DB, pool *sql.DB) {
+	router := &schemaRouter{pool: pool}
+	gormDB.ConnPool = router
+	gormDB.Statement.ConnPool = router
+}
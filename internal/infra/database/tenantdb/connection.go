@@ -0,0 +1,52 @@
+package tenantdb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"tenant-crud-simply/internal/infra/database/postgres"
+)
+
+// Connection são os dados de acesso ao banco dedicado de um tenant. Fica cifrada no banco de controle.
+type Connection struct {
+	Host     string `json:"host"`
+	Port     string `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Database string `json:"db_name"`
+	SSLMode  string `json:"ssl_mode"`
+}
+
+// Validate confere os campos obrigatórios e aplica o padrão da porta (5432) e do SSL (require).
+func (c *Connection) Validate() error {
+	c.Host, c.User, c.Database = strings.TrimSpace(c.Host), strings.TrimSpace(c.User), strings.TrimSpace(c.Database)
+	if c.Host == "" || c.User == "" || c.Database == "" {
+		return errors.New("host, user e db_name são obrigatórios")
+	}
+	if c.Port == "" {
+		c.Port = "5432"
+	}
+	if c.SSLMode == "" {
+		c.SSLMode = postgres.SSLRequire
+	}
+	if !postgres.IsValidSSLMode(c.SSLMode) {
+		return fmt.Errorf("ssl_mode inválido: %s", c.SSLMode)
+	}
+	return nil
+}
+
+// DSN monta a string de conexão no formato chave=valor da libpq, com os valores entre aspas.
+func (c Connection) DSN() string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		quote(c.Host), quote(c.Port), quote(c.User), quote(c.Password), quote(c.Database), quote(c.SSLMode),
+	)
+}
+
+// quote protege valores com espaços, aspas ou barras (comum em senhas).
+func quote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
@@ -0,0 +1,72 @@
+package tenantdb
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidKey indica que a chave de cifragem não é um valor base64 de 32 bytes (AES-256).
+var ErrInvalidKey = errors.New("tenant database key must be 32 bytes encoded in base64")
+
+// sealer cifra os dados de conexão com AES-256-GCM. O UUID do tenant entra como dado
+// autenticado, então o conteúdo cifrado de um tenant não pode ser copiado para outro.
+type sealer struct {
+	aead cipher.AEAD
+}
+
+func newSealer(key string) (*sealer, error) {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(raw) != 32 {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &sealer{aead: aead}, nil
+}
+
+// seal retorna base64(nonce || texto cifrado) da conexão serializada em JSON.
+func (s *sealer) seal(tenantUUID uuid.UUID, conn Connection) (string, error) {
+	plain, err := json.Marshal(conn)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := s.aead.Seal(nonce, nonce, plain, tenantUUID[:])
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *sealer) open(tenantUUID uuid.UUID, value string) (Connection, error) {
+	sealed, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return Connection{}, fmt.Errorf("conexão cifrada inválida: %w", err)
+	}
+	size := s.aead.NonceSize()
+	if len(sealed) < size {
+		return Connection{}, errors.New("conexão cifrada inválida")
+	}
+	plain, err := s.aead.Open(nil, sealed[:size], sealed[size:], tenantUUID[:])
+	if err != nil {
+		return Connection{}, fmt.Errorf("falha ao decifrar a conexão (chave incorreta?): %w", err)
+	}
+	var conn Connection
+	if err := json.Unmarshal(plain, &conn); err != nil {
+		return Connection{}, err
+	}
+	return conn, nil
+}
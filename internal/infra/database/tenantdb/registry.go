@@ -0,0 +1,295 @@
+package tenantdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"tenant-crud-simply/internal/infra/database/migrations"
+
+	"github.com/google/uuid"
+	gormPostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var (
+	instance          *Registry
+	once              sync.Once
+	initErr           error
+	ErrNotInitialized = errors.New("tenant database registry not initialized")
+	// ErrNotRegistered indica que o tenant não possui banco dedicado registrado.
+	ErrNotRegistered = errors.New("tenant database not registered")
+)
+
+const (
+	DefaultIdleTimeout  = 10 * time.Minute
+	DefaultMaxOpenConns = 10
+	pingTimeout         = 5 * time.Second
+)
+
+// Config usada somente no New()
+type Config struct {
+	// Key é a chave AES-256 (32 bytes em base64) que cifra os dados de conexão.
+	Key string
+	// IdleTimeout é o tempo sem uso após o qual o pool de um tenant é fechado (padrão 10 min).
+	IdleTimeout time.Duration
+	// MaxOpenConns limita as conexões abertas por banco de tenant (padrão 10).
+	MaxOpenConns int
+}
+
+// Registry mantém os pools de conexão dos bancos dedicados, abertos sob demanda e fechados
+// quando ficam ociosos. Os dados de conexão ficam cifrados na tabela tenant_database do banco de controle.
+type Registry struct {
+	control *gorm.DB
+	sealer  *sealer
+	cfg     Config
+
+	mu    sync.Mutex
+	pools map[uuid.UUID]*pool
+}
+
+// pool é o banco de um tenant. ready é fechado quando a abertura termina (com db ou err),
+// para que requisições simultâneas do mesmo tenant esperem uma única abertura.
+type pool struct {
+	ready    chan struct{}
+	db       *gorm.DB
+	err      error
+	lastUsed time.Time
+}
+
+type record struct {
+	TenantUUID uuid.UUID `gorm:"column:tenant_uuid;primaryKey"`
+	Connection string    `gorm:"column:connection"`
+}
+
+func (record) TableName() string {
+	return "tenant_database"
+}
+
+// New inicializa o singleton do registro de bancos dedicados.
+func New(control *gorm.DB, cfg Config) (*Registry, error) {
+	once.Do(func() {
+		if control == nil {
+			initErr = errors.New("database connection cannot be nil")
+			return
+		}
+		s, err := newSealer(cfg.Key)
+		if err != nil {
+			initErr = err
+			return
+		}
+		if cfg.IdleTimeout <= 0 {
+			cfg.IdleTimeout = DefaultIdleTimeout
+		}
+		if cfg.MaxOpenConns <= 0 {
+			cfg.MaxOpenConns = DefaultMaxOpenConns
+		}
+		instance = &Registry{control: control, sealer: s, cfg: cfg, pools: map[uuid.UUID]*pool{}}
+	})
+	return instance, initErr
+}
+
+// Use retorna o registro inicializado por New.
+func Use() (*Registry, error) {
+	if instance == nil {
+		return nil, ErrNotInitialized
+	}
+	return instance, nil
+}
+
+// DB retorna o pool do banco dedicado do tenant, abrindo-o na primeira chamada.
+func (r *Registry) DB(ctx context.Context, tenantUUID uuid.UUID) (*gorm.DB, error) {
+	r.mu.Lock()
+	p, ok := r.pools[tenantUUID]
+	if !ok {
+		p = &pool{ready: make(chan struct{})}
+		r.pools[tenantUUID] = p
+	}
+	p.lastUsed = time.Now()
+	r.mu.Unlock()
+
+	if !ok {
+		p.db, p.err = r.openTenant(ctx, tenantUUID)
+		if p.err != nil {
+			// A próxima chamada tenta de novo
+			r.mu.Lock()
+			delete(r.pools, tenantUUID)
+			r.mu.Unlock()
+		}
+		close(p.ready)
+	}
+
+	select {
+	case <-p.ready:
+		return p.db, p.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (r *Registry) openTenant(ctx context.Context, tenantUUID uuid.UUID) (*gorm.DB, error) {
+	conn, err := r.Connection(ctx, tenantUUID)
+	if err != nil {
+		return nil, err
+	}
+	db, err := r.open(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("banco do tenant %s: %w", tenantUUID, err)
+	}
+	log.Printf("[TENANT-DB] Pool do tenant %s aberto (%s/%s).", tenantUUID, conn.Host, conn.Database)
+	return db, nil
+}
+
+// open abre e testa um pool para a conexão informada.
+func (r *Registry) open(ctx context.Context, conn Connection) (*gorm.DB, error) {
+	db, err := gorm.Open(gormPostgres.Open(conn.DSN()), &gorm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(r.cfg.MaxOpenConns)
+	sqlDB.SetConnMaxIdleTime(r.cfg.IdleTimeout)
+
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	if err := sqlDB.PingContext(pingCtx); err != nil {
+		_ = sqlDB.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Connection lê e decifra os dados de conexão do tenant.
+func (r *Registry) Connection(ctx context.Context, tenantUUID uuid.UUID) (Connection, error) {
+	var rec record
+	if err := r.control.WithContext(ctx).Where("tenant_uuid = ?", tenantUUID).First(&rec).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Connection{}, ErrNotRegistered
+		}
+		return Connection{}, err
+	}
+	return r.sealer.open(tenantUUID, rec.Connection)
+}
+
+// Provision testa a conexão e cria (ou atualiza) as tabelas do tenant no banco dedicado.
+// O pool usado é temporário: o tenant ainda não está registrado.
+func (r *Registry) Provision(ctx context.Context, conn Connection) error {
+	if err := conn.Validate(); err != nil {
+		return err
+	}
+	db, err := r.open(ctx, conn)
+	if err != nil {
+		return err
+	}
+	defer closeDB(db)
+	return migrations.NewManager(db.WithContext(ctx)).ApplyDatabase()
+}
+
+// Save grava a conexão cifrada do tenant. Recebe a transação do chamador para que o registro
+// seja gravado junto com o tenant; um pool já aberto é descartado para usar os novos dados.
+func (r *Registry) Save(tx *gorm.DB, tenantUUID uuid.UUID, conn Connection) error {
+	if err := conn.Validate(); err != nil {
+		return err
+	}
+	sealed, err := r.sealer.seal(tenantUUID, conn)
+	if err != nil {
+		return err
+	}
+	if err := tx.Exec(`
+INSERT INTO tenant_database (tenant_uuid, connection, create_at, update_at)
+VALUES (?, ?, NOW(), NOW())
+ON CONFLICT (tenant_uuid) DO UPDATE SET connection = EXCLUDED.connection, update_at = NOW()`,
+		tenantUUID, sealed,
+	).Error; err != nil {
+		return fmt.Errorf("falha ao registrar o banco do tenant: %w", err)
+	}
+	r.Evict(tenantUUID)
+	return nil
+}
+
+// Tenants lista os tenants com banco dedicado registrado.
+func (r *Registry) Tenants(ctx context.Context) ([]uuid.UUID, error) {
+	var tenants []uuid.UUID
+	if err := r.control.WithContext(ctx).Model(&record{}).Order("tenant_uuid").Pluck("tenant_uuid", &tenants).Error; err != nil {
+		return nil, fmt.Errorf("falha ao listar bancos de tenant: %w", err)
+	}
+	return tenants, nil
+}
+
+// Migrate aplica as migrations de banco dedicado no banco do tenant.
+func (r *Registry) Migrate(ctx context.Context, tenantUUID uuid.UUID) error {
+	db, err := r.DB(ctx, tenantUUID)
+	if err != nil {
+		return err
+	}
+	return migrations.NewManager(db.WithContext(ctx)).ApplyDatabase()
+}
+
+// Evict fecha o pool do tenant, se estiver aberto.
+func (r *Registry) Evict(tenantUUID uuid.UUID) {
+	r.mu.Lock()
+	p, ok := r.pools[tenantUUID]
+	delete(r.pools, tenantUUID)
+	r.mu.Unlock()
+	if ok {
+		go closePool(p)
+	}
+}
+
+// EvictIdle fecha os pools sem uso há mais de IdleTimeout. Executado pelo scheduler.
+func (r *Registry) EvictIdle(ctx context.Context) error {
+	limit := time.Now().Add(-r.cfg.IdleTimeout)
+
+	r.mu.Lock()
+	var idle []*pool
+	for tenantUUID, p := range r.pools {
+		if p.lastUsed.Before(limit) {
+			idle = append(idle, p)
+			delete(r.pools, tenantUUID)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, p := range idle {
+		closePool(p)
+	}
+	if len(idle) > 0 {
+		log.Printf("[TENANT-DB] %d pool(s) ocioso(s) fechado(s).", len(idle))
+	}
+	return nil
+}
+
+// Close fecha todos os pools abertos.
+func (r *Registry) Close() {
+	r.mu.Lock()
+	pools := r.pools
+	r.pools = map[uuid.UUID]*pool{}
+	r.mu.Unlock()
+
+	for _, p := range pools {
+		closePool(p)
+	}
+}
+
+func closePool(p *pool) {
+	<-p.ready
+	if p.db != nil {
+		closeDB(p.db)
+	}
+}
+
+func closeDB(db *gorm.DB) {
+	sqlDB, err := db.DB()
+	if err == nil {
+		err = sqlDB.Close()
+	}
+	if err != nil {
+		log.Printf("[TENANT-DB] erro ao fechar pool: %v", err)
+	}
+}
@@ -67,7 +67,15 @@ func Use() *TokenGenerator {
 // Métodos continuam iguais, atrelados ao struct TokenGenerator
 
 func (tg *TokenGenerator) GenerateAccessToken(userID uuid.UUID, tenantID uuid.UUID) (string, time.Time, error) {
-	expirationTime := time.Now().UTC().Add(tg.accessExpiry)
+	return tg.GenerateAccessTokenWithExpiry(userID, tenantID, tg.accessExpiry)
+}
+
+// GenerateAccessTokenWithExpiry gera um access token com duração específica (ex.: definida pelo tenant).
+func (tg *TokenGenerator) GenerateAccessTokenWithExpiry(userID uuid.UUID, tenantID uuid.UUID, expiry time.Duration) (string, time.Time, error) {
+	if expiry <= 0 {
+		expiry = tg.accessExpiry
+	}
+	expirationTime := time.Now().UTC().Add(expiry)
 
 	claims := &AccessTokenClaims{
 		TenantID: tenantID.String(),
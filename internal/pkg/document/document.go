@@ -0,0 +1,182 @@
+// Package document valida e normaliza documentos de identificação de tenants:
+// CPF, CNPJ (numérico e o novo alfanumérico) e identificadores estrangeiros.
+package document
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Kind é o tipo do documento.
+type Kind string
+
+const (
+	KindCPF     Kind = "cpf"
+	KindCNPJ    Kind = "cnpj"
+	KindForeign Kind = "foreign" // Identificador estrangeiro: sem dígito verificador, só normalizado
+)
+
+var (
+	ErrInvalid     = errors.New("invalid document")
+	ErrCheckDigits = errors.New("invalid document check digits")
+	ErrUnknownKind = errors.New("unknown document type")
+)
+
+var (
+	cpfRegex     = regexp.MustCompile(`^[0-9]{11}$`)
+	cnpjRegex    = regexp.MustCompile(`^[0-9A-Z]{12}[0-9]{2}$`)
+	foreignRegex = regexp.MustCompile(`^[0-9A-Z]{3,50}$`)
+
+	// Pontuação aceita na entrada e descartada na forma canônica
+	separators = strings.NewReplacer(".", "", "-", "", "/", "", " ", "", "\t", "")
+)
+
+// Value grava o tipo vazio como NULL (documento legado, ainda não validado).
+func (k Kind) Value() (driver.Value, error) {
+	if k == "" {
+		return nil, nil
+	}
+	return string(k), nil
+}
+
+// Scan lê o tipo do banco, tratando NULL como tipo vazio.
+func (k *Kind) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*k = ""
+	case string:
+		*k = Kind(v)
+	case []byte:
+		*k = Kind(v)
+	default:
+		return fmt.Errorf("tipo de documento inesperado: %T", src)
+	}
+	return nil
+}
+
+// Document é um documento já validado, na forma canônica (sem pontuação, em maiúsculas).
+type Document struct {
+	Kind  Kind
+	Value string
+}
+
+// Normalize remove a pontuação e converte para maiúsculas, sem validar.
+// É a mesma regra usada pela migração que normalizou os documentos já cadastrados.
+func Normalize(raw string) string {
+	return strings.ToUpper(separators.Replace(strings.TrimSpace(raw)))
+}
+
+// ParseKind converte o tipo informado pelo cliente. String vazia significa detecção automática.
+func ParseKind(raw string) (Kind, error) {
+	switch k := Kind(strings.ToLower(strings.TrimSpace(raw))); k {
+	case "", KindCPF, KindCNPJ, KindForeign:
+		return k, nil
+	default:
+		return "", ErrUnknownKind
+	}
+}
+
+// Parse valida o documento e devolve sua forma canônica. Com kind vazio o tipo é detectado
+// pelo formato (11 dígitos = CPF, 14 caracteres = CNPJ); identificadores estrangeiros
+// precisam ser declarados explicitamente, para que um CPF digitado errado não seja aceito como estrangeiro.
+func Parse(raw string, kind Kind) (Document, error) {
+	value := Normalize(raw)
+	if kind == "" {
+		switch {
+		case cpfRegex.MatchString(value):
+			kind = KindCPF
+		case cnpjRegex.MatchString(value):
+			kind = KindCNPJ
+		default:
+			return Document{}, ErrInvalid
+		}
+	}
+
+	switch kind {
+	case KindCPF:
+		if !cpfRegex.MatchString(value) || repeated(value) {
+			return Document{}, ErrInvalid
+		}
+		if !validCPF(value) {
+			return Document{}, ErrCheckDigits
+		}
+	case KindCNPJ:
+		if !cnpjRegex.MatchString(value) || repeated(value) {
+			return Document{}, ErrInvalid
+		}
+		if !validCNPJ(value) {
+			return Document{}, ErrCheckDigits
+		}
+	case KindForeign:
+		if !foreignRegex.MatchString(value) {
+			return Document{}, ErrInvalid
+		}
+	default:
+		return Document{}, ErrUnknownKind
+	}
+	return Document{Kind: kind, Value: value}, nil
+}
+
+// Formatted devolve o documento formatado para exibição.
+func (d Document) Formatted() string {
+	return Format(d.Kind, d.Value)
+}
+
+// Format formata um documento canônico para exibição (000.000.000-00 / 00.000.000/0000-00).
+// Documentos estrangeiros, legados ou fora do formato esperado são devolvidos como estão.
+func Format(kind Kind, value string) string {
+	switch {
+	case kind == KindCPF && len(value) == 11:
+		return value[0:3] + "." + value[3:6] + "." + value[6:9] + "-" + value[9:11]
+	case kind == KindCNPJ && len(value) == 14:
+		return value[0:2] + "." + value[2:5] + "." + value[5:8] + "/" + value[8:12] + "-" + value[12:14]
+	default:
+		return value
+	}
+}
+
+// validCPF confere os dois dígitos verificadores (módulo 11, pesos decrescentes a partir de 10 e 11).
+func validCPF(value string) bool {
+	for n := 9; n <= 10; n++ {
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += int(value[i]-'0') * (n + 1 - i)
+		}
+		if checkDigit(sum) != int(value[n]-'0') {
+			return false
+		}
+	}
+	return true
+}
+
+// validCNPJ confere os dígitos verificadores do CNPJ. No formato alfanumérico cada caractere
+// vale seu código ASCII menos 48, o que mantém o cálculo idêntico para CNPJs só numéricos.
+func validCNPJ(value string) bool {
+	weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	for n := 12; n <= 13; n++ {
+		w := weights[13-n:]
+		sum := 0
+		for i := 0; i < n; i++ {
+			sum += int(value[i]-'0') * w[i]
+		}
+		if checkDigit(sum) != int(value[n]-'0') {
+			return false
+		}
+	}
+	return true
+}
+
+func checkDigit(sum int) int {
+	if r := sum % 11; r >= 2 {
+		return 11 - r
+	}
+	return 0
+}
+
+// repeated rejeita sequências de um único caractere (ex.: 00000000000), que passam no dígito verificador.
+func repeated(value string) bool {
+	return strings.Count(value, value[:1]) == len(value)
+}
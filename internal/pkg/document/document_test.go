@@ -0,0 +1,110 @@
+package document
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		kind     Kind
+		wantKind Kind
+		want     string
+		err      error
+	}{
+		// CPF
+		{name: "cpf válido", raw: "52998224725", wantKind: KindCPF, want: "52998224725"},
+		{name: "cpf válido com máscara", raw: "529.982.247-25", wantKind: KindCPF, want: "52998224725"},
+		{name: "cpf válido com espaços", raw: " 111 444 777 35 ", wantKind: KindCPF, want: "11144477735"},
+		{name: "cpf declarado", raw: "111.444.777-35", kind: KindCPF, wantKind: KindCPF, want: "11144477735"},
+		{name: "cpf com primeiro dígito errado", raw: "529.982.247-35", err: ErrCheckDigits},
+		{name: "cpf com segundo dígito errado", raw: "529.982.247-24", err: ErrCheckDigits},
+		{name: "cpf com dígitos repetidos", raw: "000.000.000-00", err: ErrInvalid},
+		{name: "cpf com noves repetidos", raw: "99999999999", kind: KindCPF, err: ErrInvalid},
+		{name: "cpf curto", raw: "5299822472", kind: KindCPF, err: ErrInvalid},
+		{name: "cpf com letra", raw: "5299822472A", kind: KindCPF, err: ErrInvalid},
+
+		// CNPJ numérico
+		{name: "cnpj válido", raw: "11222333000181", wantKind: KindCNPJ, want: "11222333000181"},
+		{name: "cnpj válido com máscara", raw: "11.222.333/0001-81", wantKind: KindCNPJ, want: "11222333000181"},
+		{name: "cnpj declarado", raw: "11.444.777/0001-61", kind: KindCNPJ, wantKind: KindCNPJ, want: "11444777000161"},
+		{name: "cnpj com primeiro dígito errado", raw: "11.222.333/0001-91", err: ErrCheckDigits},
+		{name: "cnpj com segundo dígito errado", raw: "11.222.333/0001-80", err: ErrCheckDigits},
+		{name: "cnpj com dígitos repetidos", raw: "11.111.111/1111-11", err: ErrInvalid},
+		{name: "cnpj com zeros repetidos", raw: "00000000000000", kind: KindCNPJ, err: ErrInvalid},
+
+		// CNPJ alfanumérico
+		{name: "cnpj alfanumérico", raw: "12ABC34501DE35", wantKind: KindCNPJ, want: "12ABC34501DE35"},
+		{name: "cnpj alfanumérico com máscara e minúsculas", raw: "12.abc.345/01de-35", wantKind: KindCNPJ, want: "12ABC34501DE35"},
+		{name: "cnpj alfanumérico com dígito errado", raw: "12.ABC.345/01DE-36", err: ErrCheckDigits},
+		{name: "cnpj alfanumérico com letra no dígito", raw: "12ABC34501DE3A", kind: KindCNPJ, err: ErrInvalid},
+		{name: "cnpj alfanumérico com letras repetidas", raw: "AAAAAAAAAAAA00", kind: KindCNPJ, err: ErrCheckDigits},
+
+		// Estrangeiro e detecção
+		{name: "estrangeiro", raw: "ab-123.456", kind: KindForeign, wantKind: KindForeign, want: "AB123456"},
+		{name: "estrangeiro curto", raw: "AB", kind: KindForeign, err: ErrInvalid},
+		{name: "estrangeiro não é detectado", raw: "AB123456", err: ErrInvalid},
+		{name: "vazio", raw: "", err: ErrInvalid},
+		{name: "tipo desconhecido", raw: "52998224725", kind: "rg", err: ErrUnknownKind},
+		{name: "cpf declarado como cnpj", raw: "52998224725", kind: KindCNPJ, err: ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.raw, tt.kind)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("Parse(%q, %q) erro = %v, esperado %v", tt.raw, tt.kind, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q, %q) erro inesperado: %v", tt.raw, tt.kind, err)
+			}
+			if got.Kind != tt.wantKind || got.Value != tt.want {
+				t.Fatalf("Parse(%q, %q) = %+v, esperado {%s %s}", tt.raw, tt.kind, got, tt.wantKind, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		kind  Kind
+		value string
+		want  string
+	}{
+		{KindCPF, "52998224725", "529.982.247-25"},
+		{KindCNPJ, "11222333000181", "11.222.333/0001-81"},
+		{KindCNPJ, "12ABC34501DE35", "12.ABC.345/01DE-35"},
+		{KindForeign, "AB123456", "AB123456"},
+		{KindCPF, "123", "123"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.kind, tt.value); got != tt.want {
+			t.Errorf("Format(%q, %q) = %q, esperado %q", tt.kind, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseKind(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Kind
+		err  error
+	}{
+		{"", "", nil},
+		{"CPF", KindCPF, nil},
+		{" cnpj ", KindCNPJ, nil},
+		{"foreign", KindForeign, nil},
+		{"passport", "", ErrUnknownKind},
+	}
+	for _, tt := range tests {
+		got, err := ParseKind(tt.raw)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("ParseKind(%q) = %q, %v; esperado %q, %v", tt.raw, got, err, tt.want, tt.err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package listing
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// cursor guarda a posição do último registro da página: o valor do campo de ordenação e o
+// desempate. O cursor só vale para a mesma ordenação em que foi gerado.
+type cursor struct {
+	Sort string          `json:"s"`
+	Raw  json.RawMessage `json:"v"`
+	Time bool            `json:"t,omitempty"`
+	ID   uuid.UUID       `json:"id"`
+}
+
+func encodeCursor(sort string, value any, id uuid.UUID) (string, error) {
+	c := cursor{Sort: sort, ID: id}
+	if t, ok := value.(time.Time); ok {
+		value, c.Time = t.UTC(), true
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	c.Raw = raw
+	encoded, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(raw, &c)
+	return c, err
+}
+
+func (c cursor) value() (any, error) {
+	if c.Time {
+		var t time.Time
+		err := json.Unmarshal(c.Raw, &t)
+		return t, err
+	}
+	var v any
+	err := json.Unmarshal(c.Raw, &v)
+	return v, err
+}
+
+// LinkHeader monta o cabeçalho Link (RFC 8288) da página: "first" sempre e "next" quando há
+// próxima página. Os demais parâmetros da requisição são mantidos.
+func LinkHeader(u *url.URL, nextCursor string) string {
+	link := func(cursor, rel string) string {
+		q := u.Query()
+		q.Del("cursor")
+		if cursor != "" {
+			q.Set("cursor", cursor)
+		}
+		target := url.URL{Path: u.Path, RawQuery: q.Encode()}
+		return "<" + target.String() + `>; rel="` + rel + `"`
+	}
+	header := link("", "first")
+	if nextCursor != "" {
+		header += ", " + link(nextCursor, "next")
+	}
+	return header
+}
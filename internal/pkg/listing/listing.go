@@ -0,0 +1,267 @@
+// Package listing implementa as opções comuns das listagens da API: filtros, ordenação por
+// campos permitidos e paginação por cursor (keyset), com o total de registros.
+package listing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultSize = 10
+	MaxSize     = 100
+)
+
+var ErrInvalidOptions = errors.New("invalid list options")
+
+// Options são as opções de uma listagem. Cada domínio aplica apenas os filtros que fazem
+// sentido para ele.
+type Options struct {
+	Filters Filters
+	// Sort é o campo de ordenação; o prefixo "-" inverte a ordem (ex.: "-create_at").
+	Sort string
+	// Size é o tamanho da página (padrão DefaultSize, máximo MaxSize).
+	Size int
+	// Cursor é o NextCursor da página anterior; vazio = primeira página.
+	Cursor string
+}
+
+type Filters struct {
+	Name  string // Contém (sem diferenciar maiúsculas)
+	Email string // Contém (sem diferenciar maiúsculas)
+	// EmailDomain é o domínio do email, sem o "@" (termina com, sem diferenciar maiúsculas).
+	EmailDomain string
+	Role        string
+	Status      string
+	Live        *bool
+	// Os intervalos de data são inclusivos no início (From) e exclusivos no fim (To).
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	UpdatedFrom   *time.Time
+	UpdatedTo     *time.Time
+	LastLoginFrom *time.Time
+	LastLoginTo   *time.Time
+	// Metadata filtra por campos personalizados: chave -> valor exato.
+	Metadata map[string]string
+}
+
+// Page é uma página de resultados. NextCursor vazio indica a última página.
+type Page[T any] struct {
+	Items      []T
+	Total      int64
+	Size       int
+	NextCursor string
+}
+
+// Column é um campo ordenável: a coluna SQL e como ler o seu valor de um registro.
+type Column[T any] struct {
+	Name  string
+	Value func(T) any
+}
+
+// Spec descreve como paginar uma entidade.
+type Spec[T any] struct {
+	// Sortable são os campos aceitos em Options.Sort, pelo nome público.
+	Sortable map[string]Column[T]
+	// DefaultSort é usado quando Options.Sort está vazio.
+	DefaultSort string
+	// IDColumn e ID formam o desempate da ordenação; a chave deve ser única.
+	IDColumn string
+	ID       func(T) uuid.UUID
+}
+
+// Find aplica ordenação e cursor à consulta (já filtrada) e retorna a página com o total.
+func Find[T any](db *gorm.DB, opts Options, spec Spec[T]) (Page[T], error) {
+	size, err := pageSize(opts.Size)
+	if err != nil {
+		return Page[T]{}, err
+	}
+	sort, column, desc, err := sortColumn(opts.Sort, spec)
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	var total int64
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return Page[T]{}, err
+	}
+
+	query := db.Session(&gorm.Session{})
+	if opts.Cursor != "" {
+		c, err := decodeCursor(opts.Cursor)
+		if err != nil || c.Sort != sort {
+			return Page[T]{}, fmt.Errorf("%w: cursor inválido para a ordenação '%s'", ErrInvalidOptions, sort)
+		}
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		value, err := c.value()
+		if err != nil {
+			return Page[T]{}, fmt.Errorf("%w: cursor inválido", ErrInvalidOptions)
+		}
+		query = query.Where(fmt.Sprintf("(%s, %s) %s (?, ?)", column.Name, spec.IDColumn, op), value, c.ID)
+	}
+
+	var items []T
+	err = query.
+		Order(orderBy(column, spec.IDColumn, desc)).
+		Limit(size + 1).
+		Find(&items).Error
+	if err != nil {
+		return Page[T]{}, err
+	}
+
+	page := Page[T]{Items: items, Total: total, Size: size}
+	if len(items) > size {
+		page.Items = items[:size]
+		last := page.Items[size-1]
+		page.NextCursor, err = encodeCursor(sort, column.Value(last), spec.ID(last))
+		if err != nil {
+			return Page[T]{}, err
+		}
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	return page, nil
+}
+
+// Stream percorre todos os registros da consulta (já filtrada) na ordenação pedida, chamando fn
+// para cada um, sem carregar o resultado inteiro em memória. Size e Cursor são ignorados; um erro
+// de fn interrompe a leitura e é retornado.
+func Stream[T any](db *gorm.DB, opts Options, spec Spec[T], fn func(T) error) error {
+	_, column, desc, err := sortColumn(opts.Sort, spec)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Session(&gorm.Session{}).Order(orderBy(column, spec.IDColumn, desc)).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item T
+		if err := db.ScanRows(rows, &item); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// sortColumn resolve Options.Sort (ou o DefaultSort) para a coluna ordenável.
+func sortColumn[T any](s string, spec Spec[T]) (string, Column[T], bool, error) {
+	if s == "" {
+		s = spec.DefaultSort
+	}
+	field, desc := strings.CutPrefix(s, "-")
+	column, ok := spec.Sortable[field]
+	if !ok {
+		return "", Column[T]{}, false, fmt.Errorf("%w: campo de ordenação '%s' não permitido", ErrInvalidOptions, field)
+	}
+	return s, column, desc, nil
+}
+
+func orderBy[T any](column Column[T], idColumn string, desc bool) string {
+	direction := "ASC"
+	if desc {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("%s %s, %s %s", column.Name, direction, idColumn, direction)
+}
+
+func pageSize(size int) (int, error) {
+	switch {
+	case size == 0:
+		return DefaultSize, nil
+	case size < 0 || size > MaxSize:
+		return 0, fmt.Errorf("%w: 'size' deve estar entre 1 e %d", ErrInvalidOptions, MaxSize)
+	}
+	return size, nil
+}
+
+// Contains monta o padrão de um filtro "contém" para LIKE/ILIKE, escapando os curingas.
+func Contains(s string) string {
+	return "%" + escapeLike(s) + "%"
+}
+
+// EndsWith monta o padrão de um filtro "termina com" para LIKE/ILIKE, escapando os curingas.
+func EndsWith(s string) string {
+	return "%" + escapeLike(s)
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// ParseTime aceita RFC 3339 ou apenas a data (2006-01-02, em UTC). endOfDay faz uma data
+// sem hora representar o fim do dia, para limites superiores exclusivos.
+func ParseTime(s string, endOfDay bool) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		t = t.UTC()
+		return &t, nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: data '%s' inválida (use 2006-01-02 ou RFC 3339)", ErrInvalidOptions, s)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+// Condition é um trecho de WHERE com os seus argumentos.
+type Condition struct {
+	SQL  string
+	Args []any
+}
+
+// MetadataConditions monta um filtro de contenção (@>) por campo personalizado, que usa o
+// índice GIN da coluna JSONB. Os valores chegam como texto da query string; números e
+// booleanos também casam com o valor tipado gravado.
+func MetadataConditions(column string, filters map[string]string) []Condition {
+	keys := make([]string, 0, len(filters))
+	for k := range filters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	conds := make([]Condition, 0, len(keys))
+	for _, key := range keys {
+		value := filters[key]
+		candidates := []any{value}
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			candidates = append(candidates, n)
+		}
+		if value == "true" || value == "false" {
+			candidates = append(candidates, value == "true")
+		}
+
+		var parts []string
+		var args []any
+		for _, candidate := range candidates {
+			raw, _ := json.Marshal(map[string]any{key: candidate})
+			parts = append(parts, column+" @> ?::jsonb")
+			args = append(args, string(raw))
+		}
+		conds = append(conds, Condition{SQL: "(" + strings.Join(parts, " OR ") + ")", Args: args})
+	}
+	return conds
+}
@@ -6,6 +6,13 @@ import (
 	"github.com/google/uuid"
 )
 
+// Status do acesso registrado. Requisições barradas por política de rede recebem um status próprio,
+// separado do status_code HTTP, para que possam ser filtradas.
+const (
+	StatusAllowed  = "allowed"
+	StatusIPDenied = "ip_denied"
+)
+
 type AccessLog struct {
 	ID         uint       `gorm:"primaryKey"`
 	TenantUUID *uuid.UUID `gorm:"type:uuid"`
@@ -16,8 +23,10 @@ type AccessLog struct {
 
 	Method       string `gorm:"size:10;not null"`
 	Path         string `gorm:"type:text;not null"`
+	Route        string `gorm:"type:text"` // Rota do Gin (ex.: /api/user/:identifier)
 	Host         string `gorm:"type:text;not null"`
 	StatusCode   int    `gorm:"not null"`
+	Status       string `gorm:"size:32;not null;default:allowed"`
 	IP           string `gorm:"type:inet;not null"`
 	UserAgent    string `gorm:"type:text"`
 	Referer      string `gorm:"type:text"`
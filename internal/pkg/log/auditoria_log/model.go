@@ -13,6 +13,10 @@ type AuditLog struct {
 	UserUUID   *uuid.UUID `gorm:"type:uuid"`
 	Identifier string     `gorm:"type:text"`
 
+	// ActingTenantUUID registra o tenant ancestral que executou a ação
+	// quando ela é feita sobre um tenant descendente (PARTNER_ADMIN).
+	ActingTenantUUID *uuid.UUID `gorm:"type:uuid"`
+
 	RayTraceCode string `gorm:"size:100;not null"`
 
 	Domain     string `gorm:"size:100;not null"`
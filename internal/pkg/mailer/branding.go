@@ -0,0 +1,79 @@
+package mailer
+
+import (
+	"html/template"
+	"strings"
+	"sync"
+)
+
+// Branding é a identidade visual aplicada aos emails enviados por SendTemplate.
+// Campos vazios usam o visual padrão do sistema.
+type Branding struct {
+	SenderName   string // Nome exibido no remetente (From)
+	LogoURL      string
+	PrimaryColor string // Cor do cabeçalho e destaques (#RRGGBB)
+	Footer       string // Texto do rodapé (texto puro; quebras de linha são mantidas)
+}
+
+// BrandingResolver retorna a identidade visual do tenant do destinatário.
+// ok = false usa o visual padrão.
+type BrandingResolver func(to string) (branding Branding, ok bool)
+
+const defaultPrimaryColor = "#1F2937"
+
+var (
+	brandingMu sync.RWMutex
+	resolver   BrandingResolver
+)
+
+// SetBrandingResolver registra quem resolve a identidade visual pelo email do destinatário.
+// O mailer fica em internal/pkg e não conhece tenants; o domínio branding faz o registro.
+func SetBrandingResolver(r BrandingResolver) {
+	brandingMu.Lock()
+	defer brandingMu.Unlock()
+	resolver = r
+}
+
+func brandingFor(to string) Branding {
+	brandingMu.RLock()
+	r := resolver
+	brandingMu.RUnlock()
+
+	var b Branding
+	if r != nil {
+		if resolved, ok := r(to); ok {
+			b = resolved
+		}
+	}
+	if b.PrimaryColor == "" {
+		b.PrimaryColor = defaultPrimaryColor
+	}
+	return b
+}
+
+// layout envolve o conteúdo renderizado de SendTemplate. Estilos inline porque
+// a maioria dos clientes de email ignora <style>.
+var layout = template.Must(template.New("layout").Funcs(template.FuncMap{
+	"lines": func(s string) []string { return strings.Split(s, "\n") },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"><title>{{.Subject}}</title></head>
+<body style="margin:0;padding:0;background:#F3F4F6;font-family:Arial,Helvetica,sans-serif;color:#111827;">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" style="background:#F3F4F6;padding:24px 0;">
+<tr><td align="center">
+<table role="presentation" width="600" cellpadding="0" cellspacing="0" style="max-width:600px;background:#FFFFFF;border-radius:8px;overflow:hidden;">
+<tr><td style="background:{{.Branding.PrimaryColor}};padding:20px 24px;">
+{{- if .Branding.LogoURL}}<img src="{{.Branding.LogoURL}}" alt="{{.Branding.SenderName}}" style="max-height:48px;border:0;">
+{{- else if .Branding.SenderName}}<span style="color:#FFFFFF;font-size:20px;font-weight:bold;">{{.Branding.SenderName}}</span>{{end -}}
+</td></tr>
+<tr><td style="padding:24px;font-size:15px;line-height:1.5;">{{.Content}}</td></tr>
+{{- if .Branding.Footer}}
+<tr><td style="padding:16px 24px;border-top:3px solid {{.Branding.PrimaryColor}};font-size:12px;color:#6B7280;">
+{{- range $i, $line := lines .Branding.Footer}}{{if $i}}<br>{{end}}{{$line}}{{end -}}
+</td></tr>
+{{- end}}
+</table>
+</td></tr>
+</table>
+</body>
+</html>`))
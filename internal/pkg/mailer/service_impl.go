@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
+	"mime"
+	"net/mail"
 	"net/smtp"
 	"sync"
 )
@@ -96,9 +98,13 @@ func (m *impl) SendRaw(to, subject, body string) error {
 	if err := validate(); err != nil {
 		return err
 	}
+	return m.send(m.cfg.Address, to, subject, body)
+}
 
+// send monta a mensagem com o cabeçalho From informado; o envelope usa sempre o endereço configurado.
+func (m *impl) send(from, to, subject, body string) error {
 	msg := []byte(
-		"From: " + m.cfg.Address + "\r\n" +
+		"From: " + from + "\r\n" +
 			"To: " + to + "\r\n" +
 			"Subject: " + subject + "\r\n" +
 			"MIME-version: 1.0;\r\n" +
@@ -189,7 +195,8 @@ func (m *impl) sendWithStartTLS(addr, to string, msg []byte) error {
 	return nil
 }
 
-// Envia email usando template HTML
+// Envia email usando template HTML, dentro do layout com a identidade visual
+// do tenant do destinatário (ou a padrão do sistema)
 func (m *impl) SendTemplate(to, subject string, tpl string, data interface{}) error {
 	if err := validate(); err != nil {
 		return err
@@ -200,10 +207,25 @@ func (m *impl) SendTemplate(to, subject string, tpl string, data interface{}) er
 		return err
 	}
 
+	var content bytes.Buffer
+	if err := t.Execute(&content, data); err != nil {
+		return err
+	}
+
+	branding := brandingFor(to)
 	var buf bytes.Buffer
-	if err := t.Execute(&buf, data); err != nil {
+	err = layout.Execute(&buf, struct {
+		Subject  string
+		Branding Branding
+		Content  template.HTML
+	}{subject, branding, template.HTML(content.String())})
+	if err != nil {
 		return err
 	}
 
-	return m.SendRaw(to, subject, buf.String())
+	from := m.cfg.Address
+	if branding.SenderName != "" {
+		from = (&mail.Address{Name: branding.SenderName, Address: m.cfg.Address}).String()
+	}
+	return m.send(from, to, mime.QEncoding.Encode("UTF-8", subject), buf.String())
 }
@@ -51,3 +51,8 @@ func NewExternalProviderError(trace_id *string, message string, causes []Causes)
 func NewConflictValidationError(trace_id *string, message string, causes []Causes) *RestErr {
 	return NewRestErr(trace_id, message, ErrConflict, http.StatusConflict, causes)
 }
+
+// NewQuotaExceededError indica que o tenant atingiu um limite do seu plano.
+func NewQuotaExceededError(trace_id *string, message string, causes []Causes) *RestErr {
+	return NewRestErr(trace_id, message, ErrQuotaExceeded, http.StatusPaymentRequired, causes)
+}
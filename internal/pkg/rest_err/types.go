@@ -7,4 +7,5 @@ const (
 	ErrForbidden           = "forbidden"
 	ErrExternalProvider    = "external_provider_error"
 	ErrConflict            = "conflict"
+	ErrQuotaExceeded       = "quota_exceeded"
 )
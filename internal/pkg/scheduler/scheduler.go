@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job é a função executada periodicamente pelo agendador.
+type Job func(ctx context.Context) error
+
+// Every executa o job imediatamente e depois a cada intervalo, em uma goroutine própria,
+// até que o contexto seja cancelado. Erros e panics são apenas registrados em log,
+// para que uma execução com falha não derrube as próximas.
+func Every(ctx context.Context, name string, interval time.Duration, job Job) {
+	if interval <= 0 {
+		log.Printf("[SCHEDULER] Job '%s' não iniciado: intervalo inválido (%s)", name, interval)
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		log.Printf("[SCHEDULER] Job '%s' iniciado (intervalo %s)", name, interval)
+		for {
+			run(ctx, name, job)
+
+			select {
+			case <-ctx.Done():
+				log.Printf("[SCHEDULER] Job '%s' finalizado", name)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func run(ctx context.Context, name string, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[SCHEDULER] Recovered in job '%s': %v", name, r)
+		}
+	}()
+
+	if err := job(ctx); err != nil {
+		log.Printf("[SCHEDULER] Erro no job '%s': %v", name, err)
+	}
+}